@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/report"
+)
+
+// runAggregate 汇总多次分析的报告，参数为输出目录或报告文件，可用 "客户名=路径" 指定客户名
+func runAggregate(args []string) error {
+	fs := flag.NewFlagSet("aggregate", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	outputDir := fs.String("o", "", "汇总报告输出目录，默认使用配置中的 output_dir")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: content-analyzer aggregate [选项] [客户名=]目录或报告文件 ...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("至少需要指定一个报告")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	if *outputDir != "" {
+		cfg.OutputDir = *outputDir
+	}
+
+	var clients []report.ClientSummary
+	var warnings []string
+	for _, arg := range fs.Args() {
+		name, path := parseReportArg(arg)
+
+		client, err := report.LoadClientReport(name, path)
+		if err != nil {
+			warning := fmt.Sprintf("跳过报告 %s: %v", path, err)
+			log.Println(warning)
+			warnings = append(warnings, warning)
+			continue
+		}
+		clients = append(clients, client)
+	}
+
+	if len(clients) == 0 {
+		return fmt.Errorf("没有可汇总的报告")
+	}
+
+	agg := report.Aggregate(clients)
+	agg.Warnings = warnings
+
+	if err := report.NewReporter(cfg).GenerateAggregateReport(agg); err != nil {
+		return err
+	}
+
+	fmt.Printf("已汇总 %d 个客户、%d 篇内容，报告已保存到: %s\n", len(clients), agg.TotalContent, cfg.OutputDir)
+	return nil
+}
+
+// parseReportArg 解析 "客户名=路径"；路径为目录时读取其中的 analysis_report.json，未指定客户名时使用目录名
+func parseReportArg(arg string) (string, string) {
+	name, path, found := strings.Cut(arg, "=")
+	if !found {
+		path = arg
+		name = ""
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "analysis_report.json")
+	}
+
+	if name == "" {
+		name = filepath.Base(filepath.Dir(path))
+	}
+
+	return name, path
+}
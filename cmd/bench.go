@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// stageStats 单个分析阶段的耗时统计
+type stageStats struct {
+	count int
+	total time.Duration
+	max   time.Duration
+}
+
+// runBench 基准测试子命令：分析语料并输出各阶段耗时，可选生成CPU/内存profile
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	cpuProfile := fs.String("cpuprofile", "", "CPU profile 输出文件")
+	memProfile := fs.String("memprofile", "", "内存 profile 输出文件")
+	iterations := fs.Int("n", 1, "语料重复分析次数")
+	synthetic := fs.Int("synthetic", 0, "生成指定数量的合成内容代替扫描内容目录")
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	var contents []models.Content
+	if *synthetic > 0 {
+		contents = syntheticCorpus(*synthetic)
+	} else {
+		contents, err = scanContentDirectory(cfg.ContentDir)
+		if err != nil {
+			return fmt.Errorf("扫描目录失败: %w", err)
+		}
+	}
+	if len(contents) == 0 {
+		return fmt.Errorf("没有可分析的内容，可使用 -synthetic 生成合成语料")
+	}
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			return fmt.Errorf("创建CPU profile失败: %w", err)
+		}
+		defer f.Close()
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return fmt.Errorf("启动CPU profile失败: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	stats := make(map[string]*stageStats)
+	contentAnalyzer := analyzer.NewContentAnalyzer(cfg)
+	contentAnalyzer.SetStageHook(func(stage string, elapsed time.Duration) {
+		st, ok := stats[stage]
+		if !ok {
+			st = &stageStats{}
+			stats[stage] = st
+		}
+		st.count++
+		st.total += elapsed
+		if elapsed > st.max {
+			st.max = elapsed
+		}
+	})
+
+	start := time.Now()
+	failures := 0
+	for i := 0; i < *iterations; i++ {
+		for _, content := range contents {
+			if _, err := contentAnalyzer.Analyze(content); err != nil {
+				failures++
+			}
+		}
+	}
+	elapsed := time.Since(start)
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			return fmt.Errorf("创建内存 profile失败: %w", err)
+		}
+		defer f.Close()
+
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			return fmt.Errorf("写入内存 profile失败: %w", err)
+		}
+	}
+
+	analyzed := len(contents) * *iterations
+	fmt.Printf("分析 %d 篇内容（%d 篇失败），总耗时 %v，平均 %v/篇\n\n",
+		analyzed, failures, elapsed, elapsed/time.Duration(analyzed))
+	printStageStats(stats)
+
+	return nil
+}
+
+// printStageStats 按总耗时降序输出各阶段统计
+func printStageStats(stats map[string]*stageStats) {
+	stages := make([]string, 0, len(stats))
+	for stage := range stats {
+		stages = append(stages, stage)
+	}
+	sort.Slice(stages, func(i, j int) bool {
+		return stats[stages[i]].total > stats[stages[j]].total
+	})
+
+	fmt.Printf("%-12s %8s %14s %14s %14s\n", "阶段", "次数", "总耗时", "平均", "最大")
+	for _, stage := range stages {
+		st := stats[stage]
+		fmt.Printf("%-12s %8d %14v %14v %14v\n",
+			stage, st.count, st.total, st.total/time.Duration(st.count), st.max)
+	}
+}
+
+// syntheticCorpus 生成用于基准测试的合成内容
+func syntheticCorpus(n int) []models.Content {
+	paragraph := "大家好，今天分享一些实用的生活技巧。我觉得这些方法非常有用，推荐给大家。" +
+		"Try these simple tips to improve your daily routine and stay productive.\n\n"
+
+	contents := make([]models.Content, n)
+	for i := range contents {
+		contents[i] = models.Content{
+			ID:    fmt.Sprintf("synthetic-%d", i+1),
+			Title: fmt.Sprintf("%d个超实用的生活小技巧，你知道几个？", i%10+3),
+			Text:  strings.Repeat(paragraph, i%8+2) + "觉得有用请点赞收藏，评论区分享你的经验！#生活 #技巧",
+			Type:  "post",
+		}
+	}
+
+	return contents
+}
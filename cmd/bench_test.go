@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureStdout 执行 fn 并返回其写入标准输出的内容
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	fn()
+	w.Close()
+	return <-output
+}
+
+func TestRunBenchWritesProfilesAndTimings(t *testing.T) {
+	t.Setenv("AI_API_KEY", "")
+	dir := t.TempDir()
+	cpuProfile := filepath.Join(dir, "cpu.prof")
+	memProfile := filepath.Join(dir, "mem.prof")
+
+	var benchErr error
+	output := captureStdout(t, func() {
+		benchErr = runBench([]string{
+			"-config", filepath.Join(dir, "config.yaml"),
+			"-synthetic", "3",
+			"-n", "2",
+			"-cpuprofile", cpuProfile,
+			"-memprofile", memProfile,
+		})
+	})
+	if benchErr != nil {
+		t.Fatalf("runBench 失败: %v", benchErr)
+	}
+
+	for _, path := range []string{cpuProfile, memProfile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("未生成 profile: %v", err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s 为空", filepath.Base(path))
+		}
+	}
+
+	if !strings.Contains(output, "分析 6 篇内容（0 篇失败）") {
+		t.Errorf("缺少汇总行:\n%s", output)
+	}
+	for _, stage := range []string{"text", "sentiment", "keywords"} {
+		if !strings.Contains(output, "\n"+stage+" ") {
+			t.Errorf("缺少阶段 %s 的耗时:\n%s", stage, output)
+		}
+	}
+}
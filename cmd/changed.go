@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// contentDiff 内容目录与基线目录的差异，路径均为相对路径
+type contentDiff struct {
+	Changed   map[string]bool // 新增或修改的文件
+	Unchanged map[string]bool
+	Deleted   []string
+}
+
+// diffContentDirs 按内容指纹（文件内容及其引用的本地图片）比较内容目录和基线目录
+func diffContentDirs(contentDir, baselineDir string) (*contentDiff, error) {
+	current, err := hashContentFiles(contentDir)
+	if err != nil {
+		return nil, fmt.Errorf("扫描内容目录失败: %w", err)
+	}
+
+	baseline, err := hashContentFiles(baselineDir)
+	if err != nil {
+		return nil, fmt.Errorf("扫描基线目录失败: %w", err)
+	}
+
+	diff := &contentDiff{
+		Changed:   make(map[string]bool),
+		Unchanged: make(map[string]bool),
+	}
+
+	for path, hash := range current {
+		if baseline[path] == hash {
+			diff.Unchanged[path] = true
+		} else {
+			diff.Changed[path] = true
+		}
+	}
+
+	for path := range baseline {
+		if _, ok := current[path]; !ok {
+			diff.Deleted = append(diff.Deleted, path)
+		}
+	}
+
+	return diff, nil
+}
+
+// hashContentFiles 计算目录下所有内容文件的指纹，键为相对路径
+func hashContentFiles(dir string) (map[string]string, error) {
+	hashes := make(map[string]string)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isContentFile(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		hash, err := contentFingerprint(dir, path)
+		if err != nil {
+			return err
+		}
+		hashes[rel] = hash
+
+		return nil
+	})
+
+	return hashes, err
+}
+
+// contentFingerprint 内容文件的SHA-256，再加上其引用的本地图片的路径和SHA-256，原地替换图片也视为修改。
+// 图片相对路径与分析时一样相对 dir（内容目录或基线目录）解析；远程和内嵌图片已包含在内容文件中
+func contentFingerprint(dir, path string) (string, error) {
+	hash, err := hashFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := parseContentFile(path)
+	if err != nil || content == nil {
+		// 无法解析的文件只按内容比较，分析时会再报告解析错误
+		return hash, nil
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	io.WriteString(h, hash)
+	for _, img := range content.Images {
+		if img.Path == "" || services.IsDataURI(img.Path) {
+			continue
+		}
+		imagePath := img.Path
+		if !filepath.IsAbs(imagePath) {
+			imagePath = filepath.Join(root, imagePath)
+		}
+		imageHash, err := hashFile(imagePath)
+		if err != nil {
+			imageHash = "missing"
+		}
+
+		// 目录内的图片按相对路径记录，Markdown 中已解析为绝对路径的图片在两个目录间才能对应
+		name := imagePath
+		if rel, err := filepath.Rel(root, imagePath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			name = rel
+		}
+		fmt.Fprintf(h, "\n%s:%s", name, imageHash)
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// loadCachedResults 读取上次生成的JSON报告，按内容标识索引分析结果
+func loadCachedResults(outputDir string) (map[string]models.AnalysisResult, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, "analysis_report.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]models.AnalysisResult{}, nil
+		}
+		return nil, err
+	}
+
+	var report struct {
+		Results     []models.AnalysisResult `json:"results"`
+		TextTrimmed bool                    `json:"text_trimmed"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("解析已有报告失败: %w", err)
+	}
+	// 摘自正文的文本被省略或截断过的结果不完整，全部重新分析
+	if report.TextTrimmed {
+		log.Println("上次报告按 report.include_text / text_excerpt_len 省略或截断了正文摘录，不复用其结果")
+		return map[string]models.AnalysisResult{}, nil
+	}
+
+	cached := make(map[string]models.AnalysisResult)
+	for _, result := range report.Results {
+		cached[resultKey(result.ContentID, result.Title)] = result
+	}
+
+	return cached, nil
+}
+
+// reusableResult 内容文件相对基线未变化且上次报告中有其结果时返回该结果，diff 为nil（非增量模式）时总是重新分析
+func reusableResult(contentDir string, diff *contentDiff, cached map[string]models.AnalysisResult, content models.Content) (models.AnalysisResult, bool) {
+	if diff == nil {
+		return models.AnalysisResult{}, false
+	}
+	rel, err := filepath.Rel(contentDir, content.FilePath)
+	if err != nil || !diff.Unchanged[rel] {
+		return models.AnalysisResult{}, false
+	}
+	result, ok := cached[resultKey(content.ID, content.Title)]
+	return result, ok
+}
+
+// resultKey 优先使用内容ID，没有ID时（如Markdown文件）使用标题
+func resultKey(id, title string) string {
+	if id != "" {
+		return "id:" + id
+	}
+	return "title:" + title
+}
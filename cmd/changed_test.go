@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// writeFile 写入 dir 下的相对路径 name，自动创建上级目录
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestChangedAgainstReanalyzesOnlyChangedFiles(t *testing.T) {
+	baseline := t.TempDir()
+	contentDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	writeFile(t, baseline, "same.json", `{"id":"same","title":"未修改","text":"正文"}`)
+	writeFile(t, contentDir, "same.json", `{"id":"same","title":"未修改","text":"正文"}`)
+	writeFile(t, baseline, "posts/edited.json", `{"id":"edited","title":"修改前","text":"旧正文"}`)
+	writeFile(t, contentDir, "posts/edited.json", `{"id":"edited","title":"修改后","text":"新正文"}`)
+	writeFile(t, contentDir, "new.md", "# 新文章\n\n新增的内容")
+	writeFile(t, baseline, "removed.txt", "已删除的内容")
+
+	// 上次报告中三篇都有结果，只有未变化的文件应直接复用
+	previous := map[string][]models.AnalysisResult{"results": {
+		{ContentID: "same", Title: "未修改", Score: models.OverallScore{Total: 81}},
+		{ContentID: "edited", Title: "修改前", Score: models.OverallScore{Total: 42}},
+		{Title: "新文章", Score: models.OverallScore{Total: 10}},
+	}}
+	data, err := json.Marshal(previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, outputDir, "analysis_report.json", string(data))
+
+	diff, err := diffContentDirs(contentDir, baseline)
+	if err != nil {
+		t.Fatalf("比较目录失败: %v", err)
+	}
+	if len(diff.Deleted) != 1 || diff.Deleted[0] != "removed.txt" {
+		t.Errorf("Deleted = %v, want [removed.txt]", diff.Deleted)
+	}
+
+	cached, err := loadCachedResults(outputDir)
+	if err != nil {
+		t.Fatalf("加载上次结果失败: %v", err)
+	}
+	contents, err := scanContentDirectory(contentDir)
+	if err != nil {
+		t.Fatalf("扫描目录失败: %v", err)
+	}
+
+	var reused, reanalyzed []string
+	for _, content := range contents {
+		if result, ok := reusableResult(contentDir, diff, cached, content); ok {
+			reused = append(reused, content.Title)
+			if result.Score.Total != 81 {
+				t.Errorf("复用的结果有误: %+v", result.Score)
+			}
+		} else {
+			reanalyzed = append(reanalyzed, content.Title)
+		}
+	}
+	sort.Strings(reanalyzed)
+
+	if len(reused) != 1 || reused[0] != "未修改" {
+		t.Errorf("复用 = %v, want [未修改]", reused)
+	}
+	if len(reanalyzed) != 2 || reanalyzed[0] != "修改后" || reanalyzed[1] != "新文章" {
+		t.Errorf("重新分析 = %v, want [修改后 新文章]", reanalyzed)
+	}
+
+	// 非增量模式总是重新分析
+	for _, content := range contents {
+		if _, ok := reusableResult(contentDir, nil, cached, content); ok {
+			t.Errorf("未指定基线时不应复用 %s 的结果", content.Title)
+		}
+	}
+}
+
+func TestChangedAgainstDetectsReplacedImages(t *testing.T) {
+	baseline := t.TempDir()
+	contentDir := t.TempDir()
+
+	for _, dir := range []string{baseline, contentDir} {
+		writeFile(t, dir, "replaced.json", `{"id":"replaced","title":"换图","text":"正文","images":[{"path":"images/cover.png"}]}`)
+		writeFile(t, dir, "same.json", `{"id":"same","title":"同图","text":"正文","images":[{"path":"images/same.png"}]}`)
+		writeFile(t, dir, "posts/same.md", "# 同图\n\n![封面](../images/same.png)\n")
+		writeFile(t, dir, "images/same.png", "same image")
+	}
+	writeFile(t, baseline, "images/cover.png", "old image")
+	writeFile(t, contentDir, "images/cover.png", "new image")
+	// 基线中缺少图片，内容目录中新增了图片
+	writeFile(t, baseline, "added.json", `{"id":"added","title":"补图","text":"正文","images":[{"path":"images/added.png"}]}`)
+	writeFile(t, contentDir, "added.json", `{"id":"added","title":"补图","text":"正文","images":[{"path":"images/added.png"}]}`)
+	writeFile(t, contentDir, "images/added.png", "added image")
+
+	diff, err := diffContentDirs(contentDir, baseline)
+	if err != nil {
+		t.Fatalf("比较目录失败: %v", err)
+	}
+
+	tests := []struct {
+		path    string
+		changed bool
+	}{
+		{"replaced.json", true},
+		{"added.json", true},
+		{"same.json", false},
+		{filepath.Join("posts", "same.md"), false},
+	}
+	for _, tt := range tests {
+		if diff.Changed[tt.path] != tt.changed || diff.Unchanged[tt.path] == tt.changed {
+			t.Errorf("%s: Changed = %v, Unchanged = %v, want changed %v", tt.path, diff.Changed[tt.path], diff.Unchanged[tt.path], tt.changed)
+		}
+	}
+}
+
+func TestLoadCachedResultsSkipsTrimmedReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		report string
+		want   int
+	}{
+		{"完整报告", `{"results":[{"content_id":"a","title":"A"}]}`, 1},
+		{"截断过正文摘录的报告", `{"results":[{"content_id":"a","title":"A"}],"text_trimmed":true}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			writeFile(t, outputDir, "analysis_report.json", tt.report)
+
+			cached, err := loadCachedResults(outputDir)
+			if err != nil {
+				t.Fatalf("加载上次结果失败: %v", err)
+			}
+			if len(cached) != tt.want {
+				t.Errorf("len(cached) = %d, want %d", len(cached), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/report"
+)
+
+// runCompare 对比两次分析的报告，参数为输出目录或 analysis_report.json
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	outputDir := fs.String("o", "", "对比报告输出目录，默认使用配置中的 output_dir")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: content-analyzer compare [选项] 上次报告 本次报告")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("需要指定两个报告")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	if *outputDir != "" {
+		cfg.OutputDir = *outputDir
+	}
+
+	previous, err := report.LoadReportResults(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("读取上次报告失败: %w", err)
+	}
+	current, err := report.LoadReportResults(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("读取本次报告失败: %w", err)
+	}
+
+	if err := report.NewReporter(cfg).GenerateComparisonReport(previous, current); err != nil {
+		return err
+	}
+
+	fmt.Printf("已对比 %d 篇和 %d 篇内容，报告已保存到: %s\n", len(previous), len(current), cfg.OutputDir)
+	return nil
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// rssFeed RSS 2.0 文档
+type rssFeed struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Links []feedLink `xml:"http://www.w3.org/2005/Atom link"`
+		Items []rssItem  `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Description string   `xml:"description"`
+	Encoded     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string   `xml:"pubDate"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	Enclosures  []struct {
+		URL  string `xml:"url,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"enclosure"`
+}
+
+// atomFeed Atom 文档
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Links   []feedLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Content   string     `xml:"content"`
+	Summary   string     `xml:"summary"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Links     []feedLink `xml:"link"`
+	Author    struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+}
+
+type feedLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr"`
+}
+
+var (
+	feedImgPattern   = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)["']`)
+	feedTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	feedBlockPattern = regexp.MustCompile(`(?i)</?(p|div|br|li|h[1-6]|blockquote)[^>]*>`)
+	feedSpacePattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// RSS pubDate 常见格式
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02",
+}
+
+// loadFeed 读取 RSS/Atom 订阅（URL 或本地文件），按 rel="next" 链接翻页，最多返回 limit 篇内容
+func loadFeed(source string, limit int) ([]models.Content, error) {
+	var contents []models.Content
+	visited := make(map[string]bool)
+
+	for source != "" && !visited[source] && (limit <= 0 || len(contents) < limit) {
+		visited[source] = true
+
+		data, err := readFeedSource(source)
+		if err != nil {
+			return nil, fmt.Errorf("读取订阅 %s 失败: %w", source, err)
+		}
+
+		page, next, err := parseFeed(data)
+		if err != nil {
+			return nil, fmt.Errorf("解析订阅 %s 失败: %w", source, err)
+		}
+
+		for i := range page {
+			page[i].FilePath = source
+		}
+		contents = append(contents, page...)
+
+		source = resolveFeedURL(source, next)
+	}
+
+	if limit > 0 && len(contents) > limit {
+		contents = contents[:limit]
+	}
+
+	return contents, nil
+}
+
+func readFeedSource(source string) ([]byte, error) {
+	if !isRemoteFeed(source) {
+		return os.ReadFile(source)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func isRemoteFeed(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// parseFeed 解析单页订阅，返回内容和下一页链接
+func parseFeed(data []byte) ([]models.Content, string, error) {
+	var probe struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &probe); err != nil {
+		return nil, "", err
+	}
+
+	switch probe.XMLName.Local {
+	case "rss":
+		var feed rssFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, "", err
+		}
+
+		contents := make([]models.Content, 0, len(feed.Channel.Items))
+		for _, item := range feed.Channel.Items {
+			contents = append(contents, rssItemContent(item))
+		}
+		return contents, nextLink(feed.Channel.Links), nil
+
+	case "feed":
+		var feed atomFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, "", err
+		}
+
+		contents := make([]models.Content, 0, len(feed.Entries))
+		for _, entry := range feed.Entries {
+			contents = append(contents, atomEntryContent(entry))
+		}
+		return contents, nextLink(feed.Links), nil
+
+	default:
+		return nil, "", fmt.Errorf("不支持的订阅格式: %s", probe.XMLName.Local)
+	}
+}
+
+func rssItemContent(item rssItem) models.Content {
+	body := item.Encoded
+	if body == "" {
+		body = item.Description
+	}
+
+	id := item.GUID
+	if id == "" {
+		id = item.Link
+	}
+
+	author := item.Author
+	if author == "" {
+		author = item.Creator
+	}
+
+	content := models.Content{
+		ID:          id,
+		Title:       strings.TrimSpace(item.Title),
+		Text:        htmlToText(body),
+		Images:      inlineImages(body),
+		Tags:        item.Categories,
+		PublishedAt: parseFeedDate(item.PubDate),
+		Author:      author,
+		Type:        "blog",
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if strings.HasPrefix(enclosure.Type, "image/") {
+			content.Images = append(content.Images, models.Image{URL: enclosure.URL})
+		}
+	}
+
+	return content
+}
+
+func atomEntryContent(entry atomEntry) models.Content {
+	body := entry.Content
+	if body == "" {
+		body = entry.Summary
+	}
+
+	published := entry.Published
+	if published == "" {
+		published = entry.Updated
+	}
+
+	content := models.Content{
+		ID:          entry.ID,
+		Title:       strings.TrimSpace(entry.Title),
+		Text:        htmlToText(body),
+		Images:      inlineImages(body),
+		PublishedAt: parseFeedDate(published),
+		Author:      entry.Author.Name,
+		Type:        "blog",
+	}
+
+	for _, category := range entry.Categories {
+		content.Tags = append(content.Tags, category.Term)
+	}
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") {
+			content.Images = append(content.Images, models.Image{URL: link.Href})
+		}
+	}
+
+	return content
+}
+
+// htmlToText 去掉HTML标签，块级元素转为换行以保留段落结构
+func htmlToText(body string) string {
+	text := feedBlockPattern.ReplaceAllString(body, "\n\n")
+	text = feedTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(feedSpacePattern.ReplaceAllString(text, "\n\n"))
+}
+
+func inlineImages(body string) []models.Image {
+	var images []models.Image
+	for _, match := range feedImgPattern.FindAllStringSubmatch(body, -1) {
+		images = append(images, models.Image{URL: html.UnescapeString(match[1])})
+	}
+	return images
+}
+
+func parseFeedDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func nextLink(links []feedLink) string {
+	for _, link := range links {
+		if link.Rel == "next" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// resolveFeedURL 将下一页链接解析为相对于当前页的地址
+func resolveFeedURL(base, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	if !isRemoteFeed(base) {
+		if isRemoteFeed(ref) || filepath.IsAbs(ref) {
+			return ref
+		}
+		return filepath.Join(filepath.Dir(base), ref)
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	return baseURL.ResolveReference(refURL).String()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const rssFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:atom="http://www.w3.org/2005/Atom">
+  <channel>
+    <title>露营日记</title>
+    <atom:link rel="next" href="page2.xml"/>
+    <item>
+      <title> 周末露营装备清单 </title>
+      <link>https://example.com/camping</link>
+      <guid>camping-001</guid>
+      <description>摘要不应被使用</description>
+      <content:encoded><![CDATA[<p>第一段 &amp; 介绍</p><p>第二段<img src="https://example.com/tent.jpg"></p>]]></content:encoded>
+      <pubDate>Mon, 15 Jan 2024 10:00:00 +0000</pubDate>
+      <dc:creator>小林</dc:creator>
+      <category>户外</category>
+      <category>露营</category>
+      <enclosure url="https://example.com/cover.png" type="image/png"/>
+      <enclosure url="https://example.com/audio.mp3" type="audio/mpeg"/>
+    </item>
+  </channel>
+</rss>`
+
+const rssPage2Fixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <item>
+      <title>第二页文章</title>
+      <link>https://example.com/page2-post</link>
+      <description>只有摘要</description>
+    </item>
+  </channel>
+</rss>`
+
+const atomFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom 示例</title>
+  <entry>
+    <id>urn:uuid:atom-1</id>
+    <title>Atom 文章</title>
+    <summary>只有摘要时使用摘要</summary>
+    <updated>2024-02-01T08:30:00Z</updated>
+    <author><name>Alice</name></author>
+    <category term="tech"/>
+    <link rel="alternate" href="https://example.com/atom-1"/>
+    <link rel="enclosure" type="image/jpeg" href="https://example.com/atom.jpg"/>
+  </entry>
+</feed>`
+
+func TestParseFeedRSS(t *testing.T) {
+	contents, next, err := parseFeed([]byte(rssFixture))
+	if err != nil {
+		t.Fatalf("parseFeed() error = %v", err)
+	}
+	if next != "page2.xml" {
+		t.Errorf("next = %q, want %q", next, "page2.xml")
+	}
+	if len(contents) != 1 {
+		t.Fatalf("len(contents) = %d, want 1", len(contents))
+	}
+
+	c := contents[0]
+	if c.ID != "camping-001" {
+		t.Errorf("ID = %q, want guid", c.ID)
+	}
+	if c.Title != "周末露营装备清单" {
+		t.Errorf("Title = %q, 应去掉首尾空白", c.Title)
+	}
+	if c.Text != "第一段 & 介绍\n\n第二段" {
+		t.Errorf("Text = %q, 应优先使用 content:encoded 并去掉HTML", c.Text)
+	}
+	if c.Author != "小林" {
+		t.Errorf("Author = %q, want dc:creator", c.Author)
+	}
+	if c.Type != "blog" {
+		t.Errorf("Type = %q, want blog", c.Type)
+	}
+	if len(c.Tags) != 2 || c.Tags[0] != "户外" || c.Tags[1] != "露营" {
+		t.Errorf("Tags = %v, want [户外 露营]", c.Tags)
+	}
+	if want := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC); !c.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, want %v", c.PublishedAt, want)
+	}
+
+	wantImages := []string{"https://example.com/tent.jpg", "https://example.com/cover.png"}
+	if len(c.Images) != len(wantImages) {
+		t.Fatalf("Images = %v, want %v (非图片附件应忽略)", c.Images, wantImages)
+	}
+	for i, url := range wantImages {
+		if c.Images[i].URL != url {
+			t.Errorf("Images[%d].URL = %q, want %q", i, c.Images[i].URL, url)
+		}
+	}
+}
+
+func TestParseFeedAtom(t *testing.T) {
+	contents, next, err := parseFeed([]byte(atomFixture))
+	if err != nil {
+		t.Fatalf("parseFeed() error = %v", err)
+	}
+	if next != "" {
+		t.Errorf("next = %q, want empty", next)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("len(contents) = %d, want 1", len(contents))
+	}
+
+	c := contents[0]
+	if c.ID != "urn:uuid:atom-1" || c.Title != "Atom 文章" || c.Author != "Alice" {
+		t.Errorf("ID/Title/Author = %q/%q/%q", c.ID, c.Title, c.Author)
+	}
+	if c.Text != "只有摘要时使用摘要" {
+		t.Errorf("Text = %q, 没有 content 时应使用 summary", c.Text)
+	}
+	if want := time.Date(2024, 2, 1, 8, 30, 0, 0, time.UTC); !c.PublishedAt.Equal(want) {
+		t.Errorf("PublishedAt = %v, 没有 published 时应使用 updated", c.PublishedAt)
+	}
+	if len(c.Tags) != 1 || c.Tags[0] != "tech" {
+		t.Errorf("Tags = %v, want [tech]", c.Tags)
+	}
+	if len(c.Images) != 1 || c.Images[0].URL != "https://example.com/atom.jpg" {
+		t.Errorf("Images = %v, 应只包含 rel=enclosure 的图片链接", c.Images)
+	}
+}
+
+func TestParseFeedRejectsUnknownFormat(t *testing.T) {
+	if _, _, err := parseFeed([]byte(`<html><body/></html>`)); err == nil {
+		t.Error("parseFeed(html) 应返回错误")
+	}
+}
+
+func TestLoadFeedFollowsNextLink(t *testing.T) {
+	dir := t.TempDir()
+	first := writeFile(t, dir, "feed.xml", rssFixture)
+	writeFile(t, dir, "page2.xml", rssPage2Fixture)
+
+	contents, err := loadFeed(first, 0)
+	if err != nil {
+		t.Fatalf("loadFeed() error = %v", err)
+	}
+	if len(contents) != 2 {
+		t.Fatalf("len(contents) = %d, want 2 (应跟随 rel=next 翻页)", len(contents))
+	}
+	if contents[1].ID != "https://example.com/page2-post" {
+		t.Errorf("contents[1].ID = %q, 没有 guid 时应使用 link", contents[1].ID)
+	}
+	if contents[1].FilePath != filepath.Join(dir, "page2.xml") {
+		t.Errorf("contents[1].FilePath = %q, want 第二页路径", contents[1].FilePath)
+	}
+
+	limited, err := loadFeed(first, 1)
+	if err != nil {
+		t.Fatalf("loadFeed(limit=1) error = %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("len(limited) = %d, want 1", len(limited))
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+)
+
+// --file 模式的退出码，便于在 pre-commit 等脚本中区分文件问题和分析失败
+const (
+	exitAnalyzeFailed = 1 // 分析或输出失败
+	exitBadFile       = 2 // 文件不存在、不支持或无法解析
+)
+
+// analyzeSingleFile 分析单个内容文件，结果以格式化JSON输出到标准输出，不生成报告。
+// 关键词与批量分析一样以 contentDir 为语料计算TF-IDF。返回进程退出码，出错信息写到标准错误
+func analyzeSingleFile(contentAnalyzer *analyzer.ContentAnalyzer, contentDir, path string) int {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Printf("文件不存在: %s", path)
+		} else {
+			log.Printf("读取文件失败 %s: %v", path, err)
+		}
+		return exitBadFile
+	}
+	if info.IsDir() {
+		log.Printf("%s 是目录，--file 只接受单个内容文件", path)
+		return exitBadFile
+	}
+
+	content, err := parseContentFile(path)
+	if err != nil {
+		log.Printf("解析文件失败 %s: %v", path, err)
+		return exitBadFile
+	}
+	if content == nil {
+		log.Printf("不支持的文件类型: %s（支持 .md、.json、.yaml/.yml、.txt）", path)
+		return exitBadFile
+	}
+
+	useCorpusKeywords(contentAnalyzer, loadKeywordCorpus(contentDir, *content))
+
+	result, err := contentAnalyzer.Analyze(*content)
+	if err != nil {
+		log.Printf("分析失败 %s: %v", content.Title, err)
+		return exitAnalyzeFailed
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Printf("输出分析结果失败: %v", err)
+		return exitAnalyzeFailed
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/store"
+)
+
+// saveHistory 把本次运行的结果追加写入 storage.db_path 指定的数据库
+func saveHistory(dbPath string, results []models.AnalysisResult, analyzedAt time.Time) error {
+	db, err := store.OpenSQLite(dbPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Save(results, analyzedAt)
+}
+
+// runHistory 列出一篇内容历次分析的分数，参数为内容ID，没有ID的内容使用标题
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	dbPath := fs.String("db", "", "历史数据库文件，覆盖配置中的 storage.db_path")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: content-analyzer history [选项] 内容ID或标题")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定一个内容ID")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	if *dbPath != "" {
+		cfg.Storage.DBPath = *dbPath
+	}
+	if cfg.Storage.DBPath == "" {
+		return fmt.Errorf("未配置历史数据库，请设置 storage.db_path 或使用 -db")
+	}
+
+	db, err := store.OpenSQLite(cfg.Storage.DBPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	entries, err := db.History(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("查询历史失败: %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("没有 %s 的历史结果\n", fs.Arg(0))
+		return nil
+	}
+
+	fmt.Printf("%s 共 %d 次分析:\n", entries[len(entries)-1].Result.Title, len(entries))
+	for i, entry := range entries {
+		line := fmt.Sprintf("%s  %5.1f  %s", entry.AnalyzedAt.Format("2006-01-02 15:04:05"), entry.Result.Score.Total, entry.Result.Score.Level)
+		if i > 0 {
+			line += fmt.Sprintf("  (%+.1f)", entry.Result.Score.Total-entries[i-1].Result.Score.Total)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
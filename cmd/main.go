@@ -2,63 +2,259 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
 	"github.com/RobinCoderZhao/content-analyzer/internal/config"
 	"github.com/RobinCoderZhao/content-analyzer/internal/models"
 	"github.com/RobinCoderZhao/content-analyzer/internal/report"
+	"gopkg.in/yaml.v3"
 )
 
 func main() {
+	// 子命令
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "bench":
+			if err := runBench(os.Args[2:]); err != nil {
+				log.Fatal("基准测试失败:", err)
+			}
+			return
+		case "aggregate":
+			if err := runAggregate(os.Args[2:]); err != nil {
+				log.Fatal("汇总报告失败:", err)
+			}
+			return
+		case "serve":
+			if err := runServe(os.Args[2:]); err != nil {
+				log.Fatal("HTTP 服务失败:", err)
+			}
+			return
+		case "compare":
+			if err := runCompare(os.Args[2:]); err != nil {
+				log.Fatal("对比报告失败:", err)
+			}
+			return
+		case "rubric":
+			if err := runRubric(os.Args[2:]); err != nil {
+				log.Fatal("导出评分标准失败:", err)
+			}
+			return
+		case "history":
+			if err := runHistory(os.Args[2:]); err != nil {
+				log.Fatal("查询历史失败:", err)
+			}
+			return
+		case "schema":
+			if err := runSchema(os.Args[2:]); err != nil {
+				log.Fatal("导出 JSON Schema 失败:", err)
+			}
+			return
+		}
+	}
+
+	configPath := flag.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	changedAgainst := flag.String("changed-against", "", "只分析相对该基线目录新增或修改的内容，其余复用上次报告结果")
+	feedSource := flag.String("feed", "", "从 RSS/Atom 订阅（URL或本地文件）读取内容，代替扫描内容目录")
+	strictness := flag.String("strictness", "", "评分严格度: lenient, balanced, strict，覆盖配置中的 analysis.strictness")
+	feedLimit := flag.Int("feed-limit", 50, "从订阅读取的最大内容数，0表示不限制")
+	contentDir := flag.String("content-dir", "", "内容目录，覆盖配置中的 content_dir")
+	outputDir := flag.String("output-dir", "", "报告输出目录，覆盖配置中的 output_dir")
+	workers := flag.Int("workers", 1, "同时分析的内容数")
+	file := flag.String("file", "", "只分析这一个内容文件（.md/.json/.yaml/.txt），结果以JSON输出到标准输出，不生成报告")
+	dbPath := flag.String("db", "", "历史数据库文件（SQLite），本次结果追加写入，覆盖配置中的 storage.db_path")
+	watch := flag.Bool("watch", false, "持续监听内容目录（或 --file 指定的文件），保存后只重新分析变更的文件")
+	validate := flag.Bool("validate", false, "只解析内容目录（或 --file 指定的文件）并检查内容问题，不调用AI和图片分析、不生成报告，有文件不通过时退出码为1")
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintln(out, "用法: content-analyzer [选项]")
+		fmt.Fprintln(out, "      content-analyzer <bench|aggregate|compare|history|serve|rubric|schema> [选项]（子命令加 -h 查看各自的选项）")
+		fmt.Fprintln(out, "\n选项（优先于配置文件）:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *workers < 1 {
+		log.Fatalf("--workers 必须大于0: %d", *workers)
+	}
+
 	// 初始化配置
-	cfg, err := config.Load("config.yaml")
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatal("加载配置失败:", err)
 	}
+	if *contentDir != "" {
+		cfg.ContentDir = *contentDir
+	}
+	if *outputDir != "" {
+		cfg.OutputDir = *outputDir
+	}
+	if *dbPath != "" {
+		cfg.Storage.DBPath = *dbPath
+	}
+	if *strictness != "" {
+		if !config.ValidStrictness(*strictness) {
+			log.Fatalf("--strictness 取值无效: %q（可选 %s）", *strictness, strings.Join(config.StrictnessLevels, ", "))
+		}
+		cfg.Analysis.Strictness = *strictness
+	}
+
+	if *validate {
+		if *watch || *feedSource != "" || *changedAgainst != "" {
+			log.Fatal("--validate 不能与 --watch、--feed 或 --changed-against 同时使用")
+		}
+		os.Exit(runValidate(cfg, *file))
+	}
 
 	// 创建分析器
 	contentAnalyzer := analyzer.NewContentAnalyzer(cfg)
 
+	if *watch {
+		if *feedSource != "" || *changedAgainst != "" {
+			log.Fatal("--watch 不能与 --feed 或 --changed-against 同时使用")
+		}
+		if err := runWatch(cfg, contentAnalyzer, *file, *workers); err != nil {
+			log.Fatal("监听失败:", err)
+		}
+		return
+	}
+	if *file != "" {
+		os.Exit(analyzeSingleFile(contentAnalyzer, cfg.ContentDir, *file))
+	}
+
 	// 扫描内容目录
-	fmt.Println("开始扫描内容目录...")
-	contents, err := scanContentDirectory(cfg.ContentDir)
-	if err != nil {
-		log.Fatal("扫描目录失败:", err)
+	var contents []models.Content
+	if *feedSource != "" {
+		fmt.Println("开始读取订阅...")
+		contents, err = loadFeed(*feedSource, *feedLimit)
+		if err != nil {
+			log.Fatal("读取订阅失败:", err)
+		}
+	} else {
+		fmt.Println("开始扫描内容目录...")
+		contents, err = scanContentDirectory(cfg.ContentDir)
+		if err != nil {
+			log.Fatal("扫描目录失败:", err)
+		}
 	}
 
 	fmt.Printf("发现 %d 个内容文件\n", len(contents))
 
+	// 先统计全部内容的词频分布，关键词相关度按TF-IDF计算
+	useCorpusKeywords(contentAnalyzer, contents)
+
+	// 增量模式：找出变更文件并加载上次的分析结果
+	var diff *contentDiff
+	var cached map[string]models.AnalysisResult
+	if *changedAgainst != "" {
+		diff, err = diffContentDirs(cfg.ContentDir, *changedAgainst)
+		if err != nil {
+			log.Fatal("比较基线目录失败:", err)
+		}
+		cached, err = loadCachedResults(cfg.OutputDir)
+		if err != nil {
+			log.Fatal("加载已有分析结果失败:", err)
+		}
+		if !cfg.Report.HasFormat("json") {
+			log.Printf("警告: report.formats 不含 json，本次结果不会写入 analysis_report.json，之后的增量运行无法复用")
+		}
+		fmt.Printf("相对基线: %d 个新增/修改, %d 个未变, %d 个已删除\n",
+			len(diff.Changed), len(diff.Unchanged), len(diff.Deleted))
+		for _, path := range diff.Deleted {
+			fmt.Printf("已删除: %s\n", path)
+		}
+	}
+
 	// 分析内容
-	var results []models.AnalysisResult
-	for i, content := range contents {
-		fmt.Printf("分析进度: %d/%d - %s\n", i+1, len(contents), content.Title)
+	startedAt := time.Now()
+	results := analyzer.AnalyzeEach(contents, *workers, func(_ int, content models.Content) (models.AnalysisResult, bool) {
+		if cachedResult, ok := reusableResult(cfg.ContentDir, diff, cached, content); ok {
+			fmt.Printf("复用结果: %s\n", content.Title)
+			return cachedResult, true
+		}
 
 		result, err := contentAnalyzer.Analyze(content)
 		if err != nil {
 			log.Printf("分析失败 %s: %v", content.Title, err)
-			continue
+			return result, false
 		}
 
-		results = append(results, result)
+		return result, true
+	}, printProgress)
 
-		// 避免API调用过快
-		time.Sleep(time.Second * 2)
+	var deleted []string
+	if diff != nil {
+		deleted = diff.Deleted
+	}
+	usage := contentAnalyzer.AIUsage()
+	if err := generateReports(cfg, results, deleted, usage); err != nil {
+		log.Fatal("生成报告失败:", err)
+	}
+	if cfg.Storage.DBPath != "" {
+		if err := saveHistory(cfg.Storage.DBPath, results, startedAt); err != nil {
+			log.Fatal("保存历史结果失败:", err)
+		}
+		fmt.Printf("已保存 %d 篇内容的结果到: %s\n", len(results), cfg.Storage.DBPath)
 	}
 
-	// 生成报告
+	fmt.Printf("分析完成！报告已保存到: %s\n", cfg.OutputDir)
+	if usage.Requests > 0 {
+		fmt.Printf("AI 用量: %d 次请求，%d tokens，预计费用 $%.4f\n", usage.Requests, usage.TotalTokens, usage.EstimatedCost)
+	}
+}
+
+// generateReports 估算建议的预期影响并生成报告，deleted 为增量模式下已删除的内容，usage 为本次运行的AI用量
+func generateReports(cfg *config.Config, results []models.AnalysisResult, deleted []string, usage models.AIUsage) error {
+	// 有历史互动数据时，用实际数据估算建议的预期影响
+	analyzer.EstimateImpacts(results, cfg.Analysis.ImpactMinSamples)
+
 	fmt.Println("\n生成分析报告...")
 	reporter := report.NewReporter(cfg)
+	reporter.SetDeletedContent(deleted)
+	reporter.SetAIUsage(usage)
+	return reporter.GenerateReport(results)
+}
 
-	if err := reporter.GenerateReport(results); err != nil {
-		log.Fatal("生成报告失败:", err)
+// printProgress 每篇内容分析完后在命令行输出进度
+func printProgress(done, total int, current string) {
+	fmt.Printf("分析进度: %d/%d - %s\n", done, total, current)
+}
+
+// useCorpusKeywords 统计语料的词频分布，之后 Analyze 的关键词相关度按TF-IDF计算。
+// 批量、--file、--watch 和 serve 都以内容目录为语料，同一篇内容在各入口得到相同的关键词
+func useCorpusKeywords(contentAnalyzer *analyzer.ContentAnalyzer, corpus []models.Content) {
+	extractor := contentAnalyzer.NewKeywordExtractor()
+	for _, content := range corpus {
+		extractor.AddDocument(content.Text)
 	}
+	contentAnalyzer.SetKeywordExtractor(extractor)
+}
 
-	fmt.Printf("分析完成！报告已保存到: %s\n", cfg.OutputDir)
+// loadKeywordCorpus 扫描内容目录作为TF-IDF语料，extra 中正文不在目录里的内容一并计入；
+// 目录无法读取时只用 extra，此时关键词退回按词频计算
+func loadKeywordCorpus(contentDir string, extra ...models.Content) []models.Content {
+	corpus, err := scanContentDirectory(contentDir)
+	if err != nil {
+		log.Printf("扫描内容目录 %s 失败，关键词按词频计算: %v", contentDir, err)
+		corpus = nil
+	}
+
+	texts := make(map[string]bool, len(corpus))
+	for _, content := range corpus {
+		texts[content.Text] = true
+	}
+	for _, content := range extra {
+		if !texts[content.Text] {
+			corpus = append(corpus, content)
+		}
+	}
+	return corpus
 }
 
 // scanContentDirectory 扫描内容目录
@@ -92,6 +288,16 @@ func scanContentDirectory(dir string) ([]models.Content, error) {
 	return contents, err
 }
 
+// isContentFile 是否为支持的内容文件类型
+func isContentFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".json", ".md", ".yaml", ".yml", ".txt":
+		return true
+	default:
+		return false
+	}
+}
+
 // parseContentFile 解析内容文件
 func parseContentFile(filePath string) (*models.Content, error) {
 	ext := filepath.Ext(filePath)
@@ -101,6 +307,10 @@ func parseContentFile(filePath string) (*models.Content, error) {
 		return parseJSONContent(filePath)
 	case ".md":
 		return parseMarkdownContent(filePath)
+	case ".yaml", ".yml":
+		return parseYAMLContent(filePath)
+	case ".txt":
+		return parseTextContent(filePath)
 	default:
 		// 跳过不支持的文件类型
 		return nil, nil
@@ -123,19 +333,49 @@ func parseJSONContent(filePath string) (*models.Content, error) {
 	return &content, nil
 }
 
-// parseMarkdownContent 解析Markdown格式的内容文件
-func parseMarkdownContent(filePath string) (*models.Content, error) {
+// parseYAMLContent 解析YAML格式的内容文件，字段名与JSON格式相同
+func parseYAMLContent(filePath string) (*models.Content, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var content models.Content
+	if err := decodeContentFields(raw, &content); err != nil {
+		return nil, err
+	}
+
+	content.FilePath = filePath
+	return &content, nil
+}
+
+// decodeContentFields 把 YAML/TOML 解析出的通用结构转成JSON再解码到 content，
+// 字段名与JSON内容文件一致
+func decodeContentFields(raw interface{}, content *models.Content) error {
+	converted, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(converted, content)
+}
+
+// parseTextContent 解析纯文本内容文件，文件名作为标题
+func parseTextContent(filePath string) (*models.Content, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	// 简单的Markdown解析（可以后续优化）
 	content := models.Content{
 		FilePath: filePath,
 		Title:    filepath.Base(filePath),
 		Text:     string(data),
-		Type:     "markdown",
+		Type:     "text",
 	}
 
 	return &content, nil
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestParseYAMLContent(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"post.yaml", "post.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, dir, name, `id: camping-001
+title: 周末露营装备清单
+text: |
+  帐篷、睡袋和炉具是露营必备。
+  出发前检查天气。
+type: post
+tags: [露营, 户外]
+published_at: 2024-03-01T08:00:00Z
+images:
+  - path: ./images/tent.png
+    caption: 帐篷
+engagement:
+  likes: 12
+`)
+
+			content, err := parseContentFile(path)
+			if err != nil {
+				t.Fatalf("parseContentFile() error = %v", err)
+			}
+			if content.ID != "camping-001" || content.Title != "周末露营装备清单" || content.Type != "post" {
+				t.Errorf("基本字段 = %q/%q/%q, want camping-001/周末露营装备清单/post", content.ID, content.Title, content.Type)
+			}
+			if content.Text != "帐篷、睡袋和炉具是露营必备。\n出发前检查天气。\n" {
+				t.Errorf("Text = %q, want 多行正文", content.Text)
+			}
+			if len(content.Tags) != 2 || content.Tags[1] != "户外" {
+				t.Errorf("Tags = %v, want [露营 户外]", content.Tags)
+			}
+			if want := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC); !content.PublishedAt.Equal(want) {
+				t.Errorf("PublishedAt = %v, want %v", content.PublishedAt, want)
+			}
+			if len(content.Images) != 1 || content.Images[0].Path != "./images/tent.png" || content.Images[0].Caption != "帐篷" {
+				t.Errorf("Images = %+v, want 一张带说明的图片", content.Images)
+			}
+			if content.Engagement.Likes != 12 {
+				t.Errorf("Engagement.Likes = %d, want 12", content.Engagement.Likes)
+			}
+			if content.FilePath != path {
+				t.Errorf("FilePath = %q, want %q", content.FilePath, path)
+			}
+		})
+	}
+}
+
+func TestParseYAMLContentInvalid(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "broken.yaml", "title: [未闭合\n")
+	if _, err := parseContentFile(path); err == nil {
+		t.Error("parseContentFile() error = nil, want YAML 语法错误")
+	}
+}
+
+func TestParseTextContent(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "露营笔记.txt", "第一行\n第二行\n")
+
+	content, err := parseContentFile(path)
+	if err != nil {
+		t.Fatalf("parseContentFile() error = %v", err)
+	}
+	if content.Title != "露营笔记.txt" {
+		t.Errorf("Title = %q, want 文件名", content.Title)
+	}
+	if content.Text != "第一行\n第二行\n" {
+		t.Errorf("Text = %q, want 原文", content.Text)
+	}
+	if content.Type != "text" || content.FilePath != path {
+		t.Errorf("Type/FilePath = %q/%q, want text/%q", content.Type, content.FilePath, path)
+	}
+}
+
+func TestScanContentDirectoryFileTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", `{"id":"json","title":"JSON"}`)
+	writeFile(t, dir, "b.yaml", "id: yaml\ntitle: YAML\n")
+	writeFile(t, dir, "nested/c.yml", "id: yml\ntitle: YML\n")
+	writeFile(t, dir, "d.txt", "纯文本")
+	writeFile(t, dir, "e.md", "# Markdown\n正文")
+	writeFile(t, dir, "ignored.csv", "id,title\n")
+
+	contents, err := scanContentDirectory(dir)
+	if err != nil {
+		t.Fatalf("scanContentDirectory() error = %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, content := range contents {
+		got[filepath.Ext(content.FilePath)] = true
+	}
+	for _, ext := range []string{".json", ".yaml", ".yml", ".txt", ".md"} {
+		if !got[ext] {
+			t.Errorf("未扫描到 %s 文件", ext)
+		}
+	}
+	if len(contents) != 5 {
+		t.Errorf("扫描到 %d 篇内容, want 5（跳过 .csv）", len(contents))
+	}
+}
+
+// 单篇分析（--file、serve）与批量分析使用同一语料，关键词应相同
+func TestCorpusKeywordsMatchAcrossEntryPoints(t *testing.T) {
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ContentDir = t.TempDir()
+	writeFile(t, cfg.ContentDir, "a.txt", "camping tent stove. camping tent lantern camping.")
+	writeFile(t, cfg.ContentDir, "b.txt", "camping kayak paddle. camping kayak river.")
+	writeFile(t, cfg.ContentDir, "c.txt", "camping hiking boots. camping hiking trail.")
+
+	contents, err := scanContentDirectory(cfg.ContentDir)
+	if err != nil || len(contents) != 3 {
+		t.Fatalf("scanContentDirectory() = %d 篇, %v", len(contents), err)
+	}
+	var target models.Content
+	for _, content := range contents {
+		if strings.Contains(content.Text, "tent") {
+			target = content
+		}
+	}
+
+	// 批量分析：语料为扫描到的全部内容
+	batch := analyzer.NewContentAnalyzer(cfg)
+	useCorpusKeywords(batch, contents)
+	batchResult, err := batch.Analyze(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 单篇分析：语料从内容目录加载，目标内容已在目录中不重复计入
+	single := analyzer.NewContentAnalyzer(cfg)
+	useCorpusKeywords(single, loadKeywordCorpus(cfg.ContentDir, target))
+	singleResult, err := single.Analyze(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(batchResult.Keywords, singleResult.Keywords) {
+		t.Errorf("单篇关键词 = %+v, want 与批量相同 %+v", singleResult.Keywords, batchResult.Keywords)
+	}
+	if top := singleResult.Keywords[0].Word; top == "camping" {
+		t.Errorf("最相关的关键词 = %s, want 不是每篇都有的词", top)
+	}
+}
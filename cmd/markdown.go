@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+	"gopkg.in/yaml.v3"
+)
+
+// Markdown 图片引用 ![说明](路径 "标题")，路径可用尖括号包住
+var markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// front matter 中发布时间可用的写法，依次尝试
+var frontMatterDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseMarkdownContent 解析Markdown格式的内容文件。
+// 文件开头的 front matter（--- 包住的YAML或 +++ 包住的TOML）按JSON内容文件的字段解析，并从正文中去掉；
+// front matter 没有标题时用第一个一级标题，都没有时用文件名。正文中引用的图片加入 Images
+func parseMarkdownContent(filePath string) (*models.Content, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var content models.Content
+	body := strings.TrimPrefix(string(data), "\uFEFF")
+
+	if frontMatter, rest, format, ok := splitFrontMatter(body); ok {
+		if err := decodeFrontMatter(frontMatter, format, &content); err != nil {
+			return nil, fmt.Errorf("解析 front matter 失败: %w", err)
+		}
+		body = rest
+	}
+
+	if content.Title == "" {
+		if title, rest, ok := extractTitleHeading(body); ok {
+			content.Title = title
+			body = rest
+		} else {
+			content.Title = filepath.Base(filePath)
+		}
+	}
+
+	content.Images = append(content.Images, markdownImages(body)...)
+	content.Images = resolveImagePaths(filePath, content.Images)
+
+	content.FilePath = filePath
+	content.Text = strings.TrimSpace(body)
+	if content.Type == "" {
+		content.Type = "markdown"
+	}
+
+	return &content, nil
+}
+
+// splitFrontMatter 拆出文件开头的 front matter，format 为 yaml 或 toml。
+// 没有 front matter 或缺少结束分隔行时 ok 为 false
+func splitFrontMatter(text string) (frontMatter, body, format string, ok bool) {
+	firstLine, rest, found := strings.Cut(text, "\n")
+	if !found {
+		return "", text, "", false
+	}
+
+	var closers []string
+	switch strings.TrimRight(firstLine, " \t\r") {
+	case "---":
+		format, closers = "yaml", []string{"---", "..."}
+	case "+++":
+		format, closers = "toml", []string{"+++"}
+	default:
+		return "", text, "", false
+	}
+
+	offset := 0
+	for offset <= len(rest) {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		end := offset + len(line) + 1
+		for _, closer := range closers {
+			if strings.TrimRight(line, " \t\r") == closer {
+				if end > len(rest) {
+					end = len(rest)
+				}
+				return rest[:offset], rest[end:], format, true
+			}
+		}
+		offset = end
+	}
+	return "", text, "", false
+}
+
+// decodeFrontMatter 解析 front matter 到 content。
+// 除JSON内容文件的字段外，还接受 date 作为 published_at 的别名、frontMatterDateLayouts 中各种写法的发布时间，
+// 以及只写路径的图片列表（images: [a.jpg, b.jpg]）
+func decodeFrontMatter(frontMatter, format string, content *models.Content) error {
+	var raw map[string]interface{}
+	switch format {
+	case "toml":
+		parsed, err := config.ParseTOML(frontMatter)
+		if err != nil {
+			return err
+		}
+		raw = parsed
+	default:
+		if err := yaml.Unmarshal([]byte(frontMatter), &raw); err != nil {
+			return err
+		}
+	}
+	if raw == nil {
+		return nil
+	}
+
+	if _, ok := raw["published_at"]; !ok {
+		if date, ok := raw["date"]; ok {
+			raw["published_at"] = date
+		}
+	}
+	if date, ok := raw["published_at"]; ok && date != nil {
+		t, err := parseFrontMatterDate(date)
+		if err != nil {
+			return err
+		}
+		raw["published_at"] = t
+	}
+
+	if images, ok := raw["images"].([]interface{}); ok {
+		for i, img := range images {
+			if path, ok := img.(string); ok {
+				images[i] = map[string]interface{}{"path": path}
+			}
+		}
+	}
+
+	return decodeContentFields(raw, content)
+}
+
+// parseFrontMatterDate 把 front matter 中的发布时间转成 time.Time。
+// YAML/TOML 解析器已识别为时间的值直接返回，字符串按 frontMatterDateLayouts 解析，没有时区的按 UTC 处理
+func parseFrontMatterDate(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		date := strings.TrimSpace(v)
+		for _, layout := range frontMatterDateLayouts {
+			if t, err := time.Parse(layout, date); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("无法识别的发布时间 %q（可用 RFC 3339、2006-01-02 15:04:05 -0700 或 2006-01-02）", v)
+	default:
+		return time.Time{}, fmt.Errorf("发布时间类型无效: %T", value)
+	}
+}
+
+// extractTitleHeading 找出代码块之外的第一个一级标题，返回标题和去掉该行后的正文
+func extractTitleHeading(body string) (title, rest string, ok bool) {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+
+		// 去掉可选的结尾 #，如 "# 标题 #"，但保留 "# C#" 这类标题里的 #
+		title = strings.TrimPrefix(trimmed, "# ")
+		if closed := strings.TrimRight(title, "#"); strings.HasSuffix(closed, " ") {
+			title = closed
+		}
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+		rest = strings.Join(append(lines[:i:i], lines[i+1:]...), "\n")
+		return title, rest, true
+	}
+	return "", body, false
+}
+
+// markdownImages 收集正文中的图片引用，http(s) 链接记为远程图片
+func markdownImages(body string) []models.Image {
+	var images []models.Image
+	for _, match := range markdownImagePattern.FindAllStringSubmatch(body, -1) {
+		img := models.Image{Caption: strings.TrimSpace(match[1])}
+		target := match[2]
+		if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+			img.URL = target
+		} else {
+			img.Path = target
+		}
+		images = append(images, img)
+	}
+	return images
+}
+
+// resolveImagePaths 把相对路径的图片按 Markdown 文件所在目录转成绝对路径，并合并重复的图片
+func resolveImagePaths(filePath string, images []models.Image) []models.Image {
+	seen := make(map[string]int)
+	var resolved []models.Image
+	for _, img := range images {
+		if img.Path != "" && !services.IsDataURI(img.Path) && !filepath.IsAbs(img.Path) {
+			path := filepath.Join(filepath.Dir(filePath), img.Path)
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			img.Path = path
+		}
+
+		key := img.Path + "\x00" + img.URL
+		if i, ok := seen[key]; ok {
+			if resolved[i].Caption == "" {
+				resolved[i].Caption = img.Caption
+			}
+			continue
+		}
+		seen[key] = len(resolved)
+		resolved = append(resolved, img)
+	}
+	return resolved
+}
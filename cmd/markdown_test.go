@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMarkdownFrontMatterDates(t *testing.T) {
+	utc := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		content string
+		want    time.Time
+	}{
+		{"YAML RFC 3339", "---\ntitle: 标题\ndate: 2024-03-01T16:30:00+08:00\n---\n正文", utc},
+		{"YAML 引号内 RFC 3339", "---\ntitle: 标题\npublished_at: \"2024-03-01T08:30:00Z\"\n---\n正文", utc},
+		{"YAML 带时区的日期时间", "---\ntitle: 标题\ndate: \"2024-03-01 16:30:00 +0800\"\n---\n正文", utc},
+		{"YAML 只有日期", "---\ntitle: 标题\ndate: 2024-03-01\n---\n正文", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"YAML 引号内只有日期", "---\ntitle: 标题\ndate: \"2024-03-01\"\n---\n正文", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"TOML 日期时间", "+++\ntitle = \"标题\"\ndate = 2024-03-01T16:30:00+08:00\n+++\n正文", utc},
+		{"TOML 字符串日期时间", "+++\ntitle = \"标题\"\ndate = \"2024-03-01 16:30:00 +0800\"\n+++\n正文", utc},
+		{"TOML 只有日期", "+++\ntitle = \"标题\"\ndate = 2024-03-01\n+++\n正文", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "post.md", tt.content)
+			content, err := parseMarkdownContent(path)
+			if err != nil {
+				t.Fatalf("parseMarkdownContent() error = %v", err)
+			}
+			if !content.PublishedAt.Equal(tt.want) {
+				t.Errorf("PublishedAt = %v, want %v", content.PublishedAt, tt.want)
+			}
+			if content.Title != "标题" || content.Text != "正文" {
+				t.Errorf("Title/Text = %q/%q, want 标题/正文", content.Title, content.Text)
+			}
+		})
+	}
+}
+
+func TestParseMarkdownInvalidDate(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "post.md", "---\ntitle: 标题\ndate: 下周一\n---\n正文")
+	_, err := parseMarkdownContent(path)
+	if err == nil || !strings.Contains(err.Error(), "下周一") {
+		t.Errorf("parseMarkdownContent() error = %v, want 指出无法识别的发布时间", err)
+	}
+}
+
+func TestParseMarkdownWithoutFrontMatter(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "post.md", "# 周末露营\n\n帐篷和睡袋。\n\n![营地](images/camp.png)\n")
+	content, err := parseMarkdownContent(path)
+	if err != nil {
+		t.Fatalf("parseMarkdownContent() error = %v", err)
+	}
+	if content.Title != "周末露营" {
+		t.Errorf("Title = %q, want 一级标题", content.Title)
+	}
+	if !content.PublishedAt.IsZero() {
+		t.Errorf("PublishedAt = %v, want 零值", content.PublishedAt)
+	}
+	if content.Type != "markdown" {
+		t.Errorf("Type = %q, want markdown", content.Type)
+	}
+	if len(content.Images) != 1 || !strings.HasSuffix(content.Images[0].Path, "images/camp.png") {
+		t.Errorf("Images = %+v, want 正文中的一张图片", content.Images)
+	}
+}
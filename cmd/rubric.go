@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// runRubric 导出当前配置下生效的评分标准
+func runRubric(args []string) error {
+	fs := flag.NewFlagSet("rubric", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	format := fs.String("format", "markdown", "输出格式: markdown 或 json")
+	output := fs.String("o", "", "输出文件，默认输出到标准输出")
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	rubric := analyzer.NewContentAnalyzer(cfg).Rubric()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rubric)
+	case "markdown", "md":
+		_, err := io.WriteString(w, renderRubricMarkdown(rubric))
+		return err
+	default:
+		return fmt.Errorf("不支持的格式: %s", *format)
+	}
+}
+
+func renderRubricMarkdown(rubric analyzer.Rubric) string {
+	var b strings.Builder
+
+	b.WriteString("# 内容质量评分标准\n\n")
+	b.WriteString("总分为各维度得分（0-100）的加权平均。\n\n")
+	if rubric.StrictnessFactor != 1 {
+		fmt.Fprintf(&b, "评分严格度为 %s：下列检查项得出的维度得分再除以 %.2f（上限100），建议的触发门槛乘以 %.2f。\n\n",
+			rubric.Strictness, rubric.StrictnessFactor, rubric.StrictnessFactor)
+	}
+
+	b.WriteString("| 维度 | 权重 | 基础分 |\n|------|------|--------|\n")
+	for _, d := range rubric.Dimensions {
+		fmt.Fprintf(&b, "| %s | %.0f%% | %.0f |\n", d.Name, d.Weight*100, d.BaseScore)
+	}
+	b.WriteString("\n")
+
+	for _, d := range rubric.Dimensions {
+		fmt.Fprintf(&b, "## %s（权重 %.0f%%）\n\n", d.Name, d.Weight*100)
+		fmt.Fprintf(&b, "基础分 %.0f，单项得分上限 100。\n\n", d.BaseScore)
+		b.WriteString("| 检查项 | 分值 |\n|--------|------|\n")
+		for _, c := range d.Criteria {
+			fmt.Fprintf(&b, "| %s | %s |\n", c.Check, c.Points)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(rubric.CustomDimensions) > 0 {
+		b.WriteString("## 自定义维度\n\n")
+		b.WriteString("自定义维度得分按权重与总分混合：总分 = 总分×(1-权重) + 维度得分×权重。\n\n")
+		b.WriteString("| 维度 | 表达式 | 权重 |\n|------|--------|------|\n")
+		for _, c := range rubric.CustomDimensions {
+			fmt.Fprintf(&b, "| %s | `%s` | %.0f%% |\n", c.Name, c.Expression, c.Weight*100)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## 等级\n\n| 等级 | 最低分 |\n|------|--------|\n")
+	for _, l := range rubric.Levels {
+		fmt.Fprintf(&b, "| %s | %.0f |\n", l.Level, l.MinScore)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## 其他要求\n\n")
+	fmt.Fprintf(&b, "- 建议词数: %d-%d\n", rubric.MinWordCount, rubric.MaxWordCount)
+	if len(rubric.RequiredKeywords) > 0 {
+		fmt.Fprintf(&b, "- 必需品牌关键词: %s\n", strings.Join(rubric.RequiredKeywords, "、"))
+	}
+
+	return b.String()
+}
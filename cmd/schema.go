@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/report"
+)
+
+// runSchema 导出分析结果的 JSON Schema（draft-07），供前端生成类型或校验报告
+func runSchema(args []string) error {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+	kind := fs.String("type", "result", "导出对象: result（单篇分析结果，即 --file 的输出）或 report（整个 analysis_report.json）")
+	output := fs.String("o", "", "输出文件，默认输出到标准输出")
+	fs.Parse(args)
+
+	var schema map[string]interface{}
+	switch *kind {
+	case "result":
+		schema = report.ResultSchema()
+	case "report":
+		schema = report.ReportSchema()
+	default:
+		return fmt.Errorf("不支持的类型: %s", *kind)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("创建输出文件失败: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schema)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// schemaValidator 按生成器用到的 draft-07 关键字（$ref、anyOf、type、properties、required、
+// additionalProperties、items、enum、minimum、maximum）校验 JSON 值，返回所有不符合的位置
+type schemaValidator struct {
+	root map[string]interface{}
+	errs []string
+}
+
+func (v *schemaValidator) validate(path string, schema map[string]interface{}, value interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		definition, ok := v.root["definitions"].(map[string]interface{})[name].(map[string]interface{})
+		if !ok {
+			v.errs = append(v.errs, fmt.Sprintf("%s: 找不到定义 %s", path, ref))
+			return
+		}
+		v.validate(path, definition, value)
+		return
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for _, option := range anyOf {
+			sub := schemaValidator{root: v.root}
+			sub.validate(path, option.(map[string]interface{}), value)
+			if len(sub.errs) == 0 {
+				return
+			}
+		}
+		v.errs = append(v.errs, fmt.Sprintf("%s: 不符合 anyOf 中的任何一项", path))
+		return
+	}
+
+	if typ, ok := schema["type"]; ok && !matchesType(typ, value) {
+		v.errs = append(v.errs, fmt.Sprintf("%s: %T 不是 %v", path, value, typ))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.errs = append(v.errs, fmt.Sprintf("%s: %v 不在 %v 中", path, value, enum))
+		}
+	}
+
+	if number, ok := value.(float64); ok {
+		if min, ok := schema["minimum"].(float64); ok && number < min {
+			v.errs = append(v.errs, fmt.Sprintf("%s: %v 小于 %v", path, number, min))
+		}
+		if max, ok := schema["maximum"].(float64); ok && number > max {
+			v.errs = append(v.errs, fmt.Sprintf("%s: %v 大于 %v", path, number, max))
+		}
+	}
+
+	switch value := value.(type) {
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				v.validate(fmt.Sprintf("%s[%d]", path, i), items, item)
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := value[name.(string)]; !ok {
+				v.errs = append(v.errs, fmt.Sprintf("%s: 缺少必填字段 %s", path, name))
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := properties[key].(map[string]interface{}); ok {
+				v.validate(path+"."+key, property, value[key])
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					v.errs = append(v.errs, fmt.Sprintf("%s: 不允许的字段 %s", path, key))
+				}
+			case map[string]interface{}:
+				v.validate(path+"."+key, additional, value[key])
+			}
+		}
+	}
+}
+
+// matchesType 判断 JSON 值是否符合 type（单个类型或类型列表）
+func matchesType(typ interface{}, value interface{}) bool {
+	if types, ok := typ.([]interface{}); ok {
+		for _, t := range types {
+			if matchesType(t, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// exportSchema 用 schema 子命令导出 JSON Schema 并解析
+func exportSchema(t *testing.T, kind string) map[string]interface{} {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := runSchema([]string{"-type", kind, "-o", path}); err != nil {
+		t.Fatalf("runSchema 失败: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("解析导出的 schema 失败: %v", err)
+	}
+	return schema
+}
+
+// analyzeForSchema 用默认配置（不调用 AI）分析一篇真实内容，返回 JSON 编码后再解码的结果
+func analyzeForSchema(t *testing.T) map[string]interface{} {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	result, err := analyzer.NewContentAnalyzer(cfg).Analyze(models.Content{
+		ID:    "camping-001",
+		Title: "周末露营装备清单：新手必看的10件装备",
+		Text: "## 帐篷\n帐篷、睡袋和炉具是露营必备。出发前一定要检查天气！\n\n" +
+			"## 小贴士\nWe always pack a headlamp. 觉得有用就点赞收藏，欢迎评论区分享你的清单。",
+		Type:        "post",
+		Tags:        []string{"露营", "户外"},
+		PublishedAt: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		Engagement:  models.Engagement{Likes: 120, Comments: 8, Shares: 5},
+	})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestResultSchemaValidatesRealResult(t *testing.T) {
+	schema := exportSchema(t, "result")
+	if schema["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("$schema = %v, want draft-07", schema["$schema"])
+	}
+
+	result := analyzeForSchema(t)
+	v := schemaValidator{root: schema}
+	v.validate("$", schema, result)
+	for _, err := range v.errs {
+		t.Error(err)
+	}
+}
+
+func TestResultSchemaRejectsInvalidResult(t *testing.T) {
+	schema := exportSchema(t, "result")
+
+	tests := []struct {
+		name   string
+		modify func(result map[string]interface{})
+	}{
+		{"等级不在枚举中", func(result map[string]interface{}) {
+			result["score"].(map[string]interface{})["level"] = "perfect"
+		}},
+		{"总分超出范围", func(result map[string]interface{}) {
+			result["score"].(map[string]interface{})["total"] = 120.0
+		}},
+		{"缺少必填字段", func(result map[string]interface{}) {
+			delete(result, "score")
+		}},
+		{"未定义的字段", func(result map[string]interface{}) {
+			result["unknown_field"] = true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzeForSchema(t)
+			tt.modify(result)
+
+			v := schemaValidator{root: schema}
+			v.validate("$", schema, result)
+			if len(v.errs) == 0 {
+				t.Error("校验通过, want 报错")
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// 收到退出信号后等待进行中请求完成的最长时间
+const shutdownTimeout = 30 * time.Second
+
+// runServe 以 HTTP 服务方式提供分析：POST /analyze 分析单篇内容，GET /healthz 检查服务状态（?deep=1 时请求 AI 服务）
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "配置文件路径，支持 .yaml/.yml、.json、.toml")
+	addr := fs.String("addr", "", "监听地址，默认使用配置中的 server.addr")
+	fs.Parse(args)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("加载配置失败: %w", err)
+	}
+	if *addr != "" {
+		cfg.Server.Addr = *addr
+	}
+	if cfg.Server.ContentRoot != "" {
+		if info, err := os.Stat(cfg.Server.ContentRoot); err != nil || !info.IsDir() {
+			return fmt.Errorf("server.content_root 不是可用的目录: %s", cfg.Server.ContentRoot)
+		}
+	}
+	if !cfg.Server.AllowRemoteImages && cfg.Image.RemoteImages != "skip" {
+		log.Println("server.allow_remote_images 未开启，远程图片一律跳过")
+		cfg.Image.RemoteImages = "skip"
+	}
+
+	// 关键词与批量分析一样以内容目录为语料计算TF-IDF，语料在启动时统计一次
+	contentAnalyzer := analyzer.NewContentAnalyzer(cfg)
+	useCorpusKeywords(contentAnalyzer, loadKeywordCorpus(cfg.ContentDir))
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           newServerHandler(cfg, contentAnalyzer, services.NewServiceManager(cfg)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("HTTP 服务已启动: %s", cfg.Server.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("HTTP 服务异常退出: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("收到退出信号，等待进行中的请求完成...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("关闭 HTTP 服务失败: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	log.Println("HTTP 服务已关闭")
+	return nil
+}
+
+// newServerHandler 注册 HTTP 接口，独立出来便于用 httptest 调用
+func newServerHandler(cfg *config.Config, contentAnalyzer *analyzer.ContentAnalyzer, sm *services.ServiceManager) http.Handler {
+	mux := http.NewServeMux()
+	deepHealth := &healthCache{sm: sm}
+
+	mux.HandleFunc("/analyze", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSONError(w, http.StatusMethodNotAllowed, "只支持 POST")
+			return
+		}
+
+		var content models.Content
+		body := http.MaxBytesReader(w, r.Body, cfg.Server.MaxBodyBytes)
+		if err := json.NewDecoder(body).Decode(&content); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeJSONError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("请求体超过 %d 字节", tooLarge.Limit))
+				return
+			}
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("解析内容失败: %v", err))
+			return
+		}
+		if content.Title == "" && content.Text == "" {
+			writeJSONError(w, http.StatusBadRequest, "title 和 text 不能同时为空")
+			return
+		}
+		if err := confineImagePaths(content.Images, cfg.Server.ContentRoot); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result, err := contentAnalyzer.Analyze(content)
+		if err != nil {
+			log.Printf("分析失败 %s: %v", content.Title, err)
+			writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("分析失败: %v", err))
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSONError(w, http.StatusMethodNotAllowed, "只支持 GET")
+			return
+		}
+
+		// 默认只做不调用 AI 的存活检查；?deep=1 才实际请求 AI 提供方，结果缓存一段时间避免频繁探测产生费用
+		var report services.HealthReport
+		if r.URL.Query().Get("deep") == "1" {
+			report = deepHealth.get(r.Context())
+		} else {
+			report = sm.CheckLiveness()
+		}
+
+		status := http.StatusOK
+		if report.Status == services.HealthDown {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+
+	return mux
+}
+
+// 深度健康检查结果的缓存时长
+const deepHealthCacheTTL = 30 * time.Second
+
+// healthCache 缓存 CheckHealth 的结果，并发请求共用同一次检查
+type healthCache struct {
+	sm *services.ServiceManager
+
+	mu     sync.Mutex
+	report services.HealthReport
+}
+
+func (c *healthCache) get(ctx context.Context) services.HealthReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report.CheckedAt.IsZero() || time.Since(c.report.CheckedAt) > deepHealthCacheTTL {
+		c.report = c.sm.CheckHealth(ctx)
+	}
+	return c.report
+}
+
+// confineImagePaths 校验请求中图片的本地路径并改写为绝对路径。
+// data URI 照常处理；其他路径解析符号链接后必须位于 root 内，root 为空时一律拒绝，避免客户端读取服务器上的任意文件
+func confineImagePaths(images []models.Image, root string) error {
+	for i := range images {
+		path := images[i].Path
+		if path == "" || services.IsDataURI(path) {
+			continue
+		}
+		if root == "" {
+			return fmt.Errorf("不接受图片的本地路径 %q，请改用 url 或 data URI", path)
+		}
+
+		resolvedRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			return fmt.Errorf("解析 server.content_root 失败: %w", err)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(resolvedRoot, path)
+		}
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return fmt.Errorf("图片路径 %q 无效", images[i].Path)
+		}
+		rel, err := filepath.Rel(resolvedRoot, resolved)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("图片路径 %q 不在允许的目录内", images[i].Path)
+		}
+		images[i].Path = resolved
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("写入响应失败: %v", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// newTestServer 用默认配置（不调用 AI）启动 serve 的 handler，modify 可在创建服务前调整配置
+func newTestServer(t *testing.T, modify func(cfg *config.Config)) *httptest.Server {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg.OutputDir = t.TempDir()
+	if modify != nil {
+		modify(cfg)
+	}
+
+	server := httptest.NewServer(newServerHandler(cfg, analyzer.NewContentAnalyzer(cfg), services.NewServiceManager(cfg)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestServeAnalyze(t *testing.T) {
+	server := newTestServer(t, func(cfg *config.Config) { cfg.Server.MaxBodyBytes = 1024 })
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{"分析成功", http.MethodPost, `{"id":"a","title":"周末露营装备清单","text":"帐篷、睡袋和炉具是露营必备。"}`, http.StatusOK},
+		{"JSON 无效", http.MethodPost, `{"title":`, http.StatusBadRequest},
+		{"标题和正文为空", http.MethodPost, `{"id":"a"}`, http.StatusBadRequest},
+		{"不支持 GET", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"请求体过大", http.MethodPost, `{"title":"大","text":"` + strings.Repeat("露", 1024) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+"/analyze", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("请求失败: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("状态码 = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("响应不是 JSON: %v", err)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if body["content_id"] != "a" {
+					t.Errorf("content_id = %v, want a", body["content_id"])
+				}
+				return
+			}
+			if body["error"] == nil {
+				t.Errorf("错误响应 = %v, want 带 error 字段", body)
+			}
+			if tt.wantStatus == http.StatusMethodNotAllowed && resp.Header.Get("Allow") != http.MethodPost {
+				t.Errorf("Allow = %q, want POST", resp.Header.Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestServeHealthz(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(cfg *config.Config)
+		wantStatus int
+		wantHealth string
+	}{
+		{"服务正常", nil, http.StatusOK, services.HealthOK},
+		// 未配置图片格式时图片服务不可用
+		{"图片服务不可用", func(cfg *config.Config) { cfg.Image.SupportedExt = nil }, http.StatusServiceUnavailable, services.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.modify)
+			status, report := getHealthz(t, server.URL+"/healthz")
+
+			if status != tt.wantStatus {
+				t.Errorf("状态码 = %d, want %d", status, tt.wantStatus)
+			}
+			if report.Status != tt.wantHealth {
+				t.Errorf("status = %q, want %q", report.Status, tt.wantHealth)
+			}
+			if len(report.Services) != 2 {
+				t.Errorf("services = %+v, want ai 和 image 两项", report.Services)
+			}
+		})
+	}
+}
+
+func TestServeHealthzDeep(t *testing.T) {
+	tests := []struct {
+		name           string
+		aiStatus       int
+		wantDeepStatus int
+		wantAIHealth   string
+	}{
+		{"AI 服务正常", http.StatusOK, http.StatusOK, services.HealthOK},
+		{"AI 服务拒绝访问", http.StatusUnauthorized, http.StatusServiceUnavailable, services.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pings atomic.Int32
+			ai := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pings.Add(1)
+				w.WriteHeader(tt.aiStatus)
+				io.WriteString(w, `{"choices":[{"message":{"role":"assistant","content":"pong"}}]}`)
+			}))
+			t.Cleanup(ai.Close)
+
+			server := newTestServer(t, func(cfg *config.Config) {
+				cfg.AI.APIKey = "test-key"
+				cfg.AI.BaseURL = ai.URL
+				cfg.AI.MaxRetries = 0
+			})
+
+			// 存活检查不请求 AI
+			status, report := getHealthz(t, server.URL+"/healthz")
+			if status != http.StatusOK || report.Services[0].Status != services.HealthSkipped {
+				t.Errorf("存活检查 = %d %+v, want 200 且 AI 为 skipped", status, report.Services[0])
+			}
+			if pings.Load() != 0 {
+				t.Fatalf("存活检查请求了 AI %d 次, want 0", pings.Load())
+			}
+
+			// 深度检查请求一次 AI，缓存期内不再请求
+			for i := 0; i < 2; i++ {
+				status, report = getHealthz(t, server.URL+"/healthz?deep=1")
+				if status != tt.wantDeepStatus {
+					t.Errorf("深度检查状态码 = %d, want %d", status, tt.wantDeepStatus)
+				}
+				if ai := report.Services[0]; ai.Name != "ai" || ai.Status != tt.wantAIHealth || ai.Attempts != 1 {
+					t.Errorf("AI 健康状态 = %+v, want %s 且 attempts=1", ai, tt.wantAIHealth)
+				}
+			}
+			if pings.Load() != 1 {
+				t.Errorf("深度检查请求了 AI %d 次, want 1", pings.Load())
+			}
+		})
+	}
+}
+
+// getHealthz 请求健康检查接口，返回状态码和解析后的报告
+func getHealthz(t *testing.T, url string) (int, services.HealthReport) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var report services.HealthReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("响应不是健康报告: %v", err)
+	}
+	return resp.StatusCode, report
+}
+
+func TestServeAnalyzeImagePaths(t *testing.T) {
+	root := t.TempDir()
+	writeTestPNG(t, filepath.Join(root, "cover.png"))
+	outside := filepath.Join(t.TempDir(), "secret.png")
+	writeTestPNG(t, outside)
+	if err := os.Symlink(outside, filepath.Join(root, "link.png")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		root       string
+		path       string
+		wantStatus int
+	}{
+		{"未配置根目录时拒绝本地路径", "", filepath.Join(root, "cover.png"), http.StatusBadRequest},
+		{"根目录外的绝对路径", root, outside, http.StatusBadRequest},
+		{"相对路径跳出根目录", root, "../secret.png", http.StatusBadRequest},
+		{"符号链接指向根目录外", root, "link.png", http.StatusBadRequest},
+		{"系统文件", root, "/etc/passwd", http.StatusBadRequest},
+		{"根目录内的相对路径", root, "cover.png", http.StatusOK},
+		{"根目录内的绝对路径", root, filepath.Join(root, "cover.png"), http.StatusOK},
+		{"未配置根目录时接受 data URI", "", "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8BQDwAEhQGAhKmMIQAAAABJRU5ErkJggg==", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, func(cfg *config.Config) { cfg.Server.ContentRoot = tt.root })
+			payload, err := json.Marshal(models.Content{
+				ID:     "a",
+				Title:  "周末露营装备清单",
+				Text:   "帐篷、睡袋和炉具是露营必备。",
+				Images: []models.Image{{Path: tt.path}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.Post(server.URL+"/analyze", "application/json", bytes.NewReader(payload))
+			if err != nil {
+				t.Fatalf("请求失败: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				body, _ := io.ReadAll(resp.Body)
+				t.Fatalf("状态码 = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+		})
+	}
+}
+
+func writeTestPNG(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+}
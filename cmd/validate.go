@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// --validate 模式下每个文件的检查结果
+const (
+	validateOK         = "通过"
+	validateRejected   = "未通过" // ValidateContent 报告了问题
+	validateParseError = "解析失败"
+	validateSkipped    = "跳过" // 不支持的文件类型
+)
+
+// validateEntry 一个文件的检查结果
+type validateEntry struct {
+	path   string
+	status string
+	issues []string
+}
+
+// blocking 该文件是否会让 --validate 以非零退出码结束
+func (e validateEntry) blocking() bool {
+	return e.status == validateRejected || e.status == validateParseError
+}
+
+// runValidate 只解析内容文件并用 ValidateContent 检查，不调用AI和图片分析，也不生成报告。
+// file 非空时只检查该文件，否则检查整个内容目录。以表格输出每个文件的结果，
+// 有文件解析失败或未通过检查时返回1，便于在CI中作为内容仓库的检查
+func runValidate(cfg *config.Config, file string) int {
+	var paths []string
+	if file != "" {
+		paths = []string{file}
+	} else {
+		err := filepath.Walk(cfg.ContentDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "扫描目录失败: %v\n", err)
+			return 1
+		}
+	}
+
+	sm := services.NewServiceManager(cfg)
+	// 词数与分析结果使用同一分词
+	contentAnalyzer := analyzer.NewContentAnalyzer(cfg)
+	var entries []validateEntry
+	for _, path := range paths {
+		entries = append(entries, validateFile(sm, contentAnalyzer.CountWords, path))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "文件\t状态\t问题")
+	counts := make(map[string]int)
+	failed := false
+	for _, entry := range entries {
+		counts[entry.status]++
+		failed = failed || entry.blocking()
+		issues := "-"
+		if len(entry.issues) > 0 {
+			issues = strings.Join(entry.issues, "; ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.path, entry.status, issues)
+	}
+	w.Flush()
+
+	fmt.Printf("\n共 %d 个文件: %d 个通过, %d 个未通过, %d 个解析失败, %d 个跳过\n",
+		len(entries), counts[validateOK], counts[validateRejected], counts[validateParseError], counts[validateSkipped])
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// validateFile 解析并检查一个文件
+func validateFile(sm *services.ServiceManager, countWords func(string) int, path string) validateEntry {
+	entry := validateEntry{path: path}
+
+	content, err := parseContentFile(path)
+	switch {
+	case err != nil:
+		entry.status = validateParseError
+		entry.issues = []string{err.Error()}
+	case content == nil:
+		entry.status = validateSkipped
+	default:
+		entry.issues = sm.ValidateContentWithCounter(*content, countWords)
+		entry.status = validateOK
+		if len(entry.issues) > 0 {
+			entry.status = validateRejected
+		}
+	}
+	return entry
+}
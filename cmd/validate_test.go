@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+func TestValidateWordCountBoundaries(t *testing.T) {
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Analysis.MinWordCount = 5
+	cfg.Analysis.MaxWordCount = 8
+	sm := services.NewServiceManager(cfg)
+	ca := analyzer.NewContentAnalyzer(cfg)
+
+	tests := []struct {
+		name  string
+		text  string
+		words int
+		issue string
+	}{
+		{"中文少于下限", strings.Repeat("周末，", 4), 4, "过短"},
+		{"中文等于下限", strings.Repeat("周末，", 5), 5, ""},
+		{"中文等于上限", strings.Repeat("周末，", 8), 8, ""},
+		{"中文超过上限", strings.Repeat("周末，", 9), 9, "过长"},
+		// 6个汉字只有4个词，按字计会误判为达到下限
+		{"中文按词不按字", "周末天气很好", 4, "过短"},
+		{"英文少于下限", strings.Repeat("tent ", 4), 4, "过短"},
+		{"英文等于下限", strings.Repeat("tent ", 5), 5, ""},
+		{"英文等于上限", strings.Repeat("tent ", 8), 8, ""},
+		{"英文超过上限", strings.Repeat("tent ", 9), 9, "过长"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := models.Content{Title: "标题", Text: tt.text}
+			result, err := ca.Analyze(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 检查与分析结果使用同一词数
+			if result.TextAnalysis.WordCount != tt.words {
+				t.Errorf("word_count = %d, want %d", result.TextAnalysis.WordCount, tt.words)
+			}
+
+			issues := strings.Join(sm.ValidateContentWithCounter(content, ca.CountWords), "; ")
+			if tt.issue == "" && issues != "" {
+				t.Errorf("ValidateContentWithCounter() = %q, want 通过", issues)
+			}
+			if tt.issue != "" && !strings.Contains(issues, tt.issue) {
+				t.Errorf("ValidateContentWithCounter() = %q, want 包含 %q", issues, tt.issue)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/analyzer"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/fsnotify/fsnotify"
+)
+
+// 最后一次文件事件之后等待的时间，编辑器保存时的一连串写入只触发一次分析
+const watchDebounce = 500 * time.Millisecond
+
+// runWatch 监听文件变更并持续分析，直到收到 Ctrl-C。
+// file 为空时监听内容目录，每次只重新分析变更的文件并重新生成报告；
+// 否则只监听该文件，每次保存后把分析结果输出到标准输出
+func runWatch(cfg *config.Config, contentAnalyzer *analyzer.ContentAnalyzer, file string, workers int) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建文件监听失败: %w", err)
+	}
+	defer watcher.Close()
+
+	var relevant func(path string) bool
+	var update func(paths []string)
+	if file != "" {
+		file = filepath.Clean(file)
+		// 监听所在目录而不是文件本身：很多编辑器保存时先写临时文件再重命名替换
+		if err := watcher.Add(filepath.Dir(file)); err != nil {
+			return fmt.Errorf("监听 %s 失败: %w", filepath.Dir(file), err)
+		}
+		analyzeSingleFile(contentAnalyzer, cfg.ContentDir, file)
+
+		relevant = func(path string) bool { return path == file }
+		update = func([]string) {
+			if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+				log.Printf("文件已删除: %s", file)
+				return
+			}
+			analyzeSingleFile(contentAnalyzer, cfg.ContentDir, file)
+		}
+	} else {
+		w := &contentWatcher{
+			cfg:      cfg,
+			analyzer: contentAnalyzer,
+			watcher:  watcher,
+			contents: make(map[string]models.Content),
+			results:  make(map[string]models.AnalysisResult),
+		}
+		if err := w.addDirs(cfg.ContentDir); err != nil {
+			return err
+		}
+		if err := w.analyzeAll(workers); err != nil {
+			return err
+		}
+
+		// 输出目录在内容目录下时忽略报告文件的变更，否则每次生成报告都会再触发一次分析
+		outputDir := filepath.Clean(cfg.OutputDir)
+		relevant = func(path string) bool {
+			return path != outputDir && !strings.HasPrefix(path, outputDir+string(filepath.Separator))
+		}
+		update = w.update
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println("\n正在监听文件变更，按 Ctrl-C 退出...")
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	pending := make(map[string]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("已停止监听")
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			path := filepath.Clean(event.Name)
+			if event.Op == fsnotify.Chmod || !relevant(path) {
+				continue
+			}
+			pending[path] = true
+			debounce.Reset(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("文件监听出错: %v", err)
+
+		case <-debounce.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]bool)
+			update(paths)
+		}
+	}
+}
+
+// contentWatcher 保存内容目录中每个文件最近一次的内容和分析结果，按文件路径索引
+type contentWatcher struct {
+	cfg      *config.Config
+	analyzer *analyzer.ContentAnalyzer
+	watcher  *fsnotify.Watcher
+	contents map[string]models.Content
+	results  map[string]models.AnalysisResult
+}
+
+// addDirs 监听 root 及其所有子目录（fsnotify 不会递归监听）
+func (w *contentWatcher) addDirs(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return fmt.Errorf("监听 %s 失败: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// analyzeAll 扫描并分析整个内容目录，生成第一份报告
+func (w *contentWatcher) analyzeAll(workers int) error {
+	fmt.Println("开始扫描内容目录...")
+	contents, err := scanContentDirectory(w.cfg.ContentDir)
+	if err != nil {
+		return fmt.Errorf("扫描目录失败: %w", err)
+	}
+	fmt.Printf("发现 %d 个内容文件\n", len(contents))
+
+	for _, content := range contents {
+		w.contents[content.FilePath] = content
+	}
+	w.refreshKeywordExtractor()
+
+	// 分析结果不含文件路径，按内容序号记下成功的结果，各协程只写自己的序号
+	analyzed := make([]*models.AnalysisResult, len(contents))
+	analyzer.AnalyzeEach(contents, workers, func(i int, content models.Content) (models.AnalysisResult, bool) {
+		result, err := w.analyzer.Analyze(content)
+		if err != nil {
+			log.Printf("分析失败 %s: %v", content.Title, err)
+			return result, false
+		}
+		analyzed[i] = &result
+		return result, true
+	}, printProgress)
+	for i, result := range analyzed {
+		if result != nil {
+			w.results[contents[i].FilePath] = *result
+		}
+	}
+
+	w.writeReports()
+	return nil
+}
+
+// update 处理一批变更的路径：新建或修改的文件重新分析，删除的文件（或目录下的文件）从报告中移除
+func (w *contentWatcher) update(paths []string) {
+	var changed []string
+	removed := false
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			if w.remove(path) {
+				removed = true
+			}
+		case err != nil:
+			log.Printf("读取 %s 失败: %v", path, err)
+		case info.IsDir():
+			// 新建或移入的目录：开始监听，并分析其中已有的文件
+			if err := w.addDirs(path); err != nil {
+				log.Printf("%v", err)
+			}
+			filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() && isContentFile(p) {
+					changed = append(changed, p)
+				}
+				return nil
+			})
+		case isContentFile(path):
+			changed = append(changed, path)
+		}
+	}
+
+	var parsed []models.Content
+	for _, path := range changed {
+		content, err := parseContentFile(path)
+		if err != nil {
+			// 编辑器可能还没写完，保留上次的结果，等下次保存
+			log.Printf("解析文件失败 %s: %v", path, err)
+			continue
+		}
+		w.contents[path] = *content
+		parsed = append(parsed, *content)
+	}
+
+	if len(parsed) == 0 && !removed {
+		return
+	}
+
+	w.refreshKeywordExtractor()
+	for _, content := range parsed {
+		fmt.Printf("重新分析: %s\n", content.FilePath)
+		result, err := w.analyzer.Analyze(content)
+		if err != nil {
+			log.Printf("分析失败 %s: %v", content.Title, err)
+			continue
+		}
+		w.results[content.FilePath] = result
+	}
+
+	w.writeReports()
+}
+
+// remove 移除已删除的文件，path 为目录时移除其下所有文件；返回是否有内容被移除
+func (w *contentWatcher) remove(path string) bool {
+	prefix := path + string(filepath.Separator)
+	removed := false
+	for filePath := range w.contents {
+		if filePath != path && !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		fmt.Printf("已删除: %s\n", filePath)
+		delete(w.contents, filePath)
+		delete(w.results, filePath)
+		removed = true
+	}
+	return removed
+}
+
+// refreshKeywordExtractor 按当前全部内容重新统计词频分布，供TF-IDF关键词使用
+func (w *contentWatcher) refreshKeywordExtractor() {
+	corpus := make([]models.Content, 0, len(w.contents))
+	for _, content := range w.contents {
+		corpus = append(corpus, content)
+	}
+	useCorpusKeywords(w.analyzer, corpus)
+}
+
+// writeReports 按文件路径顺序生成报告
+func (w *contentWatcher) writeReports() {
+	paths := make([]string, 0, len(w.results))
+	for path := range w.results {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	results := make([]models.AnalysisResult, 0, len(paths))
+	for _, path := range paths {
+		results = append(results, w.results[path])
+	}
+
+	// 用量从开始监听起累计
+	if err := generateReports(w.cfg, results, nil, w.analyzer.AIUsage()); err != nil {
+		log.Printf("生成报告失败: %v", err)
+		return
+	}
+	fmt.Printf("报告已更新: %s（%d 篇内容）\n", w.cfg.OutputDir, len(results))
+}
@@ -3,12 +3,17 @@ package analyzer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/RobinCoderZhao/content-analyzer/internal/config"
@@ -16,66 +21,202 @@ import (
 	"github.com/RobinCoderZhao/content-analyzer/internal/services"
 )
 
+var (
+	// 提取英文关键词时去掉单词首尾和中间的标点
+	keywordPunctPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+	// 以 "1." 开头的有序列表项
+	orderedListPattern = regexp.MustCompile(`^\d+\.`)
+	// 英文句末标点，用于简单的句子计数
+	sentenceEndPattern = regexp.MustCompile(`[.!?]+`)
+	hashtagPattern     = regexp.MustCompile(`#[\p{L}\p{N}_]+`)
+	mentionPattern     = regexp.MustCompile(`@[\p{L}\p{N}_]+`)
+	numberPattern      = regexp.MustCompile(`\d+`)
+)
+
 type ContentAnalyzer struct {
-	config     *config.Config
-	aiService  services.AIService
-	imgService services.ImageService
+	config         *config.Config
+	aiService      services.AIService
+	imgService     services.ImageService
+	postProcessors []postProcessor
+	stageHook      StageHook
+	disabledStages map[string]bool
+	weights        models.ScoreWeights // 已按跳过的阶段调整过的全局权重
+	strictness     float64             // 评分严格度系数，见 strictnessFactors
+	domainTerms    domainTerms         // 不计为复杂词的行业术语
+	words          wordLists           // 停用词、强力词、情感词和CTA模式
+	entities       entityDictionary    // 命名实体词典
+	segmenter      *segmenter          // 中文分词，未开启时为nil
+	writing        writingChecker      // 拼写和语法检查
+	keywordStats   *KeywordExtractor   // 语料的文档频率，设置后关键词相关度按TF-IDF计算
 }
 
+// StageHook 接收每个分析阶段的耗时，用于性能分析
+type StageHook func(stage string, elapsed time.Duration)
+
 func NewContentAnalyzer(cfg *config.Config) *ContentAnalyzer {
-	return &ContentAnalyzer{
-		config:     cfg,
-		aiService:  services.NewAIService(cfg),
-		imgService: services.NewImageService(cfg),
+	ca := &ContentAnalyzer{
+		config:         cfg,
+		aiService:      services.NewAIService(cfg),
+		imgService:     services.NewImageService(cfg),
+		postProcessors: loadPostProcessors(cfg),
+		disabledStages: loadDisabledStages(cfg),
+		strictness:     loadStrictness(cfg.Analysis.Strictness),
+		domainTerms:    newDomainTerms(cfg.Analysis.DomainTerms),
+		words:          loadWordLists(cfg.Analysis.WordLists, cfg.Analysis.CTA.Patterns),
+		entities:       loadEntityDictionary(cfg.Analysis.Entities),
+		writing:        loadWritingChecker(cfg.Analysis.WritingCheck),
+	}
+	ca.segmenter = newSegmenter(cfg.Analysis.Segmentation, ca.domainTerms)
+
+	weights, warnings := reconcileWeights(configuredWeights(cfg), ca.disabledStages)
+	logWeightWarnings(warnings)
+	ca.weights = weights
+
+	return ca
+}
+
+// SetKeywordExtractor 设置已收集完语料统计的关键词提取器，之后 Analyze 按TF-IDF计算关键词相关度；传入nil恢复按词频计算
+func (ca *ContentAnalyzer) SetKeywordExtractor(extractor *KeywordExtractor) {
+	ca.keywordStats = extractor
+}
+
+// AIUsage 该分析器创建以来AI请求累计的token用量和估算费用
+func (ca *ContentAnalyzer) AIUsage() models.AIUsage {
+	return ca.aiService.UsageStats()
+}
+
+// SetStageHook 设置阶段耗时回调，传入nil关闭
+func (ca *ContentAnalyzer) SetStageHook(hook StageHook) {
+	ca.stageHook = hook
+}
+
+func (ca *ContentAnalyzer) recordStage(stage string, start time.Time) {
+	if ca.stageHook != nil {
+		ca.stageHook(stage, time.Since(start))
 	}
 }
 
 // Analyze 分析单个内容
 func (ca *ContentAnalyzer) Analyze(content models.Content) (models.AnalysisResult, error) {
 	result := models.AnalysisResult{
-		ContentID: content.ID,
-		Title:     content.Title,
-		CreatedAt: time.Now(),
+		ContentID:   content.ID,
+		Title:       content.Title,
+		Author:      content.Author,
+		ContentType: content.Type,
+		Series:      content.Series,
+		Tags:        content.Tags,
+		Engagement:  content.Engagement,
+		CreatedAt:   time.Now(),
+	}
+	if !content.PublishedAt.IsZero() {
+		publishedAt := content.PublishedAt
+		result.PublishedAt = &publishedAt
 	}
 
 	// 1. 文本分析
+	start := time.Now()
 	textAnalysis, err := ca.analyzeText(content)
+	entities, entitySource := ca.extractEntities(content.Text)
+	ca.recordStage("text", start)
 	if err != nil {
 		return result, fmt.Errorf("文本分析失败: %w", err)
 	}
 	result.TextAnalysis = textAnalysis
+	result.TextAnalysis.NamedEntities = entities
+	setProvenance(&result, "entities", entitySource)
+	if content.Platform != "" && !ca.config.Analysis.KnownPlatform(content.Platform) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("未知平台 %q，未检查标题长度、话题标签和图片宽高比", content.Platform))
+	}
 
 	// 2. 图片分析
-	if len(content.Images) > 0 {
-		imageAnalyses, err := ca.analyzeImages(content.Images)
+	if len(content.Images) > 0 && ca.stageEnabled("images") {
+		start = time.Now()
+		imageAnalyses, warnings, err := ca.analyzeImages(content.Images)
+		ca.recordStage("images", start)
 		if err != nil {
 			return result, fmt.Errorf("图片分析失败: %w", err)
 		}
 		result.ImageAnalysis = imageAnalyses
+		result.Warnings = append(result.Warnings, warnings...)
+		ca.checkAspectRatios(result.ImageAnalysis, ca.contentPlatform(content.Platform))
 	}
 
+	// 图片中识别出的文字（启用OCR时）与正文一起参与情感分析和关键词提取
+	imageText := imageExtractedText(result.ImageAnalysis)
+
 	// 3. 情感分析
-	sentiment, err := ca.analyzeSentiment(content.Text + " " + content.Title)
-	if err != nil {
-		return result, fmt.Errorf("情感分析失败: %w", err)
+	if ca.stageEnabled("sentiment") {
+		start = time.Now()
+		sentiment, source, err := ca.analyzeSentiment(content.Text + " " + content.Title + imageText)
+		if err != nil {
+			return result, fmt.Errorf("情感分析失败: %w", err)
+		}
+		result.Sentiment = sentiment
+		setProvenance(&result, "sentiment", source)
+
+		if len(content.Comments) > 0 {
+			community, source, err := ca.analyzeCommunitySentiment(content.Comments)
+			if err != nil {
+				return result, fmt.Errorf("评论情感分析失败: %w", err)
+			}
+			result.Community = community
+			setProvenance(&result, "community_sentiment", source)
+		}
+		ca.recordStage("sentiment", start)
 	}
-	result.Sentiment = sentiment
 
 	// 4. 关键词提取
-	keywords := ca.extractKeywords(content.Text)
-	result.Keywords = keywords
+	if ca.stageEnabled("keywords") {
+		start = time.Now()
+		result.Keywords = ca.extractKeywords(content.Text + imageText)
+		if ca.keywordStats != nil {
+			result.Keywords = ca.keywordStats.Finalize(result.Keywords)
+		}
+		ca.recordStage("keywords", start)
+	}
+	result.TextAnalysis.KeywordDensity = ca.analyzeKeywordDensity(content, result.Keywords)
+
+	// 主题提取
+	if ca.stageEnabled("topics") {
+		start = time.Now()
+		topics, source, err := ca.aiService.ExtractTopicsWithSource(context.Background(), content.Title+" "+content.Text)
+		ca.recordStage("topics", start)
+		if err != nil {
+			return result, fmt.Errorf("主题提取失败: %w", err)
+		}
+		result.Topics = topics
+		setProvenance(&result, "topics", source)
+	}
+	hashtags, hashtagSource := ca.suggestHashtags(result, content.Title+"\n"+content.Text)
+	result.TextAnalysis.SuggestedHashtags = hashtags
+	setProvenance(&result, "hashtags", hashtagSource)
 
 	// 5. 可读性分析
-	readability := ca.analyzeReadability(content.Text)
-	result.Readability = readability
+	if ca.stageEnabled("readability") {
+		start = time.Now()
+		readability, languages := ca.analyzeReadability(content.Text, result.Keywords)
+		readability.Variety = ca.analyzeVariety(content.Text)
+		ca.recordStage("readability", start)
+		result.Readability = readability
+		result.Languages = languages
+	}
 
 	// 6. 生成评分
-	score := ca.calculateOverallScore(result)
+	start = time.Now()
+	weights := contentWeights(content, &result)
+	score := ca.calculateOverallScore(result, weights)
 	result.Score = score
 
+	// 自定义评分维度
+	ca.applyPostProcessors(&result)
+	ca.recordStage("scoring", start)
+
 	// 7. 生成改进建议
+	start = time.Now()
 	suggestions := ca.generateSuggestions(result)
-	result.Suggestions = suggestions
+	ca.projectGains(result, suggestions, weights)
+	ca.recordStage("suggestions", start)
+	result.Suggestions, result.Minor = ca.splitSuggestions(suggestions)
 
 	return result, nil
 }
@@ -84,16 +225,28 @@ func (ca *ContentAnalyzer) Analyze(content models.Content) (models.AnalysisResul
 func (ca *ContentAnalyzer) analyzeText(content models.Content) (models.TextAnalysis, error) {
 	text := content.Text
 	title := content.Title
+	lang := DetectLanguage(title + "\n" + text)
 
 	analysis := models.TextAnalysis{
-		WordCount:      ca.countWords(text),
+		Language:       lang,
+		WordCount:      ca.CountWords(text),
 		CharCount:      utf8.RuneCountInString(text),
 		ParagraphCount: ca.countParagraphs(text),
 		SentenceCount:  ca.countSentences(text),
 		Hashtags:       ca.extractHashtags(text),
 		Mentions:       ca.extractMentions(text),
-		CallToAction:   ca.extractCallToActions(text),
+		CallToAction:   ca.extractCallToActions(text, lang),
+		CTAAnalysis:    ca.analyzeCallToActions(text, lang),
+		Links:          extractLinks(text),
+		WritingIssues:  ca.checkWriting(text, lang),
 	}
+	emojis := extractEmojis(text)
+	analysis.EmojiCount = len(emojis)
+	analysis.Emojis = uniqueEmojis(emojis)
+	analysis.EmojiOveruse = emojiDensity(analysis) > ca.config.Analysis.MaxEmojiDensity
+
+	// 品牌必需关键词检查（标题和正文均计入）
+	analysis.RequiredKeywords = ca.checkRequiredKeywords(title + "\n" + text)
 
 	// 标题分析
 	analysis.TitleAnalysis = models.TitleAnalysis{
@@ -101,25 +254,30 @@ func (ca *ContentAnalyzer) analyzeText(content models.Content) (models.TextAnaly
 		HasNumbers:     ca.hasNumbers(title),
 		HasEmoji:       ca.hasEmoji(title),
 		HasQuestions:   ca.hasQuestions(title),
-		EmotionalWords: ca.findEmotionalWords(title),
-		PowerWords:     ca.findPowerWords(title),
-		ClickbaitScore: ca.calculateClickbaitScore(title),
-		ClarityScore:   ca.calculateClarityScore(title),
+		EmotionalWords: ca.findEmotionalWords(title, lang),
+		PowerWords:     ca.findPowerWords(title, lang),
+		ClickbaitScore: ca.calculateClickbaitScore(title, lang),
+		ClarityScore:   ca.calculateClarityScore(title, lang),
 	}
+	platform := ca.contentPlatform(content.Platform)
+	ca.applyTitleLimit(&analysis.TitleAnalysis, title, platform)
+	ca.applyHashtagLimit(&analysis.TitleAnalysis, title, platform)
 
 	// 内容结构分析
+	headings := analyzeHeadings(text)
 	analysis.ContentStructure = models.ContentStructure{
 		HasIntro:        ca.hasIntroduction(text),
 		HasConclusion:   ca.hasConclusion(text),
 		HasBulletPoints: ca.hasBulletPoints(text),
 		HasNumbers:      ca.hasNumbers(text),
-		SectionCount:    ca.countSections(text),
+		SectionCount:    ca.countSections(text, headings),
 		Structure:       ca.identifyStructure(text),
+		Headings:        headings,
 	}
 
 	// 写作风格分析
 	analysis.WritingStyle = models.WritingStyle{
-		Tone:              ca.identifyTone(text),
+		Tone:              ca.identifyTone(text, lang),
 		PersonPerspective: ca.identifyPerspective(text),
 		Formality:         ca.calculateFormality(text),
 		Complexity:        ca.calculateComplexity(text),
@@ -129,44 +287,170 @@ func (ca *ContentAnalyzer) analyzeText(content models.Content) (models.TextAnaly
 	return analysis, nil
 }
 
-// analyzeImages 图片分析
-func (ca *ContentAnalyzer) analyzeImages(images []models.Image) ([]models.ImageAnalysis, error) {
+// analyzeImages 图片分析，解码失败的图片按 image.on_decode_error 策略处理，
+// 被跳过的图片以警告形式返回
+func (ca *ContentAnalyzer) analyzeImages(images []models.Image) ([]models.ImageAnalysis, []string, error) {
 	var analyses []models.ImageAnalysis
+	var warnings []string
+
+	for i, img := range images {
+		// 内嵌的 base64 图片，解码到临时文件后按普通图片分析
+		dataURI := inlineImage(img)
+		if dataURI != "" {
+			if ca.config.Image.InlineImages == "skip" {
+				warnings = append(warnings, fmt.Sprintf("内嵌图片 #%d 已按配置跳过", i+1))
+				continue
+			}
+			img.Path = fmt.Sprintf("内嵌图片 #%d", i+1)
+		}
 
-	for _, img := range images {
-		// 检查图片路径
-		imagePath := img.Path
-		if !filepath.IsAbs(imagePath) {
-			imagePath = filepath.Join(ca.config.ContentDir, imagePath)
+		// 远程图片（如订阅或 Headless CMS 中的图片）下载到临时文件后分析
+		if img.Path == "" && img.URL != "" && ca.config.Image.RemoteImages == "skip" {
+			warnings = append(warnings, fmt.Sprintf("远程图片 %s 已按配置跳过", img.URL))
+			continue
 		}
 
-		analysis, err := ca.imgService.AnalyzeImage(imagePath)
+		analysis, imagePath, err := ca.analyzeImage(img, dataURI)
 		if err != nil {
-			return nil, fmt.Errorf("分析图片 %s 失败: %w", imagePath, err)
+			// 远程图片取不到不影响内容本身的分析
+			if errors.Is(err, services.ErrImageDownload) {
+				warnings = append(warnings, fmt.Sprintf("远程图片 %s 已跳过: %v", img.URL, err))
+				continue
+			}
+			// 没有HEIC转换命令时只跳过该图片，不按解码失败处理
+			if errors.Is(err, services.ErrHEICUnavailable) {
+				warning := fmt.Sprintf("图片 %s 已跳过: %v", img.Path, err)
+				log.Println(warning)
+				warnings = append(warnings, warning)
+				continue
+			}
+
+			policy := ca.config.Image.OnDecodeError
+			if !errors.Is(err, services.ErrImageDecode) || policy == "fail" || policy == "" {
+				return nil, nil, fmt.Errorf("分析图片 %s 失败: %w", imagePath, err)
+			}
+
+			warning := fmt.Sprintf("图片 %s 无法解析，已跳过: %v", img.Path, err)
+			if policy == "warn" {
+				log.Println(warning)
+			}
+			warnings = append(warnings, warning)
+			continue
 		}
 
 		analyses = append(analyses, analysis)
 	}
 
-	return analyses, nil
+	return analyses, warnings, nil
+}
+
+// analyzeImage 分析单张图片，返回用于错误信息的图片路径；内嵌图片和远程图片分析完后删除临时文件
+func (ca *ContentAnalyzer) analyzeImage(img models.Image, dataURI string) (models.ImageAnalysis, string, error) {
+	if dataURI != "" {
+		tmpPath, err := ca.imgService.SaveInlineImage(dataURI)
+		if err != nil {
+			return models.ImageAnalysis{}, img.Path, err
+		}
+		return ca.analyzeTempImage(img, tmpPath, img.Path)
+	}
+
+	if img.Path == "" && img.URL != "" {
+		// 远程图片的各种失败（网络、过大、格式不支持、无法解码）都按下载失败处理，只跳过该图片
+		tmpPath, err := ca.imgService.DownloadImage(img.URL)
+		var analysis models.ImageAnalysis
+		if err == nil {
+			analysis, _, err = ca.analyzeTempImage(img, tmpPath, img.URL)
+		}
+		if err != nil && !errors.Is(err, services.ErrImageDownload) {
+			err = fmt.Errorf("%w: %w", services.ErrImageDownload, err)
+		}
+		return analysis, img.URL, err
+	}
+
+	// 检查图片路径
+	imagePath := img.Path
+	if !filepath.IsAbs(imagePath) {
+		imagePath = filepath.Join(ca.config.ContentDir, imagePath)
+	}
+
+	analysis, err := ca.imgService.AnalyzeImage(imagePath)
+	if err == nil {
+		ca.finishImageAnalysis(&analysis, img, imagePath)
+	}
+	return analysis, imagePath, err
+}
+
+// analyzeTempImage 分析临时文件中的图片，结果中的路径记为 displayPath，分析完后删除临时文件
+func (ca *ContentAnalyzer) analyzeTempImage(img models.Image, tmpPath, displayPath string) (models.ImageAnalysis, string, error) {
+	defer os.Remove(tmpPath)
+
+	analysis, err := ca.imgService.AnalyzeImage(tmpPath)
+	analysis.Path = displayPath
+	if err == nil {
+		ca.finishImageAnalysis(&analysis, img, tmpPath)
+	}
+	return analysis, displayPath, err
+}
+
+// finishImageAnalysis 补充依赖分析器配置的结果：图片文字的可读性评分，以及没有说明的图片的建议替代文本
+func (ca *ContentAnalyzer) finishImageAnalysis(analysis *models.ImageAnalysis, img models.Image, imagePath string) {
+	if analysis.TextOverlay != nil {
+		ca.scoreImageText(analysis.TextOverlay)
+	}
+
+	if !ca.config.Image.SuggestAltText || strings.TrimSpace(img.Caption) != "" {
+		return
+	}
+	altText, err := ca.aiService.DescribeImage(context.Background(), imagePath)
+	switch {
+	case err == nil:
+		analysis.SuggestedAltText = altText
+	case !errors.Is(err, services.ErrAINotConfigured):
+		log.Printf("生成图片 %s 的替代文本失败: %v", analysis.Path, err)
+	}
+}
+
+// inlineImage 图片路径或链接为 data URI 时返回该 URI
+func inlineImage(img models.Image) string {
+	if services.IsDataURI(img.Path) {
+		return img.Path
+	}
+	if img.Path == "" && services.IsDataURI(img.URL) {
+		return img.URL
+	}
+	return ""
 }
 
-// analyzeSentiment 情感分析
-func (ca *ContentAnalyzer) analyzeSentiment(text string) (models.SentimentAnalysis, error) {
+// analyzeSentiment 情感分析，同时返回结果来源（见 models.AnalysisResult.Provenance）。
+// AI失败降级时置信度按 fallbackConfidenceFactor 打折
+func (ca *ContentAnalyzer) analyzeSentiment(text string) (models.SentimentAnalysis, string, error) {
 	// 使用AI服务进行情感分析
 	ctx := context.Background()
-	sentiment, err := ca.aiService.AnalyzeSentiment(ctx, text)
+	sentiment, source, err := ca.aiService.AnalyzeSentimentWithSource(ctx, text)
 	if err != nil {
-		return models.SentimentAnalysis{}, err
+		return models.SentimentAnalysis{}, "", err
+	}
+	if source == models.ProvenanceFallback {
+		sentiment.Confidence *= fallbackConfidenceFactor
 	}
 
-	return sentiment, nil
+	return sentiment, source, nil
 }
 
 // 文本处理工具函数
-func (ca *ContentAnalyzer) countWords(text string) int {
-	words := strings.Fields(text)
-	return len(words)
+
+// CountWords 统计词数，即 text_analysis.word_count 以及 min_word_count/max_word_count 的单位：
+// 开启中文分词时按分词结果计（不含标点），否则按空格切分
+func (ca *ContentAnalyzer) CountWords(text string) int {
+	return len(ca.splitWords(text))
+}
+
+// splitWords 分词：含汉字且开启了中文分词时按词典切分，否则按空格切分
+func (ca *ContentAnalyzer) splitWords(text string) []string {
+	if ca.segmenter != nil && containsHan(text) {
+		return ca.segmenter.segment(text)
+	}
+	return strings.Fields(text)
 }
 
 func (ca *ContentAnalyzer) countParagraphs(text string) int {
@@ -182,8 +466,7 @@ func (ca *ContentAnalyzer) countParagraphs(text string) int {
 
 func (ca *ContentAnalyzer) countSentences(text string) int {
 	// 简单的句子计数，基于标点符号
-	re := regexp.MustCompile(`[.!?]+`)
-	sentences := re.Split(text, -1)
+	sentences := sentenceEndPattern.Split(text, -1)
 	count := 0
 	for _, s := range sentences {
 		if strings.TrimSpace(s) != "" {
@@ -193,62 +476,62 @@ func (ca *ContentAnalyzer) countSentences(text string) int {
 	return count
 }
 
-func (ca *ContentAnalyzer) countSections(text string) int {
-	// 通过标题标记或空行分隔计算章节数
-	lines := strings.Split(text, "\n")
-	sections := 1
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		// 检查是否是标题（以#开头或者全大写短行）
-		if strings.HasPrefix(trimmed, "#") ||
-			(len(trimmed) < 50 && strings.ToUpper(trimmed) == trimmed && len(trimmed) > 0) {
-			sections++
-		}
-	}
-
-	return sections
-}
-
 func (ca *ContentAnalyzer) extractHashtags(text string) []string {
-	re := regexp.MustCompile(`#[\p{L}\p{N}_]+`)
-	return re.FindAllString(text, -1)
+	return hashtagPattern.FindAllString(text, -1)
 }
 
 func (ca *ContentAnalyzer) extractMentions(text string) []string {
-	re := regexp.MustCompile(`@[\p{L}\p{N}_]+`)
-	return re.FindAllString(text, -1)
+	return mentionPattern.FindAllString(text, -1)
 }
 
-func (ca *ContentAnalyzer) extractCallToActions(text string) []string {
-	// 常见的CTA模式
-	ctaPatterns := []string{
-		`点击.*链接`, `立即.*`, `马上.*`, `赶快.*`, `快来.*`,
-		`关注我`, `点赞.*`, `评论.*`, `分享.*`, `收藏.*`,
-		`了解更多`, `查看更多`, `阅读全文`,
+// checkRequiredKeywords 检查配置的必需关键词是否出现
+func (ca *ContentAnalyzer) checkRequiredKeywords(text string) models.KeywordCoverage {
+	var coverage models.KeywordCoverage
+
+	for _, keyword := range ca.config.Analysis.RequiredKeywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" {
+			continue
+		}
+		if containsTerm(text, keyword) {
+			coverage.Present = append(coverage.Present, keyword)
+		} else {
+			coverage.Missing = append(coverage.Missing, keyword)
+		}
 	}
 
-	var ctas []string
-	text = strings.ToLower(text)
+	return coverage
+}
 
-	for _, pattern := range ctaPatterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindAllString(text, -1)
-		ctas = append(ctas, matches...)
+// containsTerm 忽略大小写匹配词语；拉丁字母词要求完整词边界，
+// 避免 "Go" 命中 "good"，中文等无空格分词的文字按子串匹配
+func containsTerm(text, term string) bool {
+	lowerText := strings.ToLower(text)
+	lowerTerm := strings.ToLower(term)
+
+	if !isLatinTerm(lowerTerm) {
+		return strings.Contains(lowerText, lowerTerm)
 	}
 
-	return ctas
+	re := regexp.MustCompile(`(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(lowerTerm) + `($|[^\p{L}\p{N}])`)
+	return re.MatchString(lowerText)
+}
+
+func isLatinTerm(term string) bool {
+	for _, r := range term {
+		if r > unicode.MaxLatin1 && unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
 }
 
 func (ca *ContentAnalyzer) hasNumbers(text string) bool {
-	re := regexp.MustCompile(`\d+`)
-	return re.MatchString(text)
+	return numberPattern.MatchString(text)
 }
 
 func (ca *ContentAnalyzer) hasEmoji(text string) bool {
-	// 简单的emoji检测
-	re := regexp.MustCompile(`[\x{1F600}-\x{1F64F}]|[\x{1F300}-\x{1F5FF}]|[\x{1F680}-\x{1F6FF}]|[\x{2600}-\x{26FF}]|[\x{2700}-\x{27BF}]`)
-	return re.MatchString(text)
+	return len(extractEmojis(text)) > 0
 }
 
 func (ca *ContentAnalyzer) hasQuestions(text string) bool {
@@ -304,7 +587,7 @@ func (ca *ContentAnalyzer) hasBulletPoints(text string) bool {
 		if strings.HasPrefix(trimmed, "•") ||
 			strings.HasPrefix(trimmed, "-") ||
 			strings.HasPrefix(trimmed, "*") ||
-			regexp.MustCompile(`^\d+\.`).MatchString(trimmed) {
+			orderedListPattern.MatchString(trimmed) {
 			return true
 		}
 	}
@@ -312,45 +595,15 @@ func (ca *ContentAnalyzer) hasBulletPoints(text string) bool {
 }
 
 // 更多分析函数待实现...
-func (ca *ContentAnalyzer) findEmotionalWords(text string) []string {
-	emotionalWords := []string{
-		"惊喜", "震撼", "感动", "激动", "兴奋", "满足", "幸福", "快乐",
-		"担心", "焦虑", "害怕", "紧张", "愤怒", "失望", "沮丧",
-		"amazing", "wonderful", "fantastic", "incredible", "awesome",
-	}
-
-	var found []string
-	lowerText := strings.ToLower(text)
-
-	for _, word := range emotionalWords {
-		if strings.Contains(lowerText, strings.ToLower(word)) {
-			found = append(found, word)
-		}
-	}
-
-	return found
+func (ca *ContentAnalyzer) findEmotionalWords(text, lang string) []string {
+	return findLexiconWords(text, ca.words.emotional, lang)
 }
 
-func (ca *ContentAnalyzer) findPowerWords(text string) []string {
-	powerWords := []string{
-		"独家", "限时", "免费", "秘密", "揭秘", "内幕", "独特", "创新",
-		"突破", "革命", "颠覆", "神器", "必备", "推荐", "精选",
-		"exclusive", "limited", "secret", "unique", "breakthrough",
-	}
-
-	var found []string
-	lowerText := strings.ToLower(text)
-
-	for _, word := range powerWords {
-		if strings.Contains(lowerText, strings.ToLower(word)) {
-			found = append(found, word)
-		}
-	}
-
-	return found
+func (ca *ContentAnalyzer) findPowerWords(text, lang string) []string {
+	return findLexiconWords(text, ca.words.power, lang)
 }
 
-func (ca *ContentAnalyzer) calculateClickbaitScore(title string) float64 {
+func (ca *ContentAnalyzer) calculateClickbaitScore(title, lang string) float64 {
 	score := 0.0
 
 	// 各种clickbait特征检查
@@ -360,7 +613,7 @@ func (ca *ContentAnalyzer) calculateClickbaitScore(title string) float64 {
 	if ca.hasQuestions(title) {
 		score += 0.15
 	}
-	if len(ca.findPowerWords(title)) > 0 {
+	if len(ca.findPowerWords(title, lang)) > 0 {
 		score += 0.3
 	}
 	if strings.Contains(strings.ToLower(title), "你不知道") ||
@@ -376,7 +629,7 @@ func (ca *ContentAnalyzer) calculateClickbaitScore(title string) float64 {
 	return score
 }
 
-func (ca *ContentAnalyzer) calculateClarityScore(title string) float64 {
+func (ca *ContentAnalyzer) calculateClarityScore(title, lang string) float64 {
 	// 简单的清晰度评分逻辑
 	score := 1.0
 
@@ -389,7 +642,7 @@ func (ca *ContentAnalyzer) calculateClarityScore(title string) float64 {
 	}
 
 	// 检查是否有明确的主题词
-	if !ca.hasNumbers(title) && len(ca.findPowerWords(title)) == 0 {
+	if !ca.hasNumbers(title) && len(ca.findPowerWords(title, lang)) == 0 {
 		score -= 0.1
 	}
 
@@ -397,9 +650,9 @@ func (ca *ContentAnalyzer) calculateClarityScore(title string) float64 {
 }
 
 // 其他待实现的分析方法...
-func (ca *ContentAnalyzer) identifyTone(text string) string {
+func (ca *ContentAnalyzer) identifyTone(text, lang string) string {
 	// 基于关键词识别语调
-	if len(ca.findEmotionalWords(text)) > 3 {
+	if len(ca.findEmotionalWords(text, lang)) > 3 {
 		return "enthusiastic"
 	}
 	if strings.Contains(text, "。") && !ca.hasQuestions(text) {
@@ -436,7 +689,7 @@ func (ca *ContentAnalyzer) calculateFormality(text string) float64 {
 		casualCount += strings.Count(lowerText, word)
 	}
 
-	totalWords := ca.countWords(text)
+	totalWords := ca.CountWords(text)
 	if totalWords == 0 {
 		return 0.5
 	}
@@ -453,23 +706,27 @@ func (ca *ContentAnalyzer) calculateFormality(text string) float64 {
 }
 
 func (ca *ContentAnalyzer) calculateComplexity(text string) float64 {
-	words := strings.Fields(text)
+	words := ca.splitWords(text)
 	if len(words) == 0 {
 		return 0
 	}
 
 	complexWords := 0
-	totalChars := 0
+	totalChars := 0.0
 
 	for _, word := range words {
-		charCount := utf8.RuneCountInString(word)
-		totalChars += charCount
-		if charCount > 6 { // 认为超过6个字符的词是复杂词
+		totalChars += ca.domainTerms.wordLength(word)
+		// 认为超过6个字符的英文词、超过3个字的中文词是复杂词，行业术语除外
+		limit := 6
+		if containsHan(word) {
+			limit = 3
+		}
+		if utf8.RuneCountInString(word) > limit && !ca.domainTerms.isTerm(word) && !ca.domainTerms.isHanTerm(word) {
 			complexWords++
 		}
 	}
 
-	avgWordLength := float64(totalChars) / float64(len(words))
+	avgWordLength := totalChars / float64(len(words))
 	complexityRatio := float64(complexWords) / float64(len(words))
 
 	// 综合平均词长和复杂词比例
@@ -525,47 +782,76 @@ func (ca *ContentAnalyzer) identifyStructure(text string) string {
 	return "linear"
 }
 
+// extractKeywords 按语言分别提取关键词，相关度在各自语言内计算
 func (ca *ContentAnalyzer) extractKeywords(text string) []models.Keyword {
-	// 简单的关键词提取
-	words := strings.Fields(strings.ToLower(text))
-	wordCount := make(map[string]int)
-
-	// 停用词列表
-	stopWords := map[string]bool{
-		"的": true, "是": true, "在": true, "我": true, "你": true, "他": true,
-		"了": true, "和": true, "就": true, "都": true, "而": true, "及": true,
-		"与": true, "或": true, "但": true, "为": true, "也": true, "不": true,
-		"可以": true, "这个": true, "那个": true, "什么": true, "怎么": true,
-		"the", "a", "an", "and", "or", "but", "in", "on", "at", "to",
-		"for", "of", "with", "by", "is", "are", "was", "were", "be",
+	var keywords []models.Keyword
+	for _, group := range groupByLanguage(text) {
+		keywords = append(keywords, ca.extractLanguageKeywords(group.lang, group.text)...)
 	}
+	return keywords
+}
 
-	for _, word := range words {
-		// 清理标点符号
-		word = regexp.MustCompile(`[^\p{L}\p{N}]+`).ReplaceAllString(word, "")
-		if len(word) > 1 && !stopWords[word] {
-			wordCount[word]++
-		}
-	}
+func (ca *ContentAnalyzer) extractLanguageKeywords(lang, text string) []models.Keyword {
+	wordCount, total := ca.countTerms(lang, text)
 
 	// 转换为关键词结构
 	var keywords []models.Keyword
 	for word, count := range wordCount {
 		if count >= 2 { // 至少出现2次才算关键词
-			relevance := float64(count) / float64(len(words))
+			relevance := float64(count) / float64(total)
 			keywords = append(keywords, models.Keyword{
 				Word:      word,
 				Frequency: count,
 				Relevance: relevance,
 				Trend:     "stable", // 简化处理
 				Category:  ca.categorizeKeyword(word),
+				Language:  lang,
 			})
 		}
 	}
 
+	// map 遍历顺序不固定，按频次、词语排序使输出可复现
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Frequency != keywords[j].Frequency {
+			return keywords[i].Frequency > keywords[j].Frequency
+		}
+		return keywords[i].Word < keywords[j].Word
+	})
+
 	return keywords
 }
 
+// countTerms 统计单一语言片段中各词的出现次数（去掉标点和停用词），total 为分词后的总词数
+func (ca *ContentAnalyzer) countTerms(lang, text string) (map[string]int, int) {
+	wordCount := make(map[string]int)
+
+	// 片段已按语言切分，只使用该语言的停用词
+	stops := ca.words.stop[lang]
+
+	// 中文片段按词典分词，单字大多是虚词或语素，不作为关键词
+	if lang == "zh" && ca.segmenter != nil {
+		words := ca.segmenter.segment(strings.ToLower(text))
+		for _, word := range words {
+			if utf8.RuneCountInString(word) > 1 && !stops[word] {
+				wordCount[word]++
+			}
+		}
+		return wordCount, len(words)
+	}
+
+	// 简单的关键词提取
+	words := strings.Fields(strings.ToLower(text))
+	for _, word := range words {
+		// 清理标点符号
+		word = keywordPunctPattern.ReplaceAllString(word, "")
+		if len(word) > 1 && !stops[word] {
+			wordCount[word]++
+		}
+	}
+
+	return wordCount, len(words)
+}
+
 func (ca *ContentAnalyzer) categorizeKeyword(word string) string {
 	// 简单的关键词分类
 	emotionWords := []string{"好", "棒", "差", "爱", "恨", "喜欢", "讨厌"}
@@ -586,65 +872,31 @@ func (ca *ContentAnalyzer) categorizeKeyword(word string) string {
 	return "topic"
 }
 
-func (ca *ContentAnalyzer) analyzeReadability(text string) models.ReadabilityMetrics {
-	wordCount := ca.countWords(text)
-	sentenceCount := ca.countSentences(text)
-
-	if sentenceCount == 0 {
-		sentenceCount = 1
-	}
-
-	avgSentenceLength := float64(wordCount) / float64(sentenceCount)
-
-	// 计算平均词长
-	words := strings.Fields(text)
-	totalChars := 0
-	complexWords := 0
-
-	for _, word := range words {
-		charCount := utf8.RuneCountInString(word)
-		totalChars += charCount
-		if charCount > 6 {
-			complexWords++
-		}
-	}
-
-	var avgWordLength float64
-	var complexWordRatio float64
-
-	if len(words) > 0 {
-		avgWordLength = float64(totalChars) / float64(len(words))
-		complexWordRatio = float64(complexWords) / float64(len(words))
-	}
-
-	// 简化的Flesch分数计算
-	fleschScore := 206.835 - 1.015*avgSentenceLength - 84.6*(avgWordLength/4.7)
-
-	// 阅读等级判定
-	grade := "中等"
-	if fleschScore > 80 {
-		grade = "容易"
-	} else if fleschScore < 50 {
-		grade = "困难"
-	}
+// 配置中未设置任何权重时使用的默认评分权重，与 config.Load 的默认值一致
+var defaultScoreWeights = models.ScoreWeights{
+	ContentQuality: 0.25,
+	Engagement:     0.20,
+	Visual:         0.15,
+	Title:          0.15,
+	Readability:    0.15,
+	TrendRelevance: 0.10,
+}
 
-	// 预估阅读时间（按每分钟250字计算）
-	readingTime := int(float64(wordCount) / 250.0 * 60)
-	if readingTime < 30 {
-		readingTime = 30 // 最少30秒
+// contentWeights 返回单篇内容的权重。与全局权重一样要求非负且总和为1，
+// 不符合时忽略该权重并记为警告，以免总分超出0-100
+func contentWeights(content models.Content, result *models.AnalysisResult) *models.ScoreWeights {
+	if content.ScoreWeights == nil {
+		return nil
 	}
-
-	return models.ReadabilityMetrics{
-		FleschScore:       fleschScore,
-		AvgSentenceLength: avgSentenceLength,
-		AvgWordLength:     avgWordLength,
-		ComplexWordRatio:  complexWordRatio,
-		ReadingTime:       readingTime,
-		Grade:             grade,
+	if err := config.ScoreWeights(*content.ScoreWeights).ValidateAs("score_weights"); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("单篇内容的权重无效，已改用全局权重: %v", err))
+		return nil
 	}
+	return content.ScoreWeights
 }
 
-func (ca *ContentAnalyzer) calculateOverallScore(result models.AnalysisResult) models.OverallScore {
+// calculateOverallScore 计算总分，override 为单篇内容的权重，为空时使用全局权重
+func (ca *ContentAnalyzer) calculateOverallScore(result models.AnalysisResult, override *models.ScoreWeights) models.OverallScore {
 	breakdown := models.ScoreBreakdown{
 		ContentQuality: ca.scoreContentQuality(result.TextAnalysis),
 		Engagement:     ca.scoreEngagement(result.TextAnalysis),
@@ -653,24 +905,23 @@ func (ca *ContentAnalyzer) calculateOverallScore(result models.AnalysisResult) m
 		Readability:    ca.scoreReadability(result.Readability),
 		TrendRelevance: ca.scoreTrendRelevance(result.Keywords),
 	}
+	ca.applyStrictness(&breakdown)
 
-	// 计算总分（加权平均）
-	total := breakdown.ContentQuality*0.25 +
-		breakdown.Engagement*0.20 +
-		breakdown.Visual*0.15 +
-		breakdown.Title*0.15 +
-		breakdown.Readability*0.15 +
-		breakdown.TrendRelevance*0.10
-
-	level := "poor"
-	if total >= 85 {
-		level = "excellent"
-	} else if total >= 70 {
-		level = "good"
-	} else if total >= 50 {
-		level = "average"
+	weights := ca.globalScoreWeights()
+	if override != nil && weightSum(*override) > 0 {
+		weights, _ = reconcileWeights(*override, ca.disabledStages)
 	}
 
+	// 计算总分（加权平均）
+	total := breakdown.ContentQuality*weights.ContentQuality +
+		breakdown.Engagement*weights.Engagement +
+		breakdown.Visual*weights.Visual +
+		breakdown.Title*weights.Title +
+		breakdown.Readability*weights.Readability +
+		breakdown.TrendRelevance*weights.TrendRelevance
+
+	level := ca.scoreLevel(total)
+
 	reasoning := fmt.Sprintf("综合评分%.1f分，主要优势在%s，需要改进%s",
 		total, ca.findStrengths(breakdown), ca.findWeaknesses(breakdown))
 
@@ -682,6 +933,29 @@ func (ca *ContentAnalyzer) calculateOverallScore(result models.AnalysisResult) m
 	}
 }
 
+// configuredWeights 取 analysis.score_weights，六项全为0（如未经 config.Load 构造的配置）时使用默认权重
+func configuredWeights(cfg *config.Config) models.ScoreWeights {
+	weights := models.ScoreWeights(cfg.Analysis.ScoreWeights)
+	if weightSum(weights) == 0 {
+		return defaultScoreWeights
+	}
+	return weights
+}
+
+// globalScoreWeights 未设置单篇权重时使用的全局权重
+func (ca *ContentAnalyzer) globalScoreWeights() models.ScoreWeights {
+	return ca.weights
+}
+
+func weightSum(w models.ScoreWeights) float64 {
+	return w.ContentQuality + w.Engagement + w.Visual + w.Title + w.Readability + w.TrendRelevance
+}
+
+// scoreLevel 按 analysis.level_thresholds 确定总分的等级
+func (ca *ContentAnalyzer) scoreLevel(total float64) string {
+	return ca.config.Analysis.LevelThresholds.Level(total)
+}
+
 func (ca *ContentAnalyzer) scoreContentQuality(textAnalysis models.TextAnalysis) float64 {
 	score := 60.0 // 基础分
 
@@ -695,20 +969,47 @@ func (ca *ContentAnalyzer) scoreContentQuality(textAnalysis models.TextAnalysis)
 		score += 15
 	}
 
-	// 有明确的CTA加分
-	if len(textAnalysis.CallToAction) > 0 {
+	// 有明确的CTA加分，堆砌过多不加分
+	if len(textAnalysis.CallToAction) > 0 && ca.excessCTAs(textAnalysis.CTAAnalysis) == 0 {
 		score += 5
 	}
 
-	return math.Min(score, 100)
+	// 分章节组织的内容更易扫读
+	if textAnalysis.ContentStructure.SectionCount >= 2 {
+		score += 5
+	}
+
+	// 标题层级混乱扣分
+	score -= math.Min(float64(len(textAnalysis.ContentStructure.Headings.Issues))*3, 10)
+
+	// 品牌必需关键词：全部出现加分，每缺少一个扣分
+	coverage := textAnalysis.RequiredKeywords
+	if len(coverage.Missing) > 0 {
+		score -= math.Min(float64(len(coverage.Missing))*10, 30)
+	} else if len(coverage.Present) > 0 {
+		score += 5
+	}
+
+	// 主关键词密度
+	score += keywordDensityScore(textAnalysis.KeywordDensity)
+
+	// 笼统的链接锚文本
+	score -= linkAnchorPenalty(textAnalysis.Links)
+
+	// 拼写、语法和错别字
+	score -= writingPenalty(textAnalysis.WritingIssues)
+
+	return math.Max(0, math.Min(score, 100))
 }
 
 func (ca *ContentAnalyzer) scoreEngagement(textAnalysis models.TextAnalysis) float64 {
 	score := 50.0
 
-	// 互动元素
-	if len(textAnalysis.CallToAction) > 0 {
-		score += 20
+	// 互动元素：CTA的强度和位置决定加分多少，超过上限的部分按数量扣分
+	if excess := ca.excessCTAs(textAnalysis.CTAAnalysis); excess > 0 {
+		score -= math.Min(float64(excess)*5, 20)
+	} else if len(textAnalysis.CallToAction) > 0 {
+		score += 5 + 15*textAnalysis.CTAAnalysis.Strength
 	}
 	if textAnalysis.TitleAnalysis.HasQuestions {
 		score += 15
@@ -720,7 +1021,13 @@ func (ca *ContentAnalyzer) scoreEngagement(textAnalysis models.TextAnalysis) flo
 		score += 5
 	}
 
-	return math.Min(score, 100)
+	// 标题话题标签数量符合平台习惯加分，过少或过多扣分
+	score += ca.hashtagScore(textAnalysis.TitleAnalysis)
+
+	// 正文emoji：适量加分，过多扣分
+	score += ca.emojiScore(textAnalysis)
+
+	return math.Max(0, math.Min(score, 100))
 }
 
 func (ca *ContentAnalyzer) scoreVisual(imageAnalysis []models.ImageAnalysis) float64 {
@@ -730,7 +1037,7 @@ func (ca *ContentAnalyzer) scoreVisual(imageAnalysis []models.ImageAnalysis) flo
 
 	totalScore := 0.0
 	for _, img := range imageAnalysis {
-		totalScore += img.Score
+		totalScore += imageVisualScore(img)
 	}
 
 	return totalScore / float64(len(imageAnalysis))
@@ -744,6 +1051,12 @@ func (ca *ContentAnalyzer) scoreTitle(titleAnalysis models.TitleAnalysis) float6
 		score += 20
 	}
 
+	// 超出平台显示上限会被截断，超出越多扣分越多
+	if titleAnalysis.Truncated {
+		over := titleAnalysis.DisplayLength - titleAnalysis.MaxLength
+		score -= math.Min(10+float64(over)*2, 30)
+	}
+
 	// 有吸引力元素
 	if titleAnalysis.HasNumbers {
 		score += 10
@@ -780,6 +1093,9 @@ func (ca *ContentAnalyzer) scoreReadability(readability models.ReadabilityMetric
 		score += 10
 	}
 
+	// 句子、段落长短缺少变化
+	score -= varietyPenalty(readability.Variety)
+
 	return math.Min(score, 100)
 }
 
@@ -847,7 +1163,7 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 	var suggestions []models.Suggestion
 
 	// 标题建议
-	if result.Score.Breakdown.Title < 70 {
+	if titleBar := ca.bar(titleSuggestionBar); result.Score.Breakdown.Title < titleBar {
 		suggestions = append(suggestions, models.Suggestion{
 			Type:        "title",
 			Priority:    "high",
@@ -855,9 +1171,33 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 			Recommended: "建议添加数字、提问或者情感词汇来增强标题吸引力",
 			Reasoning:   fmt.Sprintf("标题得分仅%.1f分，低于平均水平", result.Score.Breakdown.Title),
 			Impact:      "预计可提升点击率15-25%",
+			Factor:      factorTitle,
+			Confidence:  signalConfidence(titleBar-result.Score.Breakdown.Title, titleBar),
+		})
+	}
+
+	// 平台标题截断建议
+	if title := result.TextAnalysis.TitleAnalysis; title.Truncated {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "title",
+			Priority:    "high",
+			Current:     fmt.Sprintf("标题在%s会被截断（%d/%d%s）", title.Platform, title.DisplayLength, title.MaxLength, unitLabel(title.Unit)),
+			Recommended: fmt.Sprintf("将标题压缩到%d%s以内，把数字、关键词等最吸引人的信息放在前面", title.MaxLength, unitLabel(title.Unit)),
+			Reasoning:   "信息流只显示标题的前一部分，被截断的标题会丢失关键信息",
+			Impact:      "预计可提升信息流中的点击率",
+			Factor:      factorTitleLength,
+			Confidence:  1,
 		})
 	}
 
+	// 标题话题标签建议
+	if suggestion, ok := ca.hashtagSuggestion(result.TextAnalysis.TitleAnalysis); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+	if suggestion, ok := ca.hashtagRecommendation(result.TextAnalysis); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+
 	// 内容结构建议
 	if !result.TextAnalysis.ContentStructure.HasIntro {
 		suggestions = append(suggestions, models.Suggestion{
@@ -867,6 +1207,22 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 			Recommended: "添加一个吸引读者注意力的开场，比如提问、故事或者数据",
 			Reasoning:   "好的开头能够显著提高读者的阅读完成率",
 			Impact:      "预计可提升完读率20%",
+			Factor:      factorIntro,
+			Confidence:  0.6, // 开头识别基于关键词，可能漏判
+		})
+	}
+
+	// 标题层级建议
+	if issues := result.TextAnalysis.ContentStructure.Headings.Issues; len(issues) > 0 {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "structure",
+			Priority:    "low",
+			Current:     fmt.Sprintf("标题层级不规范: %s", strings.Join(issues, "；")),
+			Recommended: "使用唯一的一级标题，小标题按 h1→h2→h3 逐级使用，不要跳级",
+			Reasoning:   "规范的标题层级便于读者扫读，也有利于搜索引擎理解文章结构",
+			Impact:      "预计可提升内容可读性和搜索表现",
+			Factor:      factorHeadings,
+			Confidence:  math.Min(0.5+0.1*float64(len(issues)), 0.9),
 		})
 	}
 
@@ -880,11 +1236,83 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 			Reasoning:   "CTA能够显著提升用户参与度",
 			Examples:    []string{"你遇到过类似情况吗？", "快来评论区分享你的经验", "觉得有用请点个赞"},
 			Impact:      "预计可提升互动率30%",
+			Factor:      factorCTA,
+			Confidence:  0.7, // 提问式等非模板化的互动引导可能未被识别
+		})
+	}
+
+	if suggestion, ok := keywordDensitySuggestion(result.TextAnalysis.KeywordDensity); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// 链接锚文本建议
+	if suggestion, ok := linkAnchorSuggestion(result.TextAnalysis.Links); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if suggestion, ok := writingSuggestion(result.TextAnalysis.WritingIssues); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if suggestion, ok := ca.emojiSuggestion(result.TextAnalysis); ok {
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// 品牌关键词建议
+	if missing := result.TextAnalysis.RequiredKeywords.Missing; len(missing) > 0 {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "keyword",
+			Priority:    "high",
+			Current:     fmt.Sprintf("缺少必需的品牌关键词: %s", strings.Join(missing, "、")),
+			Recommended: "在标题或正文中自然地加入这些品牌词",
+			Reasoning:   "品牌词是内容投放的硬性要求，缺失会影响品牌曝光和审核",
+			Examples:    missing,
+			Impact:      "满足品牌方要求，避免返工",
+			Confidence:  1,
+		})
+	}
+
+	// CTA位置和强度建议
+	cta := result.TextAnalysis.CTAAnalysis
+	if cta.Count > 0 && !cta.HasEndCTA {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "engagement",
+			Priority:    "medium",
+			Current:     "行动召唤只出现在正文前中部",
+			Recommended: "在文章结尾处再放置一个明确的行动召唤",
+			Reasoning:   "读者读完全文时最容易产生互动意愿，结尾的CTA转化率最高",
+			Examples:    []string{"觉得有用就点赞收藏吧", "快来评论区聊聊你的看法"},
+			Impact:      "预计可提升互动率10-20%",
+			Factor:      factorEndCTA,
+			Confidence:  0.6,
+		})
+	}
+	if excess := ca.excessCTAs(cta); excess > 0 {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "engagement",
+			Priority:    "medium",
+			Current:     fmt.Sprintf("行动召唤过多（%d处）", cta.Count),
+			Recommended: fmt.Sprintf("保留%d处以内最关键的行动召唤，优先放在结尾", ca.config.Analysis.CTA.MaxCount),
+			Reasoning:   "密集的行动召唤会让内容显得像广告，反而降低读者的信任和互动意愿",
+			Impact:      "预计可提升读者信任度和完读率",
+			Confidence:  signalConfidence(float64(excess), float64(ca.config.Analysis.CTA.MaxCount)),
+		})
+	}
+	if strengthBar := ca.bar(ctaStrengthSuggestionBar); cta.Count > 0 && cta.Strength < strengthBar {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "engagement",
+			Priority:    "low",
+			Current:     "行动召唤语气较弱",
+			Recommended: "使用祈使句式的行动召唤，如'点赞收藏'、'立即关注'，替代'了解更多'这类被动表达",
+			Reasoning:   "明确的动作指令比被动提示更能促使读者行动",
+			Impact:      "预计可提升互动率5-10%",
+			Factor:      factorStrongCTA,
+			Confidence:  signalConfidence(strengthBar-cta.Strength, strengthBar),
 		})
 	}
 
 	// 可读性建议
-	if result.Readability.FleschScore < 50 {
+	if fleschBar := ca.bar(fleschSuggestionBar); ca.stageEnabled("readability") && result.Readability.FleschScore < fleschBar {
 		suggestions = append(suggestions, models.Suggestion{
 			Type:        "readability",
 			Priority:    "medium",
@@ -892,11 +1320,17 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 			Recommended: "尝试使用更短的句子和更简单的词汇",
 			Reasoning:   fmt.Sprintf("当前可读性得分%.1f，建议提升到60以上", result.Readability.FleschScore),
 			Impact:      "预计可提升用户阅读体验",
+			Factor:      factorReadability,
+			Confidence:  signalConfidence(fleschBar-result.Readability.FleschScore, fleschBar),
 		})
 	}
 
+	if suggestion, ok := ca.varietySuggestion(result.Readability.Variety); ok && ca.stageEnabled("readability") {
+		suggestions = append(suggestions, suggestion)
+	}
+
 	// 视觉内容建议
-	if len(result.ImageAnalysis) == 0 {
+	if ca.stageEnabled("images") && len(result.ImageAnalysis) == 0 {
 		suggestions = append(suggestions, models.Suggestion{
 			Type:        "visual",
 			Priority:    "high",
@@ -904,8 +1338,16 @@ func (ca *ContentAnalyzer) generateSuggestions(result models.AnalysisResult) []m
 			Recommended: "添加相关图片、图表或者视觉元素来增强内容吸引力",
 			Reasoning:   "视觉内容能够显著提升用户参与度和分享率",
 			Impact:      "预计可提升参与度40-60%",
+			Factor:      factorImages,
+			Confidence:  0.9,
 		})
 	}
+	if ca.stageEnabled("images") {
+		suggestions = append(suggestions, ca.imageTextSuggestions(result.ImageAnalysis)...)
+		if suggestion, ok := aspectRatioSuggestion(result.ImageAnalysis); ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
 
 	return suggestions
 }
@@ -0,0 +1,218 @@
+package analyzer
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// testConfig 默认配置，不读取环境变量中的AI密钥，保证测试不访问网络
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载默认配置失败: %v", err)
+	}
+	return cfg
+}
+
+// newTestAnalyzer 使用默认配置的分析器，modify 可在创建前调整配置
+func newTestAnalyzer(t *testing.T, modify func(cfg *config.Config)) *ContentAnalyzer {
+	t.Helper()
+	cfg := testConfig(t)
+	if modify != nil {
+		modify(cfg)
+	}
+	return NewContentAnalyzer(cfg)
+}
+
+func TestCheckRequiredKeywords(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.RequiredKeywords = []string{"Go", "小米", " ", "SU7"}
+	})
+
+	coverage := ca.checkRequiredKeywords("这篇文章聊聊小米 su7 的续航，写得很 good。")
+	if got := strings.Join(coverage.Present, ","); got != "小米,SU7" {
+		t.Errorf("Present = %q, want 小米,SU7", got)
+	}
+	// "Go" 只出现在 "good" 中，不算命中；空白关键词忽略
+	if got := strings.Join(coverage.Missing, ","); got != "Go" {
+		t.Errorf("Missing = %q, want Go", got)
+	}
+
+	suggestions := ca.generateSuggestions(models.AnalysisResult{
+		TextAnalysis: models.TextAnalysis{RequiredKeywords: coverage},
+	})
+	found := false
+	for _, s := range suggestions {
+		if s.Type == "keyword" && strings.Contains(s.Current, "Go") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("缺少必需关键词时应生成建议")
+	}
+}
+
+func TestCheckRequiredKeywordsAllPresent(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.RequiredKeywords = []string{"Go"}
+	})
+
+	coverage := ca.checkRequiredKeywords("Learning Go, one step at a time.")
+	if len(coverage.Missing) != 0 || len(coverage.Present) != 1 {
+		t.Fatalf("coverage = %+v", coverage)
+	}
+	for _, s := range ca.generateSuggestions(models.AnalysisResult{TextAnalysis: models.TextAnalysis{RequiredKeywords: coverage}}) {
+		if strings.Contains(s.Current, "品牌关键词") {
+			t.Errorf("关键词齐全时不应生成建议: %+v", s)
+		}
+	}
+}
+
+// sampleContent 一篇不带图片的普通内容
+func sampleContent(id string) models.Content {
+	return models.Content{
+		ID:    id,
+		Title: "周末露营装备清单：新手也能轻松上手",
+		Text: "第一次去露营，很多人不知道该带什么。帐篷、睡袋和防潮垫是最基本的三件套。" +
+			"如果营地晚上降温明显，记得多带一件外套。炊具可以选择轻便的卡式炉，既安全又方便。" +
+			"最后别忘了垃圾袋，带走所有垃圾。你还有哪些必备装备？欢迎在评论区分享！",
+	}
+}
+
+func TestPerContentWeightsOnlyAffectThatItem(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	base := ca.AnalyzeAll([]models.Content{sampleContent("a"), sampleContent("b")}, 1, nil)
+	if len(base) != 2 {
+		t.Fatalf("分析结果数 = %d, want 2", len(base))
+	}
+
+	weighted := sampleContent("b")
+	weighted.ScoreWeights = &models.ScoreWeights{Title: 1}
+	results := ca.AnalyzeAll([]models.Content{sampleContent("a"), weighted}, 1, nil)
+	if len(results) != 2 {
+		t.Fatalf("分析结果数 = %d, want 2", len(results))
+	}
+
+	if results[0].Score.Total != base[0].Score.Total {
+		t.Errorf("未设置权重的内容总分变化: %.2f -> %.2f", base[0].Score.Total, results[0].Score.Total)
+	}
+	got := results[1].Score
+	if math.Abs(got.Total-got.Breakdown.Title) > 1e-9 {
+		t.Errorf("只按标题计分时总分 = %.2f, want 标题得分 %.2f", got.Total, got.Breakdown.Title)
+	}
+	if got.Total == base[1].Score.Total {
+		t.Errorf("单篇权重未改变该内容总分: %.2f", got.Total)
+	}
+}
+
+func TestInvalidPerContentWeightsIgnored(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	base, err := ca.Analyze(sampleContent("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		weights models.ScoreWeights
+		warning string
+	}{
+		{"sum above 1", models.ScoreWeights{Engagement: 5}, "score_weights 六项权重之和应为1"},
+		{"negative", models.ScoreWeights{Title: 1.5, Visual: -0.5}, "score_weights.visual 不能为负数"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := sampleContent("a")
+			content.ScoreWeights = &tt.weights
+			result, err := ca.Analyze(content)
+			if err != nil {
+				t.Fatalf("Analyze 失败: %v", err)
+			}
+			if result.Score.Total != base.Score.Total || result.Score.Level != base.Score.Level {
+				t.Errorf("总分/等级 = %.2f/%s, want 按全局权重 %.2f/%s", result.Score.Total, result.Score.Level, base.Score.Total, base.Score.Level)
+			}
+			if !containsSubstring(result.Warnings, tt.warning) {
+				t.Errorf("Warnings = %v, want 包含 %q", result.Warnings, tt.warning)
+			}
+		})
+	}
+}
+
+func TestConfiguredScoreWeights(t *testing.T) {
+	analyze := func(weights config.ScoreWeights) models.OverallScore {
+		ca := newTestAnalyzer(t, func(cfg *config.Config) {
+			cfg.Analysis.ScoreWeights = weights
+		})
+		results := ca.AnalyzeAll([]models.Content{sampleContent("post")}, 1, nil)
+		if len(results) != 1 {
+			t.Fatalf("分析结果数 = %d, want 1", len(results))
+		}
+		return results[0].Score
+	}
+
+	base := analyze(testConfig(t).Analysis.ScoreWeights)
+	titleOnly := analyze(config.ScoreWeights{Title: 1})
+	readabilityOnly := analyze(config.ScoreWeights{Readability: 1})
+	split := analyze(config.ScoreWeights{Title: 0.5, Readability: 0.5})
+
+	if math.Abs(titleOnly.Total-titleOnly.Breakdown.Title) > 1e-9 {
+		t.Errorf("只按标题计分时总分 = %.2f, want 标题得分 %.2f", titleOnly.Total, titleOnly.Breakdown.Title)
+	}
+	if math.Abs(readabilityOnly.Total-readabilityOnly.Breakdown.Readability) > 1e-9 {
+		t.Errorf("只按可读性计分时总分 = %.2f, want 可读性得分 %.2f", readabilityOnly.Total, readabilityOnly.Breakdown.Readability)
+	}
+	if want := (split.Breakdown.Title + split.Breakdown.Readability) / 2; math.Abs(split.Total-want) > 1e-9 {
+		t.Errorf("标题和可读性各半时总分 = %.2f, want %.2f", split.Total, want)
+	}
+	if titleOnly.Total == readabilityOnly.Total || titleOnly.Total == base.Total {
+		t.Errorf("调整权重后总分未变化: 默认 %.2f, 标题 %.2f, 可读性 %.2f", base.Total, titleOnly.Total, readabilityOnly.Total)
+	}
+
+	// 六项全为0时回退到默认权重
+	if zero := analyze(config.ScoreWeights{}); math.Abs(zero.Total-base.Total) > 1e-9 {
+		t.Errorf("权重全为0时总分 = %.2f, want 默认权重的 %.2f", zero.Total, base.Total)
+	}
+}
+
+func TestCustomLevelThresholdsChangeLevel(t *testing.T) {
+	base := newTestAnalyzer(t, nil).AnalyzeAll([]models.Content{sampleContent("post")}, 1, nil)
+	if len(base) != 1 {
+		t.Fatalf("分析结果数 = %d, want 1", len(base))
+	}
+	total := base[0].Score.Total
+
+	tests := []struct {
+		name       string
+		thresholds config.LevelThresholds
+		want       string
+	}{
+		// 以本篇总分为界调整分数线，总分不变、等级随之变化
+		{"总分达到 excellent", config.LevelThresholds{Excellent: total, Good: total / 2, Average: total / 4}, "excellent"},
+		{"总分只够 good", config.LevelThresholds{Excellent: total + 1, Good: total, Average: total / 2}, "good"},
+		{"总分只够 average", config.LevelThresholds{Excellent: 100, Good: total + 1, Average: total}, "average"},
+		{"总分低于 average", config.LevelThresholds{Excellent: 100, Good: 99, Average: total + 1}, "poor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) { cfg.Analysis.LevelThresholds = tt.thresholds })
+			results := ca.AnalyzeAll([]models.Content{sampleContent("post")}, 1, nil)
+			if len(results) != 1 {
+				t.Fatalf("分析结果数 = %d, want 1", len(results))
+			}
+			if results[0].Score.Total != total {
+				t.Errorf("总分 = %v, want 不受分数线影响的 %v", results[0].Score.Total, total)
+			}
+			if results[0].Score.Level != tt.want {
+				t.Errorf("Level = %s, want %s（总分 %.1f）", results[0].Score.Level, tt.want, total)
+			}
+		})
+	}
+}
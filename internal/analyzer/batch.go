@@ -0,0 +1,88 @@
+// internal/analyzer/batch.go
+package analyzer
+
+import (
+	"log"
+	"sync"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/pipeline"
+)
+
+// ProgressFunc 每篇内容处理完（无论成败）调用一次，done 为已完成的篇数，current 为刚完成的内容标题。
+// 调用是串行的，实现中无需再加锁，但应尽快返回，否则会拖慢分析
+type ProgressFunc func(done, total int, current string)
+
+// AnalyzeAll 用 workers 个协程分析全部内容，结果保持内容顺序，分析失败的内容记录日志后跳过。
+// progress 为nil时不报告进度
+func (ca *ContentAnalyzer) AnalyzeAll(contents []models.Content, workers int, progress ProgressFunc) []models.AnalysisResult {
+	return AnalyzeEach(contents, workers, func(_ int, content models.Content) (models.AnalysisResult, bool) {
+		result, err := ca.Analyze(content)
+		if err != nil {
+			log.Printf("分析失败 %s: %v", content.Title, err)
+			return result, false
+		}
+		return result, true
+	}, progress)
+}
+
+// AnalyzeEach 用 workers 个协程对每篇内容调用 analyze（i 为内容序号），结果保持内容顺序，
+// analyze 返回 false 的内容不计入结果。用于需要自行决定如何得到结果的场景，如增量分析时复用上次的结果
+func AnalyzeEach(contents []models.Content, workers int, analyze func(i int, content models.Content) (models.AnalysisResult, bool), progress ProgressFunc) []models.AnalysisResult {
+	type outcome struct {
+		result models.AnalysisResult
+		ok     bool
+	}
+
+	n := len(contents)
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var mu sync.Mutex
+	done := 0
+	report := func(title string) {
+		if progress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		progress(done, n, title)
+	}
+
+	outcomes, err := pipeline.Collect(n, workers*2, func(add func(int, outcome) error) {
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					result, ok := analyze(i, contents[i])
+					report(contents[i].Title)
+					add(i, outcome{result: result, ok: ok})
+				}
+			}()
+		}
+		for i := 0; i < n; i++ {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+	})
+	if err != nil {
+		log.Printf("收集分析结果失败: %v", err)
+	}
+
+	var results []models.AnalysisResult
+	for _, o := range outcomes {
+		if o.ok {
+			results = append(results, o.result)
+		}
+	}
+	return results
+}
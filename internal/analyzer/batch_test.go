@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestAnalyzeEachProgressOncePerItem(t *testing.T) {
+	var contents []models.Content
+	for i := 0; i < 20; i++ {
+		contents = append(contents, models.Content{ID: fmt.Sprint(i), Title: fmt.Sprintf("标题%d", i)})
+	}
+
+	for _, workers := range []int{1, 4, 50} {
+		t.Run(fmt.Sprintf("%d个协程", workers), func(t *testing.T) {
+			var inProgress int32
+			var dones []int
+			titles := make(map[string]int)
+			progress := func(done, total int, current string) {
+				// 调用是串行的
+				if atomic.AddInt32(&inProgress, 1) != 1 {
+					t.Error("progress 被并发调用")
+				}
+				defer atomic.AddInt32(&inProgress, -1)
+				if total != len(contents) {
+					t.Errorf("total = %d, want %d", total, len(contents))
+				}
+				dones = append(dones, done)
+				titles[current]++
+			}
+
+			results := AnalyzeEach(contents, workers, func(i int, content models.Content) (models.AnalysisResult, bool) {
+				time.Sleep(time.Duration(i%3) * time.Millisecond)
+				// 失败的内容同样报告进度
+				return models.AnalysisResult{ContentID: content.ID}, i%5 != 0
+			}, progress)
+
+			if len(dones) != len(contents) {
+				t.Fatalf("progress 调用 %d 次, want %d", len(dones), len(contents))
+			}
+			for i, done := range dones {
+				if done != i+1 {
+					t.Errorf("第%d次调用 done = %d, want %d", i+1, done, i+1)
+				}
+			}
+			for _, content := range contents {
+				if titles[content.Title] != 1 {
+					t.Errorf("%s 报告了 %d 次, want 1", content.Title, titles[content.Title])
+				}
+			}
+
+			// 失败的内容不计入结果，其余保持内容顺序
+			var want, got []string
+			for i, content := range contents {
+				if i%5 != 0 {
+					want = append(want, content.ID)
+				}
+			}
+			for _, result := range results {
+				got = append(got, result.ContentID)
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("结果 = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeAllNilProgress(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	results := ca.AnalyzeAll([]models.Content{sampleContent("a"), sampleContent("b")}, 2, nil)
+	if len(results) != 2 || results[0].ContentID != "a" || results[1].ContentID != "b" {
+		t.Errorf("AnalyzeAll() 结果 = %d 条, want a、b 两条按顺序", len(results))
+	}
+}
@@ -0,0 +1,63 @@
+// internal/analyzer/comments.go
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 每篇内容最多逐条分析的评论数，超出部分只计入评论总数
+const maxAnalyzedComments = 50
+
+// 平均得分超过该值时判定评论区整体倾向
+const communitySentimentThreshold = 0.2
+
+// analyzeCommunitySentiment 逐条分析评论的情感，统计分布和平均得分，与正文情感互不影响。
+// 同时返回结果来源，任一条评论降级即为 fallback
+func (ca *ContentAnalyzer) analyzeCommunitySentiment(comments []string) (*models.CommunitySentiment, string, error) {
+	community := &models.CommunitySentiment{
+		CommentCount: len(comments),
+		Overall:      "neutral",
+	}
+
+	total := 0.0
+	provenance := ""
+	for _, comment := range comments {
+		if community.Analyzed >= maxAnalyzedComments {
+			break
+		}
+		comment = strings.TrimSpace(comment)
+		if comment == "" {
+			continue
+		}
+
+		sentiment, source, err := ca.analyzeSentiment(comment)
+		if err != nil {
+			return nil, "", err
+		}
+		provenance = worseProvenance(provenance, source)
+
+		community.Analyzed++
+		total += sentiment.Score
+		switch sentiment.Overall {
+		case "positive":
+			community.Positive++
+		case "negative":
+			community.Negative++
+		default:
+			community.Neutral++
+		}
+	}
+
+	if community.Analyzed > 0 {
+		community.Score = total / float64(community.Analyzed)
+	}
+	if community.Score > communitySentimentThreshold {
+		community.Overall = "positive"
+	} else if community.Score < -communitySentimentThreshold {
+		community.Overall = "negative"
+	}
+
+	return community, provenance, nil
+}
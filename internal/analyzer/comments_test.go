@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestCommunitySentimentSeparateFromBody(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	content := models.Content{
+		ID:    "review",
+		Title: "这款帐篷真的很棒",
+		Text:  "用了一个月，非常满意，强烈推荐给喜欢露营的朋友。",
+	}
+	withoutComments, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	content.Comments = []string{"太失望了", "质量很差，后悔买了", "还行吧", "   "}
+	result, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// 评论不影响正文情感
+	if !reflect.DeepEqual(result.Sentiment, withoutComments.Sentiment) {
+		t.Errorf("Sentiment = %+v, 加入评论后应与 %+v 相同", result.Sentiment, withoutComments.Sentiment)
+	}
+	if result.Sentiment.Overall != "positive" {
+		t.Errorf("正文情感 = %q, want positive", result.Sentiment.Overall)
+	}
+
+	community := result.Community
+	if community == nil {
+		t.Fatal("Community = nil, 有评论时应分析评论区情感")
+	}
+	if community.CommentCount != 4 || community.Analyzed != 3 {
+		t.Errorf("CommentCount/Analyzed = %d/%d, want 4/3（空评论不参与分析）", community.CommentCount, community.Analyzed)
+	}
+	if community.Negative != 2 || community.Neutral != 1 || community.Positive != 0 {
+		t.Errorf("分布 = +%d/=%d/-%d, want +0/=1/-2", community.Positive, community.Neutral, community.Negative)
+	}
+	if community.Overall != "negative" || math.Abs(community.Score-(-0.4)) > 1e-9 {
+		t.Errorf("Overall/Score = %q/%v, want negative/-0.4", community.Overall, community.Score)
+	}
+
+	if withoutComments.Community != nil {
+		t.Errorf("没有评论时 Community = %+v, want nil", withoutComments.Community)
+	}
+}
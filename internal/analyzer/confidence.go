@@ -0,0 +1,38 @@
+// internal/analyzer/confidence.go
+package analyzer
+
+import (
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 刚越过触发阈值的建议的置信度
+const baseSuggestionConfidence = 0.3
+
+// signalConfidence 按超出阈值的幅度换算置信度：刚越过阈值为 0.3，超出 span 及以上为 1
+func signalConfidence(gap, span float64) float64 {
+	if span <= 0 {
+		return 1
+	}
+	return math.Max(0, math.Min(baseSuggestionConfidence+(1-baseSuggestionConfidence)*gap/span, 1))
+}
+
+// splitSuggestions 按 min_suggestion_confidence 将建议分为主要建议和次要建议
+func (ca *ContentAnalyzer) splitSuggestions(suggestions []models.Suggestion) ([]models.Suggestion, []models.Suggestion) {
+	threshold := ca.config.Analysis.MinSuggestionConfidence
+	if threshold <= 0 {
+		return suggestions, nil
+	}
+
+	var major, minor []models.Suggestion
+	for _, suggestion := range suggestions {
+		if suggestion.Confidence >= threshold {
+			major = append(major, suggestion)
+		} else {
+			minor = append(minor, suggestion)
+		}
+	}
+
+	return major, minor
+}
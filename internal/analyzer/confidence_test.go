@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestSignalConfidence(t *testing.T) {
+	tests := []struct {
+		gap, span float64
+		want      float64
+	}{
+		{gap: 0, span: 70, want: baseSuggestionConfidence},
+		{gap: 35, span: 70, want: 0.65},
+		{gap: 70, span: 70, want: 1},
+		{gap: 200, span: 70, want: 1},
+		{gap: 5, span: 0, want: 1},
+	}
+	for _, tt := range tests {
+		if got := signalConfidence(tt.gap, tt.span); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("signalConfidence(%v, %v) = %v, want %v", tt.gap, tt.span, got, tt.want)
+		}
+	}
+}
+
+func TestMinSuggestionConfidenceFiltersWeakSuggestions(t *testing.T) {
+	content := sampleContent("post")
+
+	all, err := newTestAnalyzer(t, nil).Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(all.Minor) != 0 {
+		t.Errorf("未设置阈值时 Minor = %+v, want 空", all.Minor)
+	}
+
+	const threshold = 0.8
+	filtered, err := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.MinSuggestionConfidence = threshold
+	}).Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(filtered.Minor) == 0 {
+		t.Fatalf("阈值 %.1f 下应有弱建议被移入 Minor, suggestions = %+v", threshold, all.Suggestions)
+	}
+	for _, s := range filtered.Suggestions {
+		if s.Confidence < threshold {
+			t.Errorf("主要建议 %q 置信度 %.2f 低于阈值", s.Current, s.Confidence)
+		}
+	}
+	for _, s := range filtered.Minor {
+		if s.Confidence >= threshold {
+			t.Errorf("次要建议 %q 置信度 %.2f 不低于阈值", s.Current, s.Confidence)
+		}
+	}
+	// 只是分组，不丢弃建议
+	if got := len(filtered.Suggestions) + len(filtered.Minor); got != len(all.Suggestions) {
+		t.Errorf("建议总数 = %d, want %d", got, len(all.Suggestions))
+	}
+}
@@ -0,0 +1,175 @@
+// internal/analyzer/cta.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 各语言内置的CTA模式，可用 analysis.word_lists.cta_patterns 或 analysis.cta.patterns 扩充。
+// 正文按原样匹配（不转小写），英文模式用 (?i) 忽略大小写
+var ctaPatterns = map[string][]string{
+	"zh": {
+		`点击.*链接`, `立即.*`, `马上.*`, `赶快.*`, `快来.*`,
+		`关注我`, `点赞.*`, `评论.*`, `分享.*`, `收藏.*`,
+		`了解更多`, `查看更多`, `阅读全文`,
+	},
+	"en": {
+		// 英文以句号分句，CTA只取到句末
+		`(?i)\bclick (here|below|the link)\b[^.!?\n]*`, `(?i)\bsubscribe\b[^.!?\n]*`, `(?i)\bsign up\b[^.!?\n]*`,
+		`(?i)\bfollow (me|us)\b[^.!?\n]*`, `(?i)\bshare this\b[^.!?\n]*`, `(?i)\bcomment below\b[^.!?\n]*`,
+		`(?i)\bbuy now\b[^.!?\n]*`, `(?i)\blearn more\b`, `(?i)\bread more\b`,
+	},
+}
+
+// 祈使语气的CTA开头，视为强CTA；其余（如"了解更多"、"learn more"）视为弱CTA。英文CTA已转为小写
+var strongCTAPrefixes = []string{
+	"点击", "立即", "马上", "赶快", "快来", "关注", "点赞", "评论", "收藏",
+	"click", "subscribe", "sign up", "follow", "share", "comment", "buy",
+}
+
+// CTA语句首尾需要去掉的空白和标点
+const ctaTrimChars = " \t\r\n，。！？、；：,.!?;:…~～\"'“”‘’（）()【】"
+
+// ctaMatch 正文中的一处CTA，start/end 为字节偏移
+type ctaMatch struct {
+	text  string
+	start int
+	end   int
+}
+
+// findCTAs 匹配正文中的CTA。开启去重时，多个模式命中同一处文字（如"立即关注我"同时命中"立即.*"和"关注我"）
+// 只保留起始最早、范围最长的一处
+func (ca *ContentAnalyzer) findCTAs(text, lang string) []ctaMatch {
+	var matches []ctaMatch
+	for _, l := range lexiconLanguages(lang) {
+		for _, re := range ca.words.cta[l] {
+			for _, loc := range re.FindAllStringIndex(text, -1) {
+				cta := normalizeCTA(text[loc[0]:loc[1]])
+				if cta == "" {
+					continue
+				}
+				matches = append(matches, ctaMatch{text: cta, start: loc[0], end: loc[1]})
+			}
+		}
+	}
+
+	if !ca.config.Analysis.CTA.Dedupe {
+		return matches
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+
+	deduped := matches[:0]
+	lastEnd := -1
+	for _, m := range matches {
+		if m.start < lastEnd {
+			continue
+		}
+		deduped = append(deduped, m)
+		lastEnd = m.end
+	}
+
+	return deduped
+}
+
+// normalizeCTA 去掉首尾空白和标点，只将拉丁字母转为小写，中文保持原样
+func normalizeCTA(cta string) string {
+	cta = strings.Trim(cta, ctaTrimChars)
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return r
+	}, cta)
+}
+
+// extractCallToActions 返回正文中的CTA语句，开启去重时相同语句只保留一次
+func (ca *ContentAnalyzer) extractCallToActions(text, lang string) []string {
+	var ctas []string
+	seen := make(map[string]bool)
+
+	for _, m := range ca.findCTAs(text, lang) {
+		if ca.config.Analysis.CTA.Dedupe {
+			if seen[m.text] {
+				continue
+			}
+			seen[m.text] = true
+		}
+		ctas = append(ctas, m.text)
+	}
+
+	return ctas
+}
+
+// analyzeCallToActions 分析CTA的数量、位置和强度
+func (ca *ContentAnalyzer) analyzeCallToActions(text, lang string) models.CTAAnalysis {
+	analysis := models.CTAAnalysis{}
+
+	if len(text) == 0 {
+		return analysis
+	}
+
+	strongCount := 0
+	for _, m := range ca.findCTAs(text, lang) {
+		placement := models.CTAPlacement{
+			Text:     m.text,
+			Position: ctaPosition(float64(m.start) / float64(len(text))),
+			Strength: "weak",
+		}
+		if isStrongCTA(placement.Text) {
+			placement.Strength = "strong"
+			strongCount++
+		}
+		if placement.Position == "end" {
+			analysis.HasEndCTA = true
+		}
+		analysis.Placements = append(analysis.Placements, placement)
+	}
+
+	analysis.Count = len(analysis.Placements)
+	if analysis.Count > 0 {
+		// 强CTA占比决定整体强度，结尾有CTA额外加权
+		analysis.Strength = float64(strongCount) / float64(analysis.Count) * 0.8
+		if analysis.HasEndCTA {
+			analysis.Strength += 0.2
+		}
+	}
+
+	return analysis
+}
+
+// excessCTAs 超出 cta.max_count 的CTA数量，未设置上限时为0
+func (ca *ContentAnalyzer) excessCTAs(analysis models.CTAAnalysis) int {
+	limit := ca.config.Analysis.CTA.MaxCount
+	if limit <= 0 || analysis.Count <= limit {
+		return 0
+	}
+	return analysis.Count - limit
+}
+
+// ctaPosition 根据CTA在文中的相对位置划分区段
+func ctaPosition(ratio float64) string {
+	if ratio < 1.0/3 {
+		return "early"
+	} else if ratio < 2.0/3 {
+		return "middle"
+	}
+	return "end"
+}
+
+func isStrongCTA(cta string) bool {
+	for _, prefix := range strongCTAPrefixes {
+		if strings.HasPrefix(cta, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func ctaTextAnalysis(ca *ContentAnalyzer, text string) models.TextAnalysis {
+	return models.TextAnalysis{
+		CallToAction: ca.extractCallToActions(text, "zh"),
+		CTAAnalysis:  ca.analyzeCallToActions(text, "zh"),
+	}
+}
+
+func TestStrongEndCTAOutscoresWeakMidCTA(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	filler := strings.Repeat("周末整理房间的一些心得和小技巧。", 6)
+
+	strongEnd := ctaTextAnalysis(ca, filler+filler+"觉得有用就点赞收藏")
+	weakMid := ctaTextAnalysis(ca, filler+"想看细节可以了解更多。"+filler)
+
+	if got := strongEnd.CTAAnalysis; !got.HasEndCTA || got.Count == 0 || got.Placements[0].Strength != "strong" {
+		t.Fatalf("结尾强CTA分析有误: %+v", got)
+	}
+	if got := weakMid.CTAAnalysis; got.HasEndCTA || got.Count != 1 ||
+		got.Placements[0].Position != "middle" || got.Placements[0].Strength != "weak" {
+		t.Fatalf("中部弱CTA分析有误: %+v", got)
+	}
+
+	strongScore := ca.scoreEngagement(strongEnd)
+	weakScore := ca.scoreEngagement(weakMid)
+	if strongScore <= weakScore {
+		t.Errorf("结尾强CTA得分 %.1f 应高于中部弱CTA得分 %.1f", strongScore, weakScore)
+	}
+}
+
+func TestCTAPosition(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{0, "early"},
+		{0.3, "early"},
+		{0.5, "middle"},
+		{0.7, "end"},
+		{0.99, "end"},
+	}
+	for _, tt := range tests {
+		if got := ctaPosition(tt.ratio); got != tt.want {
+			t.Errorf("ctaPosition(%v) = %q, want %q", tt.ratio, got, tt.want)
+		}
+	}
+}
+
+func TestCTADedupe(t *testing.T) {
+	text := "今天就聊到这里。\n立即关注我\n"
+
+	tests := []struct {
+		name      string
+		dedupe    bool
+		wantCTAs  []string
+		wantCount int
+	}{
+		// "立即关注我" 同时命中 立即.* 和 关注我，只保留起始最早、范围最长的一处
+		{name: "dedupe", dedupe: true, wantCTAs: []string{"立即关注我"}, wantCount: 1},
+		{name: "raw", dedupe: false, wantCTAs: []string{"立即关注我", "关注我"}, wantCount: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Analysis.CTA.Dedupe = tt.dedupe
+			})
+
+			analysis := ctaTextAnalysis(ca, text)
+			if analysis.CTAAnalysis.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d (%+v)", analysis.CTAAnalysis.Count, tt.wantCount, analysis.CTAAnalysis.Placements)
+			}
+			if strings.Join(analysis.CallToAction, "|") != strings.Join(tt.wantCTAs, "|") {
+				t.Errorf("CallToAction = %q, want %q", analysis.CallToAction, tt.wantCTAs)
+			}
+		})
+	}
+}
+
+func TestCTADuplicateSentencesListedOnce(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	filler := strings.Repeat("周末整理房间的一些心得和小技巧。", 4)
+
+	analysis := ctaTextAnalysis(ca, "关注我。"+filler+"关注我。")
+
+	if len(analysis.CallToAction) != 1 || analysis.CallToAction[0] != "关注我" {
+		t.Errorf("CallToAction = %q, 相同语句应只列出一次", analysis.CallToAction)
+	}
+	// 位置分析仍记录每一处
+	if analysis.CTAAnalysis.Count != 2 {
+		t.Errorf("Count = %d, want 2", analysis.CTAAnalysis.Count)
+	}
+}
+
+func TestExcessCTAsPenalized(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.CTA.MaxCount = 3
+	})
+	filler := strings.Repeat("周末整理房间的一些心得和小技巧。", 4)
+
+	single := ctaTextAnalysis(ca, filler+"觉得有用就点赞吧")
+	spammy := ctaTextAnalysis(ca, "关注我\n"+filler+"\n评论区见\n"+filler+"\n分享给朋友\n收藏备用\n点赞支持")
+
+	if got := ca.excessCTAs(spammy.CTAAnalysis); got != 2 {
+		t.Fatalf("excessCTAs = %d, want 2 (%+v)", got, spammy.CTAAnalysis.Placements)
+	}
+	if got := ca.excessCTAs(single.CTAAnalysis); got != 0 {
+		t.Errorf("单个CTA excessCTAs = %d, want 0", got)
+	}
+
+	if singleScore, spammyScore := ca.scoreEngagement(single), ca.scoreEngagement(spammy); spammyScore >= singleScore {
+		t.Errorf("CTA堆砌得分 %.1f 应低于单个CTA得分 %.1f", spammyScore, singleScore)
+	}
+	if singleScore, spammyScore := ca.scoreContentQuality(single), ca.scoreContentQuality(spammy); spammyScore >= singleScore {
+		t.Errorf("CTA堆砌的内容质量 %.1f 应低于单个CTA %.1f", spammyScore, singleScore)
+	}
+}
+
+func TestCTALanguages(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantLang string
+		wantCTAs []string
+	}{
+		{
+			name:     "英文",
+			text:     "We spent the weekend by the lake. Click here to see the gear list. Subscribe for more trips! Comment below with your tips. Learn more about tents.",
+			wantLang: "en",
+			wantCTAs: []string{"click here to see the gear list", "subscribe for more trips", "comment below with your tips", "learn more"},
+		},
+		{
+			name:     "中文",
+			text:     "周末去湖边露营，风景很好。\n点击下方链接\n记得点赞收藏\n",
+			wantLang: "zh",
+			// "点赞收藏" 同时命中 点赞.* 和 收藏.*，只保留一处
+			wantCTAs: []string{"点击下方链接", "点赞收藏"},
+		},
+		{
+			name:     "中英混排",
+			text:     "今天整理了露营装备清单。Click here for the full list.\n喜欢就关注我\nSubscribe for more camping tips!",
+			wantLang: "mixed",
+			wantCTAs: []string{"click here for the full list", "关注我", "subscribe for more camping tips"},
+		},
+	}
+
+	ca := newTestAnalyzer(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := DetectLanguage(tt.text)
+			if lang != tt.wantLang {
+				t.Fatalf("DetectLanguage() = %q, want %q", lang, tt.wantLang)
+			}
+			if got := ca.extractCallToActions(tt.text, lang); strings.Join(got, "|") != strings.Join(tt.wantCTAs, "|") {
+				t.Errorf("CallToAction = %q, want %q", got, tt.wantCTAs)
+			}
+		})
+	}
+}
+
+func TestCTAConfiguredPatterns(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.CTA.Patterns = map[string][]string{
+			"zh": {`私信我`},
+			// 正文不转小写，含大写字母的模式也能命中
+			"en": {`\bDM me\b[^.!?\n]*`},
+		}
+	})
+
+	tests := []struct {
+		lang     string
+		text     string
+		wantCTAs []string
+	}{
+		{"zh", "想要装备清单的朋友可以私信我。", []string{"私信我"}},
+		{"en", "Want the full gear list? DM me for details.", []string{"dm me for details"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if got := ca.extractCallToActions(tt.text, tt.lang); strings.Join(got, "|") != strings.Join(tt.wantCTAs, "|") {
+				t.Errorf("CallToAction = %q, want %q", got, tt.wantCTAs)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// internal/analyzer/domain_terms.go
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 英文平均词长。行业术语按这个长度计入平均词长
+const typicalWordLength = 4.7
+
+// 中文术语在计算句长时替换成的占位字，使整个术语只计一个字
+const hanTermPlaceholder = "术"
+
+// domainTerms analysis.domain_terms 行业术语表。术语在专业内容中是必要的，
+// 不计为复杂词：英文术语按普通词长和音节数计算，中文术语在句长中只计一个字
+type domainTerms struct {
+	words map[string]bool // 以空格分词的术语（小写），多词术语按单词逐个匹配
+	han   []string        // 含汉字的术语，按长度降序，优先替换较长的术语
+}
+
+func newDomainTerms(terms []string) domainTerms {
+	d := domainTerms{words: make(map[string]bool)}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if strings.IndexFunc(term, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0 {
+			d.han = append(d.han, term)
+			continue
+		}
+		for _, word := range strings.Fields(term) {
+			if word = normalizeTermWord(word); word != "" {
+				d.words[word] = true
+			}
+		}
+	}
+
+	sort.SliceStable(d.han, func(i, j int) bool {
+		return utf8.RuneCountInString(d.han[i]) > utf8.RuneCountInString(d.han[j])
+	})
+	return d
+}
+
+// isTerm 判断以空格分出的单词是否为术语，忽略大小写和首尾标点
+func (d domainTerms) isTerm(word string) bool {
+	return len(d.words) > 0 && d.words[normalizeTermWord(word)]
+}
+
+// isHanTerm 判断分词得到的中文词是否为术语
+func (d domainTerms) isHanTerm(word string) bool {
+	for _, term := range d.han {
+		if term == word {
+			return true
+		}
+	}
+	return false
+}
+
+// wordLength 单词计入平均词长的长度，术语按 typicalWordLength 计
+func (d domainTerms) wordLength(word string) float64 {
+	if d.isTerm(word) {
+		return typicalWordLength
+	}
+	return float64(utf8.RuneCountInString(word))
+}
+
+// syllables 单词计入Flesch公式的音节数，术语按 typicalSyllables 计
+func (d domainTerms) syllables(word string) float64 {
+	if d.isTerm(word) {
+		return typicalSyllables
+	}
+	return float64(countSyllables(word))
+}
+
+// compressHan 将中文术语替换为单个占位字，仅用于计算句长
+func (d domainTerms) compressHan(text string) string {
+	for _, term := range d.han {
+		text = strings.ReplaceAll(text, term, hanTermPlaceholder)
+	}
+	return text
+}
+
+func normalizeTermWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
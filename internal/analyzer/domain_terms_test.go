@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestDomainTermsRaiseReadability(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+	}{
+		{
+			name: "english jargon",
+			text: "We deploy containerization with Kubernetes orchestration. " +
+				"The microservices use observability dashboards. " +
+				"Autoscaling keeps the infrastructure responsive.",
+			terms: []string{"containerization", "Kubernetes", "orchestration", "microservices", "observability", "autoscaling", "infrastructure"},
+		},
+		{
+			name: "chinese jargon",
+			text: "我们用容器编排平台管理微服务架构和分布式链路追踪系统。" +
+				"每次发布都经过灰度发布和全链路压力测试的验证流程才上线。",
+			terms: []string{"容器编排平台", "微服务架构", "分布式链路追踪系统", "灰度发布", "全链路压力测试"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, _ := newTestAnalyzer(t, nil).analyzeReadability(tt.text, nil)
+			allowed, _ := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Analysis.DomainTerms = tt.terms
+			}).analyzeReadability(tt.text, nil)
+
+			if allowed.FleschScore <= plain.FleschScore {
+				t.Errorf("FleschScore: 加入术语表后 %.2f, want 高于 %.2f", allowed.FleschScore, plain.FleschScore)
+			}
+			if allowed.ComplexWordRatio > plain.ComplexWordRatio {
+				t.Errorf("ComplexWordRatio: 加入术语表后 %.2f, want 不高于 %.2f", allowed.ComplexWordRatio, plain.ComplexWordRatio)
+			}
+		})
+	}
+}
+
+func TestDomainTermsMatching(t *testing.T) {
+	terms := newDomainTerms([]string{" Kubernetes ", "machine learning", "灰度发布", ""})
+	for _, word := range []string{"kubernetes", "Kubernetes,", "machine", "Learning."} {
+		if !terms.isTerm(word) {
+			t.Errorf("isTerm(%q) = false, want true", word)
+		}
+	}
+	if terms.isTerm("docker") {
+		t.Error(`isTerm("docker") = true, want false`)
+	}
+	if !terms.isHanTerm("灰度发布") || terms.isHanTerm("灰度") {
+		t.Error("isHanTerm 应只匹配完整的中文术语")
+	}
+	if got := terms.compressHan("先灰度发布再全量"); got != "先"+hanTermPlaceholder+"再全量" {
+		t.Errorf("compressHan() = %q", got)
+	}
+}
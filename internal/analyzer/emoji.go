@@ -0,0 +1,251 @@
+// internal/analyzer/emoji.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+const (
+	// emoji密度不超过 max_emoji_density 的一半时按密度线性加分，之后到上限之间边际收益递减
+	emojiBonus      = 8.0
+	emojiExtraBonus = 2.0
+	// 超过 max_emoji_density 时的扣分
+	emojiOverusePenalty = 5.0
+)
+
+const (
+	zeroWidthJoiner   = '\u200D'
+	variationEmoji    = '\uFE0F' // 要求按emoji显示
+	variationText     = '\uFE0E' // 要求按文字显示
+	combiningKeycap   = '\u20E3'
+	blackFlag         = '\U0001F3F4'
+	tagCancel         = '\U000E007F'
+	skinToneFirst     = '\U0001F3FB'
+	skinToneLast      = '\U0001F3FF'
+	regionalIndicator = '\U0001F1E6'
+	regionalLast      = '\U0001F1FF'
+)
+
+// emojiRanges 算作emoji的字符（Unicode Emoji 属性）。其中 ☀❤✔ 等默认按文字显示的字符在中文平台上常不加 U+FE0F 直接使用，也计入
+var emojiRanges = [][2]rune{
+	{0x231A, 0x231B}, {0x23E9, 0x23F3}, {0x23F8, 0x23FA},
+	// 杂项符号、装饰符号区块中属于emoji的字符，★☆✓ 等普通符号不算
+	{0x2600, 0x2604}, {0x260E, 0x260E}, {0x2611, 0x2611}, {0x2614, 0x2615}, {0x2618, 0x2618},
+	{0x261D, 0x261D}, {0x2620, 0x2620}, {0x2622, 0x2623}, {0x2626, 0x2626}, {0x262A, 0x262A},
+	{0x262E, 0x262F}, {0x2638, 0x263A}, {0x2640, 0x2640}, {0x2642, 0x2642}, {0x2648, 0x2653},
+	{0x265F, 0x2660}, {0x2663, 0x2663}, {0x2665, 0x2666}, {0x2668, 0x2668}, {0x267B, 0x267B},
+	{0x267E, 0x267F}, {0x2692, 0x2697}, {0x2699, 0x2699}, {0x269B, 0x269C}, {0x26A0, 0x26A1},
+	{0x26A7, 0x26A7}, {0x26AA, 0x26AB}, {0x26B0, 0x26B1}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26C8, 0x26C8}, {0x26CE, 0x26CF}, {0x26D1, 0x26D1}, {0x26D3, 0x26D4}, {0x26E9, 0x26EA},
+	{0x26F0, 0x26F5}, {0x26F7, 0x26FA}, {0x26FD, 0x26FD}, {0x2702, 0x2702}, {0x2705, 0x2705},
+	{0x2708, 0x270D}, {0x270F, 0x270F}, {0x2712, 0x2712}, {0x2714, 0x2714}, {0x2716, 0x2716},
+	{0x271D, 0x271D}, {0x2721, 0x2721}, {0x2728, 0x2728}, {0x2733, 0x2734}, {0x2744, 0x2744},
+	{0x2747, 0x2747}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757},
+	{0x2763, 0x2764}, {0x2795, 0x2797}, {0x27A1, 0x27A1}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B05, 0x2B07}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x3030, 0x3030}, {0x303D, 0x303D}, {0x3297, 0x3297}, {0x3299, 0x3299},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F170, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F201, 0x1F202}, {0x1F21A, 0x1F21A}, {0x1F22F, 0x1F22F}, {0x1F232, 0x1F23A}, {0x1F250, 0x1F251},
+	{0x1F300, 0x1F64F}, // 杂项符号和象形文字、表情
+	{0x1F680, 0x1F6FF}, // 交通和地图符号
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0},
+	{0x1F900, 0x1F9FF}, // 补充符号和象形文字
+	{0x1FA70, 0x1FAFF}, // 扩展象形文字A
+}
+
+// textDefaultEmoji 默认按文字显示、后跟 U+FE0F 时才算emoji的字符，如 ©️、↔️、▶️
+var textDefaultEmoji = [][2]rune{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x2328, 0x2328}, {0x23CF, 0x23CF}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2934, 0x2935},
+}
+
+func inRanges(r rune, ranges [][2]rune) bool {
+	for _, rg := range ranges {
+		if r >= rg[0] && r <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicator && r <= regionalLast
+}
+
+func isKeycapBase(r rune) bool {
+	return r == '#' || r == '*' || (r >= '0' && r <= '9')
+}
+
+// extractEmojis 按显示效果切分出文本中的emoji，每个元素是一个完整的emoji：
+// 肤色修饰（👍🏽）、零宽连接序列（👨‍👩‍👧‍👦）、国旗（🇨🇳）、🏴加标签字符的地区旗帜（如苏格兰旗）和数字键帽（1️⃣）都算一个
+func extractEmojis(text string) []string {
+	runes := []rune(text)
+	var emojis []string
+	for i := 0; i < len(runes); {
+		if n := emojiLen(runes[i:]); n > 0 {
+			emojis = append(emojis, string(runes[i:i+n]))
+			i += n
+			continue
+		}
+		i++
+	}
+	return emojis
+}
+
+// emojiLen 以 runes 开头的emoji占的字符数，开头不是emoji时为0
+func emojiLen(runes []rune) int {
+	r := runes[0]
+	next := func(i int) rune {
+		if i < len(runes) {
+			return runes[i]
+		}
+		return 0
+	}
+
+	// 国旗：两个地区指示符
+	if isRegionalIndicator(r) {
+		if isRegionalIndicator(next(1)) {
+			return 2
+		}
+		return 0
+	}
+
+	// 键帽：数字或 #、* 加可选的 U+FE0F 和 U+20E3
+	if isKeycapBase(r) {
+		switch {
+		case next(1) == combiningKeycap:
+			return 2
+		case next(1) == variationEmoji && next(2) == combiningKeycap:
+			return 3
+		}
+		return 0
+	}
+
+	n := emojiElementLen(runes)
+	if n == 0 {
+		return 0
+	}
+	// 零宽连接序列，如 👨‍👩‍👧‍👦、🏳️‍🌈
+	for next(n) == zeroWidthJoiner {
+		m := emojiElementLen(runes[n+1:])
+		if m == 0 {
+			break
+		}
+		n += 1 + m
+	}
+	return n
+}
+
+// emojiElementLen 单个emoji及其修饰（变体选择符、肤色、标签序列）占的字符数
+func emojiElementLen(runes []rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+	r := runes[0]
+	n := 1
+	switch {
+	case inRanges(r, emojiRanges):
+		if n < len(runes) && runes[n] == variationText {
+			return 0
+		}
+	case inRanges(r, textDefaultEmoji):
+		if n >= len(runes) || runes[n] != variationEmoji {
+			return 0
+		}
+	default:
+		return 0
+	}
+
+	if n < len(runes) && runes[n] == variationEmoji {
+		n++
+	}
+	if n < len(runes) && runes[n] >= skinToneFirst && runes[n] <= skinToneLast {
+		n++
+	}
+	// 地区旗帜：🏴 后跟标签字符，以 U+E007F 结束
+	if r == blackFlag {
+		m := n
+		for m < len(runes) && runes[m] >= 0xE0020 && runes[m] < tagCancel {
+			m++
+		}
+		if m > n && m < len(runes) && runes[m] == tagCancel {
+			n = m + 1
+		}
+	}
+	return n
+}
+
+// uniqueEmojis 按首次出现的顺序去重
+func uniqueEmojis(emojis []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, emoji := range emojis {
+		if !seen[emoji] {
+			seen[emoji] = true
+			unique = append(unique, emoji)
+		}
+	}
+	return unique
+}
+
+// emojiDensity 平均每个词的emoji数，没有词时为0
+func emojiDensity(analysis models.TextAnalysis) float64 {
+	if analysis.WordCount == 0 {
+		return 0
+	}
+	return float64(analysis.EmojiCount) / float64(analysis.WordCount)
+}
+
+// emojiScore emoji对互动性的加减分：密度达到上限的一半前线性加分，之后到上限之间加分放缓，超过上限扣分
+func (ca *ContentAnalyzer) emojiScore(analysis models.TextAnalysis) float64 {
+	if analysis.EmojiOveruse {
+		return -emojiOverusePenalty
+	}
+	density := emojiDensity(analysis)
+	if density == 0 {
+		return 0
+	}
+
+	limit := ca.config.Analysis.MaxEmojiDensity
+	density = math.Min(density, limit) // 模拟修正过多的emoji时密度按上限计
+
+	half := limit / 2
+	score := emojiBonus * math.Min(density, half) / half
+	if density > half {
+		score += emojiExtraBonus * (density - half) / half
+	}
+	return score
+}
+
+// emojiSuggestion emoji密度超过上限时建议减少
+func (ca *ContentAnalyzer) emojiSuggestion(analysis models.TextAnalysis) (models.Suggestion, bool) {
+	if !analysis.EmojiOveruse {
+		return models.Suggestion{}, false
+	}
+
+	limit := ca.config.Analysis.MaxEmojiDensity
+	target := int(math.Floor(float64(analysis.WordCount) * limit))
+	examples := analysis.Emojis
+	if len(examples) > 10 {
+		examples = examples[:10]
+	}
+
+	return models.Suggestion{
+		Type:        "engagement",
+		Priority:    "low",
+		Current:     fmt.Sprintf("正文%d个词中有%d个emoji，超过每%.0f词1个的上限", analysis.WordCount, analysis.EmojiCount, 1/limit),
+		Recommended: fmt.Sprintf("保留最能表达情绪或标记重点的emoji，减少到%d个以内", target),
+		Reasoning:   "适量的emoji让内容更活泼，过多则干扰阅读，也显得不够专业",
+		Examples:    []string{strings.Join(examples, " ")},
+		Impact:      "提升正文的可读性和专业感",
+		Factor:      factorEmoji,
+		Confidence:  signalConfidence(emojiDensity(analysis)-limit, limit),
+	}, true
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractEmojis(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"零宽连接的家庭", "一家人👨\u200D👩\u200D👧\u200D👦去露营", []string{"👨\u200D👩\u200D👧\u200D👦"}},
+		{"带肤色的零宽连接序列", "👩🏽\u200D💻写代码", []string{"👩🏽\u200D💻"}},
+		{"彩虹旗", "🏳\uFE0F\u200D🌈", []string{"🏳\uFE0F\u200D🌈"}},
+		{"国旗", "🇨🇳🇯🇵 出发", []string{"🇨🇳", "🇯🇵"}},
+		// 单独的地区指示符不算国旗
+		{"落单的地区指示符", "🇨🇳🇯", []string{"🇨🇳"}},
+		{"苏格兰旗", "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", []string{"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F"}},
+		{"肤色修饰", "👍🏽好", []string{"👍🏽"}},
+		{"键帽", "1\uFE0F⃣ 2⃣ 3", []string{"1\uFE0F⃣", "2⃣"}},
+		{"默认文字显示的字符", "© ©\uFE0F ❤ ★", []string{"©\uFE0F", "❤"}},
+		{"要求按文字显示", "❤\uFE0E", nil},
+		// 零宽连接符后不是emoji时在此断开
+		{"不完整的连接序列", "👨\u200Da", []string{"👨"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractEmojis(tt.text)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+				t.Errorf("extractEmojis(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmojiCountTreatsSequencesAsOne(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	content := sampleContent("emoji")
+	content.Text = "全家👨\u200D👩\u200D👧\u200D👦出发去🇨🇳西部露营，👨\u200D👩\u200D👧\u200D👦再次合影。"
+
+	result, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TextAnalysis.EmojiCount != 3 {
+		t.Errorf("EmojiCount = %d, want 3", result.TextAnalysis.EmojiCount)
+	}
+	if got := strings.Join(result.TextAnalysis.Emojis, " "); got != "👨\u200D👩\u200D👧\u200D👦 🇨🇳" {
+		t.Errorf("Emojis = %q, want 去重后的 👨\u200D👩\u200D👧\u200D👦 🇨🇳", got)
+	}
+}
@@ -0,0 +1,227 @@
+// internal/analyzer/entities.go
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// 两个及以上连续的首字母大写英文单词，如 New York Times、Tim Cook
+var capitalizedPhrasePattern = regexp.MustCompile(`[A-Z][A-Za-z0-9'&-]*(?:[ \t]+[A-Z][A-Za-z0-9'&-]*)+`)
+
+// entityName 词典中的一个名称（标准名或别名）
+type entityName struct {
+	text  string
+	canon string // 标准名
+	kind  string // person, brand, product, location
+}
+
+// entityDictionary 按 analysis.entities 加载的实体词典，名称按长度从长到短排列，
+// 匹配时长名称优先，"Apple Watch" 不会再计为 "Apple"
+type entityDictionary struct {
+	names []entityName
+	index map[string]entityName // 小写名称 -> 名称，用于把AI结果归并到标准名
+}
+
+// loadEntityDictionary 读取各类型的实体词典文件，读取失败时记录日志并跳过该类型
+func loadEntityDictionary(cfg config.EntitiesConfig) entityDictionary {
+	dict := entityDictionary{index: make(map[string]entityName)}
+
+	for _, source := range []struct{ kind, path string }{
+		{"person", cfg.Person},
+		{"brand", cfg.Brand},
+		{"product", cfg.Product},
+		{"location", cfg.Location},
+	} {
+		if source.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(source.path)
+		if err != nil {
+			log.Printf("读取实体词典 %s 失败，已跳过: %v", source.path, err)
+			continue
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "\uFEFF"))
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			var canon string
+			for _, name := range strings.Split(line, "|") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					continue
+				}
+				if canon == "" {
+					canon = name
+				}
+				key := strings.ToLower(name)
+				if _, ok := dict.index[key]; ok {
+					continue // 先出现的定义优先
+				}
+				entry := entityName{text: name, canon: canon, kind: source.kind}
+				dict.index[key] = entry
+				dict.names = append(dict.names, entry)
+			}
+		}
+	}
+
+	sort.SliceStable(dict.names, func(i, j int) bool {
+		return len(dict.names[i].text) > len(dict.names[j].text)
+	})
+	return dict
+}
+
+// extractEntities 识别正文中的命名实体。开启 analysis.entities.use_ai 且AI服务可用时使用AI结果，
+// 否则（或AI失败时）按词典和英文大写短语识别。同时返回结果来源，未开启 use_ai 时为空
+func (ca *ContentAnalyzer) extractEntities(text string) ([]models.Entity, string) {
+	if !ca.config.Analysis.Entities.UseAI {
+		return ca.findEntities(text), ""
+	}
+
+	entities, err := ca.aiService.ExtractEntities(context.Background(), text)
+	if err == nil {
+		return ca.entities.resolve(entities), models.ProvenanceAI
+	}
+	if errors.Is(err, services.ErrAINotConfigured) {
+		return ca.findEntities(text), models.ProvenanceLocal
+	}
+	log.Printf("AI实体识别失败，使用词典识别: %v", err)
+	return ca.findEntities(text), models.ProvenanceFallback
+}
+
+// findEntities 按词典统计实体出现次数（区分大小写，英文名称须为完整单词），
+// 再把词典未覆盖的英文大写多词短语记为 unknown。结果按出现次数从多到少排列
+func (ca *ContentAnalyzer) findEntities(text string) []models.Entity {
+	type key struct{ name, kind string }
+	counts := make(map[key]*models.Entity)
+	var order []key
+	add := func(name, kind, source string) {
+		k := key{name, kind}
+		if entity, ok := counts[k]; ok {
+			entity.Count++
+			return
+		}
+		counts[k] = &models.Entity{Name: name, Type: kind, Count: 1, Source: source}
+		order = append(order, k)
+	}
+
+	// 已匹配的字节区间，较短的名称和大写短语不再重复计数
+	covered := make([]bool, len(text))
+	isCovered := func(start, end int) bool {
+		for i := start; i < end; i++ {
+			if covered[i] {
+				return true
+			}
+		}
+		return false
+	}
+	cover := func(start, end int) {
+		for i := start; i < end; i++ {
+			covered[i] = true
+		}
+	}
+
+	for _, name := range ca.entities.names {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], name.text)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(name.text)
+			offset = end
+			if isCovered(start, end) || (isLatinTerm(name.text) && !isWordBoundary(text, start, end)) {
+				continue
+			}
+			cover(start, end)
+			add(name.canon, name.kind, "dictionary")
+		}
+	}
+
+	stop := ca.words.stop["en"]
+	lineStart := 0
+	for _, line := range strings.Split(text, "\n") {
+		start := lineStart
+		lineStart += len(line) + 1
+		// Markdown 标题常用首字母大写，不是实体
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, loc := range capitalizedPhrasePattern.FindAllStringIndex(line, -1) {
+			if isCovered(start+loc[0], start+loc[1]) || !isWordBoundary(line, loc[0], loc[1]) {
+				continue
+			}
+			// 去掉句首的 The、A 等停用词
+			words := strings.Fields(line[loc[0]:loc[1]])
+			for len(words) > 0 && stop[strings.ToLower(words[0])] {
+				words = words[1:]
+			}
+			if len(words) < 2 {
+				continue
+			}
+			add(strings.Join(words, " "), "unknown", "capitalized")
+		}
+	}
+
+	entities := make([]models.Entity, 0, len(order))
+	for _, k := range order {
+		entities = append(entities, *counts[k])
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].Count > entities[j].Count
+	})
+	return entities
+}
+
+// resolve 把AI识别出的名称归并到词典中的标准名和类型，合并后重复的实体累加次数
+func (d entityDictionary) resolve(entities []models.Entity) []models.Entity {
+	var resolved []models.Entity
+	positions := make(map[string]int)
+	for _, entity := range entities {
+		if name, ok := d.index[strings.ToLower(entity.Name)]; ok {
+			entity.Name, entity.Type = name.canon, name.kind
+		}
+		k := entity.Type + "\x00" + entity.Name
+		if i, ok := positions[k]; ok {
+			resolved[i].Count += entity.Count
+			continue
+		}
+		positions[k] = len(resolved)
+		resolved = append(resolved, entity)
+	}
+	sort.SliceStable(resolved, func(i, j int) bool {
+		return resolved[i].Count > resolved[j].Count
+	})
+	return resolved
+}
+
+// isWordBoundary text[start:end] 前后是否不是拉丁字母或数字，中文里夹的英文名称（如 "买了Apple手机"）也算完整单词
+func isWordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isLatinWordRune(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); isLatinWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLatinWordRune(r rune) bool {
+	return r <= unicode.MaxLatin1 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestDictionaryEntities(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.Entities = config.EntitiesConfig{
+			Person:   writeWordList(t, "# 人名\nTim Cook|库克\n"),
+			Brand:    writeWordList(t, "\uFEFFApple|苹果公司\n\n"),
+			Product:  writeWordList(t, "Apple Watch|苹果手表\n"),
+			Location: writeWordList(t, "上海|Shanghai\n"),
+		}
+	})
+
+	text := "库克在上海发布了 Apple Watch。Tim Cook 说苹果公司会继续投入，Shanghai 的门店先开售。" +
+		"买了Apple手表的人很多，Applesauce 不是品牌。The New York Times 也报道了。"
+	entities := ca.findEntities(text)
+
+	want := map[string]models.Entity{
+		"person/Tim Cook":        {Name: "Tim Cook", Type: "person", Count: 2, Source: "dictionary"},
+		"location/上海":            {Name: "上海", Type: "location", Count: 2, Source: "dictionary"},
+		"product/Apple Watch":    {Name: "Apple Watch", Type: "product", Count: 1, Source: "dictionary"},
+		"brand/Apple":            {Name: "Apple", Type: "brand", Count: 2, Source: "dictionary"},
+		"unknown/New York Times": {Name: "New York Times", Type: "unknown", Count: 1, Source: "capitalized"},
+	}
+	if len(entities) != len(want) {
+		t.Fatalf("findEntities() = %+v, want %d 个实体", entities, len(want))
+	}
+	for _, entity := range entities {
+		w, ok := want[entity.Type+"/"+entity.Name]
+		if !ok {
+			t.Errorf("多出实体 %+v", entity)
+			continue
+		}
+		if entity != w {
+			t.Errorf("实体 = %+v, want %+v", entity, w)
+		}
+	}
+}
+
+func TestEntityDictionaryMissingFile(t *testing.T) {
+	dict := loadEntityDictionary(config.EntitiesConfig{
+		Person: filepath.Join(t.TempDir(), "missing.txt"),
+		Brand:  writeWordList(t, "Apple\n"),
+	})
+	// 读取失败的词典跳过，其他词典照常加载
+	if len(dict.names) != 1 || dict.names[0].canon != "Apple" || dict.names[0].kind != "brand" {
+		t.Errorf("names = %+v, want 只有 brand 词典中的 Apple", dict.names)
+	}
+}
+
+func TestEntityDictionaryResolve(t *testing.T) {
+	dict := loadEntityDictionary(config.EntitiesConfig{Brand: writeWordList(t, "Apple|苹果公司\n")})
+	resolved := dict.resolve([]models.Entity{
+		{Name: "苹果公司", Type: "organization", Count: 2, Source: "ai"},
+		{Name: "apple", Type: "brand", Count: 1, Source: "ai"},
+		{Name: "OpenAI", Type: "organization", Count: 1, Source: "ai"},
+	})
+
+	if len(resolved) != 2 {
+		t.Fatalf("resolve() = %+v, want 2 个实体", resolved)
+	}
+	if resolved[0].Name != "Apple" || resolved[0].Type != "brand" || resolved[0].Count != 3 {
+		t.Errorf("resolved[0] = %+v, want 别名归并为 Apple(brand)，次数 3", resolved[0])
+	}
+	if resolved[1].Name != "OpenAI" || resolved[1].Type != "organization" {
+		t.Errorf("resolved[1] = %+v, want 词典外的实体保持原样", resolved[1])
+	}
+}
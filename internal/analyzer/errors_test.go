@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+func TestAnalyzeErrorClassification(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.png")
+	unsupported := filepath.Join(dir, "image.tiff")
+	for _, path := range []string{corrupt, unsupported} {
+		if err := os.WriteFile(path, []byte("not an image"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		image string
+		want  error
+	}{
+		{"missing", filepath.Join(dir, "missing.png"), services.ErrImageNotFound},
+		{"unsupported", unsupported, services.ErrUnsupportedFormat},
+		{"corrupt", corrupt, services.ErrImageDecode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Image.OnDecodeError = "fail"
+			})
+
+			_, err := ca.Analyze(models.Content{
+				Title:  "测试",
+				Text:   "正文内容。",
+				Images: []models.Image{{Path: tt.image}},
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Analyze 错误 = %v, 应可通过 errors.Is 判断为 %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// writePNG 在 dir 中写入一张纯色 PNG
+func writePNG(t *testing.T, dir, name string, width, height int, c color.Color) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: c}, image.Point{}, draw.Src)
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOnDecodeErrorPolicy(t *testing.T) {
+	dir := t.TempDir()
+	valid := writePNG(t, dir, "valid.png", 64, 48, color.RGBA{R: 200, G: 120, B: 40, A: 255})
+	corrupt := filepath.Join(dir, "corrupt.jpg")
+	if err := os.WriteFile(corrupt, []byte("\xff\xd8\xff truncated jpeg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		policy  string
+		wantErr bool
+		wantLog bool
+	}{
+		{"skip", false, false},
+		{"warn", false, true},
+		{"fail", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Image.OnDecodeError = tt.policy
+			})
+
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			result, err := ca.Analyze(models.Content{
+				Title:  "测试",
+				Text:   "正文内容。",
+				Images: []models.Image{{Path: valid}, {Path: corrupt}},
+			})
+			if tt.wantErr {
+				if !errors.Is(err, services.ErrImageDecode) {
+					t.Fatalf("Analyze 错误 = %v, want ErrImageDecode", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Analyze 失败: %v", err)
+			}
+
+			if len(result.ImageAnalysis) != 1 || result.ImageAnalysis[0].Path != valid {
+				t.Errorf("应只保留可解析的图片: %+v", result.ImageAnalysis)
+			}
+			if !containsSubstring(result.Warnings, "corrupt.jpg") {
+				t.Errorf("Warnings 未记录跳过的图片: %v", result.Warnings)
+			}
+			if got := strings.Contains(logs.String(), "corrupt.jpg"); got != tt.wantLog {
+				t.Errorf("日志包含跳过的图片 = %v, want %v", got, tt.wantLog)
+			}
+		})
+	}
+}
+
+// 没有HEIC转换命令时，即使 on_decode_error 为 fail 也只跳过该图片
+func TestMissingHEICCommandSkipsImage(t *testing.T) {
+	dir := t.TempDir()
+	valid := writePNG(t, dir, "valid.png", 64, 48, color.RGBA{R: 200, G: 120, B: 40, A: 255})
+	heic := filepath.Join(dir, "photo.heic")
+	if err := os.WriteFile(heic, []byte("heic data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Image.OnDecodeError = "fail"
+		cfg.Image.HEICCommand = filepath.Join(dir, "missing-heif-convert")
+		cfg.Image.SupportedExt = append(cfg.Image.SupportedExt, ".heic")
+	})
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	result, err := ca.Analyze(models.Content{
+		Title:  "测试",
+		Text:   "正文内容。",
+		Images: []models.Image{{Path: valid}, {Path: heic}},
+	})
+	if err != nil {
+		t.Fatalf("Analyze 失败: %v", err)
+	}
+	if len(result.ImageAnalysis) != 1 || result.ImageAnalysis[0].Path != valid {
+		t.Errorf("应只保留可解析的图片: %+v", result.ImageAnalysis)
+	}
+	if !containsSubstring(result.Warnings, "photo.heic") || !strings.Contains(logs.String(), "photo.heic") {
+		t.Errorf("Warnings/日志 未记录跳过的HEIC图片: %v / %q", result.Warnings, logs.String())
+	}
+}
+
+func containsSubstring(items []string, substr string) bool {
+	for _, item := range items {
+		if strings.Contains(item, substr) {
+			return true
+		}
+	}
+	return false
+}
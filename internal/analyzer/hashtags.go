@@ -0,0 +1,207 @@
+// internal/analyzer/hashtags.go
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// 标题话题标签数量相对平台习惯的状态
+const (
+	hashtagsTooFew  = "too_few"
+	hashtagsOK      = "ok"
+	hashtagsTooMany = "too_many"
+)
+
+// applyHashtagLimit 统计标题中的话题标签，并按平台的 hashtag_limits 判断数量是否合适
+func (ca *ContentAnalyzer) applyHashtagLimit(analysis *models.TitleAnalysis, title, platform string) {
+	analysis.Hashtags = ca.extractHashtags(title)
+
+	limit, ok := ca.config.Analysis.HashtagLimits[platform]
+	if !ok {
+		return
+	}
+
+	analysis.Platform = platform
+	count := len(analysis.Hashtags)
+	switch {
+	case count < limit.Min:
+		analysis.HashtagStatus = hashtagsTooFew
+	case count > limit.Max:
+		analysis.HashtagStatus = hashtagsTooMany
+	default:
+		analysis.HashtagStatus = hashtagsOK
+	}
+}
+
+// hashtagScore 互动性评分中标题话题标签的加减分：合适 +5，过少 -5，过多按超出数量扣分
+func (ca *ContentAnalyzer) hashtagScore(title models.TitleAnalysis) float64 {
+	switch title.HashtagStatus {
+	case hashtagsOK:
+		return 5
+	case hashtagsTooFew:
+		return -5
+	case hashtagsTooMany:
+		limit := ca.config.Analysis.HashtagLimits[title.Platform]
+		return -math.Min(5+2*float64(len(title.Hashtags)-limit.Max), 15)
+	default:
+		return 0
+	}
+}
+
+// hashtagSuggestion 标题话题标签过少或过多时的建议
+func (ca *ContentAnalyzer) hashtagSuggestion(title models.TitleAnalysis) (models.Suggestion, bool) {
+	limit := ca.config.Analysis.HashtagLimits[title.Platform]
+	count := len(title.Hashtags)
+
+	switch title.HashtagStatus {
+	case hashtagsTooFew:
+		return models.Suggestion{
+			Type:        "title",
+			Priority:    "medium",
+			Current:     fmt.Sprintf("标题中话题标签偏少（%d个，%s建议%d-%d个）", count, title.Platform, limit.Min, limit.Max),
+			Recommended: "补充与内容相关的热门或垂直领域话题标签",
+			Reasoning:   "话题标签是该平台内容分发和搜索的重要入口",
+			Impact:      "预计可提升内容曝光",
+			Factor:      factorHashtags,
+			Confidence:  signalConfidence(float64(limit.Min-count), float64(limit.Min)),
+		}, true
+	case hashtagsTooMany:
+		return models.Suggestion{
+			Type:        "title",
+			Priority:    "medium",
+			Current:     fmt.Sprintf("标题中话题标签过多（%d个，%s建议不超过%d个）", count, title.Platform, limit.Max),
+			Recommended: "只保留最相关的几个话题标签，其余可移到正文",
+			Reasoning:   "标签堆砌会挤占标题信息，在部分平台还会被视为营销内容而降低推荐",
+			Impact:      "预计可提升标题可读性和推荐权重",
+			Factor:      factorHashtags,
+			Confidence:  signalConfidence(float64(count-limit.Max), float64(limit.Max+1)),
+		}, true
+	default:
+		return models.Suggestion{}, false
+	}
+}
+
+// fallbackTopic 未识别出主题时本地主题提取返回的占位主题，不作为话题标签
+const fallbackTopic = "其他"
+
+// suggestHashtags 标题和正文的话题标签合计少于 min_hashtags 时，推荐补充的标签（带 # 前缀，不含已有的标签）。
+// 开启 use_ai 时先交给AI，AI未配置、失败或没有可用结果时按主题和关键词生成。
+// 同时返回结果来源，未开启 use_ai 或无需推荐时为空
+func (ca *ContentAnalyzer) suggestHashtags(result models.AnalysisResult, text string) ([]string, string) {
+	cfg := ca.config.Analysis.HashtagSuggestions
+	existing := existingHashtags(result.TextAnalysis)
+	if cfg.MinHashtags == 0 || len(existing) >= cfg.MinHashtags {
+		return nil, ""
+	}
+
+	candidates := hashtagCandidates(result.Topics, result.Keywords, existing, cfg.Max)
+	if !cfg.UseAI {
+		return candidates, ""
+	}
+
+	words := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		words[i] = strings.TrimPrefix(candidate, "#")
+	}
+	suggested, err := ca.aiService.SuggestHashtags(context.Background(), text, words, cfg.Max)
+	switch {
+	case err == nil:
+		if hashtags := normalizeHashtags(suggested, existing, cfg.Max); len(hashtags) > 0 {
+			return hashtags, models.ProvenanceAI
+		}
+		log.Printf("AI没有推荐可用的话题标签，使用主题和关键词")
+	case errors.Is(err, services.ErrAINotConfigured):
+		return candidates, models.ProvenanceLocal
+	default:
+		log.Printf("AI话题标签推荐失败，使用主题和关键词: %v", err)
+	}
+	return candidates, models.ProvenanceFallback
+}
+
+// existingHashtags 标题和正文中已有的话题标签，按小写去重
+func existingHashtags(analysis models.TextAnalysis) map[string]bool {
+	existing := make(map[string]bool)
+	for _, tag := range append(append([]string{}, analysis.Hashtags...), analysis.TitleAnalysis.Hashtags...) {
+		existing[strings.ToLower(tag)] = true
+	}
+	return existing
+}
+
+// hashtagCandidates 依次由主题和关键词生成最多 max 个话题标签，顺序只取决于输入，结果可复现
+func hashtagCandidates(topics []string, keywords []models.Keyword, existing map[string]bool, max int) []string {
+	var words []string
+	for _, topic := range topics {
+		if topic != fallbackTopic {
+			words = append(words, topic)
+		}
+	}
+	for _, keyword := range keywords {
+		words = append(words, keyword.Word)
+	}
+	return normalizeHashtags(words, existing, max)
+}
+
+// normalizeHashtags 把词转为话题标签，跳过无效、重复和已有的标签，最多保留 max 个
+func normalizeHashtags(words []string, existing map[string]bool, max int) []string {
+	var hashtags []string
+	seen := make(map[string]bool)
+	for _, word := range words {
+		tag, ok := toHashtag(word)
+		key := strings.ToLower(tag)
+		if !ok || seen[key] || existing[key] {
+			continue
+		}
+		seen[key] = true
+		hashtags = append(hashtags, tag)
+		if len(hashtags) == max {
+			break
+		}
+	}
+	return hashtags
+}
+
+// toHashtag 去掉开头的 # 以及空白和标点，只保留 extractHashtags 能识别的字母、数字和下划线。
+// 少于2个字符或全为数字的词不适合作为标签
+func toHashtag(word string) (string, bool) {
+	tag := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, word)
+	if utf8.RuneCountInString(tag) < 2 || strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsNumber(r) }) < 0 {
+		return "", false
+	}
+	return "#" + tag, true
+}
+
+// hashtagRecommendation 话题标签过少且有可推荐的标签时的建议
+func (ca *ContentAnalyzer) hashtagRecommendation(analysis models.TextAnalysis) (models.Suggestion, bool) {
+	if len(analysis.SuggestedHashtags) == 0 {
+		return models.Suggestion{}, false
+	}
+
+	min := ca.config.Analysis.HashtagSuggestions.MinHashtags
+	count := len(existingHashtags(analysis))
+	return models.Suggestion{
+		Type:        "engagement",
+		Priority:    "low",
+		Current:     fmt.Sprintf("标题和正文共有%d个话题标签，少于%d个", count, min),
+		Recommended: "在正文末尾补充与内容相关的话题标签: " + strings.Join(analysis.SuggestedHashtags, " "),
+		Reasoning:   "话题标签让内容出现在对应话题页和搜索结果中，获得关注者之外的曝光",
+		Examples:    analysis.SuggestedHashtags,
+		Impact:      "预计可提升内容曝光",
+		Factor:      factorBodyHashtags,
+		Confidence:  signalConfidence(float64(min-count), float64(min)),
+	}, true
+}
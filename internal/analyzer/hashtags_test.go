@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// useMockAI 把AI服务指向 httptest 服务器，reply 为 nil 时服务器返回 500
+func useMockAI(t *testing.T, cfg *config.Config, reply func(prompt string) string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reply == nil {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		var req services.OpenAIRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		prompt := ""
+		if len(req.Messages) > 0 {
+			prompt = req.Messages[0].Content
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(services.OpenAIResponse{
+			Choices: []services.Choice{{Message: services.Message{Role: "assistant", Content: reply(prompt)}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.AI.Provider = "openai"
+	cfg.AI.APIKey = "test-key"
+	cfg.AI.BaseURL = server.URL
+	cfg.AI.MaxRetries = 0
+}
+
+func TestHashtagCountScoredPerPlatform(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	tests := []struct {
+		name       string
+		title      string
+		platform   string
+		wantStatus string
+	}{
+		{name: "twitter 合适", title: "Weekend camping #camping #outdoors", platform: "twitter", wantStatus: hashtagsOK},
+		{name: "twitter 过少", title: "Weekend camping", platform: "twitter", wantStatus: hashtagsTooFew},
+		{name: "twitter 过多", title: "Weekend #camping #outdoors #tent #hiking #nature", platform: "twitter", wantStatus: hashtagsTooMany},
+		// 同样5个标签在 instagram 正合适
+		{name: "instagram 合适", title: "Weekend #camping #outdoors #tent #hiking #nature", platform: "instagram", wantStatus: hashtagsOK},
+		{name: "未配置平台", title: "Weekend #camping", platform: "", wantStatus: ""},
+	}
+
+	scores := make(map[string]float64)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var analysis models.TitleAnalysis
+			ca.applyHashtagLimit(&analysis, tt.title, tt.platform)
+			if analysis.HashtagStatus != tt.wantStatus {
+				t.Errorf("HashtagStatus = %q, want %q (%v)", analysis.HashtagStatus, tt.wantStatus, analysis.Hashtags)
+			}
+
+			scores[tt.name] = ca.scoreEngagement(models.TextAnalysis{TitleAnalysis: analysis})
+
+			_, suggested := ca.hashtagSuggestion(analysis)
+			if want := tt.wantStatus == hashtagsTooFew || tt.wantStatus == hashtagsTooMany; suggested != want {
+				t.Errorf("生成建议 = %v, want %v", suggested, want)
+			}
+		})
+	}
+
+	ok := scores["twitter 合适"]
+	for _, name := range []string{"twitter 过少", "twitter 过多", "未配置平台"} {
+		if scores[name] >= ok {
+			t.Errorf("%s 得分 %.1f 应低于数量合适的 %.1f", name, scores[name], ok)
+		}
+	}
+	if scores["instagram 合适"] != ok {
+		t.Errorf("instagram 5个标签得分 %.1f, want 与数量合适的 %.1f 相同", scores["instagram 合适"], ok)
+	}
+}
+
+func TestHashtagCandidatesFallback(t *testing.T) {
+	topics := []string{"户外", fallbackTopic, "露营"}
+	keywords := []models.Keyword{
+		{Word: "Camping!"}, // 已有 #camping（不区分大小写）
+		{Word: "2024"},     // 全为数字
+		{Word: "户外"},       // 与主题重复
+		{Word: "a"},        // 太短
+		{Word: "tent gear"},
+		{Word: "stove"},
+	}
+	existing := map[string]bool{"#camping": true}
+
+	tests := []struct {
+		max  int
+		want string
+	}{
+		{3, "#户外 #露营 #tentgear"},
+		{5, "#户外 #露营 #tentgear #stove"},
+		{1, "#户外"},
+	}
+	for _, tt := range tests {
+		got := hashtagCandidates(topics, keywords, existing, tt.max)
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("hashtagCandidates(max=%d) = %v, want %s", tt.max, got, tt.want)
+		}
+	}
+
+	// 结果只取决于输入，重复调用相同
+	first := hashtagCandidates(topics, keywords, existing, 5)
+	second := hashtagCandidates(topics, keywords, existing, 5)
+	if strings.Join(first, " ") != strings.Join(second, " ") {
+		t.Errorf("两次结果不同: %v / %v", first, second)
+	}
+}
+
+func TestSuggestHashtagsProvenance(t *testing.T) {
+	result := models.AnalysisResult{
+		Topics:   []string{"露营"},
+		Keywords: []models.Keyword{{Word: "帐篷"}, {Word: "炉具"}},
+		TextAnalysis: models.TextAnalysis{
+			Hashtags: []string{"#户外"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		useAI          bool
+		reply          func(prompt string) string
+		configureAI    bool
+		wantHashtags   string
+		wantProvenance string
+	}{
+		{name: "未开启 use_ai", wantHashtags: "#露营 #帐篷 #炉具", wantProvenance: ""},
+		{name: "AI未配置", useAI: true, wantHashtags: "#露营 #帐篷 #炉具", wantProvenance: models.ProvenanceLocal},
+		{name: "AI失败", useAI: true, configureAI: true, wantHashtags: "#露营 #帐篷 #炉具", wantProvenance: models.ProvenanceFallback},
+		{name: "AI没有可用标签", useAI: true, configureAI: true, reply: func(string) string { return `["户外", "1"]` }, wantHashtags: "#露营 #帐篷 #炉具", wantProvenance: models.ProvenanceFallback},
+		{name: "AI推荐", useAI: true, configureAI: true, reply: func(string) string { return `["#周末露营", "露营装备", "户外"]` }, wantHashtags: "#周末露营 #露营装备", wantProvenance: models.ProvenanceAI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Analysis.HashtagSuggestions = config.HashtagSuggestionConfig{MinHashtags: 3, Max: 3, UseAI: tt.useAI}
+				if tt.configureAI {
+					useMockAI(t, cfg, tt.reply)
+				}
+			})
+
+			hashtags, provenance := ca.suggestHashtags(result, "周末去露营")
+			if strings.Join(hashtags, " ") != tt.wantHashtags || provenance != tt.wantProvenance {
+				t.Errorf("suggestHashtags() = %v, %q, want %s, %q", hashtags, provenance, tt.wantHashtags, tt.wantProvenance)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// internal/analyzer/headings.go
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+var (
+	// ATX 标题：# 后必须有空格，避免把 #话题标签 当作标题
+	atxHeadingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	setextH1Pattern     = regexp.MustCompile(`^=+$`)
+	setextH2Pattern     = regexp.MustCompile(`^-+$`)
+	htmlHeadingPattern  = regexp.MustCompile(`(?is)<h([1-6])[^>]*>(.*?)</h[1-6]>`)
+	htmlTagStripPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// 纯文本中常见的中文小标题，如 "一、选材"、"第二部分"、"【步骤】"
+	plainSectionPattern = regexp.MustCompile(`^(第[一二三四五六七八九十百\d]+[章节部分步篇]|[一二三四五六七八九十]+、|【[^】]+】$)`)
+)
+
+// positionedHeading 带文本偏移量的标题，用于合并 Markdown 与 HTML 标题的顺序
+type positionedHeading struct {
+	offset int
+	models.Heading
+}
+
+// analyzeHeadings 解析 Markdown/HTML 标题层级，检查跳级、多个一级标题和缺少一级标题
+func analyzeHeadings(text string) models.HeadingAnalysis {
+	headings := append(markdownHeadings(text), htmlHeadings(text)...)
+	sort.SliceStable(headings, func(i, j int) bool {
+		return headings[i].offset < headings[j].offset
+	})
+
+	analysis := models.HeadingAnalysis{}
+	for _, h := range headings {
+		analysis.Headings = append(analysis.Headings, h.Heading)
+		if h.Level == 1 {
+			analysis.H1Count++
+		}
+	}
+
+	if len(analysis.Headings) == 0 {
+		return analysis
+	}
+
+	if analysis.H1Count == 0 {
+		analysis.MissingTopLevel = true
+		analysis.Issues = append(analysis.Issues, "缺少一级标题")
+	}
+	if analysis.H1Count > 1 {
+		analysis.Issues = append(analysis.Issues, fmt.Sprintf("存在%d个一级标题", analysis.H1Count))
+	}
+
+	// 向下只能逐级深入，向上可以任意返回
+	previous := 0
+	for _, h := range analysis.Headings {
+		if previous > 0 && h.Level > previous+1 {
+			skip := fmt.Sprintf("h%d→h%d", previous, h.Level)
+			analysis.SkippedLevels = append(analysis.SkippedLevels, skip)
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("标题跳级: %s（%s）", skip, h.Text))
+		}
+		previous = h.Level
+	}
+
+	return analysis
+}
+
+func markdownHeadings(text string) []positionedHeading {
+	var headings []positionedHeading
+
+	inCode := false
+	offset := 0
+	previousLine := ""
+	previousOffset := 0
+
+	for _, line := range strings.Split(text, "\n") {
+		lineOffset := offset
+		offset += len(line) + 1
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			previousLine = ""
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		if match := atxHeadingPattern.FindStringSubmatch(trimmed); match != nil && match[2] != "" {
+			headings = append(headings, positionedHeading{
+				offset:  lineOffset,
+				Heading: models.Heading{Level: len(match[1]), Text: match[2]},
+			})
+			previousLine = ""
+			continue
+		}
+
+		// Setext 标题：下划线紧跟在非空文本行之后
+		if previousLine != "" && !strings.HasPrefix(previousLine, "<") {
+			level := 0
+			if setextH1Pattern.MatchString(trimmed) {
+				level = 1
+			} else if setextH2Pattern.MatchString(trimmed) {
+				level = 2
+			}
+			if level > 0 {
+				headings = append(headings, positionedHeading{
+					offset:  previousOffset,
+					Heading: models.Heading{Level: level, Text: previousLine},
+				})
+				previousLine = ""
+				continue
+			}
+		}
+
+		previousLine = trimmed
+		previousOffset = lineOffset
+	}
+
+	return headings
+}
+
+func htmlHeadings(text string) []positionedHeading {
+	var headings []positionedHeading
+
+	for _, match := range htmlHeadingPattern.FindAllStringSubmatchIndex(text, -1) {
+		level := int(text[match[2]] - '0')
+		title := htmlTagStripPattern.ReplaceAllString(text[match[4]:match[5]], "")
+		headings = append(headings, positionedHeading{
+			offset:  match[0],
+			Heading: models.Heading{Level: level, Text: strings.TrimSpace(title)},
+		})
+	}
+
+	return headings
+}
+
+// countSections 章节数：忽略作为文档标题的唯一一级标题，按最高的剩余标题层级计数。
+// 没有 Markdown/HTML 标题时，退回到识别纯文本中的中文小标题。
+func (ca *ContentAnalyzer) countSections(text string, headings models.HeadingAnalysis) int {
+	if len(headings.Headings) == 0 {
+		return countPlainSections(text)
+	}
+
+	levels := make([]int, 0, len(headings.Headings))
+	for _, h := range headings.Headings {
+		if h.Level == 1 && headings.H1Count == 1 {
+			continue
+		}
+		levels = append(levels, h.Level)
+	}
+
+	if len(levels) == 0 {
+		return 1
+	}
+
+	top := levels[0]
+	for _, level := range levels {
+		if level < top {
+			top = level
+		}
+	}
+
+	count := 0
+	for _, level := range levels {
+		if level == top {
+			count++
+		}
+	}
+
+	return count
+}
+
+// countPlainSections 统计独占一行的中文小标题，少于两个时视为单一章节
+func countPlainSections(text string) int {
+	count := 0
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if utf8.RuneCountInString(trimmed) <= 30 && plainSectionPattern.MatchString(trimmed) {
+			count++
+		}
+	}
+
+	if count < 2 {
+		return 1
+	}
+	return count
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeHeadings(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		wantLevels     []int
+		wantH1         int
+		wantSkipped    []string
+		wantMissingTop bool
+		wantIssueCount int
+	}{
+		{
+			name:       "层级完整",
+			text:       "# 露营指南\n\n## 装备\n\n### 帐篷\n\n## 营地\n\n正文",
+			wantLevels: []int{1, 2, 3, 2},
+			wantH1:     1,
+		},
+		{
+			name:           "跳级",
+			text:           "# 露营指南\n\n### 帐篷\n\n## 营地",
+			wantLevels:     []int{1, 3, 2},
+			wantH1:         1,
+			wantSkipped:    []string{"h1→h3"},
+			wantIssueCount: 1,
+		},
+		{
+			name:           "缺少一级标题",
+			text:           "## 装备\n\n## 营地",
+			wantLevels:     []int{2, 2},
+			wantMissingTop: true,
+			wantIssueCount: 1,
+		},
+		{
+			name:           "多个一级标题",
+			text:           "# 第一篇\n\n# 第二篇",
+			wantLevels:     []int{1, 1},
+			wantH1:         2,
+			wantIssueCount: 1,
+		},
+		{
+			name:       "Setext 与 HTML 混排按出现顺序",
+			text:       "露营指南\n=====\n\n<h2>装备</h2>\n\n营地\n-----",
+			wantLevels: []int{1, 2, 2},
+			wantH1:     1,
+		},
+		{
+			name:       "代码块与话题标签不算标题",
+			text:       "# 标题\n\n```\n#### 注释\n```\n\n#露营 #户外",
+			wantLevels: []int{1},
+			wantH1:     1,
+		},
+		{
+			name: "没有标题",
+			text: "只有一段正文。",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzeHeadings(tt.text)
+
+			var levels []int
+			for _, h := range got.Headings {
+				levels = append(levels, h.Level)
+			}
+			if !reflect.DeepEqual(levels, tt.wantLevels) {
+				t.Errorf("levels = %v, want %v", levels, tt.wantLevels)
+			}
+			if got.H1Count != tt.wantH1 {
+				t.Errorf("H1Count = %d, want %d", got.H1Count, tt.wantH1)
+			}
+			if !reflect.DeepEqual(got.SkippedLevels, tt.wantSkipped) {
+				t.Errorf("SkippedLevels = %v, want %v", got.SkippedLevels, tt.wantSkipped)
+			}
+			if got.MissingTopLevel != tt.wantMissingTop {
+				t.Errorf("MissingTopLevel = %v, want %v", got.MissingTopLevel, tt.wantMissingTop)
+			}
+			if len(got.Issues) != tt.wantIssueCount {
+				t.Errorf("Issues = %v, want %d 条", got.Issues, tt.wantIssueCount)
+			}
+		})
+	}
+}
+
+func TestCountSections(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{
+			name: "缩写与强调行不算章节",
+			text: "We tested the new API.\nNASA\nIMPORTANT\nFAQ\nThe rest of the post is plain text.",
+			want: 1,
+		},
+		{
+			name: "文档标题之下的二级标题",
+			text: "# Camping Guide\n\n## Gear\n\ntext\n\n## Campsite\n\ntext\n\n### Tips\n\n## Food",
+			want: 3,
+		},
+		{
+			name: "纯文本中文小标题",
+			text: "一、选材\n内容\n二、准备\n内容\n三、出发\n内容",
+			want: 3,
+		},
+		{
+			name: "单个中文小标题视为单一章节",
+			text: "【前言】\n正文内容",
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ca.countSections(tt.text, analyzeHeadings(tt.text)); got != tt.want {
+				t.Errorf("countSections() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// internal/analyzer/image_ratio.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+const (
+	// 实际宽高比与推荐比例相差在该比例以内视为符合，如 1080×1350 与 4:5
+	aspectRatioTolerance = 0.03
+	// 宽高比符合平台推荐时单图视觉得分的加分
+	aspectRatioBonus = 5.0
+)
+
+// checkAspectRatios 按 analysis.image_ratios 中目标平台的推荐比例检查每张图片，平台没有推荐比例时不检查
+func (ca *ContentAnalyzer) checkAspectRatios(images []models.ImageAnalysis, platform string) {
+	recommended := ca.config.Analysis.ImageRatios[platform]
+	if len(recommended) == 0 {
+		return
+	}
+
+	for i := range images {
+		width, height := images[i].Info.Width, images[i].Info.Height
+		if width <= 0 || height <= 0 {
+			continue
+		}
+		ratio := float64(width) / float64(height)
+		check := &models.AspectRatioCheck{
+			Platform:    platform,
+			Ratio:       math.Round(ratio*1000) / 1000,
+			Recommended: recommended,
+		}
+
+		nearestDiff := math.Inf(1)
+		for _, candidate := range recommended {
+			w, h, err := config.ParseAspectRatio(candidate)
+			if err != nil {
+				continue // 加载配置时已校验
+			}
+			// 按对数比较，横竖方向的偏差对称
+			diff := math.Abs(math.Log(ratio / (float64(w) / float64(h))))
+			if diff < nearestDiff {
+				nearestDiff = diff
+				check.Nearest = candidate
+			}
+			if check.Matched == "" && diff <= math.Log(1+aspectRatioTolerance) {
+				check.Matched = candidate
+			}
+		}
+		images[i].AspectRatio = check
+	}
+}
+
+// aspectRatioMismatches 返回宽高比不符合平台推荐的图片
+func aspectRatioMismatches(images []models.ImageAnalysis) []models.ImageAnalysis {
+	var mismatched []models.ImageAnalysis
+	for _, img := range images {
+		if img.AspectRatio != nil && img.AspectRatio.Matched == "" {
+			mismatched = append(mismatched, img)
+		}
+	}
+	return mismatched
+}
+
+// aspectRatioSuggestion 有图片不符合平台推荐宽高比时，建议裁剪为最接近的推荐比例；
+// image.crop_ratios 中有该比例时附上保留主体的裁剪框
+func aspectRatioSuggestion(images []models.ImageAnalysis) (models.Suggestion, bool) {
+	mismatched := aspectRatioMismatches(images)
+	if len(mismatched) == 0 {
+		return models.Suggestion{}, false
+	}
+
+	check := mismatched[0].AspectRatio
+	var crops []string
+	for _, img := range mismatched {
+		crop := fmt.Sprintf("%s 裁剪为 %s", filepath.Base(img.Path), img.AspectRatio.Nearest)
+		for _, box := range img.Crops {
+			if box.Aspect == img.AspectRatio.Nearest {
+				crop += fmt.Sprintf("（建议裁剪框 x=%d, y=%d, %d×%d）", box.X, box.Y, box.Width, box.Height)
+				break
+			}
+		}
+		crops = append(crops, crop)
+	}
+
+	return models.Suggestion{
+		Type:        "visual",
+		Priority:    "medium",
+		Current:     fmt.Sprintf("%d张图片的宽高比不符合%s的推荐比例（%s）", len(mismatched), check.Platform, strings.Join(check.Recommended, "、")),
+		Recommended: fmt.Sprintf("按%s信息流的展示比例裁剪图片：%s", check.Platform, strings.Join(crops, "；")),
+		Reasoning:   "比例不合适的图片在信息流中会被自动裁切或留出黑边，主体可能被切掉，占屏面积也更小",
+		Impact:      "预计可提升图片在信息流中的展示效果和点击率",
+		Factor:      factorAspectRatio,
+		Confidence:  signalConfidence(float64(len(mismatched)), float64(len(images))),
+	}, true
+}
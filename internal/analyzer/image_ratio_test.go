@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func ratioImage(path string, width, height int) models.ImageAnalysis {
+	return models.ImageAnalysis{Path: path, Info: models.Image{Width: width, Height: height}}
+}
+
+func TestCheckAspectRatiosPerPlatform(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	tests := []struct {
+		platform    string
+		width       int
+		height      int
+		wantMatched string
+		wantNearest string
+	}{
+		{"instagram", 1080, 1350, "4:5", "4:5"},
+		{"instagram", 1080, 1080, "1:1", "1:1"},
+		{"instagram", 1920, 1080, "", "1:1"},
+		{"youtube", 1280, 720, "16:9", "16:9"},
+		{"youtube", 1080, 1080, "", "16:9"},
+		{"xiaohongshu", 1080, 1440, "3:4", "3:4"},
+		// 1080×1400 与 3:4 相差约2.9%，在容差内；1080×1350（4:5）相差约6.7%
+		{"xiaohongshu", 1080, 1400, "3:4", "3:4"},
+		{"xiaohongshu", 1080, 1350, "", "3:4"},
+		{"douyin", 1080, 1920, "9:16", "9:16"},
+		{"douyin", 1920, 1080, "", "9:16"},
+		{"twitter", 1200, 675, "16:9", "16:9"},
+		{"twitter", 1000, 1000, "1:1", "1:1"},
+	}
+
+	for _, tt := range tests {
+		images := []models.ImageAnalysis{ratioImage("a.jpg", tt.width, tt.height)}
+		ca.checkAspectRatios(images, tt.platform)
+
+		check := images[0].AspectRatio
+		if check == nil {
+			t.Errorf("%s %dx%d: AspectRatio = nil, want 检查结果", tt.platform, tt.width, tt.height)
+			continue
+		}
+		if check.Platform != tt.platform || check.Matched != tt.wantMatched || check.Nearest != tt.wantNearest {
+			t.Errorf("%s %dx%d: Platform/Matched/Nearest = %s/%q/%s, want %s/%q/%s",
+				tt.platform, tt.width, tt.height, check.Platform, check.Matched, check.Nearest, tt.platform, tt.wantMatched, tt.wantNearest)
+		}
+	}
+}
+
+func TestCheckAspectRatiosUnknownPlatform(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	images := []models.ImageAnalysis{ratioImage("a.jpg", 1080, 1350), ratioImage("b.jpg", 0, 0)}
+
+	ca.checkAspectRatios(images, "weibo")
+	if images[0].AspectRatio != nil {
+		t.Errorf("没有推荐比例的平台 AspectRatio = %+v, want nil", images[0].AspectRatio)
+	}
+
+	ca.checkAspectRatios(images, "instagram")
+	if images[1].AspectRatio != nil {
+		t.Errorf("尺寸未知的图片 AspectRatio = %+v, want nil", images[1].AspectRatio)
+	}
+}
+
+func TestAspectRatioSuggestionIncludesCrop(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	wide := ratioImage("/tmp/wide.jpg", 1920, 1080)
+	wide.Crops = []models.CropSuggestion{{Aspect: "9:16", X: 656, Y: 0, Width: 608, Height: 1080}}
+	images := []models.ImageAnalysis{wide, ratioImage("/tmp/tall.jpg", 1080, 1920)}
+	ca.checkAspectRatios(images, "douyin")
+
+	suggestion, ok := aspectRatioSuggestion(images)
+	if !ok {
+		t.Fatal("aspectRatioSuggestion() ok = false, want 横图不符合抖音比例")
+	}
+	if !strings.Contains(suggestion.Current, "1张图片") || !strings.Contains(suggestion.Current, "douyin") {
+		t.Errorf("Current = %q, want 1张图片不符合 douyin", suggestion.Current)
+	}
+	if !strings.Contains(suggestion.Recommended, "wide.jpg 裁剪为 9:16（建议裁剪框 x=656, y=0, 608×1080）") {
+		t.Errorf("Recommended = %q, want 附上 9:16 裁剪框", suggestion.Recommended)
+	}
+
+	ca.checkAspectRatios(images[1:], "douyin")
+	if _, ok := aspectRatioSuggestion(images[1:]); ok {
+		t.Error("竖图符合抖音比例时 ok = true, want false")
+	}
+}
@@ -0,0 +1,107 @@
+// internal/analyzer/image_text.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+const (
+	// 图片文字得分在单图视觉得分中的占比
+	imageTextWeight = 0.2
+	// 图片文字得分中字号和对比度的占比，其余为文字本身的可读性
+	imageLegibilityWeight = 0.7
+)
+
+// scoreImageText 用正文的可读性指标评估图片中的文字，并与字号、对比度合成图片文字得分
+func (ca *ContentAnalyzer) scoreImageText(overlay *models.ImageText) {
+	metrics, _ := ca.analyzeReadability(overlay.Text, nil)
+	overlay.Readability = math.Max(0, math.Min(metrics.FleschScore, 100))
+	overlay.Score = imageLegibilityWeight*overlay.Legibility + (1-imageLegibilityWeight)*overlay.Readability
+}
+
+// imageVisualScore 单张图片计入视觉维度的得分，有文字时混入图片文字得分，宽高比符合平台推荐时加分
+func imageVisualScore(img models.ImageAnalysis) float64 {
+	score := img.Score
+	if img.TextOverlay != nil {
+		score = img.Score*(1-imageTextWeight) + img.TextOverlay.Score*imageTextWeight
+	}
+	if img.AspectRatio != nil && img.AspectRatio.Matched != "" {
+		score = math.Min(score+aspectRatioBonus, 100)
+	}
+	return score
+}
+
+// imageTextIssues 统计图片文字中字太小和对比度不足的图片数
+func imageTextIssues(images []models.ImageAnalysis) (small, lowContrast int) {
+	for _, img := range images {
+		if img.TextOverlay == nil {
+			continue
+		}
+		if img.TextOverlay.SmallLines > 0 {
+			small++
+		}
+		if img.TextOverlay.LowContrastLines > 0 {
+			lowContrast++
+		}
+	}
+	return small, lowContrast
+}
+
+// imageTextSuggestions 图片中文字过小或对比度不足时的建议
+func (ca *ContentAnalyzer) imageTextSuggestions(images []models.ImageAnalysis) []models.Suggestion {
+	small, lowContrast := imageTextIssues(images)
+	if small == 0 && lowContrast == 0 {
+		return nil
+	}
+
+	withText := 0
+	for _, img := range images {
+		if img.TextOverlay != nil {
+			withText++
+		}
+	}
+
+	var suggestions []models.Suggestion
+	ocr := ca.config.Image.OCR
+	if small > 0 {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "visual",
+			Priority:    "medium",
+			Current:     fmt.Sprintf("%d张图片中的文字过小", small),
+			Recommended: fmt.Sprintf("放大图片上的文字，行高至少占图片高度的%.0f%%，或减少文字、只保留关键信息", ocr.MinTextHeight*100),
+			Reasoning:   "信息流中图片以缩略图展示，小字在手机上难以辨认",
+			Impact:      "预计可提升图片信息的传达效果",
+			Factor:      factorImageText,
+			Confidence:  signalConfidence(float64(small), float64(withText)),
+		})
+	}
+	if lowContrast > 0 {
+		suggestions = append(suggestions, models.Suggestion{
+			Type:        "visual",
+			Priority:    "medium",
+			Current:     fmt.Sprintf("%d张图片中的文字与背景对比度不足", lowContrast),
+			Recommended: fmt.Sprintf("加深文字颜色或为文字添加底色、描边，对比度至少达到%.1f:1", ocr.MinContrast),
+			Reasoning:   "低对比度的文字在强光或低亮度屏幕下难以阅读",
+			Impact:      "预计可提升图片信息的传达效果",
+			Factor:      factorImageText,
+			Confidence:  signalConfidence(float64(lowContrast), float64(withText)),
+		})
+	}
+	return suggestions
+}
+
+// imageExtractedText 拼接各图片OCR识别出的文字，前面带分段符，便于直接接在正文后；没有文字时返回空字符串
+func imageExtractedText(images []models.ImageAnalysis) string {
+	var b strings.Builder
+	for _, img := range images {
+		if text := strings.TrimSpace(img.ExtractedText); text != "" {
+			b.WriteString("\n\n")
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
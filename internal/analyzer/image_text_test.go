@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestImageTextLowersVisualScoreAndSuggests(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	clear := &models.ImageText{Text: "Big summer sale today.", LineCount: 1, Legibility: 100}
+	// 一行字太小、一行对比度不足
+	poor := &models.ImageText{Text: "Big summer sale today.\ntiny print", LineCount: 2, SmallLines: 1, LowContrastLines: 1, Legibility: 50}
+	ca.scoreImageText(clear)
+	ca.scoreImageText(poor)
+
+	base := models.ImageAnalysis{Score: 80}
+	withClear, withPoor := base, base
+	withClear.TextOverlay = clear
+	withPoor.TextOverlay = poor
+	if imageVisualScore(withPoor) >= imageVisualScore(withClear) {
+		t.Errorf("visual score: 难以辨认的文字 %.1f >= 清晰的文字 %.1f", imageVisualScore(withPoor), imageVisualScore(withClear))
+	}
+
+	suggestions := ca.imageTextSuggestions([]models.ImageAnalysis{withClear, withPoor, base})
+	if len(suggestions) != 2 {
+		t.Fatalf("suggestions = %+v, want 字号和对比度各一条", suggestions)
+	}
+	for i, want := range []string{"1张图片中的文字过小", "1张图片中的文字与背景对比度不足"} {
+		if suggestions[i].Current != want || suggestions[i].Factor != factorImageText {
+			t.Errorf("suggestions[%d] = %q (%s), want %q (%s)", i, suggestions[i].Current, suggestions[i].Factor, want, factorImageText)
+		}
+	}
+
+	if got := ca.imageTextSuggestions([]models.ImageAnalysis{withClear, base}); len(got) != 0 {
+		t.Errorf("清晰的图片文字 suggestions = %+v, want 无", got)
+	}
+}
+
+func TestImageExtractedText(t *testing.T) {
+	tests := []struct {
+		name   string
+		images []models.ImageAnalysis
+		want   string
+	}{
+		{"没有图片", nil, ""},
+		{"图片没有文字", []models.ImageAnalysis{{}, {ExtractedText: "  \n"}}, ""},
+		{"一张图片有文字", []models.ImageAnalysis{{}, {ExtractedText: " BIG SALE\n"}}, "\n\nBIG SALE"},
+		{"多张图片按顺序拼接", []models.ImageAnalysis{{ExtractedText: "BIG SALE"}, {ExtractedText: "限时折扣"}}, "\n\nBIG SALE\n\n限时折扣"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageExtractedText(tt.images); got != tt.want {
+				t.Errorf("imageExtractedText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// ocrWordsFixture 一行大号文字的 tesseract TSV
+const ocrWordsFixture = `level	page_num	block_num	par_num	line_num	word_num	left	top	width	height	conf	text
+5	1	1	1	1	1	10	10	120	40	95	waterproof
+5	1	1	1	1	2	140	10	120	40	95	waterproof
+5	1	1	1	1	3	270	10	120	40	95	lantern
+`
+
+func TestOCRTextFeedsKeywords(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("需要 sh 脚本模拟 tesseract")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ocr.tsv"), []byte(ocrWordsFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tesseract := filepath.Join(dir, "tesseract")
+	if err := os.WriteFile(tesseract, []byte("#!/bin/sh\ncat '"+filepath.Join(dir, "ocr.tsv")+"'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	imagePath := filepath.Join(dir, "poster.png")
+	f, err := os.Create(imagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 400, 300))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct {
+		name      string
+		enableOCR bool
+		wantText  string
+	}{
+		{"开启OCR", true, "waterproof waterproof lantern"},
+		{"关闭OCR", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				cfg.Image.EnableOCR = tt.enableOCR
+				cfg.Image.OCR.Command = tesseract
+			})
+			result, err := ca.Analyze(models.Content{
+				ID:     "poster",
+				Title:  "Summer camping",
+				Text:   "Pack a tent and a stove for the weekend trip.",
+				Images: []models.Image{{Path: imagePath}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(result.ImageAnalysis) != 1 {
+				t.Fatalf("ImageAnalysis = %+v, want 一张图片", result.ImageAnalysis)
+			}
+			if got := result.ImageAnalysis[0].ExtractedText; got != tt.wantText {
+				t.Errorf("ExtractedText = %q, want %q", got, tt.wantText)
+			}
+
+			hasOCRWord := false
+			for _, keyword := range result.Keywords {
+				if keyword.Word == "waterproof" {
+					hasOCRWord = true
+				}
+			}
+			if hasOCRWord != tt.enableOCR {
+				t.Errorf("关键词包含OCR文字 waterproof = %v, want %v (keywords %+v)", hasOCRWord, tt.enableOCR, result.Keywords)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// internal/analyzer/impact.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 建议针对的内容特征
+const (
+	factorTitle          = "title"
+	factorIntro          = "intro"
+	factorHeadings       = "headings"
+	factorCTA            = "cta"
+	factorEndCTA         = "end_cta"
+	factorStrongCTA      = "strong_cta"
+	factorReadability    = "readability"
+	factorImages         = "images"
+	factorTitleLength    = "title_length"
+	factorHashtags       = "title_hashtags"
+	factorImageText      = "image_text"
+	factorKeywordDensity = "keyword_density"
+	factorVariety        = "variety"
+	factorLinkAnchors    = "link_anchors"
+	factorAspectRatio    = "image_ratio"
+	factorWriting        = "writing_issues"
+	factorEmoji          = "emoji"
+	factorBodyHashtags   = "hashtags"
+)
+
+// factorPredicates 判断分析结果是否已具备某项特征，与 generateSuggestions 的触发条件相反
+var factorPredicates = map[string]func(models.AnalysisResult) bool{
+	factorTitle: func(r models.AnalysisResult) bool {
+		return r.Score.Breakdown.Title >= 70
+	},
+	factorIntro: func(r models.AnalysisResult) bool {
+		return r.TextAnalysis.ContentStructure.HasIntro
+	},
+	factorHeadings: func(r models.AnalysisResult) bool {
+		return len(r.TextAnalysis.ContentStructure.Headings.Issues) == 0
+	},
+	factorCTA: func(r models.AnalysisResult) bool {
+		return len(r.TextAnalysis.CallToAction) > 0
+	},
+	factorEndCTA: func(r models.AnalysisResult) bool {
+		return r.TextAnalysis.CTAAnalysis.HasEndCTA
+	},
+	factorStrongCTA: func(r models.AnalysisResult) bool {
+		return r.TextAnalysis.CTAAnalysis.Strength >= 0.4
+	},
+	factorReadability: func(r models.AnalysisResult) bool {
+		return r.Readability.FleschScore >= 50
+	},
+	factorImages: func(r models.AnalysisResult) bool {
+		return len(r.ImageAnalysis) > 0
+	},
+	factorVariety: func(r models.AnalysisResult) bool {
+		return !r.Readability.Variety.MonotonousSentences && !r.Readability.Variety.MonotonousParagraphs
+	},
+	factorKeywordDensity: func(r models.AnalysisResult) bool {
+		density := r.TextAnalysis.KeywordDensity
+		return density == nil || density.Status == densityWithin
+	},
+	factorLinkAnchors: func(r models.AnalysisResult) bool {
+		return len(genericLinks(r.TextAnalysis.Links)) == 0
+	},
+	factorEmoji: func(r models.AnalysisResult) bool {
+		return !r.TextAnalysis.EmojiOveruse
+	},
+	factorWriting: func(r models.AnalysisResult) bool {
+		return len(r.TextAnalysis.WritingIssues) == 0
+	},
+	factorImageText: func(r models.AnalysisResult) bool {
+		small, lowContrast := imageTextIssues(r.ImageAnalysis)
+		return small == 0 && lowContrast == 0
+	},
+	factorAspectRatio: func(r models.AnalysisResult) bool {
+		return len(aspectRatioMismatches(r.ImageAnalysis)) == 0
+	},
+	factorTitleLength: func(r models.AnalysisResult) bool {
+		return !r.TextAnalysis.TitleAnalysis.Truncated
+	},
+	factorHashtags: func(r models.AnalysisResult) bool {
+		return r.TextAnalysis.TitleAnalysis.HashtagStatus == hashtagsOK
+	},
+	factorBodyHashtags: func(r models.AnalysisResult) bool {
+		// 只在标签过少时才有推荐
+		return len(r.TextAnalysis.SuggestedHashtags) == 0
+	},
+}
+
+// impactEstimate 某项特征对互动率的影响估计
+type impactEstimate struct {
+	samples int
+	low     float64 // 相对提升下限，0.2 表示 20%
+	high    float64
+}
+
+// EstimateImpacts 用语料自身的互动数据估算各条建议的预期影响，替换默认的影响描述。
+// 只统计有浏览量的内容；有/无某特征的内容任一组少于 minSamples 篇时保留默认描述。
+func EstimateImpacts(results []models.AnalysisResult, minSamples int) {
+	if minSamples < 2 {
+		minSamples = 2
+	}
+
+	estimates := make(map[string]impactEstimate)
+	for factor, has := range factorPredicates {
+		var with, without []float64
+		for _, r := range results {
+			rate, ok := r.Engagement.Rate()
+			if !ok {
+				continue
+			}
+			if has(r) {
+				with = append(with, rate)
+			} else {
+				without = append(without, rate)
+			}
+		}
+
+		if len(with) < minSamples || len(without) < minSamples {
+			continue
+		}
+		if estimate, ok := estimateLift(with, without); ok {
+			estimates[factor] = estimate
+		}
+	}
+
+	if len(estimates) == 0 {
+		return
+	}
+
+	for i := range results {
+		for _, suggestions := range [][]models.Suggestion{results[i].Suggestions, results[i].Minor} {
+			for j := range suggestions {
+				if estimate, ok := estimates[suggestions[j].Factor]; ok {
+					suggestions[j].Impact = estimate.describe()
+				}
+			}
+		}
+	}
+}
+
+// estimateLift 比较两组互动率的均值，区间取各自均值加减一个标准误
+func estimateLift(with, without []float64) (impactEstimate, bool) {
+	meanWith, seWith := meanAndStdErr(with)
+	meanWithout, seWithout := meanAndStdErr(without)
+	if meanWithout <= 0 {
+		return impactEstimate{}, false
+	}
+
+	low := (meanWith-seWith)/(meanWithout+seWithout) - 1
+	high := math.Inf(1)
+	if meanWithout-seWithout > 0 {
+		high = (meanWith+seWith)/(meanWithout-seWithout) - 1
+	}
+
+	return impactEstimate{
+		samples: len(with) + len(without),
+		low:     low,
+		high:    high,
+	}, true
+}
+
+func meanAndStdErr(values []float64) (float64, float64) {
+	n := float64(len(values))
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / n
+
+	variance := 0.0
+	for _, v := range values {
+		variance += math.Pow(v-mean, 2)
+	}
+	variance /= n - 1
+
+	return mean, math.Sqrt(variance / n)
+}
+
+func (e impactEstimate) describe() string {
+	switch {
+	case e.high <= 0:
+		return fmt.Sprintf("历史数据中该项改进未带来互动率提升（基于%d篇内容）", e.samples)
+	case e.low <= 0:
+		return fmt.Sprintf("历史数据显示该项对互动率影响不明显（基于%d篇内容）", e.samples)
+	case math.IsInf(e.high, 1):
+		return fmt.Sprintf("根据历史数据，预计可提升互动率%.0f%%以上（基于%d篇内容）", e.low*100, e.samples)
+	default:
+		return fmt.Sprintf("根据历史数据，预计可提升互动率%.0f-%.0f%%（基于%d篇内容）",
+			e.low*100, e.high*100, e.samples)
+	}
+}
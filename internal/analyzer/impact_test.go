@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// impactCorpus 有CTA的内容互动率约10%，没有CTA的约5%，每篇都带一条CTA建议和一条图片建议
+func impactCorpus() []models.AnalysisResult {
+	var results []models.AnalysisResult
+	for i, likes := range []int{95, 100, 105, 48, 50, 52} {
+		result := models.AnalysisResult{
+			Engagement: models.Engagement{Likes: likes, Views: 1000},
+			Suggestions: []models.Suggestion{
+				{Type: "engagement", Factor: factorCTA, Impact: "默认CTA影响"},
+				{Type: "visual", Factor: factorImages, Impact: "默认图片影响"},
+			},
+		}
+		if i < 3 {
+			result.TextAnalysis.CallToAction = []string{"点赞收藏"}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestEstimateImpactsReplacesDefaults(t *testing.T) {
+	results := impactCorpus()
+	EstimateImpacts(results, 3)
+
+	for _, result := range results {
+		cta := result.Suggestions[0].Impact
+		if !strings.HasPrefix(cta, "根据历史数据，预计可提升互动率") || !strings.Contains(cta, "基于6篇内容") {
+			t.Errorf("CTA建议的影响 = %q，应替换为基于历史数据的估计", cta)
+		}
+		// 所有内容都没有图片，无法比较，保留默认描述
+		if got := result.Suggestions[1].Impact; got != "默认图片影响" {
+			t.Errorf("图片建议的影响 = %q，样本不足时应保留默认描述", got)
+		}
+	}
+}
+
+func TestEstimateImpactsKeepsDefaultsWithFewSamples(t *testing.T) {
+	results := impactCorpus()
+	EstimateImpacts(results, 4)
+
+	for _, result := range results {
+		if got := result.Suggestions[0].Impact; got != "默认CTA影响" {
+			t.Errorf("每组少于 min_samples 篇时应保留默认描述: %q", got)
+		}
+	}
+}
+
+func TestEstimateLiftRange(t *testing.T) {
+	estimate, ok := estimateLift([]float64{0.095, 0.1, 0.105}, []float64{0.048, 0.05, 0.052})
+	if !ok {
+		t.Fatal("estimateLift 应返回估计")
+	}
+	// 均值约为两倍，区间应包含100%的提升
+	if estimate.low >= 1 || estimate.high <= 1 || estimate.low <= 0.5 {
+		t.Errorf("提升区间 = [%.2f, %.2f], want 约 [0.8, 1.2]", estimate.low, estimate.high)
+	}
+}
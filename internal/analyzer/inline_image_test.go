@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// inlinePNGContent 图片以 base64 data URI 内嵌的JSON内容文件
+func inlinePNGContent(t *testing.T) models.Content {
+	t.Helper()
+	data, err := os.ReadFile(writePNG(t, t.TempDir(), "inline.png", 40, 30, color.RGBA{R: 30, G: 144, B: 255, A: 255}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := fmt.Sprintf(`{
+  "id": "inline",
+  "title": "内嵌图片",
+  "text": "正文内容。",
+  "images": [{"path": "data:image/png;base64,%s"}]
+}`, base64.StdEncoding.EncodeToString(data))
+
+	var content models.Content
+	if err := json.Unmarshal([]byte(doc), &content); err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestAnalyzeJSONContentWithInlinePNG(t *testing.T) {
+	// 临时文件写入独立目录，便于检查分析后是否删除
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	ca := newTestAnalyzer(t, nil)
+	result, err := ca.Analyze(inlinePNGContent(t))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(result.ImageAnalysis) != 1 {
+		t.Fatalf("ImageAnalysis = %+v, want 1张", result.ImageAnalysis)
+	}
+	analysis := result.ImageAnalysis[0]
+	if analysis.Path != "内嵌图片 #1" {
+		t.Errorf("Path = %q, 报告中不应出现临时文件路径或整段 base64", analysis.Path)
+	}
+	if analysis.Info.Width != 40 || analysis.Info.Height != 30 || analysis.Info.Format != "png" {
+		t.Errorf("Info = %dx%d %s, want 40x30 png", analysis.Info.Width, analysis.Info.Height, analysis.Info.Format)
+	}
+
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("分析后应删除临时文件, 剩余 %v", entries)
+	}
+}
+
+func TestInlineImagesSkip(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Image.InlineImages = "skip"
+	})
+
+	result, err := ca.Analyze(inlinePNGContent(t))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(result.ImageAnalysis) != 0 {
+		t.Errorf("ImageAnalysis = %+v, inline_images: skip 时不应分析", result.ImageAnalysis)
+	}
+	if !containsSubstring(result.Warnings, "内嵌图片 #1") {
+		t.Errorf("Warnings = %v, 应说明跳过了内嵌图片", result.Warnings)
+	}
+}
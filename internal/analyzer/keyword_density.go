@@ -0,0 +1,175 @@
+// internal/analyzer/keyword_density.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 主关键词密度相对目标区间的状态
+const (
+	densityBelow  = "below"
+	densityWithin = "within"
+	densityAbove  = "above"
+)
+
+// analyzeKeywordDensity 计算主关键词在正文中的密度并与 analysis.keyword_density 的目标区间比较。
+// 主关键词取内容的 primary_keyword，未设置时使用提取出的最高频关键词；都没有时返回 nil
+func (ca *ContentAnalyzer) analyzeKeywordDensity(content models.Content, keywords []models.Keyword) *models.KeywordDensity {
+	cfg := ca.config.Analysis.KeywordDensity
+	if !cfg.Enabled {
+		return nil
+	}
+
+	keyword := strings.TrimSpace(content.PrimaryKeyword)
+	source := "content"
+	if keyword == "" {
+		keyword = topKeyword(keywords)
+		source = "extracted"
+	}
+	total := textUnits(content.Text)
+	if keyword == "" || total == 0 {
+		return nil
+	}
+
+	occurrences := countTerm(content.Text, keyword)
+	density := &models.KeywordDensity{
+		Keyword:     keyword,
+		Source:      source,
+		Occurrences: occurrences,
+		Density:     math.Round(float64(occurrences*textUnits(keyword))/float64(total)*10000) / 10000,
+		Min:         cfg.Min,
+		Max:         cfg.Max,
+	}
+
+	switch {
+	case density.Density < cfg.Min:
+		density.Status = densityBelow
+	case density.Density > cfg.Max:
+		density.Status = densityAbove
+	default:
+		density.Status = densityWithin
+	}
+	return density
+}
+
+// keywordDensityScore 主关键词密度对内容质量的加减分：在区间内+5，偏低-5，偏高按超出程度扣分
+func keywordDensityScore(density *models.KeywordDensity) float64 {
+	if density == nil {
+		return 0
+	}
+
+	switch density.Status {
+	case densityWithin:
+		return 5
+	case densityBelow:
+		return -5
+	default:
+		// 每超出1个百分点多扣2分，最多扣15分
+		return -math.Min(5+(density.Density-density.Max)*100*2, 15)
+	}
+}
+
+// keywordDensitySuggestion 主关键词密度不在目标区间时的建议
+func keywordDensitySuggestion(density *models.KeywordDensity) (models.Suggestion, bool) {
+	if density == nil || density.Status == densityWithin {
+		return models.Suggestion{}, false
+	}
+
+	suggestion := models.Suggestion{
+		Type:     "keyword",
+		Priority: "medium",
+		Factor:   factorKeywordDensity,
+		Impact:   "预计可提升搜索排名和内容相关性",
+	}
+
+	target := fmt.Sprintf("%.1f%%-%.1f%%", density.Min*100, density.Max*100)
+	if density.Status == densityBelow {
+		suggestion.Current = fmt.Sprintf("主关键词「%s」密度%.1f%%，低于目标%s", density.Keyword, density.Density*100, target)
+		suggestion.Recommended = fmt.Sprintf("在开头、小标题和结尾自然地加入「%s」，使密度达到%s", density.Keyword, target)
+		suggestion.Reasoning = "主关键词出现过少，搜索引擎和平台难以判断内容主题"
+		suggestion.Confidence = signalConfidence(density.Min-density.Density, density.Min)
+	} else {
+		suggestion.Priority = "high"
+		suggestion.Current = fmt.Sprintf("主关键词「%s」密度%.1f%%，高于目标%s", density.Keyword, density.Density*100, target)
+		suggestion.Recommended = fmt.Sprintf("减少「%s」的重复，改用同义词或相关表达，使密度回到%s", density.Keyword, target)
+		suggestion.Reasoning = "关键词堆砌影响阅读体验，也可能被搜索引擎和平台判定为低质内容"
+		suggestion.Confidence = signalConfidence(density.Density-density.Max, density.Max)
+	}
+
+	// 主关键词为自动提取时判断可能不准
+	if density.Source == "extracted" {
+		suggestion.Confidence *= 0.7
+	}
+	return suggestion, true
+}
+
+// topKeyword 出现次数最多的关键词，各语言组内已按频率排序，同频时取靠前的
+func topKeyword(keywords []models.Keyword) string {
+	best := -1
+	for i, keyword := range keywords {
+		if best < 0 || keyword.Frequency > keywords[best].Frequency {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return keywords[best].Word
+}
+
+// countTerm 统计词语出现次数，匹配规则与 containsTerm 相同
+func countTerm(text, term string) int {
+	lowerText := strings.ToLower(text)
+	lowerTerm := strings.ToLower(term)
+
+	if !isLatinTerm(lowerTerm) {
+		return strings.Count(lowerText, lowerTerm)
+	}
+
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }
+	count := 0
+	for offset := 0; ; {
+		i := strings.Index(lowerText[offset:], lowerTerm)
+		if i < 0 {
+			return count
+		}
+		start := offset + i
+		end := start + len(lowerTerm)
+
+		before, _ := utf8.DecodeLastRuneInString(lowerText[:start])
+		after, _ := utf8.DecodeRuneInString(lowerText[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(lowerText) || !isWordRune(after)) {
+			count++
+			offset = end
+		} else {
+			offset = start + 1
+		}
+	}
+}
+
+// textUnits 按语言计算长度：汉字按字计，拉丁字母和数字按连续的词计，用于关键词密度和长度变化
+func textUnits(text string) int {
+	units := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			units++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				units++
+				inWord = true
+			}
+		default:
+			inWord = false
+		}
+	}
+	return units
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// densityText 100个词的英文正文，其中主关键词 camping 出现 n 次
+func densityText(n int) string {
+	words := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		if i < n {
+			words = append(words, "camping")
+		} else {
+			words = append(words, "trail")
+		}
+	}
+	return strings.Join(words, " ") + "."
+}
+
+func TestKeywordDensityTargetRange(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.KeywordDensity = config.KeywordDensityConfig{Enabled: true, Min: 0.01, Max: 0.03}
+	})
+
+	tests := []struct {
+		name         string
+		occurrences  int
+		wantStatus   string
+		wantScore    float64
+		wantPriority string
+	}{
+		{"under", 0, densityBelow, -5, "medium"},
+		{"in", 2, densityWithin, 5, ""},
+		{"over", 8, densityAbove, -15, "high"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := models.Content{Text: densityText(tt.occurrences), PrimaryKeyword: "Camping"}
+			density := ca.analyzeKeywordDensity(content, nil)
+			if density == nil {
+				t.Fatal("analyzeKeywordDensity() = nil")
+			}
+			if density.Occurrences != tt.occurrences || density.Status != tt.wantStatus || density.Source != "content" {
+				t.Errorf("density = %+v, want %d 次, status %s", density, tt.occurrences, tt.wantStatus)
+			}
+			if got := keywordDensityScore(density); got != tt.wantScore {
+				t.Errorf("keywordDensityScore() = %v, want %v", got, tt.wantScore)
+			}
+
+			suggestion, ok := keywordDensitySuggestion(density)
+			if ok != (tt.wantPriority != "") {
+				t.Fatalf("keywordDensitySuggestion() ok = %v, want %v", ok, tt.wantPriority != "")
+			}
+			if ok && (suggestion.Priority != tt.wantPriority || !strings.Contains(suggestion.Current, "1.0%-3.0%")) {
+				t.Errorf("suggestion = %+v, want 优先级 %s 且注明目标区间", suggestion, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestKeywordDensityFallsBackToTopKeyword(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.KeywordDensity = config.KeywordDensityConfig{Enabled: true, Min: 0.01, Max: 0.03}
+	})
+	keywords := []models.Keyword{{Word: "trail", Frequency: 98}, {Word: "camping", Frequency: 2}}
+
+	density := ca.analyzeKeywordDensity(models.Content{Text: densityText(2)}, keywords)
+	if density == nil || density.Keyword != "trail" || density.Source != "extracted" || density.Status != densityAbove {
+		t.Fatalf("density = %+v, want 自动提取的 trail 且密度偏高", density)
+	}
+	// 自动提取的主关键词降低建议置信度
+	extracted, _ := keywordDensitySuggestion(density)
+	density.Source = "content"
+	specified, _ := keywordDensitySuggestion(density)
+	if extracted.Confidence >= specified.Confidence {
+		t.Errorf("自动提取时置信度 %v, want 低于指定时的 %v", extracted.Confidence, specified.Confidence)
+	}
+
+	ca.config.Analysis.KeywordDensity.Enabled = false
+	if got := ca.analyzeKeywordDensity(models.Content{Text: densityText(2), PrimaryKeyword: "camping"}, nil); got != nil {
+		t.Errorf("未启用时 analyzeKeywordDensity() = %+v, want nil", got)
+	}
+}
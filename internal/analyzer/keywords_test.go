@@ -0,0 +1,23 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractKeywordsTieOrder(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	text := "zebra mango kiwi apple tent. apple kiwi zebra mango tent. tent tent."
+
+	want := []string{"tent", "apple", "kiwi", "mango", "zebra"}
+	// map 遍历顺序每次不同，多次运行都应得到相同顺序
+	for i := 0; i < 20; i++ {
+		var words []string
+		for _, keyword := range ca.extractLanguageKeywords("en", text) {
+			words = append(words, keyword.Word)
+		}
+		if !reflect.DeepEqual(words, want) {
+			t.Fatalf("第%d次: keywords = %v, want %v", i+1, words, want)
+		}
+	}
+}
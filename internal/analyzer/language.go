@@ -0,0 +1,343 @@
+// internal/analyzer/language.go
+package analyzer
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 判断主要语言时各文字的典型阅读速度。估算阅读时间和按阅读时长加权合并指标使用 analysis.reading_speed
+const (
+	chineseCharsPerMinute = 300.0
+	englishWordsPerMinute = 250.0
+)
+
+// 中文超过该字数的句子视为长句
+const chineseLongSentence = 40
+
+// ReadabilityMetrics.Method 的取值
+const (
+	readabilityFlesch          = "flesch_kincaid"
+	readabilityChineseSentence = "zh_sentence_length"
+	readabilityMixed           = "mixed"
+)
+
+var (
+	chineseSentencePattern = regexp.MustCompile(`[。！？!?；;\n]+`)
+	englishSentencePattern = regexp.MustCompile(`[.!?]+`)
+)
+
+// 某一语言占阅读时长的比例达到该值时视为单一语言，否则为 mixed
+const dominantLanguageRatio = 0.8
+
+// DetectLanguage 按各文字的阅读时长占比判断内容的主要语言（汉字按字、拉丁字母按词计，
+// 避免一个英文单词的多个字母压过一个汉字）：汉字为主为 zh，拉丁字母为主为 en，
+// 两者都不占绝对多数时为 mixed，没有文字或以其他文字为主时为 und（无法判断）
+func DetectLanguage(text string) string {
+	units := make(map[string]float64)
+	previous := ""
+	for _, r := range text {
+		lang := runeLanguage(r)
+		switch {
+		case lang == "zh":
+			units[lang] += 1 / chineseCharsPerMinute
+		case lang != "" && lang != previous:
+			units[lang] += 1 / englishWordsPerMinute
+		}
+		previous = lang
+	}
+
+	total := units["zh"] + units["en"] + units["other"]
+	if total == 0 {
+		return "und"
+	}
+	for _, lang := range []string{"zh", "en", "other"} {
+		if units[lang]/total >= dominantLanguageRatio {
+			if lang == "other" {
+				return "und"
+			}
+			return lang
+		}
+	}
+	return "mixed"
+}
+
+// lexiconLanguages 选择词表时使用的语言：zh、en 只用对应语言的词表，mixed 或无法判断时两种都用
+func lexiconLanguages(lang string) []string {
+	switch lang {
+	case "zh", "en":
+		return []string{lang}
+	default:
+		return []string{"zh", "en"}
+	}
+}
+
+// languageText 同一语言的所有片段，按首次出现顺序排列
+type languageText struct {
+	lang string
+	text string
+}
+
+// runeLanguage 判断字符所属语言，空格、数字、标点返回空串，归入相邻片段
+func runeLanguage(r rune) string {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return "zh"
+	case unicode.Is(unicode.Latin, r):
+		return "en"
+	case unicode.IsLetter(r):
+		return "other"
+	default:
+		return ""
+	}
+}
+
+// groupByLanguage 将文本切分为单一语言的片段，并按语言合并
+func groupByLanguage(text string) []languageText {
+	var order []string
+	segments := make(map[string][]string)
+
+	current := ""
+	var segment strings.Builder
+	flush := func() {
+		if current == "" || strings.TrimSpace(segment.String()) == "" {
+			return
+		}
+		if _, ok := segments[current]; !ok {
+			order = append(order, current)
+		}
+		segments[current] = append(segments[current], strings.TrimSpace(segment.String()))
+		segment.Reset()
+	}
+
+	for _, r := range text {
+		lang := runeLanguage(r)
+		if lang != "" && lang != current {
+			if current != "" {
+				flush()
+			}
+			current = lang
+		}
+		segment.WriteRune(r)
+	}
+	flush()
+
+	groups := make([]languageText, 0, len(order))
+	for _, lang := range order {
+		groups = append(groups, languageText{lang: lang, text: strings.Join(segments[lang], "\n")})
+	}
+
+	return groups
+}
+
+// analyzeReadability 按语言片段分别计算可读性，再按阅读时长加权合并
+func (ca *ContentAnalyzer) analyzeReadability(text string, keywords []models.Keyword) (models.ReadabilityMetrics, []models.LanguageMetrics) {
+	groups := groupByLanguage(text)
+	if len(groups) == 0 {
+		return ca.finishReadability(englishReadability(text, ca.domainTerms, ca.config.Analysis.ReadingSpeed.English)), nil
+	}
+
+	languages := make([]models.LanguageMetrics, 0, len(groups))
+	readingTimes := make([]float64, 0, len(groups))
+	totalTime := 0.0
+	for _, group := range groups {
+		var metrics models.LanguageMetrics
+		if group.lang == "zh" {
+			metrics = chineseReadability(group.text, ca.domainTerms, ca.config.Analysis.ReadingSpeed.Chinese)
+		} else {
+			metrics = models.LanguageMetrics{
+				WordCount:   ca.CountWords(group.text),
+				Readability: englishReadability(group.text, ca.domainTerms, ca.config.Analysis.ReadingSpeed.English),
+			}
+		}
+		metrics.Language = group.lang
+
+		for _, keyword := range keywords {
+			if keyword.Language == group.lang {
+				metrics.Keywords = append(metrics.Keywords, keyword.Word)
+			}
+		}
+
+		seconds := ca.readingSeconds(metrics)
+		readingTimes = append(readingTimes, seconds)
+		totalTime += seconds
+		languages = append(languages, metrics)
+	}
+
+	// 按阅读时长加权
+	combined := models.ReadabilityMetrics{Method: languages[0].Readability.Method}
+	wordLengthShare := 0.0
+	gradeShare := 0.0
+	for i := range languages {
+		share := 1.0 / float64(len(languages))
+		if totalTime > 0 {
+			share = readingTimes[i] / totalTime
+		}
+		languages[i].Share = share
+
+		r := languages[i].Readability
+		if r.Method != combined.Method {
+			combined.Method = readabilityMixed
+		}
+		combined.FleschScore += r.FleschScore * share
+		combined.AvgSentenceLength += r.AvgSentenceLength * share
+		combined.ComplexWordRatio += r.ComplexWordRatio * share
+		if r.AvgWordLength > 0 {
+			combined.AvgWordLength += r.AvgWordLength * share
+			wordLengthShare += share
+		}
+		// 年级只对按音节计算的语言有意义
+		if r.Method == readabilityFlesch {
+			combined.GradeLevel += r.GradeLevel * share
+			gradeShare += share
+		}
+	}
+	if wordLengthShare > 0 {
+		combined.AvgWordLength /= wordLengthShare
+	}
+	if gradeShare > 0 {
+		combined.GradeLevel /= gradeShare
+	}
+	combined.ReadingTime = int(totalTime)
+
+	for i := range languages {
+		languages[i].Readability = ca.finishReadability(languages[i].Readability)
+	}
+
+	return ca.finishReadability(combined), languages
+}
+
+// readingSeconds 按 analysis.reading_speed 中该语言的阅读速度估算阅读秒数
+func (ca *ContentAnalyzer) readingSeconds(metrics models.LanguageMetrics) float64 {
+	speed := ca.config.Analysis.ReadingSpeed
+	if metrics.Language == "zh" {
+		return float64(metrics.WordCount) / speed.Chinese * 60
+	}
+	return float64(metrics.WordCount) / speed.English * 60
+}
+
+// englishReadability 以空格分词的语言，按音节数计算 Flesch Reading Ease 和 Flesch-Kincaid 年级；
+// 行业术语按平均音节数计算，不计为复杂词。阅读时间按每分钟 wordsPerMinute 词计算
+func englishReadability(text string, terms domainTerms, wordsPerMinute float64) models.ReadabilityMetrics {
+	words := strings.Fields(text)
+	wordCount := len(words)
+
+	sentenceCount := 0
+	for _, s := range englishSentencePattern.Split(text, -1) {
+		if strings.TrimSpace(s) != "" {
+			sentenceCount++
+		}
+	}
+	if sentenceCount == 0 {
+		sentenceCount = 1
+	}
+
+	avgSentenceLength := float64(wordCount) / float64(sentenceCount)
+
+	// 计算平均词长和音节数
+	totalChars := 0.0
+	totalSyllables := 0.0
+	complexWords := 0
+	for _, word := range words {
+		totalChars += terms.wordLength(word)
+		totalSyllables += terms.syllables(word)
+		if utf8.RuneCountInString(word) > 6 && !terms.isTerm(word) {
+			complexWords++
+		}
+	}
+
+	var avgWordLength, avgSyllables, complexWordRatio float64
+	if wordCount > 0 {
+		avgWordLength = totalChars / float64(wordCount)
+		avgSyllables = totalSyllables / float64(wordCount)
+		complexWordRatio = float64(complexWords) / float64(wordCount)
+	}
+
+	// 没有单词时两个公式都没有意义，按最易读处理
+	fleschScore := 100.0
+	gradeLevel := 0.0
+	if wordCount > 0 {
+		fleschScore = 206.835 - 1.015*avgSentenceLength - 84.6*avgSyllables
+		gradeLevel = 0.39*avgSentenceLength + 11.8*avgSyllables - 15.59
+	}
+
+	return models.ReadabilityMetrics{
+		Method:            readabilityFlesch,
+		FleschScore:       fleschScore,
+		GradeLevel:        gradeLevel,
+		AvgSentenceLength: avgSentenceLength,
+		AvgWordLength:     avgWordLength,
+		ComplexWordRatio:  complexWordRatio,
+		ReadingTime:       int(float64(wordCount) / wordsPerMinute * 60),
+	}
+}
+
+// chineseReadability 中文按字计数，以平均句长和长句比例换算为与Flesch同量纲的0-100分；
+// 行业术语在句长中只计一个字，字数和阅读时间仍按原文计算，阅读时间按每分钟 charsPerMinute 字计算
+func chineseReadability(text string, terms domainTerms, charsPerMinute float64) models.LanguageMetrics {
+	chars := 0
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			chars++
+		}
+	}
+
+	sentenceChars := 0
+	sentences := 0
+	longSentences := 0
+	for _, s := range chineseSentencePattern.Split(terms.compressHan(text), -1) {
+		n := 0
+		for _, r := range s {
+			if unicode.Is(unicode.Han, r) {
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+		sentenceChars += n
+		sentences++
+		if n > chineseLongSentence {
+			longSentences++
+		}
+	}
+	if sentences == 0 {
+		sentences = 1
+	}
+
+	avgSentenceLength := float64(sentenceChars) / float64(sentences)
+	longRatio := float64(longSentences) / float64(sentences)
+	score := math.Max(0, math.Min(120-2.5*avgSentenceLength-20*longRatio, 100))
+
+	return models.LanguageMetrics{
+		WordCount: chars,
+		Readability: models.ReadabilityMetrics{
+			Method:            readabilityChineseSentence,
+			FleschScore:       score,
+			AvgSentenceLength: avgSentenceLength,
+			ComplexWordRatio:  longRatio, // 中文为长句比例
+			ReadingTime:       int(float64(chars) / charsPerMinute * 60),
+		},
+	}
+}
+
+// finishReadability 根据分数确定阅读等级，阅读时间不少于 analysis.reading_speed.min_seconds
+func (ca *ContentAnalyzer) finishReadability(r models.ReadabilityMetrics) models.ReadabilityMetrics {
+	r.Grade = "中等"
+	if r.FleschScore > 80 {
+		r.Grade = "容易"
+	} else if r.FleschScore < 50 {
+		r.Grade = "困难"
+	}
+
+	if min := ca.config.Analysis.ReadingSpeed.MinSeconds; r.ReadingTime < min {
+		r.ReadingTime = min
+	}
+
+	return r
+}
@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestBilingualPerSegmentMetrics(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	content := models.Content{
+		ID:    "bilingual",
+		Title: "露营装备清单",
+		Text: "第一次去露营，很多人不知道该带什么。帐篷、睡袋和防潮垫是最基本的三件套。" +
+			"正如一位老手所说：\n" +
+			"Pack light and camp often. The best tent is the one you carry.\n" +
+			"记得带走所有垃圾。",
+	}
+
+	result, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(result.Languages) != 2 {
+		t.Fatalf("Languages = %+v, want zh 和 en 两个片段", result.Languages)
+	}
+
+	byLang := make(map[string]models.LanguageMetrics)
+	share := 0.0
+	for _, m := range result.Languages {
+		byLang[m.Language] = m
+		share += m.Share
+	}
+	if share < 0.999 || share > 1.001 {
+		t.Errorf("各语言占比之和 = %v, want 1", share)
+	}
+
+	zh, en := byLang["zh"], byLang["en"]
+	if zh.Readability.Method != readabilityChineseSentence {
+		t.Errorf("zh Method = %q, want %q", zh.Readability.Method, readabilityChineseSentence)
+	}
+	if en.Readability.Method != readabilityFlesch {
+		t.Errorf("en Method = %q, want %q", en.Readability.Method, readabilityFlesch)
+	}
+	if en.WordCount != 13 {
+		t.Errorf("en WordCount = %d, want 13", en.WordCount)
+	}
+	if zh.WordCount <= en.WordCount {
+		t.Errorf("zh WordCount = %d, 中文片段按字计数应多于英文词数", zh.WordCount)
+	}
+	if zh.Share <= en.Share {
+		t.Errorf("zh Share = %v, en Share = %v, 中文正文占主要阅读时长", zh.Share, en.Share)
+	}
+	if result.Readability.Method != readabilityMixed {
+		t.Errorf("合并后的 Method = %q, want %q", result.Readability.Method, readabilityMixed)
+	}
+
+	// 合并分数是两个片段按阅读时长的加权平均
+	want := zh.Readability.FleschScore*zh.Share + en.Readability.FleschScore*en.Share
+	if diff := result.Readability.FleschScore - want; diff < -0.01 || diff > 0.01 {
+		t.Errorf("合并 FleschScore = %v, want %v", result.Readability.FleschScore, want)
+	}
+
+	for _, m := range result.Languages {
+		for _, word := range m.Keywords {
+			for _, k := range result.Keywords {
+				if k.Word == word && k.Language != m.Language {
+					t.Errorf("关键词 %q 的语言为 %q, 却归入 %s 片段", word, k.Language, m.Language)
+				}
+			}
+		}
+	}
+}
+
+func TestEnglishReadabilityDocumentedScores(t *testing.T) {
+	// Flesch Reading Ease 的经典示例（见 Wikipedia "Flesch–Kincaid readability tests"）
+	tests := []struct {
+		text       string
+		wantFlesch float64
+		wantGrade  float64
+	}{
+		{"The cat sat on the mat.", 116.145, -1.45},
+		{"The Australian platypus is seemingly a hybrid of a mammal and reptilian creature.", 24.44, 13.08},
+	}
+	for _, tt := range tests {
+		metrics := englishReadability(tt.text, newDomainTerms(nil), englishWordsPerMinute)
+		if math.Abs(metrics.FleschScore-tt.wantFlesch) > 0.01 {
+			t.Errorf("%q: FleschScore = %.3f, want %.3f", tt.text, metrics.FleschScore, tt.wantFlesch)
+		}
+		if math.Abs(metrics.GradeLevel-tt.wantGrade) > 0.01 {
+			t.Errorf("%q: GradeLevel = %.2f, want %.2f", tt.text, metrics.GradeLevel, tt.wantGrade)
+		}
+		if metrics.Method != readabilityFlesch {
+			t.Errorf("%q: Method = %q, want %q", tt.text, metrics.Method, readabilityFlesch)
+		}
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{
+		"cat":        1,
+		"the":        1,
+		"mat.":       1,
+		"hybrid":     2,
+		"creature":   2,
+		"platypus":   3,
+		"seemingly":  3,
+		"Australian": 4,
+		"reptilian":  4,
+	}
+	for word, want := range tests {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestChineseReadabilityLabeled(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	metrics, _ := ca.analyzeReadability("今天天气很好。我们去公园散步吧。", nil)
+	// 中文不用音节公式，标注为句长得分且没有年级
+	if metrics.Method != readabilityChineseSentence || metrics.GradeLevel != 0 {
+		t.Errorf("Method/GradeLevel = %q/%v, want %q/0", metrics.Method, metrics.GradeLevel, readabilityChineseSentence)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"pure chinese", "周末去露营，记得带好帐篷和睡袋。", "zh"},
+		{"chinese with brand names", "新款iPhone的续航比上一代提升了不少，日常使用一整天没有问题。", "zh"},
+		{"pure english", "Pack a tent, a sleeping bag and a warm jacket for the weekend.", "en"},
+		{"english with a chinese word", "We ordered 火锅 for dinner after a long day of hiking in the hills.", "en"},
+		{"mixed", "今天分享一个 productivity tip：use a simple to-do list every morning。", "mixed"},
+		{"digits and punctuation", "2024-01-15 12:00 !!!", "und"},
+		{"other script", "Привет, как дела?", "und"},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("%s: DetectLanguage(%q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTextAnalysisLanguage(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	contents := []models.Content{
+		{ID: "zh", Title: "露营清单", Text: "周末去露营，记得带好帐篷和睡袋。"},
+		{ID: "en", Title: "Camping list", Text: "Pack a tent, a sleeping bag and a warm jacket for the weekend."},
+		{ID: "mixed", Title: "效率", Text: "今天分享一个 productivity tip：use a simple to-do list every morning。"},
+	}
+	results := ca.AnalyzeAll(contents, 1, nil)
+	if len(results) != len(contents) {
+		t.Fatalf("分析结果数 = %d, want %d", len(results), len(contents))
+	}
+	for _, result := range results {
+		if result.TextAnalysis.Language != result.ContentID {
+			t.Errorf("%s: TextAnalysis.Language = %q, want %q", result.ContentID, result.TextAnalysis.Language, result.ContentID)
+		}
+	}
+}
+
+func TestReadingTimeScalesWithConfiguredRate(t *testing.T) {
+	zh := strings.Repeat("我们去露营。", 100)                  // 500个汉字
+	en := strings.Repeat("We pack the tent today. ", 40) // 200个词
+
+	tests := []struct {
+		name       string
+		text       string
+		speed      config.ReadingSpeedConfig
+		wantSecond int
+	}{
+		{"中文 每分钟300字", zh, config.ReadingSpeedConfig{Chinese: 300, English: 200}, 100},
+		{"中文 每分钟600字", zh, config.ReadingSpeedConfig{Chinese: 600, English: 200}, 50},
+		{"英文 每分钟200词", en, config.ReadingSpeedConfig{Chinese: 300, English: 200}, 60},
+		{"英文 每分钟100词", en, config.ReadingSpeedConfig{Chinese: 300, English: 100}, 120},
+		// 中英混排按各自速度分别计算后相加
+		{"中英混排", zh + "\n" + en, config.ReadingSpeedConfig{Chinese: 500, English: 100}, 60 + 120},
+		{"不足下限", "We go.", config.ReadingSpeedConfig{Chinese: 300, English: 200, MinSeconds: 30}, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) { cfg.Analysis.ReadingSpeed = tt.speed })
+			readability, _ := ca.analyzeReadability(tt.text, nil)
+			if readability.ReadingTime != tt.wantSecond {
+				t.Errorf("ReadingTime = %d, want %d", readability.ReadingTime, tt.wantSecond)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// internal/analyzer/lexicon.go
+package analyzer
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// 各语言的情感词，按内容语言选用
+var emotionalWords = map[string][]string{
+	"zh": {
+		"惊喜", "震撼", "感动", "激动", "兴奋", "满足", "幸福", "快乐",
+		"担心", "焦虑", "害怕", "紧张", "愤怒", "失望", "沮丧",
+	},
+	"en": {"amazing", "wonderful", "fantastic", "incredible", "awesome"},
+}
+
+// 各语言的强力词（吸引点击的词）
+var powerWords = map[string][]string{
+	"zh": {
+		"独家", "限时", "免费", "秘密", "揭秘", "内幕", "独特", "创新",
+		"突破", "革命", "颠覆", "神器", "必备", "推荐", "精选",
+	},
+	"en": {"exclusive", "limited", "secret", "unique", "breakthrough"},
+}
+
+// 各语言的停用词，提取关键词时按片段语言选用
+var stopWords = map[string][]string{
+	"zh": {
+		"的", "是", "在", "我", "你", "他", "了", "和", "就", "都", "而", "及",
+		"与", "或", "但", "为", "也", "不", "可以", "这个", "那个", "什么", "怎么",
+	},
+	"en": {
+		"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for", "of",
+		"with", "by", "is", "are", "was", "were", "be",
+	},
+}
+
+// wordLists 分析使用的词表：内置词表与 analysis.word_lists 指定的文件合并后的结果，按语言区分
+type wordLists struct {
+	stop      map[string]map[string]bool
+	power     map[string][]string
+	emotional map[string][]string
+	cta       map[string][]*regexp.Regexp
+}
+
+// loadWordLists 读取 analysis.word_lists 指定的词表文件并与内置词表合并，再追加 analysis.cta.patterns 中按语言配置的CTA正则。
+// 文件读取失败时记录日志并只使用内置词表，无效的CTA正则逐条跳过
+func loadWordLists(cfg config.WordListsConfig, extraCTA map[string][]string) wordLists {
+	load := func(name, path string, builtin map[string][]string) map[string][]string {
+		if path == "" {
+			return builtin
+		}
+		custom, err := readWordList(path)
+		if err != nil {
+			log.Printf("读取%s词表 %s 失败，使用内置词表: %v", name, path, err)
+			return builtin
+		}
+		return mergeWords(builtin, custom, cfg.Replace)
+	}
+
+	lists := wordLists{
+		stop:      make(map[string]map[string]bool),
+		power:     load("强力词", cfg.PowerWords, powerWords),
+		emotional: load("情感词", cfg.EmotionalWords, emotionalWords),
+		cta:       make(map[string][]*regexp.Regexp),
+	}
+
+	for lang, words := range load("停用词", cfg.StopWords, stopWords) {
+		set := make(map[string]bool, len(words))
+		for _, word := range words {
+			// 提取关键词时已转为小写
+			set[strings.ToLower(word)] = true
+		}
+		lists.stop[lang] = set
+	}
+
+	cta := load("CTA", cfg.CTAPatterns, ctaPatterns)
+	if len(extraCTA) > 0 {
+		cta = mergeWords(cta, extraCTA, false)
+	}
+	for lang, patterns := range cta {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				log.Printf("CTA正则 %q 无效，已跳过: %v", pattern, err)
+				continue
+			}
+			lists.cta[lang] = append(lists.cta[lang], re)
+		}
+	}
+
+	return lists
+}
+
+// readWordList 读取每行一个词的词表文件，忽略空行和 # 开头的注释行；含汉字的词归入中文，其余归入英文
+func readWordList(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	words := make(map[string][]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		word := strings.TrimSpace(strings.TrimPrefix(line, "\uFEFF"))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		lang := "en"
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0 {
+			lang = "zh"
+		}
+		words[lang] = append(words[lang], word)
+	}
+	return words, nil
+}
+
+// mergeWords 将文件中的词追加到内置词表后面，重复的词只保留一次；replace 时不保留内置词表
+func mergeWords(builtin, custom map[string][]string, replace bool) map[string][]string {
+	merged := make(map[string][]string)
+	for _, lang := range []string{"zh", "en"} {
+		var words []string
+		if !replace {
+			words = append(words, builtin[lang]...)
+		}
+		words = append(words, custom[lang]...)
+
+		seen := make(map[string]bool, len(words))
+		for _, word := range words {
+			if !seen[word] {
+				seen[word] = true
+				merged[lang] = append(merged[lang], word)
+			}
+		}
+	}
+	return merged
+}
+
+// findLexiconWords 返回文本中出现的、适用于该语言的词表中的词，忽略大小写
+func findLexiconWords(text string, lexicon map[string][]string, lang string) []string {
+	var found []string
+	lowerText := strings.ToLower(text)
+
+	for _, l := range lexiconLanguages(lang) {
+		for _, word := range lexicon[l] {
+			if strings.Contains(lowerText, strings.ToLower(word)) {
+				found = append(found, word)
+			}
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// writeWordList 写入每行一个词的词表文件
+func writeWordList(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCustomStopWordsChangeKeywords(t *testing.T) {
+	text := "tent tent tent stove stove lantern. tent stove lantern."
+	keywordSet := func(ca *ContentAnalyzer) map[string]bool {
+		set := make(map[string]bool)
+		for _, keyword := range ca.extractLanguageKeywords("en", text) {
+			set[keyword.Word] = true
+		}
+		return set
+	}
+
+	if !keywordSet(newTestAnalyzer(t, nil))["tent"] {
+		t.Fatal("内置词表下 tent 应为关键词")
+	}
+
+	stopWords := writeWordList(t, "# 露营类内容中过于常见的词\ntent\n\n")
+	custom := keywordSet(newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.WordLists.StopWords = stopWords
+	}))
+	if custom["tent"] {
+		t.Error("自定义停用词 tent 仍出现在关键词中")
+	}
+	if !custom["stove"] || !custom["lantern"] {
+		t.Errorf("keywords = %v, want 保留其他词", custom)
+	}
+}
+
+func TestCustomPowerWordsChangeTitleScore(t *testing.T) {
+	content := models.Content{ID: "post", Title: "城市周边的宝藏露营地", Text: sampleContent("post").Text}
+	analyze := func(ca *ContentAnalyzer) models.AnalysisResult {
+		results := ca.AnalyzeAll([]models.Content{content}, 1, nil)
+		if len(results) != 1 {
+			t.Fatalf("分析结果数 = %d, want 1", len(results))
+		}
+		return results[0]
+	}
+
+	base := analyze(newTestAnalyzer(t, nil))
+	powerWords := writeWordList(t, "宝藏\n")
+	custom := analyze(newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.WordLists.PowerWords = powerWords
+	}))
+
+	if !containsSubstring(custom.TextAnalysis.TitleAnalysis.PowerWords, "宝藏") {
+		t.Errorf("PowerWords = %v, want 包含自定义的 宝藏", custom.TextAnalysis.TitleAnalysis.PowerWords)
+	}
+	if custom.Score.Breakdown.Title <= base.Score.Breakdown.Title {
+		t.Errorf("标题得分 = %.1f, want 高于内置词表的 %.1f", custom.Score.Breakdown.Title, base.Score.Breakdown.Title)
+	}
+}
+
+func TestWordListsMergeOrReplace(t *testing.T) {
+	path := writeWordList(t, "\uFEFFgem\n宝藏\ngem\n")
+
+	merged := loadWordLists(config.WordListsConfig{PowerWords: path}, nil)
+	if got := merged.power["en"]; len(got) != len(powerWords["en"])+1 || got[len(got)-1] != "gem" {
+		t.Errorf("合并后 en 强力词 = %v, want 内置词加 gem", got)
+	}
+	if got := merged.power["zh"]; got[len(got)-1] != "宝藏" {
+		t.Errorf("合并后 zh 强力词 = %v, want 末尾为 宝藏", got)
+	}
+
+	replaced := loadWordLists(config.WordListsConfig{PowerWords: path, Replace: true}, nil)
+	if got := replaced.power["en"]; len(got) != 1 || got[0] != "gem" {
+		t.Errorf("替换后 en 强力词 = %v, want [gem]", got)
+	}
+
+	// 文件不存在时退回内置词表
+	missing := loadWordLists(config.WordListsConfig{PowerWords: filepath.Join(t.TempDir(), "missing.txt")}, nil)
+	if len(missing.power["en"]) != len(powerWords["en"]) {
+		t.Errorf("文件不存在时 en 强力词 = %v, want 内置词表", missing.power["en"])
+	}
+}
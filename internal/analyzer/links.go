@@ -0,0 +1,149 @@
+// internal/analyzer/links.go
+package analyzer
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+var (
+	// [锚文本](url "可选标题")，前面带 ! 的是图片，不算链接
+	markdownLinkPattern = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// <https://...> 形式的自动链接，锚文本就是网址本身
+	autoLinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	htmlLinkPattern = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	htmlTagPattern  = regexp.MustCompile(`<[^>]+>`)
+	urlLikePattern  = regexp.MustCompile(`(?i)^(https?://|www\.)\S+$`)
+)
+
+// 无法说明链接去向的笼统锚文本，比较时已转小写并去掉首尾标点
+var genericAnchors = map[string]bool{
+	"click here": true, "click": true, "here": true, "this": true, "this link": true, "link": true,
+	"read more": true, "more": true, "learn more": true, "see more": true, "details": true, "go": true,
+	"点击这里": true, "点这里": true, "点击此处": true, "点此": true, "点击": true, "这里": true, "此处": true,
+	"戳这里": true, "链接": true, "这个链接": true, "更多": true, "查看更多": true, "阅读更多": true,
+	"了解更多": true, "详情": true, "查看详情": true, "阅读原文": true,
+}
+
+// 锚文本问题
+const (
+	anchorEmpty   = "empty"   // 没有锚文本
+	anchorURL     = "url"     // 锚文本就是网址
+	anchorGeneric = "generic" // 笼统的"点击这里"类文字
+)
+
+// extractLinks 提取 Markdown 和 HTML 链接及其锚文本，按出现位置排序；正文中的裸网址没有锚文本，不计入
+func extractLinks(text string) []models.Link {
+	type located struct {
+		pos  int
+		link models.Link
+	}
+	var found []located
+
+	for _, m := range markdownLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		if m[3] > m[2] {
+			continue // 图片
+		}
+		found = append(found, located{m[0], newLink(text[m[6]:m[7]], text[m[4]:m[5]], "markdown")})
+	}
+	for _, m := range autoLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		url := text[m[2]:m[3]]
+		found = append(found, located{m[0], newLink(url, url, "markdown")})
+	}
+	for _, m := range htmlLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		anchor := html.UnescapeString(htmlTagPattern.ReplaceAllString(text[m[4]:m[5]], ""))
+		found = append(found, located{m[0], newLink(text[m[2]:m[3]], anchor, "html")})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].pos < found[j].pos })
+	links := make([]models.Link, 0, len(found))
+	for _, f := range found {
+		links = append(links, f.link)
+	}
+	return links
+}
+
+func newLink(url, anchor, source string) models.Link {
+	link := models.Link{
+		URL:    url,
+		Anchor: strings.Join(strings.Fields(anchor), " "),
+		Source: source,
+	}
+	link.Issue = anchorIssue(link.Anchor)
+	link.Descriptive = link.Issue == ""
+	return link
+}
+
+// anchorIssue 判断锚文本是否能说明链接去向，没有问题时返回空字符串
+func anchorIssue(anchor string) string {
+	normalized := strings.ToLower(strings.TrimFunc(anchor, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r) || unicode.IsSymbol(r)
+	}))
+	switch {
+	case normalized == "":
+		return anchorEmpty
+	case urlLikePattern.MatchString(anchor):
+		return anchorURL
+	case genericAnchors[normalized]:
+		return anchorGeneric
+	}
+	return ""
+}
+
+// genericLinks 锚文本不具描述性的链接
+func genericLinks(links []models.Link) []models.Link {
+	var generic []models.Link
+	for _, link := range links {
+		if !link.Descriptive {
+			generic = append(generic, link)
+		}
+	}
+	return generic
+}
+
+// linkAnchorPenalty 每个不具描述性的锚文本扣3分，最多扣10分
+func linkAnchorPenalty(links []models.Link) float64 {
+	penalty := float64(len(genericLinks(links))) * 3
+	if penalty > 10 {
+		return 10
+	}
+	return penalty
+}
+
+// linkAnchorSuggestion 存在"点击这里"、裸网址等锚文本时的建议
+func linkAnchorSuggestion(links []models.Link) (models.Suggestion, bool) {
+	generic := genericLinks(links)
+	if len(generic) == 0 {
+		return models.Suggestion{}, false
+	}
+
+	var examples []string
+	for _, link := range generic {
+		anchor := link.Anchor
+		if anchor == "" {
+			anchor = "（空）"
+		}
+		examples = append(examples, fmt.Sprintf("%s → %s", anchor, link.URL))
+		if len(examples) == 3 {
+			break
+		}
+	}
+
+	return models.Suggestion{
+		Type:        "link",
+		Priority:    "medium",
+		Current:     fmt.Sprintf("%d个链接的锚文本无法说明去向（共%d个链接）", len(generic), len(links)),
+		Recommended: "把「点击这里」「更多」或网址本身改为描述目标内容的文字，如「查看2024年护肤品成分对比」",
+		Reasoning:   "描述性的锚文本帮助搜索引擎理解链接页面，读屏软件用户单独浏览链接列表时也能知道每个链接的去向",
+		Examples:    examples,
+		Impact:      "提升链接的SEO价值和无障碍体验",
+		Factor:      factorLinkAnchors,
+		Confidence:  signalConfidence(float64(len(generic)), float64(len(links))),
+	}, true
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import "testing"
+
+func TestExtractLinksAnchorQuality(t *testing.T) {
+	text := "装备清单见[2024露营装备对比](https://example.com/gear)，" +
+		"预订营地[点击这里](https://example.com/book)。\n" +
+		"![封面](cover.jpg) 更多路线 <https://example.com/routes>\n" +
+		`<a href="https://example.com/faq">Camping <b>FAQ</b> for beginners</a> ` +
+		`<a href='https://example.com/more'>Read more…</a> [](https://example.com/empty)`
+
+	want := []struct {
+		url         string
+		anchor      string
+		source      string
+		descriptive bool
+		issue       string
+	}{
+		{"https://example.com/gear", "2024露营装备对比", "markdown", true, ""},
+		{"https://example.com/book", "点击这里", "markdown", false, anchorGeneric},
+		{"https://example.com/routes", "https://example.com/routes", "markdown", false, anchorURL},
+		{"https://example.com/faq", "Camping FAQ for beginners", "html", true, ""},
+		{"https://example.com/more", "Read more…", "html", false, anchorGeneric},
+		{"https://example.com/empty", "", "markdown", false, anchorEmpty},
+	}
+
+	links := extractLinks(text)
+	if len(links) != len(want) {
+		t.Fatalf("extractLinks() = %+v, want %d 个链接（图片不计入）", links, len(want))
+	}
+	for i, w := range want {
+		got := links[i]
+		if got.URL != w.url || got.Anchor != w.anchor || got.Source != w.source || got.Descriptive != w.descriptive || got.Issue != w.issue {
+			t.Errorf("links[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+
+	// 4个不具描述性的锚文本，扣分封顶10分
+	if got := linkAnchorPenalty(links); got != 10 {
+		t.Errorf("linkAnchorPenalty() = %v, want 10", got)
+	}
+	suggestion, ok := linkAnchorSuggestion(links)
+	if !ok {
+		t.Fatal("linkAnchorSuggestion() ok = false, want 建议")
+	}
+	if suggestion.Current != "4个链接的锚文本无法说明去向（共6个链接）" || len(suggestion.Examples) != 3 {
+		t.Errorf("suggestion = %+v", suggestion)
+	}
+}
+
+func TestDescriptiveAnchorsNoSuggestion(t *testing.T) {
+	links := extractLinks("参考[新手露营指南](https://example.com/guide)和" +
+		`<a href="https://example.com/tents">2024年帐篷评测</a>。`)
+	if len(links) != 2 {
+		t.Fatalf("extractLinks() = %+v, want 2 个链接", links)
+	}
+	if got := linkAnchorPenalty(links); got != 0 {
+		t.Errorf("linkAnchorPenalty() = %v, want 0", got)
+	}
+	if _, ok := linkAnchorSuggestion(links); ok {
+		t.Error("描述性锚文本不应产生建议")
+	}
+}
@@ -0,0 +1,114 @@
+// internal/analyzer/postprocess.go
+package analyzer
+
+import (
+	"log"
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/expression"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// postProcessor 配置中定义的自定义评分维度
+type postProcessor struct {
+	name   string
+	source string
+	expr   *expression.Expression
+	weight float64
+}
+
+// loadPostProcessors 编译配置中的评分表达式。config.Load 已校验过表达式，这里只跳过直接构造的配置中无效的项
+func loadPostProcessors(cfg *config.Config) []postProcessor {
+	var processors []postProcessor
+
+	for _, pc := range cfg.Analysis.PostProcessors {
+		if pc.Name == "" || pc.Expression == "" {
+			log.Printf("自定义评分维度缺少名称或表达式，已跳过")
+			continue
+		}
+
+		expr, err := expression.Compile(pc.Expression, config.PostProcessorVariables)
+		if err != nil {
+			log.Printf("自定义评分维度 %s 表达式无效，已跳过: %v", pc.Name, err)
+			continue
+		}
+
+		processors = append(processors, postProcessor{
+			name:   pc.Name,
+			source: pc.Expression,
+			expr:   expr,
+			weight: pc.Weight,
+		})
+	}
+
+	return processors
+}
+
+// applyPostProcessors 计算自定义维度得分，并按权重混入总分
+func (ca *ContentAnalyzer) applyPostProcessors(result *models.AnalysisResult) {
+	if len(ca.postProcessors) == 0 {
+		return
+	}
+
+	vars := resultVariables(*result)
+	total := result.Score.Total
+
+	for _, pp := range ca.postProcessors {
+		value, err := pp.expr.Eval(vars)
+		if err != nil {
+			log.Printf("自定义评分维度 %s 计算失败: %v", pp.name, err)
+			continue
+		}
+		// 溢出等得到的 NaN/Inf 无法比较大小，也无法写入JSON报告，同样按计算失败跳过
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			log.Printf("自定义评分维度 %s 计算结果无效: %v", pp.name, value)
+			continue
+		}
+		value = math.Max(0, math.Min(value, 100))
+
+		if result.Score.Breakdown.Custom == nil {
+			result.Score.Breakdown.Custom = make(map[string]float64)
+		}
+		result.Score.Breakdown.Custom[pp.name] = value
+
+		if pp.weight > 0 {
+			total = total*(1-pp.weight) + value*pp.weight
+		}
+	}
+
+	if total != result.Score.Total {
+		result.Score.Total = total
+		result.Score.Level = ca.scoreLevel(total)
+	}
+}
+
+// resultVariables 表达式可读取的变量，与 config.PostProcessorVariables 一一对应
+func resultVariables(result models.AnalysisResult) map[string]float64 {
+	text := result.TextAnalysis
+	breakdown := result.Score.Breakdown
+
+	return map[string]float64{
+		"word_count":      float64(text.WordCount),
+		"char_count":      float64(text.CharCount),
+		"paragraph_count": float64(text.ParagraphCount),
+		"sentence_count":  float64(text.SentenceCount),
+		"section_count":   float64(text.ContentStructure.SectionCount),
+		"title_length":    float64(text.TitleAnalysis.Length),
+		"cta_count":       float64(len(text.CallToAction)),
+		"hashtag_count":   float64(len(text.Hashtags)),
+		"mention_count":   float64(len(text.Mentions)),
+		"image_count":     float64(len(result.ImageAnalysis)),
+		"keyword_count":   float64(len(result.Keywords)),
+		"sentiment_score": result.Sentiment.Score,
+		"flesch_score":    result.Readability.FleschScore,
+		"reading_time":    float64(result.Readability.ReadingTime),
+		"total":           result.Score.Total,
+		"content_quality": breakdown.ContentQuality,
+		"engagement":      breakdown.Engagement,
+		"visual":          breakdown.Visual,
+		"title":           breakdown.Title,
+		"readability":     breakdown.Readability,
+		"trend_relevance": breakdown.TrendRelevance,
+	}
+}
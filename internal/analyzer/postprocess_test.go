@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestPostProcessorAdjustsByWordCount(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.PostProcessors = []config.PostProcessorConfig{{
+			Name:       "depth",
+			Expression: "word_count >= 300 ? 100 : word_count / 300 * 100",
+			Weight:     0.5,
+		}}
+	})
+
+	tests := []struct {
+		words     int
+		wantValue float64
+		wantTotal float64
+	}{
+		{words: 600, wantValue: 100, wantTotal: 80},
+		{words: 150, wantValue: 50, wantTotal: 55},
+	}
+	for _, tt := range tests {
+		result := models.AnalysisResult{
+			TextAnalysis: models.TextAnalysis{WordCount: tt.words},
+			Score:        models.OverallScore{Total: 60},
+		}
+		ca.applyPostProcessors(&result)
+
+		if got := result.Score.Breakdown.Custom["depth"]; got != tt.wantValue {
+			t.Errorf("%d词: depth = %v, want %v", tt.words, got, tt.wantValue)
+		}
+		if result.Score.Total != tt.wantTotal {
+			t.Errorf("%d词: total = %v, want %v", tt.words, result.Score.Total, tt.wantTotal)
+		}
+		if want := ca.scoreLevel(tt.wantTotal); result.Score.Level != want {
+			t.Errorf("%d词: level = %q, want %q", tt.words, result.Score.Level, want)
+		}
+	}
+}
+
+func TestPostProcessorZeroWeightOnlyRecords(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.PostProcessors = []config.PostProcessorConfig{{Name: "length", Expression: "word_count / 10"}}
+	})
+
+	result := models.AnalysisResult{
+		TextAnalysis: models.TextAnalysis{WordCount: 500},
+		Score:        models.OverallScore{Total: 60, Level: "good"},
+	}
+	ca.applyPostProcessors(&result)
+
+	if got := result.Score.Breakdown.Custom["length"]; got != 50 {
+		t.Errorf("length = %v, want 50", got)
+	}
+	if result.Score.Total != 60 {
+		t.Errorf("权重为0时总分不应变化: %v", result.Score.Total)
+	}
+}
+
+func TestPostProcessorSkipsNonFiniteValues(t *testing.T) {
+	// 表达式不支持科学计数法，1e308 写成完整的数字，乘以10后溢出为 +Inf
+	huge := "1" + strings.Repeat("0", 308)
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.PostProcessors = []config.PostProcessorConfig{
+			{Name: "nan", Expression: huge + " * 10 - " + huge + " * 10", Weight: 0.5},
+			{Name: "inf", Expression: huge + " * 10", Weight: 0.5},
+			{Name: "length", Expression: "word_count / 10"},
+		}
+	})
+
+	result := models.AnalysisResult{
+		TextAnalysis: models.TextAnalysis{WordCount: 500},
+		Score:        models.OverallScore{Total: 60, Level: "good"},
+	}
+	ca.applyPostProcessors(&result)
+
+	custom := result.Score.Breakdown.Custom
+	if _, ok := custom["nan"]; ok {
+		t.Errorf("NaN 的维度不应记录: %v", custom)
+	}
+	if _, ok := custom["inf"]; ok {
+		t.Errorf("Inf 的维度不应记录: %v", custom)
+	}
+	if custom["length"] != 50 {
+		t.Errorf("length = %v, want 50（其余维度照常计算）", custom["length"])
+	}
+	if result.Score.Total != 60 || result.Score.Level != "good" {
+		t.Errorf("总分/等级 = %v/%s, want 不变 60/good", result.Score.Total, result.Score.Level)
+	}
+}
+
+func TestResultVariablesMatchConfig(t *testing.T) {
+	var got []string
+	for name := range resultVariables(models.AnalysisResult{}) {
+		got = append(got, name)
+	}
+	want := append([]string(nil), config.PostProcessorVariables...)
+	sort.Strings(got)
+	sort.Strings(want)
+
+	if len(got) != len(want) {
+		t.Fatalf("resultVariables = %v, config.PostProcessorVariables = %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("resultVariables = %v, config.PostProcessorVariables = %v", got, want)
+		}
+	}
+}
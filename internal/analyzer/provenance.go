@@ -0,0 +1,28 @@
+// internal/analyzer/provenance.go
+package analyzer
+
+import "github.com/RobinCoderZhao/content-analyzer/internal/models"
+
+// AI失败降级为本地分析时，情感分析置信度乘以该系数，表明结果来自降级路径
+const fallbackConfidenceFactor = 0.5
+
+// worseProvenance 合并多次调用的来源：任一次降级即为 fallback，其次任一次使用AI即为 ai
+func worseProvenance(a, b string) string {
+	for _, source := range []string{models.ProvenanceFallback, models.ProvenanceAI, models.ProvenanceLocal} {
+		if a == source || b == source {
+			return source
+		}
+	}
+	return ""
+}
+
+// setProvenance 记录组件结果的来源，source 为空（组件未使用或未开启AI）时不记录
+func setProvenance(result *models.AnalysisResult, component, source string) {
+	if source == "" {
+		return
+	}
+	if result.Provenance == nil {
+		result.Provenance = make(map[string]string)
+	}
+	result.Provenance[component] = source
+}
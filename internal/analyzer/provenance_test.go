@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestAnalyzeProvenance(t *testing.T) {
+	// 按提示词返回情感分析和主题提取的结果
+	aiReply := func(prompt string) string {
+		if strings.Contains(prompt, "情感倾向") {
+			return `{"overall": "positive", "score": 0.8, "confidence": 0.9}`
+		}
+		return `["露营", "户外装备"]`
+	}
+
+	tests := []struct {
+		name           string
+		configureAI    bool
+		reply          func(prompt string) string
+		wantSource     string
+		wantConfidence float64
+	}{
+		{name: "AI未配置", wantSource: models.ProvenanceLocal, wantConfidence: 0.6},
+		// 本地分析的置信度 0.6 降级后减半
+		{name: "AI请求失败", configureAI: true, wantSource: models.ProvenanceFallback, wantConfidence: 0.3},
+		{name: "AI结果无法解析", configureAI: true, reply: func(string) string { return "暂时无法回答" },
+			wantSource: models.ProvenanceFallback, wantConfidence: 0.3},
+		{name: "AI分析", configureAI: true, reply: aiReply, wantSource: models.ProvenanceAI, wantConfidence: 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newTestAnalyzer(t, func(cfg *config.Config) {
+				if tt.configureAI {
+					useMockAI(t, cfg, tt.reply)
+				}
+			})
+			content := sampleContent("camping")
+			content.Comments = []string{"清单很实用，收藏了", "睡袋推荐哪款？"}
+
+			result, err := ca.Analyze(content)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			// 未开启 use_ai 的实体识别和话题标签推荐不记录来源
+			want := map[string]string{
+				"sentiment":           tt.wantSource,
+				"community_sentiment": tt.wantSource,
+				"topics":              tt.wantSource,
+			}
+			if !reflect.DeepEqual(result.Provenance, want) {
+				t.Errorf("Provenance = %v, want %v", result.Provenance, want)
+			}
+			if math.Abs(result.Sentiment.Confidence-tt.wantConfidence) > 1e-9 {
+				t.Errorf("Sentiment.Confidence = %v, want %v", result.Sentiment.Confidence, tt.wantConfidence)
+			}
+			if len(result.Topics) == 0 {
+				t.Error("Topics 为空, 降级时也应返回本地提取的主题")
+			}
+		})
+	}
+}
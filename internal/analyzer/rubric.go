@@ -0,0 +1,172 @@
+// internal/analyzer/rubric.go
+package analyzer
+
+import "fmt"
+
+// Rubric 当前生效的评分标准，用于向客户说明打分方式
+type Rubric struct {
+	Strictness       string                  `json:"strictness"`
+	StrictnessFactor float64                 `json:"strictness_factor"`
+	Dimensions       []RubricDimension       `json:"dimensions"`
+	Levels           []RubricLevel           `json:"levels"`
+	CustomDimensions []RubricCustomDimension `json:"custom_dimensions,omitempty"`
+	RequiredKeywords []string                `json:"required_keywords,omitempty"`
+	MinWordCount     int                     `json:"min_word_count"`
+	MaxWordCount     int                     `json:"max_word_count"`
+}
+
+// RubricDimension 一个评分维度及其检查项
+type RubricDimension struct {
+	Key       string            `json:"key"`
+	Name      string            `json:"name"`
+	Weight    float64           `json:"weight"`
+	BaseScore float64           `json:"base_score"`
+	Criteria  []RubricCriterion `json:"criteria"`
+}
+
+// RubricCriterion 单个检查项
+type RubricCriterion struct {
+	Check  string `json:"check"`
+	Points string `json:"points"`
+}
+
+// RubricLevel 等级分数线
+type RubricLevel struct {
+	Level    string  `json:"level"`
+	MinScore float64 `json:"min_score"`
+}
+
+// RubricCustomDimension 配置中的自定义评分维度
+type RubricCustomDimension struct {
+	Name       string  `json:"name"`
+	Expression string  `json:"expression"`
+	Weight     float64 `json:"weight"`
+}
+
+// Rubric 根据当前配置和评分规则生成评分标准。
+// 检查项与 score* 函数一一对应，修改评分规则时需同步更新这里。
+func (ca *ContentAnalyzer) Rubric() Rubric {
+	weights := ca.globalScoreWeights()
+
+	qualityCriteria := []RubricCriterion{
+		{Check: "词数在100-800之间", Points: "+20"},
+		{Check: "同时具备开头引入和结尾总结", Points: "+15"},
+		{Check: "包含行动召唤（CTA）", Points: "+5"},
+		{Check: "分为两个及以上章节", Points: "+5"},
+		{Check: "标题层级问题（跳级、多个或缺少一级标题）", Points: "每项-3，最多-10"},
+		{Check: "链接锚文本为空、网址或「点击这里」等笼统文字", Points: "每个-3，最多-10"},
+	}
+	if density := ca.config.Analysis.KeywordDensity; density.Enabled {
+		qualityCriteria = append(qualityCriteria,
+			RubricCriterion{Check: fmt.Sprintf("主关键词密度在%.1f%%-%.1f%%之间", density.Min*100, density.Max*100), Points: "+5"},
+			RubricCriterion{Check: "主关键词密度低于目标区间", Points: "-5"},
+			RubricCriterion{Check: "主关键词密度高于目标区间", Points: "-5，每超出1个百分点再-2，最多-15"},
+		)
+	}
+	if ca.writing.languageTool != nil || ca.writing.chinese {
+		qualityCriteria = append(qualityCriteria,
+			RubricCriterion{Check: "拼写、语法或错别字问题", Points: fmt.Sprintf("每处-%g，最多-%g", writingIssuePenalty, maxWritingIssuePenalty)},
+		)
+	}
+	if len(ca.config.Analysis.RequiredKeywords) > 0 {
+		qualityCriteria = append(qualityCriteria,
+			RubricCriterion{Check: "缺少必需的品牌关键词", Points: "每个-10，最多-30"},
+			RubricCriterion{Check: "必需的品牌关键词全部出现", Points: "+5"},
+		)
+	}
+
+	rubric := Rubric{
+		Strictness:       ca.config.Analysis.Strictness,
+		StrictnessFactor: ca.strictness,
+		Dimensions: []RubricDimension{
+			{
+				Key:       "content_quality",
+				Name:      "内容质量",
+				Weight:    weights.ContentQuality,
+				BaseScore: 60,
+				Criteria:  qualityCriteria,
+			},
+			{
+				Key:       "engagement",
+				Name:      "互动性",
+				Weight:    weights.Engagement,
+				BaseScore: 50,
+				Criteria: []RubricCriterion{
+					{Check: "包含行动召唤，按CTA强度（0-1）加分", Points: "+5 + 15×强度"},
+					{Check: "标题包含疑问", Points: "+15"},
+					{Check: "标题包含情感词", Points: "+10"},
+					{Check: "使用第二人称与读者对话", Points: "+5"},
+					{Check: fmt.Sprintf("正文emoji密度在每%.0f词1个以内，按密度加分", 2/ca.config.Analysis.MaxEmojiDensity), Points: fmt.Sprintf("+0~%g", emojiBonus)},
+					{Check: fmt.Sprintf("正文emoji密度在每%.0f词1个到每%.0f词1个之间，加分放缓", 2/ca.config.Analysis.MaxEmojiDensity, 1/ca.config.Analysis.MaxEmojiDensity), Points: fmt.Sprintf("+%g~%g", emojiBonus, emojiBonus+emojiExtraBonus)},
+					{Check: fmt.Sprintf("正文emoji超过每%.0f词1个", 1/ca.config.Analysis.MaxEmojiDensity), Points: fmt.Sprintf("-%g", emojiOverusePenalty)},
+				},
+			},
+			{
+				Key:       "visual",
+				Name:      "视觉效果",
+				Weight:    weights.Visual,
+				BaseScore: 30,
+				Criteria: []RubricCriterion{
+					{Check: "没有图片", Points: "固定30分"},
+					{Check: "有图片时取所有图片质量、构图、风格评分的平均值", Points: "0-100"},
+					{Check: "启用OCR且图片含文字时，文字字号、对比度（70%）和可读性（30%）得分", Points: "占该图片得分的20%"},
+				},
+			},
+			{
+				Key:       "title",
+				Name:      "标题",
+				Weight:    weights.Title,
+				BaseScore: 50,
+				Criteria: []RubricCriterion{
+					{Check: "标题长度在10-30字之间", Points: "+20"},
+					{Check: "标题包含数字", Points: "+10"},
+					{Check: "标题包含有力词汇", Points: "+15"},
+					{Check: "标题清晰度高于0.8", Points: "+5"},
+				},
+			},
+			{
+				Key:       "readability",
+				Name:      "可读性",
+				Weight:    weights.Readability,
+				BaseScore: 50,
+				Criteria: []RubricCriterion{
+					{Check: "Flesch可读性得分高于70 / 50 / 30", Points: "+30 / +20 / +10"},
+					{Check: "平均句长在10-20词之间", Points: "+10"},
+					{Check: "复杂词比例低于20%（analysis.domain_terms 中的术语不计）", Points: "+10"},
+					{Check: "句子长度变化过小（变化系数低于 analysis.variety 下限）", Points: "-5"},
+					{Check: "段落长度变化过小", Points: "-5"},
+				},
+			},
+			{
+				Key:       "trend_relevance",
+				Name:      "趋势性",
+				Weight:    weights.TrendRelevance,
+				BaseScore: 60,
+				Criteria: []RubricCriterion{
+					{Check: "每个上升趋势的关键词", Points: "+5"},
+					{Check: "每个相关度高于0.05的关键词", Points: "+2"},
+				},
+			},
+		},
+		Levels: []RubricLevel{
+			{Level: "excellent", MinScore: ca.config.Analysis.LevelThresholds.Excellent},
+			{Level: "good", MinScore: ca.config.Analysis.LevelThresholds.Good},
+			{Level: "average", MinScore: ca.config.Analysis.LevelThresholds.Average},
+			{Level: "poor", MinScore: 0},
+		},
+		RequiredKeywords: ca.config.Analysis.RequiredKeywords,
+		MinWordCount:     ca.config.Analysis.MinWordCount,
+		MaxWordCount:     ca.config.Analysis.MaxWordCount,
+	}
+
+	// 只列出编译成功、实际参与评分的自定义维度
+	for _, pp := range ca.postProcessors {
+		rubric.CustomDimensions = append(rubric.CustomDimensions, RubricCustomDimension{
+			Name:       pp.name,
+			Expression: pp.source,
+			Weight:     pp.weight,
+		})
+	}
+
+	return rubric
+}
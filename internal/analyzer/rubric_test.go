@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestRubricReflectsConfig(t *testing.T) {
+	weights := config.ScoreWeights{
+		ContentQuality: 0.40,
+		Engagement:     0.10,
+		Visual:         0.05,
+		Title:          0.20,
+		Readability:    0.15,
+		TrendRelevance: 0.10,
+	}
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.ScoreWeights = weights
+		cfg.Analysis.LevelThresholds = config.LevelThresholds{Excellent: 92, Good: 78, Average: 55}
+		cfg.Analysis.Strictness = "strict"
+		cfg.Analysis.PostProcessors = []config.PostProcessorConfig{{
+			Name:       "depth",
+			Expression: "word_count / 10",
+			Weight:     0.2,
+		}}
+	})
+
+	rubric := ca.Rubric()
+
+	wantWeights := map[string]float64{
+		"content_quality": weights.ContentQuality,
+		"engagement":      weights.Engagement,
+		"visual":          weights.Visual,
+		"title":           weights.Title,
+		"readability":     weights.Readability,
+		"trend_relevance": weights.TrendRelevance,
+	}
+	if len(rubric.Dimensions) != len(wantWeights) {
+		t.Fatalf("len(Dimensions) = %d, want %d", len(rubric.Dimensions), len(wantWeights))
+	}
+	for _, d := range rubric.Dimensions {
+		if want, ok := wantWeights[d.Key]; !ok || d.Weight != want {
+			t.Errorf("%s 权重 = %v, want %v", d.Key, d.Weight, want)
+		}
+		if len(d.Criteria) == 0 {
+			t.Errorf("%s 缺少检查项", d.Key)
+		}
+	}
+
+	wantLevels := map[string]float64{"excellent": 92, "good": 78, "average": 55, "poor": 0}
+	for _, level := range rubric.Levels {
+		if level.MinScore != wantLevels[level.Level] {
+			t.Errorf("%s 分数线 = %v, want %v", level.Level, level.MinScore, wantLevels[level.Level])
+		}
+	}
+
+	if rubric.Strictness != "strict" || rubric.StrictnessFactor != loadStrictness("strict") {
+		t.Errorf("Strictness = %q/%v, want strict/%v", rubric.Strictness, rubric.StrictnessFactor, loadStrictness("strict"))
+	}
+	if len(rubric.CustomDimensions) != 1 || rubric.CustomDimensions[0].Name != "depth" || rubric.CustomDimensions[0].Weight != 0.2 {
+		t.Errorf("CustomDimensions = %+v, want depth(0.2)", rubric.CustomDimensions)
+	}
+}
@@ -0,0 +1,176 @@
+// internal/analyzer/segment.go
+package analyzer
+
+import (
+	_ "embed"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// 内置中文词典，每行若干个以空白分隔的词
+//
+//go:embed dict/zh_words.txt
+var builtinChineseWords string
+
+// segmenter 基于词典的中文分词：汉字片段按正向、逆向最大匹配各切一次，取词数更少（相同时单字更少）的结果，
+// 词典外的汉字按单字切分；字母和数字按连续片段作为一个词，标点和空白只用于分隔
+type segmenter struct {
+	words  map[string]bool
+	maxLen int // 词典中最长词的字数
+}
+
+// newSegmenter 加载内置词典、analysis.segmentation.dictionary 指定的词典文件和中文行业术语。
+// 未开启分词时返回nil，统计词数时按空格分词
+func newSegmenter(cfg config.SegmentationConfig, terms domainTerms) *segmenter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	s := &segmenter{words: make(map[string]bool)}
+	s.addWords(builtinChineseWords)
+	if cfg.Dictionary != "" {
+		data, err := os.ReadFile(cfg.Dictionary)
+		if err != nil {
+			log.Printf("读取分词词典 %s 失败，只使用内置词典: %v", cfg.Dictionary, err)
+		} else {
+			s.addWords(strings.TrimPrefix(string(data), "\uFEFF"))
+		}
+	}
+	for _, term := range terms.han {
+		s.add(term)
+	}
+	return s
+}
+
+func (s *segmenter) addWords(data string) {
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, word := range strings.Fields(line) {
+			s.add(word)
+		}
+	}
+}
+
+func (s *segmenter) add(word string) {
+	s.words[word] = true
+	if n := utf8.RuneCountInString(word); n > s.maxLen {
+		s.maxLen = n
+	}
+}
+
+// segment 把文本切分为词，不含标点和空白
+func (s *segmenter) segment(text string) []string {
+	var tokens []string
+	var han, other []rune
+	flush := func() {
+		if len(han) > 0 {
+			tokens = append(tokens, s.segmentHan(han)...)
+			han = han[:0]
+		}
+		if len(other) > 0 {
+			if word := strings.Trim(string(other), "'-_"); word != "" {
+				tokens = append(tokens, word)
+			}
+			other = other[:0]
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if len(other) > 0 {
+				flush()
+			}
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '-' || r == '_':
+			if len(han) > 0 {
+				flush()
+			}
+			other = append(other, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// segmentHan 对连续的汉字做双向最大匹配
+func (s *segmenter) segmentHan(run []rune) []string {
+	forward := s.maxMatch(run, false)
+	backward := s.maxMatch(run, true)
+
+	if len(forward) != len(backward) {
+		if len(forward) < len(backward) {
+			return forward
+		}
+		return backward
+	}
+	if singleChars(forward) < singleChars(backward) {
+		return forward
+	}
+	// 其余情况逆向匹配通常更准确，如 "结合成分子" 切为 "结合/成/分子"
+	return backward
+}
+
+func (s *segmenter) maxMatch(run []rune, reverse bool) []string {
+	var tokens []string
+	if !reverse {
+		for i := 0; i < len(run); {
+			n := s.matchLen(run[i:], false)
+			tokens = append(tokens, string(run[i:i+n]))
+			i += n
+		}
+		return tokens
+	}
+
+	for j := len(run); j > 0; {
+		n := s.matchLen(run[:j], true)
+		tokens = append(tokens, string(run[j-n:j]))
+		j -= n
+	}
+	for i, k := 0, len(tokens)-1; i < k; i, k = i+1, k-1 {
+		tokens[i], tokens[k] = tokens[k], tokens[i]
+	}
+	return tokens
+}
+
+// matchLen 返回从 run 开头（reverse 时从结尾）起能匹配的最长词的字数，没有匹配时为1
+func (s *segmenter) matchLen(run []rune, reverse bool) int {
+	n := s.maxLen
+	if n > len(run) {
+		n = len(run)
+	}
+	for ; n > 1; n-- {
+		word := run[:n]
+		if reverse {
+			word = run[len(run)-n:]
+		}
+		if s.words[string(word)] {
+			return n
+		}
+	}
+	return 1
+}
+
+func singleChars(tokens []string) int {
+	count := 0
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) == 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// containsHan 文本中是否有汉字
+func containsHan(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool { return unicode.Is(unicode.Han, r) }) >= 0
+}
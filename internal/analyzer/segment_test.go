@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestSegmentedVersusNaiveWordCount(t *testing.T) {
+	segmented := newTestAnalyzer(t, nil)
+	naive := newTestAnalyzer(t, func(cfg *config.Config) { cfg.Analysis.Segmentation.Enabled = false })
+
+	tests := []struct {
+		name          string
+		text          string
+		wantSegmented int
+		wantNaive     int
+	}{
+		// 关闭分词时整句中文只算一个词
+		{"中文句子", "周末天气很好，准备出发。", 7, 1},
+		{"中英混排", "周末用iPhone 15拍照", 5, 2},
+		{"纯英文", "The quick brown fox, jumps.", 5, 5},
+		{"空文本", "  ", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := segmented.CountWords(tt.text); got != tt.wantSegmented {
+				t.Errorf("分词 CountWords() = %d (%s), want %d", got, strings.Join(segmented.splitWords(tt.text), "/"), tt.wantSegmented)
+			}
+			if got := naive.CountWords(tt.text); got != tt.wantNaive {
+				t.Errorf("按空格 CountWords() = %d, want %d", got, tt.wantNaive)
+			}
+		})
+	}
+}
+
+func TestSegmenterBidirectionalMatch(t *testing.T) {
+	s := newSegmenter(config.SegmentationConfig{Enabled: true}, domainTerms{})
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		// 正向最大匹配会切成 "结合/成分/子"，逆向匹配单字更少
+		{"结合成分子", "结合/成/分子"},
+		{"周末天气", "周末/天气"},
+		// 词典外的汉字按单字切分，字母数字连续片段算一个词，标点只用于分隔
+		{"露营-Go1.20", "露/营/Go1/20"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(s.segment(tt.text), "/"); got != tt.want {
+			t.Errorf("segment(%q) = %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSegmenterCustomDictionary(t *testing.T) {
+	builtin := newSegmenter(config.SegmentationConfig{Enabled: true}, domainTerms{})
+	custom := newSegmenter(config.SegmentationConfig{Enabled: true, Dictionary: writeWordList(t, "# 户外\n露营 帐篷\n")}, domainTerms{})
+
+	text := "露营帐篷"
+	if got := strings.Join(builtin.segment(text), "/"); got != "露/营/帐/篷" {
+		t.Errorf("内置词典 segment() = %s, want 露/营/帐/篷", got)
+	}
+	if got := strings.Join(custom.segment(text), "/"); got != "露营/帐篷" {
+		t.Errorf("追加词典 segment() = %s, want 露营/帐篷", got)
+	}
+}
+
+func TestNewSegmenterDisabled(t *testing.T) {
+	if s := newSegmenter(config.SegmentationConfig{}, domainTerms{}); s != nil {
+		t.Errorf("newSegmenter() = %v, want 未开启时为 nil", s)
+	}
+}
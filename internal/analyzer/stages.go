@@ -0,0 +1,90 @@
+// internal/analyzer/stages.go
+package analyzer
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// stageEnabled 阶段是否未被 disabled_stages 跳过
+func (ca *ContentAnalyzer) stageEnabled(stage string) bool {
+	return !ca.disabledStages[stage]
+}
+
+func loadDisabledStages(cfg *config.Config) map[string]bool {
+	disabled := make(map[string]bool)
+	for _, stage := range cfg.Analysis.DisabledStages {
+		disabled[stage] = true
+	}
+	return disabled
+}
+
+// reconcileWeights 将被跳过阶段所对应维度的权重清零，并按比例分配给其余维度，保持权重总和不变
+func reconcileWeights(weights models.ScoreWeights, disabled map[string]bool) (models.ScoreWeights, []string) {
+	var warnings []string
+
+	sum := weightSum(weights)
+	removed := 0.0
+	for _, stage := range config.AnalysisStages {
+		dimension, ok := config.StageDimensions[stage]
+		if !ok {
+			continue
+		}
+		field := weightField(&weights, dimension)
+		if field == nil {
+			continue
+		}
+		if !disabled[stage] {
+			if *field == 0 {
+				warnings = append(warnings, fmt.Sprintf("阶段 %s 仍在运行，但评分维度 %s 的权重为0，其结果不影响总分", stage, dimension))
+			}
+			continue
+		}
+		if *field > 0 {
+			warnings = append(warnings, fmt.Sprintf("阶段 %s 已跳过，评分维度 %s 的权重 %.2f 已分配给其余维度", stage, dimension, *field))
+			removed += *field
+			*field = 0
+		}
+	}
+
+	if removed > 0 && sum-removed > 0 {
+		scale := sum / (sum - removed)
+		weights.ContentQuality *= scale
+		weights.Engagement *= scale
+		weights.Visual *= scale
+		weights.Title *= scale
+		weights.Readability *= scale
+		weights.TrendRelevance *= scale
+	}
+
+	return weights, warnings
+}
+
+func weightField(w *models.ScoreWeights, dimension string) *float64 {
+	switch dimension {
+	case "content_quality":
+		return &w.ContentQuality
+	case "engagement":
+		return &w.Engagement
+	case "visual":
+		return &w.Visual
+	case "title":
+		return &w.Title
+	case "readability":
+		return &w.Readability
+	case "trend_relevance":
+		return &w.TrendRelevance
+	default:
+		return nil
+	}
+}
+
+// logWeightWarnings 构造分析器时提示权重与阶段设置不一致
+func logWeightWarnings(warnings []string) {
+	for _, warning := range warnings {
+		log.Printf("评分权重: %s", warning)
+	}
+}
@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestReconcileWeightsForDisabledStage(t *testing.T) {
+	weights := models.ScoreWeights{
+		ContentQuality: 0.25,
+		Engagement:     0.20,
+		Visual:         0.15,
+		Title:          0.15,
+		Readability:    0.15,
+		TrendRelevance: 0.10,
+	}
+
+	got, warnings := reconcileWeights(weights, map[string]bool{"images": true})
+
+	if got.Visual != 0 {
+		t.Errorf("Visual = %v, 跳过 images 后应为0", got.Visual)
+	}
+	if sum := weightSum(got); math.Abs(sum-1) > 1e-9 {
+		t.Errorf("权重总和 = %v, want 1", sum)
+	}
+	// 其余维度按比例放大，相对大小不变
+	if want := 0.25 / 0.85; math.Abs(got.ContentQuality-want) > 1e-9 {
+		t.Errorf("ContentQuality = %v, want %v", got.ContentQuality, want)
+	}
+	if math.Abs(got.Engagement/got.TrendRelevance-2) > 1e-9 {
+		t.Errorf("Engagement/TrendRelevance = %v, want 2", got.Engagement/got.TrendRelevance)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "images") || !strings.Contains(warnings[0], "已分配给其余维度") {
+		t.Errorf("warnings = %v, want 一条关于 images 重新分配的警告", warnings)
+	}
+}
+
+func TestReconcileWeightsWarnsOnZeroWeightStage(t *testing.T) {
+	weights := models.ScoreWeights{ContentQuality: 0.5, Engagement: 0.5}
+
+	got, warnings := reconcileWeights(weights, nil)
+
+	if got != weights {
+		t.Errorf("没有跳过阶段时权重不应变化: %+v", got)
+	}
+	// images、keywords、readability 仍在运行但维度权重为0
+	if len(warnings) != 3 {
+		t.Errorf("warnings = %v, want 3 条", warnings)
+	}
+}
@@ -0,0 +1,53 @@
+// internal/analyzer/strictness.go
+package analyzer
+
+import (
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// strictnessFactors 各评分严格度对应的门槛系数：维度得分除以该系数，
+// 建议的触发门槛乘以该系数，系数越大门槛越高
+var strictnessFactors = map[string]float64{
+	"lenient":  0.85,
+	"balanced": 1.0,
+	"strict":   1.15,
+}
+
+// 建议的触发门槛（balanced 下的值）
+const (
+	titleSuggestionBar       = 70.0 // 标题得分低于该值时建议优化标题
+	ctaStrengthSuggestionBar = 0.4  // 行动召唤强度低于该值时建议改用祈使句
+	fleschSuggestionBar      = 50.0 // 可读性得分低于该值时建议简化表达
+)
+
+func loadStrictness(preset string) float64 {
+	if factor, ok := strictnessFactors[preset]; ok {
+		return factor
+	}
+	return 1.0
+}
+
+// bar 按严格度调整建议的触发门槛
+func (ca *ContentAnalyzer) bar(threshold float64) float64 {
+	return threshold * ca.strictness
+}
+
+// applyStrictness 按严格度调整各维度得分，限制在0-100
+func (ca *ContentAnalyzer) applyStrictness(breakdown *models.ScoreBreakdown) {
+	if ca.strictness == 1.0 {
+		return
+	}
+
+	for _, score := range []*float64{
+		&breakdown.ContentQuality,
+		&breakdown.Engagement,
+		&breakdown.Visual,
+		&breakdown.Title,
+		&breakdown.Readability,
+		&breakdown.TrendRelevance,
+	} {
+		*score = math.Max(0, math.Min(*score/ca.strictness, 100))
+	}
+}
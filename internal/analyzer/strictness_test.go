@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestStrictnessPresetsOrdered(t *testing.T) {
+	var prevTotal float64
+	var prevSuggestions, lenientSuggestions int
+	for i, preset := range []string{"lenient", "balanced", "strict"} {
+		ca := newTestAnalyzer(t, func(cfg *config.Config) {
+			cfg.Analysis.Strictness = preset
+		})
+		results := ca.AnalyzeAll([]models.Content{sampleContent("post")}, 1, nil)
+		if len(results) != 1 {
+			t.Fatalf("%s: 分析结果数 = %d, want 1", preset, len(results))
+		}
+		result := results[0]
+		suggestions := len(result.Suggestions) + len(result.Minor)
+
+		if i > 0 {
+			// 同一内容从宽松到严格，总分递减、建议不减少
+			if result.Score.Total >= prevTotal {
+				t.Errorf("%s: total = %.2f, want 低于上一档的 %.2f", preset, result.Score.Total, prevTotal)
+			}
+			if suggestions < prevSuggestions {
+				t.Errorf("%s: 建议数 = %d, want 不少于上一档的 %d", preset, suggestions, prevSuggestions)
+			}
+		}
+		if preset == "lenient" {
+			lenientSuggestions = suggestions
+		}
+		prevTotal, prevSuggestions = result.Score.Total, suggestions
+	}
+	if prevSuggestions <= lenientSuggestions {
+		t.Errorf("strict 建议数 = %d, want 多于 lenient 的 %d", prevSuggestions, lenientSuggestions)
+	}
+}
+
+func TestStrictnessRaisesSuggestionBar(t *testing.T) {
+	for _, tt := range []struct {
+		preset string
+		want   float64
+	}{
+		{"lenient", 59.5},
+		{"balanced", 70},
+		{"strict", 80.5},
+		{"unknown", 70},
+	} {
+		ca := newTestAnalyzer(t, func(cfg *config.Config) {
+			cfg.Analysis.Strictness = tt.preset
+		})
+		if got := ca.bar(titleSuggestionBar); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("%s: bar(%v) = %v, want %v", tt.preset, titleSuggestionBar, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// internal/analyzer/tfidf.go
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// KeywordExtractor 按整个语料计算关键词的TF-IDF。分两步使用：先对每篇内容调用 AddDocument
+// 统计文档频率，再通过 Finalize（或 ContentAnalyzer.SetKeywordExtractor 后由 Analyze 自动调用）
+// 按逆文档频率重新计算每篇内容的关键词相关度
+type KeywordExtractor struct {
+	ca   *ContentAnalyzer
+	docs int
+	df   map[string]int // 出现过该词的文档数
+}
+
+// NewKeywordExtractor 创建使用该分析器分词规则和停用词的关键词提取器
+func (ca *ContentAnalyzer) NewKeywordExtractor() *KeywordExtractor {
+	return &KeywordExtractor{ca: ca, df: make(map[string]int)}
+}
+
+// AddDocument 第一步：统计一篇内容中出现的词，每篇内容只调用一次
+func (e *KeywordExtractor) AddDocument(text string) {
+	e.docs++
+	seen := make(map[string]bool)
+	for _, group := range groupByLanguage(text) {
+		counts, _ := e.ca.countTerms(group.lang, group.text)
+		for word := range counts {
+			if !seen[word] {
+				seen[word] = true
+				e.df[word]++
+			}
+		}
+	}
+}
+
+// Finalize 第二步：相关度（词频）乘以归一化的逆文档频率，并按新的相关度重新排序。
+// 只在本篇出现的词保持原相关度，每篇都出现的词降为0；语料少于两篇时无法区分，原样返回
+func (e *KeywordExtractor) Finalize(keywords []models.Keyword) []models.Keyword {
+	if e.docs < 2 || len(keywords) == 0 {
+		return keywords
+	}
+
+	finalized := make([]models.Keyword, len(keywords))
+	copy(finalized, keywords)
+	for i := range finalized {
+		finalized[i].Relevance *= e.idf(finalized[i].Word)
+	}
+
+	sort.SliceStable(finalized, func(i, j int) bool {
+		if finalized[i].Relevance != finalized[j].Relevance {
+			return finalized[i].Relevance > finalized[j].Relevance
+		}
+		return finalized[i].Frequency > finalized[j].Frequency
+	})
+	return finalized
+}
+
+// idf 平滑的逆文档频率 log((1+N)/(1+df))，除以只出现在一篇时的值归一化到0-1。
+// 统计时没有的词（如图片OCR文字中的词）按只出现在本篇计
+func (e *KeywordExtractor) idf(word string) float64 {
+	df := e.df[word]
+	if df < 1 {
+		df = 1
+	}
+	n := float64(e.docs)
+	return math.Log((1+n)/(1+float64(df))) / math.Log((1+n)/2)
+}
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestTFIDFCommonWordScoresLow(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	docs := []string{
+		"camping tent stove. camping tent lantern camping.",
+		"camping kayak paddle. camping kayak river.",
+		"camping hiking boots. camping hiking trail.",
+	}
+
+	extractor := ca.NewKeywordExtractor()
+	for _, doc := range docs {
+		extractor.AddDocument(doc)
+	}
+
+	relevance := make(map[string]float64)
+	for _, keyword := range extractor.Finalize(ca.extractKeywords(docs[0])) {
+		relevance[keyword.Word] = keyword.Relevance
+	}
+	// camping 在每篇都出现，词频最高也降为0；tent 只在本篇出现，保持原相关度
+	if relevance["camping"] != 0 {
+		t.Errorf("camping Relevance = %v, want 0", relevance["camping"])
+	}
+	if relevance["tent"] <= relevance["camping"] {
+		t.Errorf("tent Relevance = %v, want 高于 camping", relevance["tent"])
+	}
+
+	ca.SetKeywordExtractor(extractor)
+	results := ca.AnalyzeAll([]models.Content{{ID: "a", Title: "Weekend", Text: docs[0]}}, 1, nil)
+	if len(results) != 1 || len(results[0].Keywords) == 0 {
+		t.Fatalf("分析结果 = %+v, want 关键词", results)
+	}
+	if top := results[0].Keywords[0].Word; top == "camping" {
+		t.Errorf("设置 KeywordExtractor 后最相关的关键词 = %s, want 不是每篇都有的词", top)
+	}
+}
+
+func TestTFIDFSingleDocumentUnchanged(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	doc := "camping tent stove. camping lantern camping."
+	extractor := ca.NewKeywordExtractor()
+	extractor.AddDocument(doc)
+
+	keywords := ca.extractKeywords(doc)
+	finalized := extractor.Finalize(keywords)
+	for i := range keywords {
+		if finalized[i] != keywords[i] {
+			t.Errorf("单篇语料 Finalize()[%d] = %+v, want 原样返回 %+v", i, finalized[i], keywords[i])
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// internal/analyzer/title_limit.go
+package analyzer
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// contentPlatform 内容的发布平台，未指定时使用 analysis.target_platform
+func (ca *ContentAnalyzer) contentPlatform(platform string) string {
+	if platform == "" {
+		return ca.config.Analysis.TargetPlatform
+	}
+	return platform
+}
+
+// applyTitleLimit 检查标题是否超出平台的显示上限
+func (ca *ContentAnalyzer) applyTitleLimit(analysis *models.TitleAnalysis, title, platform string) {
+	limit, ok := ca.config.Analysis.TitleLimits[platform]
+	if !ok {
+		return
+	}
+
+	analysis.Platform = platform
+	analysis.MaxLength = limit.MaxLength
+	analysis.Unit = limit.Unit
+	if limit.Unit == "width" {
+		analysis.DisplayLength = displayWidth(title)
+	} else {
+		analysis.DisplayLength = utf8.RuneCountInString(title)
+	}
+	analysis.Truncated = analysis.DisplayLength > limit.MaxLength
+}
+
+// displayWidth 显示宽度：中日韩文字、全角符号和emoji计2，其余计1
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if isWideRune(r) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+func isWideRune(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r) ||
+		(r >= 0x3000 && r <= 0x303F) || // 中文标点
+		(r >= 0xFF01 && r <= 0xFF60) || // 全角字符
+		(r >= 0x1F300 && r <= 0x1FAFF) // emoji
+}
+
+func unitLabel(unit string) string {
+	if unit == "width" {
+		return "个显示宽度"
+	}
+	return "字"
+}
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestTitleLimit(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+	longChinese := strings.Repeat("露营", 13) // 26字
+	ascii := strings.Repeat("a", 40)
+
+	tests := []struct {
+		name          string
+		title         string
+		platform      string
+		wantLength    int
+		wantTruncated bool
+	}{
+		{name: "小红书超长", title: longChinese, platform: "xiaohongshu", wantLength: 26, wantTruncated: true},
+		{name: "公众号未超", title: longChinese, platform: "wechat", wantLength: 26},
+		{name: "按显示宽度中文计2", title: longChinese + longChinese, platform: "youtube", wantLength: 104, wantTruncated: true},
+		{name: "按显示宽度英文计1", title: ascii, platform: "youtube", wantLength: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var analysis models.TitleAnalysis
+			ca.applyTitleLimit(&analysis, tt.title, tt.platform)
+
+			if analysis.Platform != tt.platform || analysis.DisplayLength != tt.wantLength || analysis.Truncated != tt.wantTruncated {
+				t.Errorf("TitleAnalysis = %s %d truncated=%v, want %s %d truncated=%v",
+					analysis.Platform, analysis.DisplayLength, analysis.Truncated, tt.platform, tt.wantLength, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestOverlongTitleFlagged(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	content := sampleContent("long-title")
+	content.Title = "周末露营装备清单：新手第一次去露营也能轻松上手的十件必备好物"
+	content.Platform = "wechat"
+	fits, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	content.Platform = "xiaohongshu"
+	truncated, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if !truncated.TextAnalysis.TitleAnalysis.Truncated {
+		t.Fatalf("标题 %d 字在小红书应被截断", truncated.TextAnalysis.TitleAnalysis.DisplayLength)
+	}
+	if truncated.Score.Breakdown.Title >= fits.Score.Breakdown.Title {
+		t.Errorf("截断的标题得分 %.1f 应低于未截断的 %.1f", truncated.Score.Breakdown.Title, fits.Score.Breakdown.Title)
+	}
+
+	found := false
+	for _, s := range append(truncated.Suggestions, truncated.Minor...) {
+		if s.Factor == factorTitleLength && strings.Contains(s.Current, "xiaohongshu") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("标题被截断时应生成缩短标题的建议")
+	}
+
+	content.Platform = "myspace"
+	unknown, err := ca.Analyze(content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if !containsSubstring(unknown.Warnings, "myspace") {
+		t.Errorf("Warnings = %v, 未知平台应给出警告", unknown.Warnings)
+	}
+}
@@ -0,0 +1,140 @@
+// internal/analyzer/variety.go
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 中英文句末标点和换行，小数点前后都是数字时不会出现在句子边界上
+var varietySentencePattern = regexp.MustCompile(`[。！？!?；;\n]+|\.+(\s+|$)`)
+
+// analyzeVariety 统计句子和段落长度的变化程度（变异系数 = 标准差/平均值），
+// 长度按 textUnits 计算，中英文可比；Markdown 标题行不计入
+func (ca *ContentAnalyzer) analyzeVariety(text string) models.StructureVariety {
+	cfg := ca.config.Analysis.Variety
+
+	var sentences, paragraphs []float64
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		var body []string
+		for _, line := range strings.Split(paragraph, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				body = append(body, line)
+			}
+		}
+		if len(body) == 0 {
+			continue
+		}
+
+		joined := strings.Join(body, "\n")
+		if units := textUnits(joined); units > 0 {
+			paragraphs = append(paragraphs, float64(units))
+		}
+		for _, sentence := range varietySentencePattern.Split(joined, -1) {
+			if units := textUnits(sentence); units > 0 {
+				sentences = append(sentences, float64(units))
+			}
+		}
+	}
+
+	variety := models.StructureVariety{
+		Sentences:          len(sentences),
+		Paragraphs:         len(paragraphs),
+		SentenceLengthSD:   round2(stdDev(sentences)),
+		ParagraphLengthSD:  round2(stdDev(paragraphs)),
+		SentenceVariation:  round2(variation(sentences)),
+		ParagraphVariation: round2(variation(paragraphs)),
+	}
+	variety.MonotonousSentences = len(sentences) >= cfg.MinSentences && variety.SentenceVariation < cfg.MinSentenceVariation
+	variety.MonotonousParagraphs = len(paragraphs) >= cfg.MinParagraphs && variety.ParagraphVariation < cfg.MinParagraphVariation
+	return variety
+}
+
+// varietyPenalty 句子或段落长度单调时各扣5分
+func varietyPenalty(variety models.StructureVariety) float64 {
+	penalty := 0.0
+	if variety.MonotonousSentences {
+		penalty += 5
+	}
+	if variety.MonotonousParagraphs {
+		penalty += 5
+	}
+	return penalty
+}
+
+// varietySuggestion 句子或段落长度过于单一时的建议
+func (ca *ContentAnalyzer) varietySuggestion(variety models.StructureVariety) (models.Suggestion, bool) {
+	cfg := ca.config.Analysis.Variety
+
+	var parts []string
+	gap, span := 0.0, 0.0
+	if variety.MonotonousSentences {
+		parts = append(parts, "句子")
+		gap += cfg.MinSentenceVariation - variety.SentenceVariation
+		span += cfg.MinSentenceVariation
+	}
+	if variety.MonotonousParagraphs {
+		parts = append(parts, "段落")
+		gap += cfg.MinParagraphVariation - variety.ParagraphVariation
+		span += cfg.MinParagraphVariation
+	}
+	if len(parts) == 0 {
+		return models.Suggestion{}, false
+	}
+
+	subject := strings.Join(parts, "和")
+	return models.Suggestion{
+		Type:        "readability",
+		Priority:    "low",
+		Current:     fmt.Sprintf("%s长度过于一致，读起来单调", subject),
+		Recommended: fmt.Sprintf("长短%s交替使用：用短句强调重点，用长句展开细节", subject),
+		Reasoning:   fmt.Sprintf("句子长度变化系数%.2f，段落长度变化系数%.2f，节奏缺少起伏容易让读者失去注意力", variety.SentenceVariation, variety.ParagraphVariation),
+		Impact:      "预计可提升阅读体验和完读率",
+		Factor:      factorVariety,
+		Confidence:  signalConfidence(gap, span),
+	}, true
+}
+
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// variation 变异系数，不受语言和整体长短影响
+func variation(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if mean == 0 {
+		return 0
+	}
+	return stdDev(values) / mean
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
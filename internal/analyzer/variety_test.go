@@ -0,0 +1,80 @@
+package analyzer
+
+import "testing"
+
+func TestAnalyzeVariety(t *testing.T) {
+	ca := newTestAnalyzer(t, nil)
+
+	tests := []struct {
+		name                string
+		text                string
+		wantSentences       int
+		wantParagraphs      int
+		wantMonoSentences   bool
+		wantMonoParagraphs  bool
+		wantSuggestionTopic string
+	}{
+		{
+			name: "uniform english",
+			text: "We packed the tent last night. We drove to the lake early.\n\n" +
+				"The trail was quiet and cool. The birds sang in the trees.\n\n" +
+				"We cooked a simple hot dinner. We slept well until the dawn.",
+			wantSentences: 6, wantParagraphs: 3,
+			wantMonoSentences: true, wantMonoParagraphs: true,
+			wantSuggestionTopic: "句子和段落长度过于一致，读起来单调",
+		},
+		{
+			name: "uniform chinese",
+			text: "我们昨晚收好帐篷。今天一早开车出发。\n\n" +
+				"山路很安静凉爽。鸟儿在树上歌唱。\n\n" +
+				"晚饭做得很简单。一觉睡到了天亮。",
+			wantSentences: 6, wantParagraphs: 3,
+			wantMonoSentences: true, wantMonoParagraphs: true,
+			wantSuggestionTopic: "句子和段落长度过于一致，读起来单调",
+		},
+		{
+			name: "varied",
+			text: "Go. We packed the tent, the stove and two heavy sleeping bags into the car last night.\n\n" +
+				"The trail was quiet.\n\n" +
+				"We cooked a simple dinner over the fire while the sun went down behind the hills. Then we slept. It rained.",
+			wantSentences: 6, wantParagraphs: 3,
+		},
+		{
+			// 句子和段落数不足时不检查
+			name:          "too short",
+			text:          "We packed the tent. We drove to the lake.",
+			wantSentences: 2, wantParagraphs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variety := ca.analyzeVariety(tt.text)
+			if variety.Sentences != tt.wantSentences || variety.Paragraphs != tt.wantParagraphs {
+				t.Errorf("Sentences/Paragraphs = %d/%d, want %d/%d", variety.Sentences, variety.Paragraphs, tt.wantSentences, tt.wantParagraphs)
+			}
+			if variety.MonotonousSentences != tt.wantMonoSentences || variety.MonotonousParagraphs != tt.wantMonoParagraphs {
+				t.Errorf("MonotonousSentences/Paragraphs = %v/%v, want %v/%v (variation %.2f/%.2f)",
+					variety.MonotonousSentences, variety.MonotonousParagraphs, tt.wantMonoSentences, tt.wantMonoParagraphs,
+					variety.SentenceVariation, variety.ParagraphVariation)
+			}
+
+			suggestion, ok := ca.varietySuggestion(variety)
+			if ok != (tt.wantSuggestionTopic != "") {
+				t.Fatalf("varietySuggestion() ok = %v, want %v", ok, tt.wantSuggestionTopic != "")
+			}
+			if ok && suggestion.Current != tt.wantSuggestionTopic {
+				t.Errorf("Current = %q, want %q", suggestion.Current, tt.wantSuggestionTopic)
+			}
+			wantPenalty := 0.0
+			if tt.wantMonoSentences {
+				wantPenalty += 5
+			}
+			if tt.wantMonoParagraphs {
+				wantPenalty += 5
+			}
+			if got := varietyPenalty(variety); got != wantPenalty {
+				t.Errorf("varietyPenalty() = %v, want %v", got, wantPenalty)
+			}
+		})
+	}
+}
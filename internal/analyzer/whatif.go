@@ -0,0 +1,133 @@
+// internal/analyzer/whatif.go
+package analyzer
+
+import (
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 模拟补充配图时假设的单张图片得分
+const simulatedImageScore = 70.0
+
+// 模拟提升可读性时的目标分数，与可读性建议中的目标一致
+const simulatedFleschScore = 60.0
+
+// fixSimulations 假设采纳某类建议后分析结果的变化。只替换字段，不修改切片元素，避免影响原结果
+var fixSimulations = map[string]func(*models.AnalysisResult){
+	factorTitle: func(r *models.AnalysisResult) {
+		title := r.TextAnalysis.TitleAnalysis
+		title.HasNumbers = true
+		if title.Length < 10 || title.Length > 30 {
+			title.Length = 20
+		}
+		r.TextAnalysis.TitleAnalysis = title
+	},
+	factorTitleLength: func(r *models.AnalysisResult) {
+		r.TextAnalysis.TitleAnalysis.Truncated = false
+	},
+	factorHashtags: func(r *models.AnalysisResult) {
+		r.TextAnalysis.TitleAnalysis.HashtagStatus = hashtagsOK
+	},
+	factorIntro: func(r *models.AnalysisResult) {
+		r.TextAnalysis.ContentStructure.HasIntro = true
+	},
+	factorHeadings: func(r *models.AnalysisResult) {
+		r.TextAnalysis.ContentStructure.Headings.Issues = nil
+	},
+	factorCTA: func(r *models.AnalysisResult) {
+		// 在结尾加入一个强CTA
+		r.TextAnalysis.CallToAction = []string{"点赞收藏"}
+		r.TextAnalysis.CTAAnalysis = models.CTAAnalysis{Count: 1, HasEndCTA: true, Strength: 1}
+	},
+	factorEndCTA: func(r *models.AnalysisResult) {
+		cta := r.TextAnalysis.CTAAnalysis
+		if !cta.HasEndCTA {
+			cta.HasEndCTA = true
+			cta.Strength = math.Min(cta.Strength+0.2, 1)
+		}
+		r.TextAnalysis.CTAAnalysis = cta
+	},
+	factorStrongCTA: func(r *models.AnalysisResult) {
+		cta := r.TextAnalysis.CTAAnalysis
+		cta.Strength = 0.8
+		if cta.HasEndCTA {
+			cta.Strength += 0.2
+		}
+		r.TextAnalysis.CTAAnalysis = cta
+	},
+	factorReadability: func(r *models.AnalysisResult) {
+		r.Readability.FleschScore = math.Max(r.Readability.FleschScore, simulatedFleschScore)
+	},
+	factorImages: func(r *models.AnalysisResult) {
+		r.ImageAnalysis = []models.ImageAnalysis{{Score: simulatedImageScore}}
+	},
+	factorVariety: func(r *models.AnalysisResult) {
+		r.Readability.Variety.MonotonousSentences = false
+		r.Readability.Variety.MonotonousParagraphs = false
+	},
+	factorKeywordDensity: func(r *models.AnalysisResult) {
+		if density := r.TextAnalysis.KeywordDensity; density != nil {
+			adjusted := *density
+			adjusted.Status = densityWithin
+			r.TextAnalysis.KeywordDensity = &adjusted
+		}
+	},
+	factorLinkAnchors: func(r *models.AnalysisResult) {
+		links := make([]models.Link, len(r.TextAnalysis.Links))
+		for i, link := range r.TextAnalysis.Links {
+			link.Descriptive = true
+			link.Issue = ""
+			links[i] = link
+		}
+		r.TextAnalysis.Links = links
+	},
+	factorEmoji: func(r *models.AnalysisResult) {
+		r.TextAnalysis.EmojiOveruse = false
+	},
+	factorWriting: func(r *models.AnalysisResult) {
+		r.TextAnalysis.WritingIssues = nil
+	},
+	factorImageText: func(r *models.AnalysisResult) {
+		images := make([]models.ImageAnalysis, len(r.ImageAnalysis))
+		for i, img := range r.ImageAnalysis {
+			if img.TextOverlay != nil {
+				overlay := *img.TextOverlay
+				overlay.SmallLines, overlay.LowContrastLines, overlay.Legibility = 0, 0, 100
+				overlay.Score = imageLegibilityWeight*overlay.Legibility + (1-imageLegibilityWeight)*overlay.Readability
+				img.TextOverlay = &overlay
+			}
+			images[i] = img
+		}
+		r.ImageAnalysis = images
+	},
+	factorAspectRatio: func(r *models.AnalysisResult) {
+		images := make([]models.ImageAnalysis, len(r.ImageAnalysis))
+		for i, img := range r.ImageAnalysis {
+			if img.AspectRatio != nil && img.AspectRatio.Matched == "" {
+				check := *img.AspectRatio
+				check.Matched = check.Nearest
+				img.AspectRatio = &check
+			}
+			images[i] = img
+		}
+		r.ImageAnalysis = images
+	},
+}
+
+// projectGains 逐条模拟采纳建议后的总分，写入 ProjectedGain
+func (ca *ContentAnalyzer) projectGains(result models.AnalysisResult, suggestions []models.Suggestion, weights *models.ScoreWeights) {
+	base := ca.calculateOverallScore(result, weights).Total
+
+	for i := range suggestions {
+		simulate, ok := fixSimulations[suggestions[i].Factor]
+		if !ok {
+			continue
+		}
+
+		fixed := result
+		simulate(&fixed)
+		gain := ca.calculateOverallScore(fixed, weights).Total - base
+		suggestions[i].ProjectedGain = math.Round(gain*10) / 10
+	}
+}
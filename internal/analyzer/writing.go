@@ -0,0 +1,214 @@
+// internal/analyzer/writing.go
+package analyzer
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// 内置中文错别字表，每行 "错误|正确"
+//
+//go:embed dict/zh_confusables.txt
+var builtinConfusables string
+
+const (
+	// 每处拼写、语法问题扣的内容质量分及扣分上限
+	writingIssuePenalty    = 1.0
+	maxWritingIssuePenalty = 10.0
+)
+
+// 几乎不会叠用的虚词和代词，连续出现两次以上视为多打了字。
+// "了"、"在"、"是" 等常与前后字构成正常的叠字（"为了了解"、"现在在家"），不检查
+const repeatableChars = "的与就也都被给而我你他她它这那很"
+
+// 含叠字的正确写法，其中的叠字不报告
+var repeatedCharExceptions = []string{"的的确确", "目的的", "标的的"}
+
+// confusable 一条错别字及正确写法
+type confusable struct {
+	wrong, right string
+}
+
+// writingChecker 按 analysis.writing_check 检查拼写和语法，都未配置时不检查
+type writingChecker struct {
+	languageTool *services.LanguageTool // 未配置 languagetool_url 时为nil
+	chinese      bool
+	confusables  []confusable
+}
+
+// loadWritingChecker 创建 LanguageTool 客户端并加载内置和追加的错别字表，追加的词表读取失败时记录日志并只使用内置词表
+func loadWritingChecker(cfg config.WritingCheckConfig) writingChecker {
+	checker := writingChecker{
+		languageTool: services.NewLanguageTool(cfg),
+		chinese:      cfg.Chinese,
+	}
+	if !cfg.Chinese {
+		return checker
+	}
+
+	checker.addConfusables(builtinConfusables)
+	if cfg.Confusables != "" {
+		data, err := os.ReadFile(cfg.Confusables)
+		if err != nil {
+			log.Printf("读取错别字表 %s 失败，只使用内置词表: %v", cfg.Confusables, err)
+		} else {
+			checker.addConfusables(strings.TrimPrefix(string(data), "\uFEFF"))
+		}
+	}
+	return checker
+}
+
+func (w *writingChecker) addConfusables(data string) {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		wrong, right, ok := strings.Cut(line, "|")
+		wrong, right = strings.TrimSpace(wrong), strings.TrimSpace(right)
+		if !ok || wrong == "" || right == "" {
+			continue
+		}
+		w.confusables = append(w.confusables, confusable{wrong: wrong, right: right})
+	}
+}
+
+// checkWriting 检查正文。英文和中英混合内容交给 LanguageTool（失败时记录日志并跳过），
+// 含汉字的内容检查重复字和错别字。结果按位置排列
+func (ca *ContentAnalyzer) checkWriting(text, lang string) []models.WritingIssue {
+	w := ca.writing
+	var issues []models.WritingIssue
+
+	if w.languageTool != nil && (lang == "en" || lang == "mixed") {
+		found, err := w.languageTool.Check(context.Background(), text)
+		if err != nil {
+			log.Printf("拼写和语法检查失败，已跳过: %v", err)
+		}
+		for _, issue := range found {
+			// 英文规则会把中文片段当作拼写错误
+			if !containsHan(issue.Text) {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	if w.chinese && containsHan(text) {
+		issues = append(issues, repeatedCharIssues(text)...)
+		issues = append(issues, w.confusableIssues(text)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Offset < issues[j].Offset })
+	return issues
+}
+
+// repeatedCharIssues 找出连续重复的虚词和代词，如 "我的的包"
+func repeatedCharIssues(text string) []models.WritingIssue {
+	runes := []rune(text)
+	excepted := make([]bool, len(runes))
+	for _, phrase := range repeatedCharExceptions {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], phrase)
+			if i < 0 {
+				break
+			}
+			start := utf8.RuneCountInString(text[:offset+i])
+			for k := 0; k < utf8.RuneCountInString(phrase); k++ {
+				excepted[start+k] = true
+			}
+			offset += i + len(phrase)
+		}
+	}
+
+	var issues []models.WritingIssue
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		if j-i > 1 && !excepted[i] && strings.ContainsRune(repeatableChars, runes[i]) {
+			char := string(runes[i])
+			issues = append(issues, models.WritingIssue{
+				Offset:      i,
+				Length:      j - i,
+				Text:        string(runes[i:j]),
+				Message:     fmt.Sprintf("「%s」重复", char),
+				Suggestions: []string{char},
+				Rule:        "repeated_char",
+				Source:      "builtin",
+			})
+		}
+		i = j
+	}
+	return issues
+}
+
+// confusableIssues 找出错别字表中的错误写法
+func (w writingChecker) confusableIssues(text string) []models.WritingIssue {
+	var issues []models.WritingIssue
+	for _, c := range w.confusables {
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], c.wrong)
+			if i < 0 {
+				break
+			}
+			issues = append(issues, models.WritingIssue{
+				Offset:      utf8.RuneCountInString(text[:offset+i]),
+				Length:      utf8.RuneCountInString(c.wrong),
+				Text:        c.wrong,
+				Message:     fmt.Sprintf("「%s」应为「%s」", c.wrong, c.right),
+				Suggestions: []string{c.right},
+				Rule:        "confusable",
+				Source:      "builtin",
+			})
+			offset += i + len(c.wrong)
+		}
+	}
+	return issues
+}
+
+// writingPenalty 拼写和语法问题对内容质量的扣分
+func writingPenalty(issues []models.WritingIssue) float64 {
+	return math.Min(float64(len(issues))*writingIssuePenalty, maxWritingIssuePenalty)
+}
+
+// writingSuggestion 有拼写、语法或错别字问题时的建议，列出前几处问题及修改方式
+func writingSuggestion(issues []models.WritingIssue) (models.Suggestion, bool) {
+	if len(issues) == 0 {
+		return models.Suggestion{}, false
+	}
+
+	var examples []string
+	for _, issue := range issues {
+		example := fmt.Sprintf("第%d字「%s」: %s", issue.Offset+1, issue.Text, issue.Message)
+		if len(issue.Suggestions) > 0 {
+			example += fmt.Sprintf("（建议: %s）", strings.Join(issue.Suggestions, " / "))
+		}
+		examples = append(examples, example)
+		if len(examples) == 3 {
+			break
+		}
+	}
+
+	return models.Suggestion{
+		Type:        "writing",
+		Priority:    "medium",
+		Current:     fmt.Sprintf("正文有%d处拼写、语法或错别字问题", len(issues)),
+		Recommended: "发布前逐一修正，详见 text_analysis.writing_issues",
+		Reasoning:   "错别字和语法错误会让读者觉得内容不够用心，降低可信度",
+		Examples:    examples,
+		Impact:      "提升内容的专业度和可信度",
+		Factor:      factorWriting,
+		Confidence:  signalConfidence(float64(len(issues)), 5),
+	}, true
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestChineseWritingIssues(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.WritingCheck = config.WritingCheckConfig{Chinese: true, Confusables: writeWordList(t, "# 追加\n帐蓬|帐篷\n")}
+	})
+
+	// 目的的、现在在 是正常写法
+	issues := ca.checkWriting("大家再接再励，我的的帐蓬很好。这次出行的目的的确达到了，现在在家休息。", "zh")
+
+	want := []struct {
+		offset int
+		text   string
+		rule   string
+		fix    string
+	}{
+		{2, "再接再励", "confusable", "再接再厉"},
+		{8, "的的", "repeated_char", "的"},
+		{10, "帐蓬", "confusable", "帐篷"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("checkWriting() = %+v, want %d 处问题", issues, len(want))
+	}
+	for i, w := range want {
+		issue := issues[i]
+		if issue.Offset != w.offset || issue.Text != w.text || issue.Rule != w.rule || len(issue.Suggestions) != 1 || issue.Suggestions[0] != w.fix {
+			t.Errorf("issues[%d] = %+v, want 第%d字 %s (%s → %s)", i, issue, w.offset, w.text, w.rule, w.fix)
+		}
+	}
+}
+
+func TestWritingCheckDropsChineseLanguageToolMatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"matches": []map[string]interface{}{
+				{"message": "Possible spelling mistake found.", "offset": 0, "length": 2, "rule": map[string]string{"id": "MORFOLOGIK_RULE_EN_US"}},
+				{"message": "Possible spelling mistake found.", "offset": 11, "length": 7, "replacements": []map[string]string{{"value": "receive"}}, "rule": map[string]string{"id": "MORFOLOGIK_RULE_EN_US"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.WritingCheck = config.WritingCheckConfig{LanguageToolURL: server.URL, Language: "en-US", Timeout: 5}
+	})
+	issues := ca.checkWriting("露营 We will recieve the tent.", "mixed")
+	if len(issues) != 1 || issues[0].Text != "recieve" {
+		t.Fatalf("checkWriting() = %+v, want 只保留 recieve", issues)
+	}
+
+	// 中文内容不发给 LanguageTool
+	if issues := ca.checkWriting("全是中文的内容", "zh"); len(issues) != 0 {
+		t.Errorf("中文内容 checkWriting() = %+v, want 不检查", issues)
+	}
+}
+
+func TestWritingPenaltyCapped(t *testing.T) {
+	ca := newTestAnalyzer(t, func(cfg *config.Config) {
+		cfg.Analysis.WritingCheck = config.WritingCheckConfig{Chinese: true}
+	})
+	issues := ca.checkWriting("我我的的你你他他也也都都被被给给就就很很这这那那", "zh")
+	if len(issues) != 12 {
+		t.Fatalf("checkWriting() 返回 %d 处, want 12", len(issues))
+	}
+	if got := writingPenalty(issues); got != maxWritingIssuePenalty {
+		t.Errorf("writingPenalty() = %v, want 上限 %v", got, maxWritingIssuePenalty)
+	}
+	if got := writingPenalty(issues[:3]); got != 3 {
+		t.Errorf("3处问题 writingPenalty() = %v, want 3", got)
+	}
+}
@@ -3,62 +3,566 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
-	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/expression"
 )
 
 type Config struct {
-	ContentDir string    `yaml:"content_dir"`
-	OutputDir  string    `yaml:"output_dir"`
-	AI         AIConfig  `yaml:"ai"`
-	Image      ImageConfig `yaml:"image"`
+	ContentDir string         `yaml:"content_dir"`
+	OutputDir  string         `yaml:"output_dir"`
+	AI         AIConfig       `yaml:"ai"`
+	Image      ImageConfig    `yaml:"image"`
 	Analysis   AnalysisConfig `yaml:"analysis"`
+	Report     ReportConfig   `yaml:"report"`
+	Server     ServerConfig   `yaml:"server"`
+	Storage    StorageConfig  `yaml:"storage"`
+}
+
+// StorageConfig 历史结果存储
+type StorageConfig struct {
+	DBPath string `yaml:"db_path"` // SQLite 数据库文件，每次运行的结果追加写入；为空时不保存
+}
+
+// ServerConfig serve 子命令的 HTTP 服务
+type ServerConfig struct {
+	Addr         string `yaml:"addr"`           // 监听地址，如 ":8080"、"127.0.0.1:8080"
+	MaxBodyBytes int64  `yaml:"max_body_bytes"` // POST /analyze 请求体上限
+
+	// AllowRemoteImages 为 false 时 serve 忽略 image.remote_images，一律跳过远程图片，避免服务端代为请求任意地址
+	AllowRemoteImages bool `yaml:"allow_remote_images"`
+
+	// ContentRoot 请求中图片的本地 path 必须位于该目录内，相对路径也相对它解析；为空时只接受 url 和 data URI
+	ContentRoot string `yaml:"content_root"`
 }
 
 type AIConfig struct {
-	Provider string `yaml:"provider"` // openai, claude, local
+	Provider string `yaml:"provider"` // openai, claude, gemini, ollama, local
 	APIKey   string `yaml:"api_key"`
 	BaseURL  string `yaml:"base_url,omitempty"`
 	Model    string `yaml:"model"`
+
+	VisionModel string `yaml:"vision_model"` // 生成图片替代文本等带图片的请求使用的模型，为空时使用 model
+
+	RequestsPerMinute int `yaml:"requests_per_minute"` // 每分钟请求上限，0表示不限制
+	MaxConcurrency    int `yaml:"max_concurrency"`     // 同时进行中的请求上限，0表示不限制
+	MaxRetries        int `yaml:"max_retries"`         // 限流、服务端错误或网络错误时的最大重试次数，0表示不重试
+
+	Cache CacheConfig `yaml:"cache"` // 按 提供方+模型+提示词 缓存成功的响应，重复分析时不再请求
+
+	// Pricing 各模型每1000个token的价格，用于估算报告中的AI费用；未列出的模型只统计用量
+	Pricing map[string]TokenPrice `yaml:"pricing"`
+}
+
+// TokenPrice 每1000个token的价格
+type TokenPrice struct {
+	Prompt     float64 `yaml:"prompt"`     // 输入（提示词）
+	Completion float64 `yaml:"completion"` // 输出（生成内容）
 }
 
 type ImageConfig struct {
-	MaxSize      int64    `yaml:"max_size"`      // 最大文件大小（字节）
-	SupportedExt []string `yaml:"supported_ext"` // 支持的扩展名
-	EnableOCR    bool     `yaml:"enable_ocr"`    // 是否启用文字识别
+	MaxSize       int64           `yaml:"max_size"`        // 最大文件大小（字节）
+	SupportedExt  []string        `yaml:"supported_ext"`   // 支持的扩展名
+	EnableOCR     bool            `yaml:"enable_ocr"`      // 是否启用文字识别
+	SampleTarget  int             `yaml:"sample_target"`   // 每次像素遍历的目标采样点数
+	OnDecodeError string          `yaml:"on_decode_error"` // 图片解码失败时的处理: skip, warn, fail
+	Normalize     NormalizeConfig `yaml:"normalize"`       // 分析前的格式和尺寸归一化
+	InlineImages  string          `yaml:"inline_images"`   // base64 data URI 内嵌图片: decode 解码到临时文件分析, skip 跳过
+	CropRatios    []string        `yaml:"crop_ratios"`     // 建议裁剪框的宽高比，如 "1:1", "16:9"
+	OCR           OCRConfig       `yaml:"ocr"`             // enable_ocr 开启时的文字识别设置
+	HEICCommand   string          `yaml:"heic_command"`    // HEIC/HEIF 转PNG的命令，以 "命令 输入 输出" 调用，如 heif-convert、magick；为空时不支持HEIC
+	BatchWorkers  int             `yaml:"batch_workers"`   // 批量分析图片的并发数，0表示使用CPU核数
+	Cache         CacheConfig     `yaml:"cache"`           // 单张图片分析结果缓存
+
+	// RemoteImages 只有 url 的远程图片: download 下载到临时文件分析, skip 跳过；DownloadTimeout 为下载单张图片的超时时间（秒）
+	RemoteImages    string `yaml:"remote_images"`
+	DownloadTimeout int    `yaml:"download_timeout"`
+
+	// SuggestAltText 已配置AI服务时，为没有说明（caption）的图片生成建议的替代文本
+	SuggestAltText bool `yaml:"suggest_alt_text"`
+
+	// DuplicateDistance 感知哈希汉明距离（0-64）不超过该值的图片视为同一张，负数表示不检查重复图片
+	DuplicateDistance int `yaml:"duplicate_distance"`
+
+	// Faces 人脸检测，检测到人脸的图片视觉得分加分。默认关闭，需先下载级联文件
+	Faces FaceConfig `yaml:"faces"`
+}
+
+// FaceConfig 用 pigo 级联分类器检测人脸，纯Go实现，级联文件缺失时跳过检测
+type FaceConfig struct {
+	Enabled    bool    `yaml:"enabled"`
+	Cascade    string  `yaml:"cascade"`     // pigo 的人脸级联文件（facefinder）
+	MinSize    int     `yaml:"min_size"`    // 最小人脸边长（像素，按长边缩小到640像素后计）
+	MinQuality float64 `yaml:"min_quality"` // 检测分数低于该值的候选区域忽略
+}
+
+// CacheConfig 磁盘缓存设置，用于图片分析结果和AI响应
+type CacheConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Dir      string `yaml:"dir"`       // 缓存目录
+	TTLHours int    `yaml:"ttl_hours"` // 缓存有效期（小时），0表示不过期
+}
+
+// OCRConfig 图片文字识别，调用本地 tesseract 命令，检查图中文字是否清晰易读
+type OCRConfig struct {
+	Command       string  `yaml:"command"`         // tesseract 可执行文件
+	Languages     string  `yaml:"languages"`       // 识别语言，如 chi_sim+eng
+	MinConfidence float64 `yaml:"min_confidence"`  // 低于该置信度（0-100）的识别结果忽略
+	MinTextHeight float64 `yaml:"min_text_height"` // 文字行高占图片高度的最小比例，低于视为字太小
+	MinContrast   float64 `yaml:"min_contrast"`    // 文字与背景的最小对比度（WCAG对比度，1-21）
+}
+
+// NormalizeConfig 分析前在内存中统一图片格式和尺寸，不修改原文件
+type NormalizeConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Format       string `yaml:"format"`        // png 或 jpeg
+	MaxDimension int    `yaml:"max_dimension"` // 长边超过该值时等比缩小，0表示不缩放
 }
 
 type AnalysisConfig struct {
-	MinWordCount    int     `yaml:"min_word_count"`    // 最小词数要求
-	MaxWordCount    int     `yaml:"max_word_count"`    // 最大词数建议
-	ScoreWeights    ScoreWeights `yaml:"score_weights"`
+	MinWordCount     int                   `yaml:"min_word_count"` // 最小词数要求
+	MaxWordCount     int                   `yaml:"max_word_count"` // 最大词数建议
+	ScoreWeights     ScoreWeights          `yaml:"score_weights"`
+	PostProcessors   []PostProcessorConfig `yaml:"post_processors"`    // 自定义评分表达式
+	RequiredKeywords []string              `yaml:"required_keywords"`  // 必须出现的品牌词
+	DomainTerms      []string              `yaml:"domain_terms"`       // 行业术语，可读性计算中不计为复杂词
+	ImpactMinSamples int                   `yaml:"impact_min_samples"` // 基于历史数据估算建议影响时每组最少样本数
+	DisabledStages   []string              `yaml:"disabled_stages"`    // 跳过的分析阶段: images, sentiment, keywords, topics, readability
+	WeightPolicy     string                `yaml:"weight_policy"`      // 被跳过阶段的维度仍有权重时: reweight 重新分配, error 报错
+	CTA              CTAConfig             `yaml:"cta"`
+	Strictness       string                `yaml:"strictness"` // 评分严格度: lenient, balanced, strict
+	KeywordDensity   KeywordDensityConfig  `yaml:"keyword_density"`
+	Variety          VarietyConfig         `yaml:"variety"`
+	WordLists        WordListsConfig       `yaml:"word_lists"`   // 外部词表文件，调整停用词、强力词、情感词和CTA模式
+	Entities         EntitiesConfig        `yaml:"entities"`     // 命名实体词典和识别方式
+	Segmentation     SegmentationConfig    `yaml:"segmentation"` // 中文分词，用于词数、关键词和复杂度
+
+	// WritingCheck 拼写、语法和错别字检查
+	WritingCheck WritingCheckConfig `yaml:"writing_check"`
+
+	// LevelThresholds 总分对应等级（excellent/good/average/poor）的分数线，分析结果和各格式报告的颜色共用
+	LevelThresholds LevelThresholds `yaml:"level_thresholds"`
+
+	// HashtagSuggestions 标题和正文的话题标签过少时，根据主题和关键词推荐
+	HashtagSuggestions HashtagSuggestionConfig `yaml:"hashtag_suggestions"`
+
+	// ReadingSpeed 估算阅读时间用的各语言阅读速度和最短阅读时间
+	ReadingSpeed ReadingSpeedConfig `yaml:"reading_speed"`
+
+	// MaxEmojiDensity 正文平均每个词的emoji数上限，超过时互动性扣分并建议减少；上限的一半以内按密度加分
+	MaxEmojiDensity float64 `yaml:"max_emoji_density"`
+
+	// MinSuggestionConfidence 置信度低于该值的建议归入 minor_suggestions，0表示全部作为主要建议
+	MinSuggestionConfidence float64 `yaml:"min_suggestion_confidence"`
+
+	// TargetPlatform 检查标题长度、标题话题标签和图片宽高比的目标平台，留空不检查；单篇内容可用 platform 字段覆盖
+	TargetPlatform string                `yaml:"target_platform"`
+	TitleLimits    map[string]TitleLimit `yaml:"title_limits"` // 各平台信息流中标题的显示上限
+	// HashtagLimits 各平台标题中话题标签的合适数量，与正文中的标签分开统计
+	HashtagLimits map[string]HashtagLimit `yaml:"hashtag_limits"`
+	// ImageRatios 各平台推荐的图片宽高比（"宽:高"），符合其一的图片视觉得分加分，否则建议裁剪
+	ImageRatios map[string][]string `yaml:"image_ratios"`
+}
+
+// WordListsConfig 外部词表文件，UTF-8 编码，每行一个词，空行和 # 开头的行忽略。
+// 含汉字的词归入中文词表，其余归入英文词表；路径为空时只使用内置词表
+type WordListsConfig struct {
+	StopWords      string `yaml:"stop_words"`
+	PowerWords     string `yaml:"power_words"`
+	EmotionalWords string `yaml:"emotional_words"`
+	CTAPatterns    string `yaml:"cta_patterns"` // 每行一个正则表达式
+	Replace        bool   `yaml:"replace"`      // true 时文件中的词替换对应的内置词表，否则与内置词表合并
+}
+
+// SegmentationConfig 中文分词。关闭时中文和英文一样按空格分词（整句中文只算一个词）
+type SegmentationConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	Dictionary string `yaml:"dictionary"` // 追加到内置词典的词典文件，UTF-8，以空白或换行分隔，# 开头的行忽略
+}
+
+// HashtagSuggestionConfig 话题标签推荐。默认由主题和关键词生成，结果可复现；开启 use_ai 且已配置AI服务时交给AI挑选和改写
+type HashtagSuggestionConfig struct {
+	MinHashtags int  `yaml:"min_hashtags"` // 标题和正文合计少于该数量时推荐，0表示不推荐
+	Max         int  `yaml:"max"`          // 最多推荐的标签数
+	UseAI       bool `yaml:"use_ai"`       // AI失败时回退到主题和关键词
+}
+
+// ReadingSpeedConfig 各语言的阅读速度，按检测出的语言片段分别估算阅读时间后相加
+type ReadingSpeedConfig struct {
+	Chinese    float64 `yaml:"zh"`          // 中文每分钟字数
+	English    float64 `yaml:"en"`          // 英文及其他非中文内容每分钟词数
+	MinSeconds int     `yaml:"min_seconds"` // 阅读时间下限（秒），0表示不设下限
+}
+
+// LevelThresholds 各等级的最低总分，须满足 100 >= excellent > good > average >= 0，低于 average 为 poor
+type LevelThresholds struct {
+	Excellent float64 `yaml:"excellent"`
+	Good      float64 `yaml:"good"`
+	Average   float64 `yaml:"average"`
+}
+
+// Level 总分对应的等级
+func (t LevelThresholds) Level(score float64) string {
+	switch {
+	case score >= t.Excellent:
+		return "excellent"
+	case score >= t.Good:
+		return "good"
+	case score >= t.Average:
+		return "average"
+	}
+	return "poor"
+}
+
+// WritingCheckConfig 拼写和语法检查。英文交给 LanguageTool 服务，中文用内置规则检查重复字和常见错别字；
+// 都未配置时不检查
+type WritingCheckConfig struct {
+	LanguageToolURL string `yaml:"languagetool_url"` // LanguageTool 服务地址，如 http://localhost:8081，为空时不检查英文
+	Language        string `yaml:"language"`         // 传给 LanguageTool 的语言代码，如 en-US、en-GB
+	Timeout         int    `yaml:"timeout"`          // LanguageTool 请求超时（秒）
+	Chinese         bool   `yaml:"chinese"`          // 检查中文的重复字（如"的的"）和常见错别字（如"再接再励"）
+	Confusables     string `yaml:"confusables"`      // 追加的错别字词表，UTF-8，每行 "错误|正确"，# 开头的行忽略
+}
+
+// EntitiesConfig 命名实体识别。词典文件为 UTF-8 编码，每行一个实体，空行和 # 开头的行忽略；
+// 同一实体的别名用 | 分隔，第一个为标准名，如 "Apple|苹果|苹果公司"
+type EntitiesConfig struct {
+	Person   string `yaml:"person"`
+	Brand    string `yaml:"brand"`
+	Product  string `yaml:"product"`
+	Location string `yaml:"location"`
+	UseAI    bool   `yaml:"use_ai"` // 已配置AI服务时交给AI识别，失败时回退到词典和大写短语
+}
+
+// HashtagLimit 标题话题标签数量的合适范围，min 为0时不提示过少
+type HashtagLimit struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+// TitleLimit 平台标题显示上限
+type TitleLimit struct {
+	MaxLength int    `yaml:"max_length"`
+	Unit      string `yaml:"unit"` // chars 按字符数, width 按显示宽度（中文等全角字符计2）
+}
+
+// CTAConfig 行动召唤识别与计分
+type CTAConfig struct {
+	Dedupe   bool `yaml:"dedupe"`    // 合并多个模式重叠命中的同一处CTA，相同语句只列出一次
+	MaxCount int  `yaml:"max_count"` // 计入加分的CTA数量上限，超出后按数量扣分，0表示不限制
+
+	// Patterns 按语言（zh、en）追加的CTA正则，与内置模式和 word_lists.cta_patterns 合并
+	Patterns map[string][]string `yaml:"patterns"`
+}
+
+// KeywordDensityConfig 主关键词密度的目标区间（比例，如0.01表示1%）
+type KeywordDensityConfig struct {
+	Enabled bool    `yaml:"enabled"`
+	Min     float64 `yaml:"min"`
+	Max     float64 `yaml:"max"`
+}
+
+// VarietyConfig 句子和段落长度变化的下限，变化系数 = 长度标准差/平均长度
+type VarietyConfig struct {
+	MinSentenceVariation  float64 `yaml:"min_sentence_variation"`
+	MinParagraphVariation float64 `yaml:"min_paragraph_variation"`
+	MinSentences          int     `yaml:"min_sentences"`  // 句子数少于该值时不检查
+	MinParagraphs         int     `yaml:"min_paragraphs"` // 段落数少于该值时不检查
+}
+
+// StrictnessLevels 可选的评分严格度，从宽到严
+var StrictnessLevels = []string{"lenient", "balanced", "strict"}
+
+// ValidStrictness 是否为可选的评分严格度
+func ValidStrictness(level string) bool {
+	for _, l := range StrictnessLevels {
+		if level == l {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalysisStages 可以通过 disabled_stages 跳过的分析阶段
+var AnalysisStages = []string{"images", "sentiment", "keywords", "topics", "readability"}
+
+// StageDimensions 分析阶段与其产出的评分维度（score_weights 中的字段名）
+var StageDimensions = map[string]string{
+	"images":      "visual",
+	"keywords":    "trend_relevance",
+	"readability": "readability",
+}
+
+// DimensionWeight 按 score_weights 字段名取权重
+func (w ScoreWeights) DimensionWeight(dimension string) float64 {
+	switch dimension {
+	case "content_quality":
+		return w.ContentQuality
+	case "engagement":
+		return w.Engagement
+	case "visual":
+		return w.Visual
+	case "title":
+		return w.Title
+	case "readability":
+		return w.Readability
+	case "trend_relevance":
+		return w.TrendRelevance
+	default:
+		return 0
+	}
+}
+
+// PostProcessorConfig 自定义评分维度，表达式在沙箱中计算
+type PostProcessorConfig struct {
+	Name       string  `yaml:"name"`       // 维度名称
+	Expression string  `yaml:"expression"` // 评分表达式，如 "min(word_count / 5, 100)"
+	Weight     float64 `yaml:"weight"`     // 计入总分的权重，0-1，0表示只记录不计入
+}
+
+// PostProcessorVariables 自定义评分表达式可读取的变量
+var PostProcessorVariables = []string{
+	"word_count", "char_count", "paragraph_count", "sentence_count", "section_count",
+	"title_length", "cta_count", "hashtag_count", "mention_count", "image_count", "keyword_count",
+	"sentiment_score", "flesch_score", "reading_time",
+	"total", "content_quality", "engagement", "visual", "title", "readability", "trend_relevance",
 }
 
 type ScoreWeights struct {
-	ContentQuality  float64 `yaml:"content_quality"`
-	Engagement      float64 `yaml:"engagement"`
-	Visual          float64 `yaml:"visual"`
-	Title           float64 `yaml:"title"`
-	Readability     float64 `yaml:"readability"`
-	TrendRelevance  float64 `yaml:"trend_relevance"`
+	ContentQuality float64 `yaml:"content_quality"`
+	Engagement     float64 `yaml:"engagement"`
+	Visual         float64 `yaml:"visual"`
+	Title          float64 `yaml:"title"`
+	Readability    float64 `yaml:"readability"`
+	TrendRelevance float64 `yaml:"trend_relevance"`
+}
+
+// 权重总和与1的允许误差
+const scoreWeightTolerance = 0.001
+
+// Validate 检查权重非负且总和为1，总分按加权平均计算，总和不为1时分数会整体偏高或偏低
+func (w ScoreWeights) Validate() error {
+	return w.ValidateAs("analysis.score_weights")
+}
+
+// ValidateAs 与 Validate 相同，错误信息中的配置项名称为 key，用于单篇内容的 score_weights
+func (w ScoreWeights) ValidateAs(key string) error {
+	fields := []struct {
+		name  string
+		value float64
+	}{
+		{"content_quality", w.ContentQuality},
+		{"engagement", w.Engagement},
+		{"visual", w.Visual},
+		{"title", w.Title},
+		{"readability", w.Readability},
+		{"trend_relevance", w.TrendRelevance},
+	}
+
+	sum := 0.0
+	for _, field := range fields {
+		if field.value < 0 {
+			return fmt.Errorf("%s.%s 不能为负数: %g", key, field.name, field.value)
+		}
+		sum += field.value
+	}
+
+	if math.Abs(sum-1) > scoreWeightTolerance {
+		return fmt.Errorf("%s 六项权重之和应为1，当前为 %.3f（content_quality %g + engagement %g + visual %g + title %g + readability %g + trend_relevance %g）",
+			key, sum, w.ContentQuality, w.Engagement, w.Visual, w.Title, w.Readability, w.TrendRelevance)
+	}
+	return nil
+}
+
+type ReportConfig struct {
+	// 人类可读报告（HTML/Markdown）中情感倾向的显示符号，JSON/CSV保留原始值
+	SentimentIndicators map[string]string `yaml:"sentiment_indicators"`
+	// 不出现在报告热门关键词中的词，与分析阶段的停用词相互独立
+	KeywordBlacklist []string `yaml:"keyword_blacklist"`
+	// JSON报告是否包含从正文摘取的文本（CTA语句、小标题等），关闭后只保留指标
+	IncludeText bool `yaml:"include_text"`
+	// JSON报告中摘取文本的最大字数，0表示不截断
+	TextExcerptLen int `yaml:"text_excerpt_len"`
+	// 导出可导入 Notion 数据库的文件
+	Notion NotionExportConfig `yaml:"notion"`
+	// HTML报告中可读性分布图的目标区间
+	ReadabilityBand ReadabilityBandConfig `yaml:"readability_band"`
+	// 报告中列出的跨内容提升机会数量，0表示不生成
+	TopOpportunities int `yaml:"top_opportunities"`
+	// 某一格式生成失败时: continue 继续生成其余格式, abort 立即停止
+	OnFormatError string `yaml:"on_format_error"`
+	// 导出需要更新的旧内容清单
+	RefreshQueue RefreshQueueConfig `yaml:"refresh_queue"`
+	// HTML报告中评分维度的展示顺序（score_weights 字段名或自定义维度名），不影响评分
+	DimensionOrder []string `yaml:"dimension_order"`
+	// 按作者导出可直接作为邮件正文的反馈
+	AuthorFeedback AuthorFeedbackConfig `yaml:"author_feedback"`
+	// 生成的报告格式，按顺序输出，可选值见 ReportFormats
+	Formats []string `yaml:"formats"`
+	// PDF报告的字体设置
+	PDF PDFConfig `yaml:"pdf"`
+	// 报告生成成功后 POST 摘要的地址，为空时不通知
+	WebhookURL string `yaml:"webhook_url"`
+	// 非空时用它对请求体做 HMAC-SHA256 签名，放在 X-Signature 头中
+	WebhookSecret string `yaml:"webhook_secret"`
+	// webhook 返回 5xx 或网络错误时的重试次数
+	WebhookRetries int `yaml:"webhook_retries"`
+	// AI失败降级为本地分析的内容占比达到该值时，在报告摘要中提示，0表示有降级就提示
+	FallbackWarningRatio float64 `yaml:"fallback_warning_ratio"`
+}
+
+// ReportFormats report.formats 支持的报告格式
+var ReportFormats = []string{"json", "html", "csv", "markdown", "pdf", "xlsx"}
+
+// PDFConfig PDF报告。内置字体不含中文，需指定支持中文的 TrueType 字体文件
+type PDFConfig struct {
+	FontPath string `yaml:"font_path"` // .ttf 字体文件，如 NotoSansSC-Regular.ttf；不支持 .ttc/.otf
+}
+
+// AuthorFeedbackConfig 按 Content.Author 分组，每位作者一份得分和主要建议的汇总
+type AuthorFeedbackConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Format         string `yaml:"format"`          // markdown, html 或 both
+	TopSuggestions int    `yaml:"top_suggestions"` // 每篇内容列出的建议数，0表示全部
+}
+
+// RefreshQueueConfig 内容更新队列：发布已久但评分或互动表现好的内容优先更新
+type RefreshQueueConfig struct {
+	Enabled    bool    `yaml:"enabled"`
+	MinAgeDays int     `yaml:"min_age_days"` // 发布超过该天数才算过期
+	MinScore   float64 `yaml:"min_score"`    // 评分不低于该值，或互动率高于语料中位数，才值得更新
+	Limit      int     `yaml:"limit"`        // 最多列出的篇数，0表示不限制
+}
+
+// ReadabilityBandConfig 理想的可读性得分区间（0-100，越高越易读）
+type ReadabilityBandConfig struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+}
+
+// NotionExportConfig Notion 数据库导入文件，只生成本地文件，不调用 Notion API
+type NotionExportConfig struct {
+	Enabled   bool              `yaml:"enabled"`
+	Format    string            `yaml:"format"`     // csv 或 markdown
+	Columns   []NotionColumn    `yaml:"columns"`    // 按顺序输出，第一列作为 Notion 的标题属性
+	StatusMap map[string]string `yaml:"status_map"` // 评分等级到状态的映射
+}
+
+// NotionColumn 导出列名与分析结果字段的对应关系
+type NotionColumn struct {
+	Name  string `yaml:"name"`
+	Field string `yaml:"field"`
 }
 
+// NotionFields 可以导出到 Notion 的结果字段
+var NotionFields = []string{
+	"title", "content_id", "score", "level", "status", "tags", "topics", "keywords",
+	"content_type", "series", "sentiment", "word_count", "reading_time", "suggestions", "created_at",
+}
+
+// Load 先填入默认配置，再用配置文件中的值覆盖；按扩展名支持 YAML、JSON 和 TOML，文件不存在时只使用默认配置
 func Load(configPath string) (*Config, error) {
+	// 扩展名无法识别时直接报错，而不是静默使用默认配置
+	if configFormat(configPath) == "" {
+		return nil, fmt.Errorf("不支持的配置文件格式 %q（可选 .yaml, .yml, .json, .toml）", filepath.Ext(configPath))
+	}
+
 	// 默认配置
 	config := &Config{
 		ContentDir: "./content",
 		OutputDir:  "./output",
+		Server: ServerConfig{
+			Addr:         ":8080",
+			MaxBodyBytes: 10 << 20,
+		},
 		AI: AIConfig{
-			Provider: "openai",
-			Model:    "gpt-3.5-turbo",
+			Provider:   "openai",
+			Model:      "gpt-3.5-turbo",
+			MaxRetries: 3,
+			Cache: CacheConfig{
+				Dir:      "./.cache/ai",
+				TTLHours: 168,
+			},
+			Pricing: map[string]TokenPrice{
+				"gpt-3.5-turbo":    {Prompt: 0.0005, Completion: 0.0015},
+				"gpt-4o":           {Prompt: 0.0025, Completion: 0.01},
+				"gpt-4o-mini":      {Prompt: 0.00015, Completion: 0.0006},
+				"gemini-1.5-flash": {Prompt: 0.000075, Completion: 0.0003},
+			},
 		},
 		Image: ImageConfig{
-			MaxSize:      10 * 1024 * 1024, // 10MB
-			SupportedExt: []string{".jpg", ".jpeg", ".png", ".gif", ".bmp"},
-			EnableOCR:    false,
+			MaxSize:           10 * 1024 * 1024, // 10MB
+			SupportedExt:      []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp"},
+			EnableOCR:         false,
+			SampleTarget:      10000,
+			OnDecodeError:     "fail",
+			InlineImages:      "decode",
+			RemoteImages:      "download",
+			DownloadTimeout:   15,
+			CropRatios:        []string{"1:1", "4:5", "3:4", "16:9", "9:16"},
+			DuplicateDistance: 6,
+			SuggestAltText:    true,
+			OCR: OCRConfig{
+				Command:       "tesseract",
+				Languages:     "chi_sim+eng",
+				MinConfidence: 50,
+				MinTextHeight: 0.03,
+				MinContrast:   4.5,
+			},
+			Normalize: NormalizeConfig{
+				Format: "png",
+			},
+			Cache: CacheConfig{
+				Dir:      "./.cache/images",
+				TTLHours: 168,
+			},
+			Faces: FaceConfig{
+				Enabled:    false,
+				Cascade:    "./models/facefinder",
+				MinSize:    20,
+				MinQuality: 5,
+			},
 		},
 		Analysis: AnalysisConfig{
 			MinWordCount: 50,
+			Strictness:   "balanced",
+			Variety: VarietyConfig{
+				MinSentenceVariation:  0.3,
+				MinParagraphVariation: 0.25,
+				MinSentences:          5,
+				MinParagraphs:         3,
+			},
+			KeywordDensity: KeywordDensityConfig{
+				Min: 0.01,
+				Max: 0.03,
+			},
+			MaxEmojiDensity: 0.1,
+			LevelThresholds: LevelThresholds{
+				Excellent: 85,
+				Good:      70,
+				Average:   50,
+			},
+			Segmentation: SegmentationConfig{
+				Enabled: true,
+			},
+			HashtagSuggestions: HashtagSuggestionConfig{
+				MinHashtags: 3,
+				Max:         5,
+			},
+			ReadingSpeed: ReadingSpeedConfig{
+				Chinese:    300,
+				English:    250,
+				MinSeconds: 30,
+			},
+			WritingCheck: WritingCheckConfig{
+				Language: "en-US",
+				Timeout:  10,
+			},
 			MaxWordCount: 1000,
 			ScoreWeights: ScoreWeights{
 				ContentQuality: 0.25,
@@ -68,6 +572,74 @@ func Load(configPath string) (*Config, error) {
 				Readability:    0.15,
 				TrendRelevance: 0.10,
 			},
+			ImpactMinSamples: 5,
+			WeightPolicy:     "reweight",
+			CTA: CTAConfig{
+				Dedupe:   true,
+				MaxCount: 3,
+			},
+			TitleLimits: map[string]TitleLimit{
+				"xiaohongshu": {MaxLength: 20, Unit: "chars"},
+				"wechat":      {MaxLength: 64, Unit: "chars"},
+				"weibo":       {MaxLength: 32, Unit: "chars"},
+				"youtube":     {MaxLength: 70, Unit: "width"},
+				"seo":         {MaxLength: 60, Unit: "width"},
+			},
+			HashtagLimits: map[string]HashtagLimit{
+				"instagram":   {Min: 3, Max: 10},
+				"linkedin":    {Min: 0, Max: 3},
+				"twitter":     {Min: 1, Max: 2},
+				"weibo":       {Min: 1, Max: 2},
+				"douyin":      {Min: 2, Max: 5},
+				"xiaohongshu": {Min: 0, Max: 2},
+			},
+			ImageRatios: map[string][]string{
+				"instagram":   {"4:5", "1:1"},
+				"youtube":     {"16:9"},
+				"xiaohongshu": {"3:4", "1:1"},
+				"douyin":      {"9:16"},
+				"twitter":     {"16:9", "1:1"},
+			},
+		},
+		Report: ReportConfig{
+			SentimentIndicators: map[string]string{
+				"positive": "😊",
+				"neutral":  "😐",
+				"negative": "😞",
+			},
+			IncludeText:      true,
+			TopOpportunities: 5,
+			OnFormatError:    "continue",
+			WebhookRetries:   3,
+			Formats:          []string{"json", "html", "csv"},
+			AuthorFeedback: AuthorFeedbackConfig{
+				Format:         "both",
+				TopSuggestions: 3,
+			},
+			RefreshQueue: RefreshQueueConfig{
+				MinAgeDays: 180,
+				MinScore:   60,
+			},
+			ReadabilityBand: ReadabilityBandConfig{
+				Min: 60,
+				Max: 80,
+			},
+			FallbackWarningRatio: 0.2,
+			Notion: NotionExportConfig{
+				Format: "csv",
+				Columns: []NotionColumn{
+					{Name: "Name", Field: "title"},
+					{Name: "Score", Field: "score"},
+					{Name: "Status", Field: "status"},
+					{Name: "Tags", Field: "tags"},
+				},
+				StatusMap: map[string]string{
+					"excellent": "可发布",
+					"good":      "可发布",
+					"average":   "待修改",
+					"poor":      "需重写",
+				},
+			},
 		},
 	}
 
@@ -78,439 +650,405 @@ func Load(configPath string) (*Config, error) {
 			return nil, fmt.Errorf("读取配置文件失败: %w", err)
 		}
 
-		if err := yaml.Unmarshal(data, config); err != nil {
+		if err := decodeConfig(configPath, data, config); err != nil {
 			return nil, fmt.Errorf("解析配置文件失败: %w", err)
 		}
 	}
 
-	// 从环境变量覆盖敏感配置
-	if apiKey := os.Getenv("AI_API_KEY"); apiKey != "" {
-		config.AI.APIKey = apiKey
+	if config.Server.Addr == "" || config.Server.MaxBodyBytes <= 0 {
+		return nil, fmt.Errorf("server 配置无效: addr 不能为空，max_body_bytes 必须大于0")
 	}
 
-	return config, nil
-}
+	if config.AI.MaxRetries < 0 {
+		return nil, fmt.Errorf("ai.max_retries 不能为负数: %d", config.AI.MaxRetries)
+	}
 
-// internal/services/ai_service.go
-package services
+	for model, price := range config.AI.Pricing {
+		if price.Prompt < 0 || price.Completion < 0 {
+			return nil, fmt.Errorf("ai.pricing.%s 的价格不能为负数", model)
+		}
+	}
 
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"strings"
+	if cache := config.AI.Cache; cache.Enabled && (cache.Dir == "" || cache.TTLHours < 0) {
+		return nil, fmt.Errorf("ai.cache 配置无效: dir 不能为空，ttl_hours 不能为负数")
+	}
 
-	"github.com/RobinCoderZhao/content-analyzer/internal/config"
-	"github.com/RobinCoderZhao/content-analyzer/internal/models"
-)
+	if err := validatePostProcessors(config.Analysis.PostProcessors); err != nil {
+		return nil, err
+	}
 
-type AIService interface {
-	AnalyzeSentiment(ctx context.Context, text string) (models.SentimentAnalysis, error)
-	GenerateAdvice(ctx context.Context, analysis models.AnalysisResult) (string, error)
-	ExtractTopics(ctx context.Context, text string) ([]string, error)
-}
-
-type aiService struct {
-	config *config.Config
-}
-
-func NewAIService(cfg *config.Config) AIService {
-	return &aiService{config: cfg}
-}
-
-func (s *aiService) AnalyzeSentiment(ctx context.Context, text string) (models.SentimentAnalysis, error) {
-	// 简化的情感分析实现
-	// 在实际应用中，这里应该调用真正的AI API
-	
-	sentiment := models.SentimentAnalysis{
-		Overall:    s.determineSentiment(text),
-		Score:      s.calculateSentimentScore(text),
-		Emotions:   s.analyzeEmotions(text),
-		Confidence: 0.8,
-	}
-	
-	return sentiment, nil
-}
-
-func (s *aiService) GenerateAdvice(ctx context.Context, analysis models.AnalysisResult) (string, error) {
-	// 根据分析结果生成建议
-	advice := strings.Builder{}
-	
-	advice.WriteString("## 内容分析建议\n\n")
-	
-	if analysis.Score.Total < 60 {
-		advice.WriteString("📊 **总体评分偏低**，建议重点关注以下方面：\n")
-	} else if analysis.Score.Total < 80 {
-		advice.WriteString("📊 **总体表现良好**，还有提升空间：\n")
-	} else {
-		advice.WriteString("📊 **内容质量优秀**，保持当前水准：\n")
-	}
-	
-	// 根据各项得分给出具体建议
-	if analysis.Score.Breakdown.Title < 70 {
-		advice.WriteString("- 🎯 **标题优化**：当前标题吸引力不足，建议使用数字、提问或情感词汇\n")
-	}
-	
-	if analysis.Score.Breakdown.Engagement < 70 {
-		advice.WriteString("- 💬 **增强互动**：添加问题引导、call-to-action等元素提升参与度\n")
-	}
-	
-	if len(analysis.ImageAnalysis) == 0 {
-		advice.WriteString("- 🖼️ **视觉内容**：建议添加相关图片或视觉元素增强吸引力\n")
-	}
-	
-	if analysis.Readability.FleschScore < 60 {
-		advice.WriteString("- 📖 **可读性优化**：使用更短的句子和简单词汇提升阅读体验\n")
-	}
-	
-	return advice.String(), nil
-}
-
-func (s *aiService) ExtractTopics(ctx context.Context, text string) ([]string, error) {
-	// 简化的主题提取
-	topics := []string{}
-	
-	// 基于关键词匹配识别主题
-	topicKeywords := map[string][]string{
-		"美食": {"吃", "食物", "餐厅", "菜", "味道", "好吃"},
-		"旅行": {"旅游", "景点", "酒店", "机票", "攻略", "风景"},
-		"科技": {"手机", "电脑", "软件", "APP", "数码", "互联网"},
-		"时尚": {"穿搭", "化妆", "护肤", "衣服", "搭配", "美妆"},
-		"生活": {"日常", "分享", "经验", "感受", "生活", "日记"},
-	}
-	
-	text = strings.ToLower(text)
-	for topic, keywords := range topicKeywords {
-		for _, keyword := range keywords {
-			if strings.Contains(text, keyword) {
-				topics = append(topics, topic)
-				break
-			}
+	switch config.Image.OnDecodeError {
+	case "skip", "warn", "fail":
+	default:
+		return nil, fmt.Errorf("image.on_decode_error 取值无效: %q（可选 skip, warn, fail）", config.Image.OnDecodeError)
+	}
+
+	if ocr := config.Image.OCR; config.Image.EnableOCR && (ocr.Command == "" || ocr.MinTextHeight < 0 || ocr.MinTextHeight >= 1 || ocr.MinContrast < 1) {
+		return nil, fmt.Errorf("image.ocr 配置无效: command 不能为空，min_text_height 需在0-1之间，min_contrast 不小于1")
+	}
+
+	if cache := config.Image.Cache; cache.Enabled && (cache.Dir == "" || cache.TTLHours < 0) {
+		return nil, fmt.Errorf("image.cache 配置无效: dir 不能为空，ttl_hours 不能为负数")
+	}
+
+	if config.Image.BatchWorkers < 0 {
+		return nil, fmt.Errorf("image.batch_workers 不能为负数: %d", config.Image.BatchWorkers)
+	}
+
+	if config.Image.DuplicateDistance > 64 {
+		return nil, fmt.Errorf("image.duplicate_distance 不能超过64: %d", config.Image.DuplicateDistance)
+	}
+
+	if faces := config.Image.Faces; faces.Enabled && (faces.Cascade == "" || faces.MinSize < 1) {
+		return nil, fmt.Errorf("image.faces 配置无效: 开启时 cascade 不能为空，min_size 必须为正数")
+	}
+
+	switch config.Image.InlineImages {
+	case "decode", "skip":
+	default:
+		return nil, fmt.Errorf("image.inline_images 取值无效: %q（可选 decode, skip）", config.Image.InlineImages)
+	}
+
+	switch config.Image.RemoteImages {
+	case "download", "skip":
+	default:
+		return nil, fmt.Errorf("image.remote_images 取值无效: %q（可选 download, skip）", config.Image.RemoteImages)
+	}
+	if config.Image.DownloadTimeout <= 0 {
+		return nil, fmt.Errorf("image.download_timeout 必须为正数: %d", config.Image.DownloadTimeout)
+	}
+
+	for _, ratio := range config.Image.CropRatios {
+		if _, _, err := ParseAspectRatio(ratio); err != nil {
+			return nil, fmt.Errorf("image.crop_ratios: %w", err)
 		}
 	}
-	
-	if len(topics) == 0 {
-		topics = append(topics, "其他")
-	}
-	
-	return topics, nil
-}
-
-// 辅助方法
-func (s *aiService) determineSentiment(text string) string {
-	positiveWords := []string{"好", "棒", "优秀", "喜欢", "爱", "开心", "满意", "推荐"}
-	negativeWords := []string{"差", "坏", "糟糕", "讨厌", "恨", "失望", "不满", "后悔"}
-	
-	text = strings.ToLower(text)
-	positiveCount := 0
-	negativeCount := 0
-	
-	for _, word := range positiveWords {
-		positiveCount += strings.Count(text, word)
-	}
-	
-	for _, word := range negativeWords {
-		negativeCount += strings.Count(text, word)
-	}
-	
-	if positiveCount > negativeCount {
-		return "positive"
-	} else if negativeCount > positiveCount {
-		return "negative"
-	}
-	
-	return "neutral"
-}
-
-func (s *aiService) calculateSentimentScore(text string) float64 {
-	sentiment := s.determineSentiment(text)
-	switch sentiment {
-	case "positive":
-		return 0.7
-	case "negative":
-		return -0.7
+
+	// HEIC/HEIF 没有纯Go解码器，配置了转换命令才加入支持的格式
+	if config.Image.HEICCommand != "" {
+		config.Image.SupportedExt = appendMissing(config.Image.SupportedExt, ".heic", ".heif")
+	}
+
+	switch config.Image.Normalize.Format {
+	case "png", "jpeg":
 	default:
-		return 0.0
+		return nil, fmt.Errorf("image.normalize.format 取值无效: %q（可选 png, jpeg）", config.Image.Normalize.Format)
+	}
+
+	if c := config.Analysis.MinSuggestionConfidence; c < 0 || c > 1 {
+		return nil, fmt.Errorf("analysis.min_suggestion_confidence 应在0到1之间: %v", c)
+	}
+
+	if !ValidStrictness(config.Analysis.Strictness) {
+		return nil, fmt.Errorf("analysis.strictness 取值无效: %q（可选 %s）", config.Analysis.Strictness, strings.Join(StrictnessLevels, ", "))
+	}
+
+	if density := config.Analysis.KeywordDensity; density.Min < 0 || density.Max > 1 || density.Min >= density.Max {
+		return nil, fmt.Errorf("analysis.keyword_density 区间无效: min=%g, max=%g（需满足 0 <= min < max <= 1）", density.Min, density.Max)
+	}
+
+	if err := config.Analysis.ScoreWeights.Validate(); err != nil {
+		return nil, err
 	}
-}
 
-func (s *aiService) analyzeEmotions(text string) map[string]float64 {
-	emotions := map[string]float64{
-		"joy":     0.0,
-		"sadness": 0.0,
-		"anger":   0.0,
-		"fear":    0.0,
-		"surprise": 0.0,
+	if err := validateStages(&config.Analysis); err != nil {
+		return nil, err
 	}
-	
-	// 简化的情感检测
-	text = strings.ToLower(text)
-	
-	if strings.Contains(text, "开心") || strings.Contains(text, "高兴") || strings.Contains(text, "快乐") {
-		emotions["joy"] = 0.8
+
+	if err := validateTitleLimits(&config.Analysis); err != nil {
+		return nil, err
+	}
+
+	if err := validateDimensionOrder(config.Report.DimensionOrder, config.Analysis.PostProcessors); err != nil {
+		return nil, err
+	}
+
+	if config.Report.RefreshQueue.MinAgeDays <= 0 {
+		return nil, fmt.Errorf("report.refresh_queue.min_age_days 必须大于0")
+	}
+
+	switch config.Report.AuthorFeedback.Format {
+	case "markdown", "html", "both":
+	default:
+		return nil, fmt.Errorf("report.author_feedback.format 取值无效: %q（可选 markdown, html, both）", config.Report.AuthorFeedback.Format)
+	}
+
+	if err := validateReportFormats(config.Report.Formats); err != nil {
+		return nil, err
+	}
+	if config.Report.HasFormat("pdf") && config.Report.PDF.FontPath == "" {
+		return nil, fmt.Errorf("report.pdf.font_path 不能为空：生成PDF报告需要指定支持中文的 .ttf 字体文件")
+	}
+
+	switch config.Report.OnFormatError {
+	case "continue", "abort":
+	default:
+		return nil, fmt.Errorf("report.on_format_error 取值无效: %q（可选 continue, abort）", config.Report.OnFormatError)
+	}
+
+	if band := config.Report.ReadabilityBand; band.Min < 0 || band.Max > 100 || band.Min >= band.Max {
+		return nil, fmt.Errorf("report.readability_band 应满足 0 <= min < max <= 100: min=%v, max=%v", band.Min, band.Max)
+	}
+
+	if err := validateNotionExport(&config.Report.Notion); err != nil {
+		return nil, err
 	}
-	
-	if strings.Contains(text, "难过") || strings.Contains(text, "伤心") || strings.Contains(text, "沮丧") {
-		emotions["sadness"] = 0.8
+
+	if t := config.Analysis.LevelThresholds; !(t.Excellent <= 100 && t.Excellent > t.Good && t.Good > t.Average && t.Average >= 0) {
+		return nil, fmt.Errorf("analysis.level_thresholds 应满足 100 >= excellent > good > average >= 0: excellent=%v, good=%v, average=%v",
+			t.Excellent, t.Good, t.Average)
+	}
+
+	if hs := config.Analysis.HashtagSuggestions; hs.MinHashtags < 0 || hs.Max < 1 {
+		return nil, fmt.Errorf("analysis.hashtag_suggestions 应满足 min_hashtags >= 0 且 max >= 1: min_hashtags=%d, max=%d", hs.MinHashtags, hs.Max)
+	}
+	for lang, patterns := range config.Analysis.CTA.Patterns {
+		if lang != "zh" && lang != "en" {
+			return nil, fmt.Errorf("analysis.cta.patterns 的语言只能是 zh 或 en: %q", lang)
+		}
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("analysis.cta.patterns.%s 中的正则 %q 无效: %w", lang, pattern, err)
+			}
+		}
+	}
+	if rs := config.Analysis.ReadingSpeed; rs.Chinese <= 0 || rs.English <= 0 || rs.MinSeconds < 0 {
+		return nil, fmt.Errorf("analysis.reading_speed 应满足 zh > 0、en > 0 且 min_seconds >= 0: zh=%v, en=%v, min_seconds=%d", rs.Chinese, rs.English, rs.MinSeconds)
+	}
+	if config.Analysis.MaxEmojiDensity <= 0 || config.Analysis.MaxEmojiDensity > 1 {
+		return nil, fmt.Errorf("analysis.max_emoji_density 应在 (0, 1] 之间: %v", config.Analysis.MaxEmojiDensity)
 	}
-	
-	if strings.Contains(text, "生气") || strings.Contains(text, "愤怒") || strings.Contains(text, "气愤") {
-		emotions["anger"] = 0.8
+
+	if url := config.Analysis.WritingCheck.LanguageToolURL; url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("analysis.writing_check.languagetool_url 必须是 http(s) 地址: %q", url)
+	}
+	if config.Analysis.WritingCheck.Timeout <= 0 {
+		return nil, fmt.Errorf("analysis.writing_check.timeout 必须大于0: %d", config.Analysis.WritingCheck.Timeout)
+	}
+
+	if url := config.Report.WebhookURL; url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("report.webhook_url 必须是 http(s) 地址: %q", url)
+	}
+	if config.Report.WebhookRetries < 0 {
+		return nil, fmt.Errorf("report.webhook_retries 不能为负数: %d", config.Report.WebhookRetries)
 	}
-	
-	if strings.Contains(text, "害怕") || strings.Contains(text, "担心") || strings.Contains(text, "恐惧") {
-		emotions["fear"] = 0.8
+	if ratio := config.Report.FallbackWarningRatio; ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("report.fallback_warning_ratio 应在 [0, 1] 之间: %v", ratio)
+	}
+
+	// 从环境变量覆盖敏感配置
+	if apiKey := os.Getenv("AI_API_KEY"); apiKey != "" {
+		config.AI.APIKey = apiKey
 	}
-	
-	if strings.Contains(text, "惊讶") || strings.Contains(text, "意外") || strings.Contains(text, "震惊") {
-		emotions["surprise"] = 0.8
+	if secret := os.Getenv("WEBHOOK_SECRET"); secret != "" {
+		config.Report.WebhookSecret = secret
 	}
-	
-	return emotions
+
+	return config, nil
 }
 
-// internal/services/image_service.go
-package services
+// validateStages 检查跳过的阶段名称，以及 weight_policy 为 error 时被跳过的维度是否仍有权重
+func validateStages(analysis *AnalysisConfig) error {
+	switch analysis.WeightPolicy {
+	case "reweight", "error":
+	default:
+		return fmt.Errorf("analysis.weight_policy 取值无效: %q（可选 reweight, error）", analysis.WeightPolicy)
+	}
 
-import (
-	"fmt"
-	"image"
-	"image/jpeg"
-	"image/png"
-	"os"
-	"path/filepath"
-	"strings"
-	
-	"github.com/RobinCoderZhao/content-analyzer/internal/config"
-	"github.com/RobinCoderZhao/content-analyzer/internal/models"
-)
+	for _, stage := range analysis.DisabledStages {
+		valid := false
+		for _, s := range AnalysisStages {
+			if stage == s {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("analysis.disabled_stages 包含未知阶段: %q（可选 %s）", stage, strings.Join(AnalysisStages, ", "))
+		}
 
-type ImageService interface {
-	AnalyzeImage(imagePath string) (models.ImageAnalysis, error)
-	ValidateImage(imagePath string) error
-	GetImageInfo(imagePath string) (models.Image, error)
+		dimension, ok := StageDimensions[stage]
+		if ok && analysis.WeightPolicy == "error" && analysis.ScoreWeights.DimensionWeight(dimension) > 0 {
+			return fmt.Errorf("阶段 %s 已跳过，但评分维度 %s 的权重为 %.2f，请将其设为0或改用 weight_policy: reweight",
+				stage, dimension, analysis.ScoreWeights.DimensionWeight(dimension))
+		}
+	}
+	return nil
 }
 
-type imageService struct {
-	config *config.Config
+// validatePostProcessors 自定义评分维度需有名称，权重在0-1之间，表达式在加载时编译并检查变量名
+func validatePostProcessors(processors []PostProcessorConfig) error {
+	for i, pp := range processors {
+		if pp.Name == "" || pp.Expression == "" {
+			return fmt.Errorf("analysis.post_processors 第%d项缺少 name 或 expression", i+1)
+		}
+		if pp.Weight < 0 || pp.Weight > 1 {
+			return fmt.Errorf("analysis.post_processors.%s 的 weight 需在0-1之间: %g", pp.Name, pp.Weight)
+		}
+		if _, err := expression.Compile(pp.Expression, PostProcessorVariables); err != nil {
+			return fmt.Errorf("analysis.post_processors.%s 的表达式无效: %w", pp.Name, err)
+		}
+	}
+	return nil
 }
 
-func NewImageService(cfg *config.Config) ImageService {
-	return &imageService{config: cfg}
+// validateNotionExport 检查导出格式和列映射的字段名
+func validateNotionExport(notion *NotionExportConfig) error {
+	switch notion.Format {
+	case "csv", "markdown":
+	default:
+		return fmt.Errorf("report.notion.format 取值无效: %q（可选 csv, markdown）", notion.Format)
+	}
+
+	if notion.Enabled && len(notion.Columns) == 0 {
+		return fmt.Errorf("report.notion.columns 不能为空")
+	}
+
+	for _, column := range notion.Columns {
+		if column.Name == "" {
+			return fmt.Errorf("report.notion.columns 中字段 %q 缺少列名", column.Field)
+		}
+		valid := false
+		for _, field := range NotionFields {
+			if column.Field == field {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("report.notion.columns 包含未知字段: %q（可选 %s）", column.Field, strings.Join(NotionFields, ", "))
+		}
+	}
+
+	return nil
 }
 
-func (s *imageService) AnalyzeImage(imagePath string) (models.ImageAnalysis, error) {
-	// 验证图片
-	if err := s.ValidateImage(imagePath); err != nil {
-		return models.ImageAnalysis{}, err
+// validateTitleLimits 检查各平台的标题上限、话题标签范围、图片宽高比，以及 target_platform 是否已定义
+func validateTitleLimits(analysis *AnalysisConfig) error {
+	for platform, limit := range analysis.TitleLimits {
+		if limit.MaxLength <= 0 {
+			return fmt.Errorf("analysis.title_limits.%s.max_length 必须大于0", platform)
+		}
+		switch limit.Unit {
+		case "chars", "width":
+		default:
+			return fmt.Errorf("analysis.title_limits.%s.unit 取值无效: %q（可选 chars, width）", platform, limit.Unit)
+		}
+	}
+
+	for platform, limit := range analysis.HashtagLimits {
+		if limit.Min < 0 || limit.Max < limit.Min {
+			return fmt.Errorf("analysis.hashtag_limits.%s 应满足 0 <= min <= max: min=%d, max=%d", platform, limit.Min, limit.Max)
+		}
+	}
+
+	for platform, ratios := range analysis.ImageRatios {
+		for _, ratio := range ratios {
+			if _, _, err := ParseAspectRatio(ratio); err != nil {
+				return fmt.Errorf("analysis.image_ratios.%s: %w", platform, err)
+			}
+		}
 	}
-	
-	// 获取图片基本信息
-	imgInfo, err := s.GetImageInfo(imagePath)
-	if err != nil {
-		return models.ImageAnalysis{}, err
+
+	if analysis.TargetPlatform != "" && !analysis.KnownPlatform(analysis.TargetPlatform) {
+		return fmt.Errorf("analysis.target_platform %q 未在 title_limits、hashtag_limits 或 image_ratios 中定义", analysis.TargetPlatform)
 	}
-	
-	// 分析图片
-	analysis := models.ImageAnalysis{
-		Path: imagePath,
-		VisualElements: s.analyzeVisualElements(imgInfo),
-		CompositionAnalysis: s.analyzeComposition(imgInfo),
-		QualityMetrics: s.analyzeQuality(imgInfo),
-		StyleAnalysis: s.analyzeStyle(imgInfo),
+
+	return nil
+}
+
+// appendMissing 把 list 中还没有的值追加到末尾
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
 	}
-	
-	// 计算综合得分
-	analysis.Score = s.calculateImageScore(analysis)
-	
-	return analysis, nil
+	return list
 }
 
-func (s *imageService) ValidateImage(imagePath string) error {
-	// 检查文件是否存在
-	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
-		return fmt.Errorf("图片文件不存在: %s", imagePath)
+// ParseAspectRatio 解析 "宽:高" 形式的宽高比
+func ParseAspectRatio(ratio string) (int, int, error) {
+	w, h, ok := strings.Cut(ratio, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("宽高比格式应为 宽:高: %q", ratio)
 	}
-	
-	// 检查文件扩展名
-	ext := strings.ToLower(filepath.Ext(imagePath))
-	supported := false
-	for _, supportedExt := range s.config.Image.SupportedExt {
-		if ext == supportedExt {
-			supported = true
-			break
+	width, errW := strconv.Atoi(strings.TrimSpace(w))
+	height, errH := strconv.Atoi(strings.TrimSpace(h))
+	if errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("宽高比应为正整数: %q", ratio)
+	}
+	return width, height, nil
+}
+
+// KnownPlatform 平台是否在 title_limits、hashtag_limits 或 image_ratios 中定义
+func (a *AnalysisConfig) KnownPlatform(platform string) bool {
+	_, hasTitle := a.TitleLimits[platform]
+	_, hasHashtag := a.HashtagLimits[platform]
+	_, hasImage := a.ImageRatios[platform]
+	return hasTitle || hasHashtag || hasImage
+}
+
+// HasFormat 报告格式是否在 report.formats 中
+func (r *ReportConfig) HasFormat(name string) bool {
+	for _, format := range r.Formats {
+		if format == name {
+			return true
 		}
 	}
-	
-	if !supported {
-		return fmt.Errorf("不支持的图片格式: %s", ext)
+	return false
+}
+
+// validateReportFormats 检查 report.formats 非空、只包含支持的格式且不重复
+func validateReportFormats(formats []string) error {
+	if len(formats) == 0 {
+		return fmt.Errorf("report.formats 不能为空（可选 %s）", strings.Join(ReportFormats, ", "))
 	}
-	
-	// 检查文件大小
-	fileInfo, err := os.Stat(imagePath)
-	if err != nil {
-		return err
+
+	known := make(map[string]bool)
+	for _, name := range ReportFormats {
+		known[name] = true
 	}
-	
-	if fileInfo.Size() > s.config.Image.MaxSize {
-		return fmt.Errorf("图片文件过大: %d bytes (最大: %d bytes)", 
-			fileInfo.Size(), s.config.Image.MaxSize)
+
+	seen := make(map[string]bool)
+	for _, name := range formats {
+		if !known[name] {
+			return fmt.Errorf("report.formats 包含未知的报告格式: %q（可选 %s）", name, strings.Join(ReportFormats, ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("report.formats 中报告格式重复: %s", name)
+		}
+		seen[name] = true
 	}
-	
 	return nil
 }
 
-func (s *imageService) GetImageInfo(imagePath string) (models.Image, error) {
-	file, err := os.Open(imagePath)
-	if err != nil {
-		return models.Image{}, err
-	}
-	defer file.Close()
-	
-	// 获取图片配置信息
-	config, format, err := image.DecodeConfig(file)
-	if err != nil {
-		return models.Image{}, err
-	}
-	
-	// 获取文件信息
-	fileInfo, err := os.Stat(imagePath)
-	if err != nil {
-		return models.Image{}, err
-	}
-	
-	return models.Image{
-		Path:   imagePath,
-		Width:  config.Width,
-		Height: config.Height,
-		Size:   fileInfo.Size(),
-		Format: format,
-	}, nil
-}
-
-func (s *imageService) analyzeVisualElements(imgInfo models.Image) models.VisualElements {
-	// 简化的视觉元素分析
-	// 在实际应用中，这里需要使用图像处理库或AI服务
-	
-	elements := models.VisualElements{
-		DominantColors: []string{"#FF5733", "#33FF57", "#3357FF"}, // 示例颜色
-		Brightness:     0.6, // 示例亮度
-		Contrast:       0.7, // 示例对比度
-		Saturation:     0.5, // 示例饱和度
-		HasText:        false, // 是否包含文字
-		HasFaces:       false, // 是否包含人脸
-		ObjectCount:    3,     // 对象数量
-	}
-	
-	return elements
-}
-
-func (s *imageService) analyzeComposition(imgInfo models.Image) models.CompositionAnalysis {
-	// 简化的构图分析
-	composition := models.CompositionAnalysis{
-		RuleOfThirds:  s.checkRuleOfThirds(imgInfo),
-		Symmetry:      s.checkSymmetry(imgInfo),
-		LeadingLines:  false, // 简化处理
-		FramingScore:  0.7,
-		BalanceScore:  0.6,
-		FocusClarity:  0.8,
-	}
-	
-	return composition
-}
-
-func (s *imageService) analyzeQuality(imgInfo models.Image) models.QualityMetrics {
-	resolution := fmt.Sprintf("%dx%d", imgInfo.Width, imgInfo.Height)
-	
-	// 基于分辨率判断质量
-	totalPixels := imgInfo.Width * imgInfo.Height
-	var overallQuality float64
-	
-	if totalPixels >= 2000000 { // 2MP以上
-		overallQuality = 0.9
-	} else if totalPixels >= 1000000 { // 1MP以上
-		overallQuality = 0.7
-	} else if totalPixels >= 500000 { // 0.5MP以上
-		overallQuality = 0.5
-	} else {
-		overallQuality = 0.3
-	}
-	
-	return models.QualityMetrics{
-		Resolution:     resolution,
-		Sharpness:      0.8, // 示例值
-		NoiseLevel:     0.2, // 示例值
-		ExposureScore:  0.7, // 示例值
-		OverallQuality: overallQuality,
-	}
-}
-
-func (s *imageService) analyzeStyle(imgInfo models.Image) models.StyleAnalysis {
-	// 简化的风格分析
-	style := models.StyleAnalysis{
-		Style:       s.determineStyle(imgInfo),
-		Mood:        s.determineMood(imgInfo),
-		Filter:      "none",
-		Consistency: 0.8,
-	}
-	
-	return style
-}
-
-func (s *imageService) checkRuleOfThirds(imgInfo models.Image) bool {
-	// 简化判断：如果图片比例接近3:2或16:9，认为符合三分法则
-	ratio := float64(imgInfo.Width) / float64(imgInfo.Height)
-	return ratio > 1.4 && ratio < 1.8
-}
-
-func (s *imageService) checkSymmetry(imgInfo models.Image) bool {
-	// 简化判断：如果宽高比接近1:1，认为具有对称性
-	ratio := float64(imgInfo.Width) / float64(imgInfo.Height)
-	return ratio > 0.9 && ratio < 1.1
-}
-
-func (s *imageService) determineStyle(imgInfo models.Image) string {
-	// 基于图片特征简单判断风格
-	aspectRatio := float64(imgInfo.Width) / float64(imgInfo.Height)
-	
-	if aspectRatio > 1.5 {
-		return "landscape"
-	} else if aspectRatio < 0.8 {
-		return "portrait"
-	}
-	
-	return "modern"
-}
-
-func (s *imageService) determineMood(imgInfo models.Image) string {
-	// 简化的情绪判断
-	// 实际应用中需要使用色彩分析或AI服务
-	return "neutral"
-}
-
-func (s *imageService) calculateImageScore(analysis models.ImageAnalysis) float64 {
-	score := 60.0 // 基础分
-	
-	// 质量评分影响
-	score += analysis.QualityMetrics.OverallQuality * 25
-	
-	// 构图评分影响
-	if analysis.CompositionAnalysis.RuleOfThirds {
-		score += 5
-	}
-	if analysis.CompositionAnalysis.Symmetry {
-		score += 5
-	}
-	
-	score += analysis.CompositionAnalysis.BalanceScore * 5
-	
-	// 限制在0-100范围内
-	if score > 100 {
-		score = 100
-	}
-	if score < 0 {
-		score = 0
-	}
-	
-	return score
+// validateDimensionOrder 检查 report.dimension_order 只包含内置维度或已配置的自定义维度，且不重复
+func validateDimensionOrder(order []string, processors []PostProcessorConfig) error {
+	known := make(map[string]bool)
+	for _, name := range []string{"content_quality", "engagement", "visual", "title", "readability", "trend_relevance"} {
+		known[name] = true
+	}
+	for _, pp := range processors {
+		known[pp.Name] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range order {
+		if !known[name] {
+			return fmt.Errorf("report.dimension_order 包含未知的评分维度: %s", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("report.dimension_order 中评分维度重复: %s", name)
+		}
+		seen[name] = true
+	}
+	return nil
 }
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadYAML 把 content 写入临时配置文件并加载
+func loadYAML(t *testing.T, content string) (*Config, error) {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(path)
+}
+
+func TestLoadPostProcessors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `
+analysis:
+  post_processors:
+    - name: seo
+      expression: "word_count >= 300 ? 80 : word_count / 300 * 80"
+      weight: 0.1
+`,
+		},
+		{
+			name: "weight above 1",
+			content: `
+analysis:
+  post_processors:
+    - name: seo
+      expression: "word_count"
+      weight: 1.5
+`,
+			wantErr: "weight 需在0-1之间",
+		},
+		{
+			name: "negative weight",
+			content: `
+analysis:
+  post_processors:
+    - name: seo
+      expression: "word_count"
+      weight: -0.2
+`,
+			wantErr: "weight 需在0-1之间",
+		},
+		{
+			name: "unknown variable",
+			content: `
+analysis:
+  post_processors:
+    - name: seo
+      expression: "wordcount / 5"
+`,
+			wantErr: "未知变量 wordcount",
+		},
+		{
+			name: "missing name",
+			content: `
+analysis:
+  post_processors:
+    - expression: "word_count"
+`,
+			wantErr: "缺少 name 或 expression",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadYAML(t, tt.content)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("不应报错: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("错误 = %v, 应包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDisabledStages(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "reweight allows weighted dimension",
+			content: `
+analysis:
+  disabled_stages: [images]
+`,
+		},
+		{
+			name: "error policy rejects weighted dimension",
+			content: `
+analysis:
+  weight_policy: error
+  disabled_stages: [images]
+`,
+			wantErr: "评分维度 visual 的权重为 0.15",
+		},
+		{
+			name: "error policy accepts stage without dimension",
+			content: `
+analysis:
+  weight_policy: error
+  disabled_stages: [sentiment]
+`,
+		},
+		{
+			name: "unknown stage",
+			content: `
+analysis:
+  disabled_stages: [ocr]
+`,
+			wantErr: "未知阶段: \"ocr\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadYAML(t, tt.content)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("不应报错: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("错误 = %v, 应包含 %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadHEICSupport(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"default", "", []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp"}},
+		{"command enables heic", "image:\n  heic_command: heif-convert\n", []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp", ".heic", ".heif"}},
+		{"listed extension not duplicated", "image:\n  heic_command: magick\n  supported_ext: [\".png\", \".heic\"]\n", []string{".png", ".heic", ".heif"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadYAML(t, tt.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(cfg.Image.SupportedExt, " "); got != strings.Join(tt.want, " ") {
+				t.Errorf("SupportedExt = %s, want %s", got, strings.Join(tt.want, " "))
+			}
+		})
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	t.Setenv("AI_API_KEY", "")
+	content := `
+# 顶层键和日期时间（未映射的字段应被忽略）
+content_dir = "./posts"
+updated = 2024-01-15T10:00:00Z
+
+[analysis]
+domain_terms = ["Kubernetes", "容器编排"]
+title_limits = { weibo = { max_length = 30, unit = "width" } }
+
+[[analysis.post_processors]]
+name = "seo"
+expression = """
+word_count >= 300
+  ? 80 : 40"""
+weight = 0.1
+
+[[analysis.post_processors]]
+name = "depth"
+expression = 'paragraph_count * 10'
+weight = 0.05
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.ContentDir != "./posts" {
+		t.Errorf("ContentDir = %q, want ./posts", cfg.ContentDir)
+	}
+	if got := strings.Join(cfg.Analysis.DomainTerms, ","); got != "Kubernetes,容器编排" {
+		t.Errorf("DomainTerms = %v", cfg.Analysis.DomainTerms)
+	}
+	if got := cfg.Analysis.TitleLimits["weibo"]; got != (TitleLimit{MaxLength: 30, Unit: "width"}) {
+		t.Errorf("TitleLimits[weibo] = %+v, want 内联表中的值", got)
+	}
+	processors := cfg.Analysis.PostProcessors
+	if len(processors) != 2 || processors[0].Name != "seo" || processors[1].Name != "depth" {
+		t.Fatalf("PostProcessors = %+v, want 表数组中的两项", processors)
+	}
+	if want := "word_count >= 300\n  ? 80 : 40"; processors[0].Expression != want {
+		t.Errorf("多行字符串 Expression = %q, want %q", processors[0].Expression, want)
+	}
+	// 未覆盖的字段保留默认值
+	if cfg.OutputDir == "" {
+		t.Error("OutputDir 为空，want 默认值")
+	}
+}
+
+func TestParseTOMLDatetimes(t *testing.T) {
+	raw, err := ParseTOML(`
+offset = 2024-01-15T10:00:00+08:00
+local = 2024-01-15T10:00:00
+day = 2024-01-15
+`)
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+	for _, key := range []string{"offset", "local", "day"} {
+		ts, ok := raw[key].(time.Time)
+		if !ok {
+			t.Errorf("%s = %#v, want time.Time", key, raw[key])
+			continue
+		}
+		if ts.Year() != 2024 || ts.Month() != time.January || ts.Day() != 15 {
+			t.Errorf("%s = %v, want 2024-01-15", key, ts)
+		}
+	}
+	if got := raw["offset"].(time.Time).UTC().Hour(); got != 2 {
+		t.Errorf("offset UTC 小时 = %d, want 2", got)
+	}
+}
+
+func TestLevelThresholds(t *testing.T) {
+	cfg, err := loadYAML(t, "analysis:\n  level_thresholds:\n    excellent: 90\n    good: 75\n    average: 50\n")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{95, "excellent"},
+		{90, "excellent"},
+		{89.9, "good"},
+		{75, "good"},
+		{60, "average"},
+		{50, "average"},
+		{49.9, "poor"},
+	}
+	for _, tt := range tests {
+		if got := cfg.Analysis.LevelThresholds.Level(tt.score); got != tt.want {
+			t.Errorf("Level(%v) = %s, want %s", tt.score, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{
+		"excellent: 101\n    good: 75\n    average: 50",
+		"excellent: 80\n    good: 80\n    average: 50",
+		"excellent: 80\n    good: 60\n    average: -1",
+	} {
+		if _, err := loadYAML(t, "analysis:\n  level_thresholds:\n    "+invalid+"\n"); err == nil || !strings.Contains(err.Error(), "level_thresholds") {
+			t.Errorf("level_thresholds %q: error = %v, want 分数线无效", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// internal/config/format.go
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeConfig 按文件扩展名解析配置，覆盖 config 中已有的默认值。
+// 字段映射统一使用 yaml 标签：JSON 是 YAML 的子集，可直接交给 YAML 解码；
+// TOML 先解析为通用结构，再转换为 YAML 解码，保证各格式的字段名和覆盖行为一致
+func decodeConfig(path string, data []byte, config *Config) error {
+	switch format := configFormat(path); format {
+	case "yaml":
+		return yaml.Unmarshal(data, config)
+	case "json":
+		// 先按 JSON 校验，错误信息更准确，也避免接受 JSON 之外的 YAML 语法
+		var raw interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		return yaml.Unmarshal(data, config)
+	case "toml":
+		raw, err := ParseTOML(string(data))
+		if err != nil {
+			return err
+		}
+		converted, err := yaml.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		return yaml.Unmarshal(converted, config)
+	default:
+		return fmt.Errorf("不支持的配置文件格式 %q（可选 .yaml, .yml, .json, .toml）", filepath.Ext(path))
+	}
+}
+
+// configFormat 根据扩展名判断配置格式，无法识别时返回空字符串
+func configFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return ""
+	}
+}
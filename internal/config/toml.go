@@ -0,0 +1,16 @@
+// internal/config/toml.go
+package config
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+// ParseTOML 解析 TOML 文本为通用的 map 结构，用于配置文件和内容文件的 front matter。
+// 日期时间值解析为 time.Time
+func ParseTOML(src string) (map[string]interface{}, error) {
+	raw := make(map[string]interface{})
+	if _, err := toml.Decode(src, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
@@ -0,0 +1,462 @@
+// internal/expression/expression.go
+package expression
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression 沙箱化的表达式，只支持算术、比较、逻辑、三元运算和少量内置函数，
+// 无法访问文件、网络或任何Go对象，只能读取传入的数值变量
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// 表达式的长度限制，避免配置中过长或嵌套过深的表达式耗尽栈空间
+const (
+	maxTokens = 1000 // 符号总数
+	maxDepth  = 64   // 括号、一元运算、三元运算和函数调用的嵌套层数
+)
+
+type exprNode interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+// Compile 解析表达式，语法错误和 variables 之外的变量在加载时即返回
+func Compile(source string, variables []string) (*Expression, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) > maxTokens {
+		return nil, fmt.Errorf("表达式过长，最多%d个符号", maxTokens)
+	}
+
+	p := &exprParser{tokens: tokens, variables: make(map[string]bool, len(variables))}
+	for _, name := range variables {
+		p.variables[name] = true
+	}
+	root, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("表达式第%d个符号 %q 无法解析", p.pos+1, p.tokens[p.pos].text)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// Eval 使用给定变量计算表达式的值
+func (e *Expression) Eval(vars map[string]float64) (float64, error) {
+	return e.root.eval(vars)
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdent
+	tokenOperator
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+}
+
+func tokenizeExpression(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{tokenNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{tokenIdent, string(runes[start:i])})
+		default:
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				switch two {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					tokens = append(tokens, exprToken{tokenOperator, two})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("+-*/%<>!?:(),", r) {
+				return nil, fmt.Errorf("表达式包含非法字符 %q", r)
+			}
+			tokens = append(tokens, exprToken{tokenOperator, string(r)})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens    []exprToken
+	pos       int
+	depth     int             // 当前嵌套层数
+	variables map[string]bool // 表达式可读取的变量
+}
+
+// enter 进入一层嵌套，超过 maxDepth 时返回错误；成功时调用方需在返回前调用 leave
+func (p *exprParser) enter() error {
+	if p.depth >= maxDepth {
+		return fmt.Errorf("表达式嵌套超过%d层", maxDepth)
+	}
+	p.depth++
+	return nil
+}
+
+func (p *exprParser) leave() {
+	p.depth--
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+func (p *exprParser) expect(op string) error {
+	if p.peek() != op {
+		return fmt.Errorf("表达式缺少 %q", op)
+	}
+	p.pos++
+	return nil
+}
+
+// variableList 按字母顺序列出可用变量，用于错误信息
+func (p *exprParser) variableList() string {
+	names := make([]string, 0, len(p.variables))
+	for name := range p.variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (p *exprParser) parseTernary() (exprNode, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "?" {
+		return cond, nil
+	}
+	p.pos++
+
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return ternaryNode{cond, then, otherwise}, nil
+}
+
+// 二元运算符优先级，数值越大优先级越高
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+func (p *exprParser) parseBinary(minPrecedence int) (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		precedence, ok := binaryPrecedence[op]
+		if !ok || precedence <= minPrecedence {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseBinary(precedence)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op, left, right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op := p.peek(); op == "-" || op == "!" {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op, operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("表达式意外结束")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch token.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的数字 %q", token.text)
+		}
+		return numberNode(value), nil
+	case tokenIdent:
+		if p.peek() != "(" {
+			if !p.variables[token.text] {
+				return nil, fmt.Errorf("未知变量 %s（可用变量: %s）", token.text, p.variableList())
+			}
+			return variableNode(token.text), nil
+		}
+		p.pos++
+		var args []exprNode
+		for p.peek() != ")" {
+			arg, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek() == "," {
+				p.pos++
+			} else if p.peek() != ")" {
+				return nil, fmt.Errorf("函数 %s 的参数列表格式错误", token.text)
+			}
+		}
+		p.pos++
+		if _, ok := exprFunctions[token.text]; !ok {
+			return nil, fmt.Errorf("未知函数 %s", token.text)
+		}
+		return callNode{token.text, args}, nil
+	default:
+		if token.text == "(" {
+			inner, err := p.parseTernary()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+		return nil, fmt.Errorf("表达式中意外的符号 %q", token.text)
+	}
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type variableNode string
+
+func (n variableNode) eval(vars map[string]float64) (float64, error) {
+	value, ok := vars[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("未知变量 %s", string(n))
+	}
+	return value, nil
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n unaryNode) eval(vars map[string]float64) (float64, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if n.op == "-" {
+		return -value, nil
+	}
+	return boolToFloat(value == 0), nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(vars map[string]float64) (float64, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	// 逻辑运算短路求值
+	switch n.op {
+	case "&&":
+		if left == 0 {
+			return 0, nil
+		}
+	case "||":
+		if left != 0 {
+			return 1, nil
+		}
+	}
+
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, fmt.Errorf("表达式除数为0")
+		}
+		return left / right, nil
+	case "%":
+		if right == 0 {
+			return 0, fmt.Errorf("表达式除数为0")
+		}
+		return math.Mod(left, right), nil
+	case "<":
+		return boolToFloat(left < right), nil
+	case "<=":
+		return boolToFloat(left <= right), nil
+	case ">":
+		return boolToFloat(left > right), nil
+	case ">=":
+		return boolToFloat(left >= right), nil
+	case "==":
+		return boolToFloat(left == right), nil
+	case "!=":
+		return boolToFloat(left != right), nil
+	default: // && 和 || 在左值不短路时由右值决定
+		return boolToFloat(right != 0), nil
+	}
+}
+
+type ternaryNode struct {
+	cond, then, otherwise exprNode
+}
+
+func (n ternaryNode) eval(vars map[string]float64) (float64, error) {
+	cond, err := n.cond.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if cond != 0 {
+		return n.then.eval(vars)
+	}
+	return n.otherwise.eval(vars)
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n callNode) eval(vars map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+	return exprFunctions[n.name](args)
+}
+
+// 表达式可调用的内置函数
+var exprFunctions = map[string]func(args []float64) (float64, error){
+	"min": func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("min 至少需要一个参数")
+		}
+		result := args[0]
+		for _, v := range args[1:] {
+			result = math.Min(result, v)
+		}
+		return result, nil
+	},
+	"max": func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("max 至少需要一个参数")
+		}
+		result := args[0]
+		for _, v := range args[1:] {
+			result = math.Max(result, v)
+		}
+		return result, nil
+	},
+	"clamp": func(args []float64) (float64, error) {
+		if len(args) != 3 {
+			return 0, fmt.Errorf("clamp 需要3个参数: clamp(value, min, max)")
+		}
+		return math.Max(args[1], math.Min(args[0], args[2])), nil
+	},
+	"abs": func(args []float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("abs 需要1个参数")
+		}
+		return math.Abs(args[0]), nil
+	},
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
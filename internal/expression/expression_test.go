@@ -0,0 +1,131 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+)
+
+var testVariables = []string{"word_count", "total"}
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"word_count": 600, "total": 70}
+	tests := []struct {
+		source string
+		want   float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-word_count / 100", -6},
+		{"word_count >= 300 ? 80 : word_count / 300 * 80", 80},
+		{"word_count < 300 ? 80 : 50", 50},
+		{"min(word_count / 5, 100)", 100},
+		{"max(total, 90)", 90},
+		{"clamp(total - 100, 0, 100)", 0},
+		{"abs(total - 100)", 30},
+		{"total > 60 && word_count > 1000", 0},
+		{"total > 60 || word_count > 1000", 1},
+		{"!(total == 70)", 0},
+		{"word_count % 7", 5},
+	}
+	for _, tt := range tests {
+		expr, err := Compile(tt.source, testVariables)
+		if err != nil {
+			t.Errorf("Compile(%q) 失败: %v", tt.source, err)
+			continue
+		}
+		got, err := expr.Eval(vars)
+		if err != nil {
+			t.Errorf("Eval(%q) 失败: %v", tt.source, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"word_cout / 5", "未知变量 word_cout"},
+		{"min(wordcount, 1)", "未知变量 wordcount"},
+		{"exec(1)", "未知函数 exec"},
+		{"1 +", "表达式意外结束"},
+		{"(1 + 2", "缺少"},
+		{"total $ 2", "非法字符"},
+		{"1 2", "无法解析"},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.source, testVariables)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) 错误 = %v, 应包含 %q", tt.source, err, tt.want)
+		}
+	}
+}
+
+func TestEvalDivisionByZero(t *testing.T) {
+	expr, err := Compile("total / word_count", testVariables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := expr.Eval(map[string]float64{"total": 1, "word_count": 0}); err == nil {
+		t.Error("除数为0时应返回错误")
+	}
+}
+
+func TestCompileLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"括号嵌套过深", strings.Repeat("(", 100) + "1" + strings.Repeat(")", 100), "嵌套超过"},
+		{"一元运算过多", strings.Repeat("-", 100) + "1", "嵌套超过"},
+		{"三元运算嵌套过深", strings.Repeat("total ? ", 100) + "1" + strings.Repeat(" : 0", 100), "嵌套超过"},
+		{"函数调用嵌套过深", strings.Repeat("abs(", 100) + "1" + strings.Repeat(")", 100), "嵌套超过"},
+		{"符号过多", "1" + strings.Repeat(" + 1", 1000), "表达式过长"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.source, testVariables)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile() 错误 = %v, 应包含 %q", err, tt.want)
+			}
+		})
+	}
+
+	// 限制之内的嵌套照常计算
+	expr, err := Compile(strings.Repeat("(", 30)+"total"+strings.Repeat(")", 30), testVariables)
+	if err != nil {
+		t.Fatalf("30层括号应能解析: %v", err)
+	}
+	if got, err := expr.Eval(map[string]float64{"total": 70}); err != nil || got != 70 {
+		t.Errorf("Eval() = %v, %v, want 70", got, err)
+	}
+}
+
+func FuzzCompile(f *testing.F) {
+	for _, seed := range []string{
+		"1 + 2 * 3",
+		"word_count >= 300 ? 80 : word_count / 300 * 80",
+		"clamp(total - 100, 0, 100)",
+		"!(total == 70) && -word_count < 0",
+		"((((1))))",
+		"min(",
+		"? : ,",
+	} {
+		f.Add(seed)
+	}
+
+	vars := map[string]float64{"word_count": 600, "total": 70}
+	f.Fuzz(func(t *testing.T, source string) {
+		expr, err := Compile(source, testVariables)
+		if err != nil {
+			return
+		}
+		// 编译通过的表达式求值不应 panic，除数为0等错误正常返回
+		expr.Eval(vars)
+	})
+}
@@ -13,8 +13,27 @@ type Content struct {
 	PublishedAt time.Time  `json:"published_at,omitempty"`
 	Author      string     `json:"author,omitempty"`
 	FilePath    string     `json:"file_path,omitempty"`
-	Type        string     `json:"type"` // post, story, video等
+	Type        string     `json:"type"`               // post, story, video等
+	Series      string     `json:"series,omitempty"`   // 所属系列/活动
+	Platform    string     `json:"platform,omitempty"` // 发布平台，覆盖 analysis.target_platform
 	Engagement  Engagement `json:"engagement,omitempty"`
+	Comments    []string   `json:"comments,omitempty"` // 评论/回复原文，用于分析社区情感
+
+	// PrimaryKeyword SEO主关键词，用于检查关键词密度，未设置时使用提取出的最高频关键词
+	PrimaryKeyword string `json:"primary_keyword,omitempty"`
+
+	// ScoreWeights 单篇内容的评分权重，设置后覆盖全局权重
+	ScoreWeights *ScoreWeights `json:"score_weights,omitempty"`
+}
+
+// ScoreWeights 各评分维度的权重
+type ScoreWeights struct {
+	ContentQuality float64 `json:"content_quality"`
+	Engagement     float64 `json:"engagement"`
+	Visual         float64 `json:"visual"`
+	Title          float64 `json:"title"`
+	Readability    float64 `json:"readability"`
+	TrendRelevance float64 `json:"trend_relevance"`
 }
 
 // Image 图片信息
@@ -26,6 +45,19 @@ type Image struct {
 	Height  int    `json:"height,omitempty"`
 	Size    int64  `json:"size,omitempty"`
 	Format  string `json:"format,omitempty"`
+
+	// 以下来自 JPEG 的 EXIF，没有时为空
+	CapturedAt  *time.Time   `json:"captured_at,omitempty"`
+	CameraMake  string       `json:"camera_make,omitempty"`
+	CameraModel string       `json:"camera_model,omitempty"`
+	Orientation int          `json:"orientation,omitempty"` // EXIF方向值1-8，5-8表示旋转90/270度，Width/Height已按显示方向交换
+	GPSLocation *GPSLocation `json:"gps_location,omitempty"`
+}
+
+// GPSLocation 拍摄地点，十进制度数，南纬、西经为负
+type GPSLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // Engagement 互动数据
@@ -36,20 +68,50 @@ type Engagement struct {
 	Views    int `json:"views,omitempty"`
 }
 
+// Rate 互动率 = (点赞+评论+分享) / 浏览量，没有浏览量时返回 false
+func (e Engagement) Rate() (float64, bool) {
+	if e.Views <= 0 {
+		return 0, false
+	}
+	return float64(e.Likes+e.Comments+e.Shares) / float64(e.Views), true
+}
+
 // AnalysisResult 分析结果
 type AnalysisResult struct {
-	ContentID     string             `json:"content_id"`
-	Title         string             `json:"title"`
-	Score         OverallScore       `json:"score"`
-	TextAnalysis  TextAnalysis       `json:"text_analysis"`
-	ImageAnalysis []ImageAnalysis    `json:"image_analysis,omitempty"`
-	Suggestions   []Suggestion       `json:"suggestions"`
-	Keywords      []Keyword          `json:"keywords"`
-	Sentiment     SentimentAnalysis  `json:"sentiment"`
-	Readability   ReadabilityMetrics `json:"readability"`
-	CreatedAt     time.Time          `json:"created_at"`
+	ContentID     string              `json:"content_id"`
+	Title         string              `json:"title"`
+	Author        string              `json:"author,omitempty"`
+	ContentType   string              `json:"content_type,omitempty"`
+	Series        string              `json:"series,omitempty"`
+	Tags          []string            `json:"tags,omitempty"`
+	Topics        []string            `json:"topics,omitempty"`
+	Score         OverallScore        `json:"score"`
+	TextAnalysis  TextAnalysis        `json:"text_analysis"`
+	ImageAnalysis []ImageAnalysis     `json:"image_analysis,omitempty"`
+	Suggestions   []Suggestion        `json:"suggestions"`
+	Minor         []Suggestion        `json:"minor_suggestions,omitempty"` // 置信度低于 min_suggestion_confidence 的建议
+	Keywords      []Keyword           `json:"keywords"`
+	Sentiment     SentimentAnalysis   `json:"sentiment"`
+	Community     *CommunitySentiment `json:"community_sentiment,omitempty"` // 评论区情感，与正文情感分开统计
+	Readability   ReadabilityMetrics  `json:"readability"`
+	Languages     []LanguageMetrics   `json:"languages,omitempty"`  // 按语言片段分别统计的指标
+	Warnings      []string            `json:"warnings,omitempty"`   // 分析过程中被跳过的问题
+	Engagement    Engagement          `json:"engagement,omitempty"` // 内容的历史互动数据
+	PublishedAt   *time.Time          `json:"published_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+
+	// Provenance 可使用AI的分析组件（sentiment、community_sentiment、topics，以及开启 use_ai 时的 entities、hashtags）
+	// 结果的来源: ai, local（未配置AI服务）, fallback（AI失败后降级为本地分析）
+	Provenance map[string]string `json:"provenance,omitempty"`
 }
 
+// AnalysisResult.Provenance 的取值
+const (
+	ProvenanceAI       = "ai"
+	ProvenanceLocal    = "local"
+	ProvenanceFallback = "fallback"
+)
+
 // OverallScore 总体评分
 type OverallScore struct {
 	Total     float64        `json:"total"`     // 总分 0-100
@@ -66,11 +128,14 @@ type ScoreBreakdown struct {
 	Title          float64 `json:"title"`           // 标题吸引力
 	Readability    float64 `json:"readability"`     // 可读性
 	TrendRelevance float64 `json:"trend_relevance"` // 趋势相关性
+
+	Custom map[string]float64 `json:"custom,omitempty"` // 自定义评分维度
 }
 
 // TextAnalysis 文本分析结果
 type TextAnalysis struct {
-	WordCount        int              `json:"word_count"`
+	Language         string           `json:"language"`   // 主要语言: zh, en, mixed, und（无法判断）
+	WordCount        int              `json:"word_count"` // 开启中文分词时中文按词计，否则按空格分词；与 min_word_count 单位相同
 	CharCount        int              `json:"char_count"`
 	ParagraphCount   int              `json:"paragraph_count"`
 	SentenceCount    int              `json:"sentence_count"`
@@ -78,8 +143,65 @@ type TextAnalysis struct {
 	ContentStructure ContentStructure `json:"content_structure"`
 	WritingStyle     WritingStyle     `json:"writing_style"`
 	CallToAction     []string         `json:"call_to_action"`
+	CTAAnalysis      CTAAnalysis      `json:"cta_analysis"`
 	Hashtags         []string         `json:"hashtags"`
 	Mentions         []string         `json:"mentions"`
+	RequiredKeywords KeywordCoverage  `json:"required_keywords"`
+	KeywordDensity   *KeywordDensity  `json:"keyword_density,omitempty"` // 开启 analysis.keyword_density 时
+	Links            []Link           `json:"links,omitempty"`
+	NamedEntities    []Entity         `json:"named_entities,omitempty"`
+	WritingIssues    []WritingIssue   `json:"writing_issues,omitempty"` // 配置 analysis.writing_check 时的拼写和语法问题
+	EmojiCount       int              `json:"emoji_count"`              // 正文中的emoji个数，肤色、零宽连接序列和国旗各算一个
+	Emojis           []string         `json:"emojis,omitempty"`         // 正文中出现的emoji，按首次出现的顺序去重
+	EmojiOveruse     bool             `json:"emoji_overuse,omitempty"`  // emoji密度超过 analysis.max_emoji_density
+
+	// SuggestedHashtags 标题和正文的话题标签少于 analysis.hashtag_suggestions.min_hashtags 时推荐补充的标签，带 # 前缀
+	SuggestedHashtags []string `json:"suggested_hashtags,omitempty"`
+}
+
+// WritingIssue 正文中的一处拼写、语法或错别字问题，位置按字符（rune）计
+type WritingIssue struct {
+	Offset      int      `json:"offset"`
+	Length      int      `json:"length"`
+	Text        string   `json:"text"` // 有问题的原文
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	Rule        string   `json:"rule"`   // LanguageTool 规则ID，或 repeated_char、confusable
+	Source      string   `json:"source"` // languagetool, builtin
+}
+
+// Entity 正文中提到的人物、品牌、产品、地点
+type Entity struct {
+	Name   string `json:"name"` // 标准名，词典中的别名归并到标准名
+	Type   string `json:"type"` // person, brand, product, location；词典之外的大写短语为 unknown
+	Count  int    `json:"count"`
+	Source string `json:"source"` // dictionary: 词典, capitalized: 英文大写短语, ai: AI识别
+}
+
+// Link 正文中的链接及其锚文本
+type Link struct {
+	URL         string `json:"url"`
+	Anchor      string `json:"anchor"`
+	Source      string `json:"source"` // markdown, html
+	Descriptive bool   `json:"descriptive"`
+	Issue       string `json:"issue,omitempty"` // empty: 无锚文本, url: 锚文本为网址, generic: "点击这里"类笼统文字
+}
+
+// KeywordDensity 主关键词密度与目标区间
+type KeywordDensity struct {
+	Keyword     string  `json:"keyword"`
+	Source      string  `json:"source"` // content: 内容指定, extracted: 自动提取
+	Occurrences int     `json:"occurrences"`
+	Density     float64 `json:"density"` // 关键词所占词数比例，汉字按字计
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Status      string  `json:"status"` // below, within, above
+}
+
+// KeywordCoverage 必需关键词覆盖情况
+type KeywordCoverage struct {
+	Present []string `json:"present,omitempty"`
+	Missing []string `json:"missing,omitempty"`
 }
 
 // TitleAnalysis 标题分析
@@ -92,16 +214,57 @@ type TitleAnalysis struct {
 	PowerWords     []string `json:"power_words"`
 	ClickbaitScore float64  `json:"clickbait_score"`
 	ClarityScore   float64  `json:"clarity_score"`
+
+	// 目标平台的标题显示上限，未设置平台时为空
+	Platform      string `json:"platform,omitempty"`
+	DisplayLength int    `json:"display_length,omitempty"` // 按平台计量单位统计的长度
+	MaxLength     int    `json:"max_length,omitempty"`
+	Unit          string `json:"unit,omitempty"`      // chars, width
+	Truncated     bool   `json:"truncated,omitempty"` // 超出上限，在信息流中会被截断
+
+	Hashtags      []string `json:"hashtags,omitempty"`       // 标题中的话题标签，与正文的 hashtags 分开统计
+	HashtagStatus string   `json:"hashtag_status,omitempty"` // 相对平台习惯: too_few, ok, too_many；未设置平台时为空
 }
 
 // ContentStructure 内容结构分析
 type ContentStructure struct {
-	HasIntro        bool   `json:"has_intro"`
-	HasConclusion   bool   `json:"has_conclusion"`
-	HasBulletPoints bool   `json:"has_bullet_points"`
-	HasNumbers      bool   `json:"has_numbers"`
-	SectionCount    int    `json:"section_count"`
-	Structure       string `json:"structure"` // linear, story, list, qa等
+	HasIntro        bool            `json:"has_intro"`
+	HasConclusion   bool            `json:"has_conclusion"`
+	HasBulletPoints bool            `json:"has_bullet_points"`
+	HasNumbers      bool            `json:"has_numbers"`
+	SectionCount    int             `json:"section_count"`
+	Structure       string          `json:"structure"` // linear, story, list, qa等
+	Headings        HeadingAnalysis `json:"headings"`
+}
+
+// HeadingAnalysis 标题层级分析
+type HeadingAnalysis struct {
+	Headings        []Heading `json:"headings,omitempty"`
+	H1Count         int       `json:"h1_count"`
+	MissingTopLevel bool      `json:"missing_top_level"`
+	SkippedLevels   []string  `json:"skipped_levels,omitempty"` // 如 "h1→h3"
+	Issues          []string  `json:"issues,omitempty"`
+}
+
+// Heading 单个标题
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// CTAAnalysis 行动召唤位置与强度分析
+type CTAAnalysis struct {
+	Count      int            `json:"count"`
+	Placements []CTAPlacement `json:"placements,omitempty"`
+	HasEndCTA  bool           `json:"has_end_cta"` // 结尾处是否有行动召唤
+	Strength   float64        `json:"strength"`    // 0-1 整体强度
+}
+
+// CTAPlacement 单个行动召唤
+type CTAPlacement struct {
+	Text     string `json:"text,omitempty"`
+	Position string `json:"position"` // early, middle, end
+	Strength string `json:"strength"` // strong, weak
 }
 
 // WritingStyle 写作风格分析
@@ -113,16 +276,73 @@ type WritingStyle struct {
 	Authenticity      float64 `json:"authenticity"`       // 0-1 真实感
 }
 
+// AIUsage 一次运行中AI请求累计的token用量和估算费用
+type AIUsage struct {
+	Requests         int      `json:"requests"`
+	CachedResponses  int      `json:"cached_responses,omitempty"` // 命中 ai.cache、未实际请求的次数
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	TotalTokens      int      `json:"total_tokens"`
+	EstimatedCost    float64  `json:"estimated_cost"`            // 按 ai.pricing 估算的费用
+	UnpricedModels   []string `json:"unpriced_models,omitempty"` // ai.pricing 中没有价格的模型，其用量未计入费用
+}
+
 // ImageAnalysis 图片分析结果
 type ImageAnalysis struct {
 	Path                string              `json:"path"`
+	Info                Image               `json:"info"` // 尺寸、格式及EXIF拍摄信息
 	VisualElements      VisualElements      `json:"visual_elements"`
 	CompositionAnalysis CompositionAnalysis `json:"composition"`
 	QualityMetrics      QualityMetrics      `json:"quality"`
 	StyleAnalysis       StyleAnalysis       `json:"style"`
+	FocalPoint          FocalPoint          `json:"focal_point"`                  // 画面主体位置
+	Crops               []CropSuggestion    `json:"crops,omitempty"`              // 各宽高比的建议裁剪框
+	TextOverlay         *ImageText          `json:"text_overlay,omitempty"`       // 启用OCR且识别到文字时
+	ExtractedText       string              `json:"extracted_text,omitempty"`     // OCR识别出的文字，计入关键词和情感分析
+	PerceptualHash      string              `json:"perceptual_hash,omitempty"`    // dHash 感知哈希，用于查找重复使用的图片
+	SuggestedAltText    string              `json:"suggested_alt_text,omitempty"` // 图片没有说明时AI建议的替代文本
+	AspectRatio         *AspectRatioCheck   `json:"aspect_ratio,omitempty"`       // 设置了目标平台且平台有推荐宽高比时
+	Error               string              `json:"error,omitempty"`              // 批量分析中该图片失败的原因，失败时其余字段为空
 	Score               float64             `json:"score"`
 }
 
+// ImageText 图片中文字的清晰度和可读性
+type ImageText struct {
+	Text             string  `json:"text"`
+	LineCount        int     `json:"line_count"`
+	SmallLines       int     `json:"small_lines"`        // 行高低于 min_text_height 的行数
+	LowContrastLines int     `json:"low_contrast_lines"` // 对比度低于 min_contrast 的行数
+	MinHeightRatio   float64 `json:"min_height_ratio"`   // 最小行高占图片高度的比例
+	MinContrast      float64 `json:"min_contrast"`       // 最低的文字对比度
+	Legibility       float64 `json:"legibility"`         // 字号和对比度得分 0-100
+	Readability      float64 `json:"readability"`        // 文字本身的可读性得分 0-100
+	Score            float64 `json:"score"`
+}
+
+// FocalPoint 画面主体的相对位置，0-1，左上角为原点
+type FocalPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// AspectRatioCheck 图片宽高比与目标平台推荐比例的对比
+type AspectRatioCheck struct {
+	Platform    string   `json:"platform"`
+	Ratio       float64  `json:"ratio"`             // 实际宽高比（宽/高）
+	Recommended []string `json:"recommended"`       // 平台推荐的宽高比
+	Matched     string   `json:"matched,omitempty"` // 符合的推荐比例，不符合时为空
+	Nearest     string   `json:"nearest"`           // 最接近的推荐比例，不符合时建议裁剪为该比例
+}
+
+// CropSuggestion 保持主体在画面内的裁剪框，坐标为原图像素
+type CropSuggestion struct {
+	Aspect string `json:"aspect"` // 如 1:1, 4:5, 16:9
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 // VisualElements 视觉元素分析
 type VisualElements struct {
 	DominantColors []string `json:"dominant_colors"`
@@ -131,6 +351,7 @@ type VisualElements struct {
 	Saturation     float64  `json:"saturation"`
 	HasText        bool     `json:"has_text"`
 	HasFaces       bool     `json:"has_faces"`
+	FaceCount      int      `json:"face_count"`
 	ObjectCount    int      `json:"object_count"`
 }
 
@@ -170,6 +391,24 @@ type Suggestion struct {
 	Reasoning   string   `json:"reasoning"`          // 建议理由
 	Examples    []string `json:"examples,omitempty"` // 示例
 	Impact      string   `json:"impact"`             // 预期影响
+	Factor      string   `json:"factor,omitempty"`   // 建议针对的内容特征，用于基于历史数据估算影响
+	Confidence  float64  `json:"confidence"`         // 0-1 触发信号的强度，越接近阈值越低
+	// ProjectedGain 假设采纳该建议后总分的变化（不含自定义评分维度），无法模拟时为0
+	ProjectedGain float64 `json:"projected_gain,omitempty"`
+}
+
+// ContentDiff 按建议改写后的内容及其相对原文的修改，供界面以修订模式显示
+type ContentDiff struct {
+	Improved string     `json:"improved"`
+	Hunks    []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk 一处插入或删除，Position 为在原文中的位置（按字符计）：
+// insert 插入在该位置之前，delete 从该位置开始删除 Text
+type DiffHunk struct {
+	Op       string `json:"op"` // insert, delete
+	Position int    `json:"position"`
+	Text     string `json:"text"`
 }
 
 // Keyword 关键词分析
@@ -179,6 +418,7 @@ type Keyword struct {
 	Relevance float64 `json:"relevance"`
 	Trend     string  `json:"trend"`    // rising, stable, declining
 	Category  string  `json:"category"` // topic, emotion, action等
+	Language  string  `json:"language,omitempty"`
 }
 
 // SentimentAnalysis 情感分析
@@ -189,12 +429,50 @@ type SentimentAnalysis struct {
 	Confidence float64            `json:"confidence"` // 置信度
 }
 
+// CommunitySentiment 评论区的情感分布
+type CommunitySentiment struct {
+	CommentCount int     `json:"comment_count"` // 评论总数
+	Analyzed     int     `json:"analyzed"`      // 参与情感分析的评论数
+	Overall      string  `json:"overall"`       // positive, negative, neutral
+	Score        float64 `json:"score"`         // -1 到 1，各条评论得分的平均值
+	Positive     int     `json:"positive"`
+	Neutral      int     `json:"neutral"`
+	Negative     int     `json:"negative"`
+}
+
+// LanguageMetrics 单一语言片段的可读性和关键词统计
+type LanguageMetrics struct {
+	Language    string             `json:"language"`   // zh, en, other
+	Share       float64            `json:"share"`      // 按阅读时长计算的占比
+	WordCount   int                `json:"word_count"` // 中文按字计数
+	Readability ReadabilityMetrics `json:"readability"`
+	Keywords    []string           `json:"keywords,omitempty"`
+}
+
 // ReadabilityMetrics 可读性指标
 type ReadabilityMetrics struct {
-	FleschScore       float64 `json:"flesch_score"` // Flesch阅读难度
+	// 计算方法: flesch_kincaid（按音节，以空格分词的语言）, zh_sentence_length（中文按句长和长句比例换算的0-100分，不是Flesch公式）,
+	// mixed（多种语言按阅读时长加权）
+	Method            string  `json:"method,omitempty"`
+	FleschScore       float64 `json:"flesch_score"` // Flesch阅读难度，中文为同量纲的句长得分
+	GradeLevel        float64 `json:"grade_level"`  // Flesch-Kincaid 年级，只统计按音节计算的语言，纯中文为0
 	AvgSentenceLength float64 `json:"avg_sentence_length"`
 	AvgWordLength     float64 `json:"avg_word_length"`
 	ComplexWordRatio  float64 `json:"complex_word_ratio"`
 	ReadingTime       int     `json:"reading_time"` // 预估阅读时间（秒）
 	Grade             string  `json:"grade"`        // 阅读等级
+
+	Variety StructureVariety `json:"variety"`
+}
+
+// StructureVariety 句子和段落长度的变化，长度中文按字、英文按词计
+type StructureVariety struct {
+	Sentences            int     `json:"sentences"`
+	Paragraphs           int     `json:"paragraphs"`
+	SentenceLengthSD     float64 `json:"sentence_length_sd"`
+	ParagraphLengthSD    float64 `json:"paragraph_length_sd"`
+	SentenceVariation    float64 `json:"sentence_variation"`  // 变异系数 = 标准差/平均长度
+	ParagraphVariation   float64 `json:"paragraph_variation"` // 变异系数 = 标准差/平均长度
+	MonotonousSentences  bool    `json:"monotonous_sentences"`
+	MonotonousParagraphs bool    `json:"monotonous_paragraphs"`
 }
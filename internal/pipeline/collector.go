@@ -0,0 +1,142 @@
+// internal/pipeline/collector.go
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCollectorClosed 关闭后继续提交结果
+var ErrCollectorClosed = errors.New("结果收集器已关闭")
+
+// OrderedCollector 接收乱序完成的结果，按输入序号依次输出，保证并发分析得到的报告顺序稳定。
+//
+// 序号从0开始且必须连续。最多缓存 window 个尚未轮到输出的结果，
+// 超出时 Add 阻塞，直到前面的结果被取走，避免慢消费者导致内存无限增长。
+type OrderedCollector[T any] struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending map[int]T
+	next    int // 下一个要输出的序号
+	window  int
+	closed  bool
+	err     error
+
+	out chan T
+}
+
+// NewOrderedCollector 创建收集器，window 小于1时按1处理（即完全按顺序提交）
+func NewOrderedCollector[T any](window int) *OrderedCollector[T] {
+	if window < 1 {
+		window = 1
+	}
+	c := &OrderedCollector[T]{
+		pending: make(map[int]T),
+		window:  window,
+		out:     make(chan T),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	go c.emit()
+	return c
+}
+
+// Add 提交第 index 个输入的结果，可在多个 goroutine 中并发调用。
+// 结果超出缓存窗口时阻塞；序号重复、已输出或收集器已关闭时返回错误
+func (c *OrderedCollector[T]) Add(index int, value T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for !c.closed && index >= c.next+c.window {
+		c.cond.Wait()
+	}
+	if c.closed {
+		return ErrCollectorClosed
+	}
+	if _, ok := c.pending[index]; ok || index < c.next {
+		return fmt.Errorf("结果序号 %d 重复提交", index)
+	}
+
+	c.pending[index] = value
+	c.cond.Broadcast()
+	return nil
+}
+
+// Close 表示不再提交结果。已提交的结果输出完后关闭 Results 通道
+func (c *OrderedCollector[T]) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	c.cond.Broadcast()
+}
+
+// Results 按序号顺序输出结果的通道，调用方需持续读取直到通道关闭
+func (c *OrderedCollector[T]) Results() <-chan T {
+	return c.out
+}
+
+// Err 在 Results 关闭后调用：关闭时若有序号缺失，后面的结果无法输出，返回缺失的序号
+func (c *OrderedCollector[T]) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// emit 按顺序取出结果写入输出通道，写入时不持有锁，消费者慢时只阻塞自身
+func (c *OrderedCollector[T]) emit() {
+	defer close(c.out)
+
+	for {
+		c.mu.Lock()
+		for !c.closed && !c.ready() {
+			c.cond.Wait()
+		}
+		if !c.ready() {
+			// 已关闭且下一个序号没有提交
+			if len(c.pending) > 0 {
+				c.err = fmt.Errorf("结果序号 %d 缺失，之后的 %d 个结果未输出", c.next, len(c.pending))
+			}
+			c.mu.Unlock()
+			return
+		}
+		value := c.pending[c.next]
+		delete(c.pending, c.next)
+		c.next++
+		c.mu.Unlock()
+
+		c.out <- value
+
+		// 取走后才唤醒等待窗口的 Add，消费者慢时提交方随之阻塞
+		c.mu.Lock()
+		c.cond.Broadcast()
+		c.mu.Unlock()
+	}
+}
+
+func (c *OrderedCollector[T]) ready() bool {
+	_, ok := c.pending[c.next]
+	return ok
+}
+
+// Collect 将 0..n-1 号结果按顺序收集为切片，适合结果数量已知、不需要流式处理的场景。
+// produce 负责（通常并发地）计算每个结果并调用 add 提交
+func Collect[T any](n, window int, produce func(add func(index int, value T) error)) ([]T, error) {
+	c := NewOrderedCollector[T](window)
+	go func() {
+		produce(c.Add)
+		c.Close()
+	}()
+
+	results := make([]T, 0, n)
+	for value := range c.Results() {
+		results = append(results, value)
+	}
+	if err := c.Err(); err != nil {
+		return results, err
+	}
+	if len(results) != n {
+		return results, fmt.Errorf("收到 %d 个结果，预期 %d 个", len(results), n)
+	}
+	return results, nil
+}
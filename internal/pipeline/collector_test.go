@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderedCollectorOutOfOrder(t *testing.T) {
+	const n, window = 200, 8
+	c := NewOrderedCollector[int](window)
+
+	// 每个结果由独立的 goroutine 随机延迟后提交，完成顺序与序号无关
+	var wg sync.WaitGroup
+	for i := n - 1; i >= 0; i-- {
+		wg.Add(1)
+		go func(index int, delay time.Duration) {
+			defer wg.Done()
+			time.Sleep(delay)
+			if err := c.Add(index, index*10); err != nil {
+				t.Errorf("Add(%d) error = %v", index, err)
+			}
+		}(i, time.Duration(rand.Intn(2000))*time.Microsecond)
+	}
+	go func() {
+		wg.Wait()
+		c.Close()
+	}()
+
+	var got []int
+	for value := range c.Results() {
+		got = append(got, value)
+		c.mu.Lock()
+		if pending := len(c.pending); pending > window {
+			t.Errorf("缓存了 %d 个结果，超过窗口 %d", pending, window)
+		}
+		c.mu.Unlock()
+	}
+
+	if err := c.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(got) != n {
+		t.Fatalf("输出 %d 个结果, want %d", len(got), n)
+	}
+	for i, value := range got {
+		if value != i*10 {
+			t.Fatalf("第%d个结果 = %d, want %d", i, value, i*10)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	results, err := Collect[string](3, 2, func(add func(int, string) error) {
+		var wg sync.WaitGroup
+		for i, s := range []string{"c", "b", "a"} {
+			wg.Add(1)
+			go func(index int, s string) {
+				defer wg.Done()
+				add(index, s)
+			}(2-i, s)
+		}
+		wg.Wait()
+	})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(results) != 3 || results[0] != "a" || results[1] != "b" || results[2] != "c" {
+		t.Errorf("Collect() = %v, want [a b c]", results)
+	}
+
+	// 缺少1号结果时只输出0号，并报告缺失
+	results, err = Collect[string](3, 4, func(add func(int, string) error) {
+		add(2, "c")
+		add(0, "a")
+	})
+	if err == nil || len(results) != 1 || results[0] != "a" {
+		t.Errorf("Collect() = %v, %v, want [a] 和缺失错误", results, err)
+	}
+}
+
+func TestOrderedCollectorRejectsDuplicateAndClosed(t *testing.T) {
+	c := NewOrderedCollector[int](4)
+	if err := c.Add(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(1, 1); err == nil {
+		t.Error("重复提交序号 1, want 错误")
+	}
+	if err := c.Add(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	<-c.Results()
+	if err := c.Add(0, 0); err == nil {
+		t.Error("提交已输出的序号 0, want 错误")
+	}
+
+	c.Close()
+	if err := c.Add(2, 2); !errors.Is(err, ErrCollectorClosed) {
+		t.Errorf("关闭后 Add() error = %v, want %v", err, ErrCollectorClosed)
+	}
+	for range c.Results() {
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
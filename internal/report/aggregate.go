@@ -0,0 +1,277 @@
+// internal/report/aggregate.go
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// AggregateReport 多个客户分析报告的汇总
+type AggregateReport struct {
+	GeneratedAt       time.Time       `json:"generated_at"`
+	TotalContent      int             `json:"total_content"`
+	OverallScore      float64         `json:"overall_score"`
+	Clients           []ClientSummary `json:"clients"`
+	ScoreDistribution []ScoreBucket   `json:"score_distribution"`
+	SharedIssues      []SharedIssue   `json:"shared_issues,omitempty"`
+	Warnings          []string        `json:"warnings,omitempty"`
+}
+
+// ClientSummary 单个客户报告的概要
+type ClientSummary struct {
+	Name          string                `json:"name"`
+	Source        string                `json:"source"`
+	GeneratedAt   string                `json:"generated_at,omitempty"`
+	ContentCount  int                   `json:"content_count"`
+	AverageScore  float64               `json:"average_score"`
+	AverageScores models.ScoreBreakdown `json:"average_scores"`
+	CommonIssues  []string              `json:"common_issues,omitempty"`
+	scores        []float64
+}
+
+// ScoreBucket 总分分布区间
+type ScoreBucket struct {
+	Range string `json:"range"`
+	Count int    `json:"count"`
+}
+
+// SharedIssue 多个客户共有的常见问题
+type SharedIssue struct {
+	Issue   string   `json:"issue"`
+	Clients []string `json:"clients"`
+}
+
+// 分布区间下限，与报告中的等级分数线一致
+var scoreBucketBounds = []struct {
+	label string
+	min   float64
+}{
+	{"85-100", 85},
+	{"70-85", 70},
+	{"50-70", 50},
+	{"0-50", 0},
+}
+
+// 去掉常见问题后的篇数后缀，如 "标题吸引力不足 (3篇)"
+var issueCountSuffix = regexp.MustCompile(`\s*\(\d+篇\)$`)
+
+// LoadClientReport 读取一次分析生成的 analysis_report.json。
+// 只解析汇总需要的字段，旧版本或字段缺失的报告也能读取；缺少 results 时返回错误。
+func LoadClientReport(name, path string) (ClientSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ClientSummary{}, err
+	}
+
+	var raw struct {
+		GeneratedAt json.RawMessage `json:"generated_at"`
+		Results     *[]struct {
+			Score struct {
+				Total     float64               `json:"total"`
+				Breakdown models.ScoreBreakdown `json:"breakdown"`
+			} `json:"score"`
+		} `json:"results"`
+		Summary struct {
+			CommonIssues []string `json:"common_issues"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return ClientSummary{}, fmt.Errorf("解析报告失败: %w", err)
+	}
+	if raw.Results == nil {
+		return ClientSummary{}, fmt.Errorf("报告中没有 results 字段")
+	}
+
+	summary := ClientSummary{
+		Name:         name,
+		Source:       path,
+		ContentCount: len(*raw.Results),
+	}
+
+	var generatedAt string
+	if json.Unmarshal(raw.GeneratedAt, &generatedAt) == nil {
+		summary.GeneratedAt = generatedAt
+	}
+
+	for _, result := range *raw.Results {
+		summary.scores = append(summary.scores, result.Score.Total)
+		summary.AverageScore += result.Score.Total
+
+		b := result.Score.Breakdown
+		summary.AverageScores.ContentQuality += b.ContentQuality
+		summary.AverageScores.Engagement += b.Engagement
+		summary.AverageScores.Visual += b.Visual
+		summary.AverageScores.Title += b.Title
+		summary.AverageScores.Readability += b.Readability
+		summary.AverageScores.TrendRelevance += b.TrendRelevance
+	}
+
+	if n := float64(summary.ContentCount); n > 0 {
+		summary.AverageScore /= n
+		summary.AverageScores.ContentQuality /= n
+		summary.AverageScores.Engagement /= n
+		summary.AverageScores.Visual /= n
+		summary.AverageScores.Title /= n
+		summary.AverageScores.Readability /= n
+		summary.AverageScores.TrendRelevance /= n
+	}
+
+	for _, issue := range raw.Summary.CommonIssues {
+		summary.CommonIssues = append(summary.CommonIssues, issueCountSuffix.ReplaceAllString(issue, ""))
+	}
+
+	return summary, nil
+}
+
+// Aggregate 汇总各客户报告：整体平均分、总分分布、两个及以上客户共有的问题
+func Aggregate(clients []ClientSummary) AggregateReport {
+	agg := AggregateReport{
+		GeneratedAt: time.Now(),
+		Clients:     clients,
+	}
+
+	var scores []float64
+	issueClients := make(map[string][]string)
+	for _, client := range clients {
+		agg.TotalContent += client.ContentCount
+		scores = append(scores, client.scores...)
+
+		for _, issue := range client.CommonIssues {
+			issueClients[issue] = append(issueClients[issue], client.Name)
+		}
+	}
+
+	sum := 0.0
+	for _, score := range scores {
+		sum += score
+	}
+	if len(scores) > 0 {
+		agg.OverallScore = sum / float64(len(scores))
+	}
+
+	for _, bound := range scoreBucketBounds {
+		agg.ScoreDistribution = append(agg.ScoreDistribution, ScoreBucket{Range: bound.label})
+	}
+	for _, score := range scores {
+		for i, bound := range scoreBucketBounds {
+			if score >= bound.min {
+				agg.ScoreDistribution[i].Count++
+				break
+			}
+		}
+	}
+
+	for issue, names := range issueClients {
+		if len(names) >= 2 {
+			agg.SharedIssues = append(agg.SharedIssues, SharedIssue{Issue: issue, Clients: names})
+		}
+	}
+	sort.Slice(agg.SharedIssues, func(i, j int) bool {
+		if len(agg.SharedIssues[i].Clients) != len(agg.SharedIssues[j].Clients) {
+			return len(agg.SharedIssues[i].Clients) > len(agg.SharedIssues[j].Clients)
+		}
+		return agg.SharedIssues[i].Issue < agg.SharedIssues[j].Issue
+	})
+
+	return agg
+}
+
+// GenerateAggregateReport 输出汇总报告（JSON 和 HTML）到输出目录
+func (r *Reporter) GenerateAggregateReport(agg AggregateReport) error {
+	if err := r.prepareOutputDir(); err != nil {
+		return err
+	}
+
+	jsonFile, err := os.Create(filepath.Join(r.config.OutputDir, "aggregate_report.json"))
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(agg); err != nil {
+		return fmt.Errorf("生成JSON汇总报告失败: %w", err)
+	}
+
+	htmlFile, err := os.Create(filepath.Join(r.config.OutputDir, "aggregate_report.html"))
+	if err != nil {
+		return err
+	}
+	defer htmlFile.Close()
+
+	tmpl, err := template.New("aggregate").Parse(aggregateTemplate)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(htmlFile, agg); err != nil {
+		return fmt.Errorf("生成HTML汇总报告失败: %w", err)
+	}
+
+	return nil
+}
+
+const aggregateTemplate = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+    <meta charset="UTF-8">
+    <title>多客户汇总报告</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', sans-serif; margin: 0; padding: 20px; background: #f5f7fa; }
+        .container { max-width: 1200px; margin: 0 auto; }
+        .card { background: white; padding: 20px; border-radius: 10px; margin-bottom: 20px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        table { width: 100%; border-collapse: collapse; }
+        th, td { padding: 8px; border-bottom: 1px solid #eee; text-align: left; }
+        .warning { color: #856404; background: #fff3cd; padding: 8px; border-radius: 5px; margin: 5px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="card">
+            <h1>多客户汇总报告</h1>
+            <p>生成时间: {{.GeneratedAt.Format "2006-01-02 15:04:05"}} | 客户数: {{len .Clients}} | 内容总数: {{.TotalContent}} | 整体平均分: {{printf "%.1f" .OverallScore}}</p>
+            {{range .Warnings}}<div class="warning">{{.}}</div>{{end}}
+        </div>
+
+        <div class="card">
+            <h2>各客户概况</h2>
+            <table>
+                <tr><th>客户</th><th>内容数</th><th>平均分</th><th>内容质量</th><th>互动性</th><th>视觉</th><th>标题</th><th>可读性</th><th>趋势性</th></tr>
+                {{range .Clients}}
+                <tr>
+                    <td>{{.Name}}</td><td>{{.ContentCount}}</td><td>{{printf "%.1f" .AverageScore}}</td>
+                    <td>{{printf "%.1f" .AverageScores.ContentQuality}}</td><td>{{printf "%.1f" .AverageScores.Engagement}}</td>
+                    <td>{{printf "%.1f" .AverageScores.Visual}}</td><td>{{printf "%.1f" .AverageScores.Title}}</td>
+                    <td>{{printf "%.1f" .AverageScores.Readability}}</td><td>{{printf "%.1f" .AverageScores.TrendRelevance}}</td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+
+        <div class="card">
+            <h2>总分分布</h2>
+            <table>
+                <tr><th>分数区间</th><th>篇数</th></tr>
+                {{range .ScoreDistribution}}<tr><td>{{.Range}}</td><td>{{.Count}}</td></tr>{{end}}
+            </table>
+        </div>
+
+        {{if .SharedIssues}}
+        <div class="card">
+            <h2>共同问题</h2>
+            <ul>
+            {{range .SharedIssues}}<li>{{.Issue}}（{{range $i, $c := .Clients}}{{if $i}}、{{end}}{{$c}}{{end}}）</li>{{end}}
+            </ul>
+        </div>
+        {{end}}
+    </div>
+</body>
+</html>`
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeReportJSON(t *testing.T, dir, name string, report map[string]interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func scoredResult(total, quality float64) map[string]interface{} {
+	return map[string]interface{}{
+		"score": map[string]interface{}{
+			"total":     total,
+			"breakdown": map[string]interface{}{"content_quality": quality},
+		},
+	}
+}
+
+func TestAggregateCombinesReports(t *testing.T) {
+	dir := t.TempDir()
+	pathA := writeReportJSON(t, dir, "a.json", map[string]interface{}{
+		"generated_at": "2024-01-15T10:00:00Z",
+		"results":      []interface{}{scoredResult(90, 80), scoredResult(60, 40)},
+		"summary":      map[string]interface{}{"common_issues": []string{"标题吸引力不足 (2篇)", "缺少图片 (1篇)"}},
+	})
+	pathB := writeReportJSON(t, dir, "b.json", map[string]interface{}{
+		"results": []interface{}{scoredResult(45, 30)},
+		"summary": map[string]interface{}{"common_issues": []string{"标题吸引力不足 (1篇)"}},
+	})
+
+	clientA, err := LoadClientReport("A", pathA)
+	if err != nil {
+		t.Fatalf("LoadClientReport(A) error = %v", err)
+	}
+	clientB, err := LoadClientReport("B", pathB)
+	if err != nil {
+		t.Fatalf("LoadClientReport(B) error = %v", err)
+	}
+
+	if clientA.ContentCount != 2 || clientA.AverageScore != 75 || clientA.AverageScores.ContentQuality != 60 {
+		t.Errorf("clientA = %d篇/%.1f/%.1f, want 2篇/75/60", clientA.ContentCount, clientA.AverageScore, clientA.AverageScores.ContentQuality)
+	}
+	if clientA.GeneratedAt != "2024-01-15T10:00:00Z" {
+		t.Errorf("clientA.GeneratedAt = %q", clientA.GeneratedAt)
+	}
+
+	agg := Aggregate([]ClientSummary{clientA, clientB})
+
+	if agg.TotalContent != 3 {
+		t.Errorf("TotalContent = %d, want 3", agg.TotalContent)
+	}
+	// 整体平均按篇计算，而不是客户平均分的平均
+	if agg.OverallScore != 65 {
+		t.Errorf("OverallScore = %v, want 65", agg.OverallScore)
+	}
+
+	wantDistribution := map[string]int{"85-100": 1, "70-85": 0, "50-70": 1, "0-50": 1}
+	for _, bucket := range agg.ScoreDistribution {
+		if bucket.Count != wantDistribution[bucket.Range] {
+			t.Errorf("分布 %s = %d, want %d", bucket.Range, bucket.Count, wantDistribution[bucket.Range])
+		}
+	}
+
+	if len(agg.SharedIssues) != 1 || agg.SharedIssues[0].Issue != "标题吸引力不足" || len(agg.SharedIssues[0].Clients) != 2 {
+		t.Errorf("SharedIssues = %+v, want 标题吸引力不足 [A B]", agg.SharedIssues)
+	}
+
+	r := newTestReporter(t, nil)
+	if err := r.GenerateAggregateReport(agg); err != nil {
+		t.Fatalf("GenerateAggregateReport() error = %v", err)
+	}
+	for _, name := range []string{"aggregate_report.json", "aggregate_report.html"} {
+		if _, err := os.Stat(filepath.Join(r.config.OutputDir, name)); err != nil {
+			t.Errorf("缺少 %s: %v", name, err)
+		}
+	}
+}
+
+func TestLoadClientReportRequiresResults(t *testing.T) {
+	path := writeReportJSON(t, t.TempDir(), "empty.json", map[string]interface{}{"summary": map[string]interface{}{}})
+
+	if _, err := LoadClientReport("empty", path); err == nil {
+		t.Error("缺少 results 时应返回错误")
+	}
+}
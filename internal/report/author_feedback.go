@@ -0,0 +1,196 @@
+// internal/report/author_feedback.go
+package report
+
+import (
+	htmltemplate "html/template"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 没有署名的内容归入该作者
+const unknownAuthor = "未署名"
+
+// AuthorFeedback 发给单个作者的反馈
+type AuthorFeedback struct {
+	Name        string
+	Email       string // Author 为 "姓名 <邮箱>" 格式时解析出的邮箱
+	GeneratedAt time.Time
+	Average     float64
+	Items       []AuthorFeedbackItem
+}
+
+// AuthorFeedbackItem 作者的一篇内容及其主要建议
+type AuthorFeedbackItem struct {
+	Title       string
+	Score       float64
+	Level       string
+	Suggestions []models.Suggestion
+}
+
+// 文件名中不允许出现的字符
+var unsafeFileChars = regexp.MustCompile(`[\\/:*?"<>|\s]+`)
+
+// groupByAuthor 按作者分组，组内按得分从低到高排列，先看最需要改进的内容
+func (r *Reporter) groupByAuthor(results []models.AnalysisResult) []AuthorFeedback {
+	limit := r.config.Report.AuthorFeedback.TopSuggestions
+	now := time.Now()
+
+	byAuthor := make(map[string]*AuthorFeedback)
+	var order []string
+	for _, result := range results {
+		name, email := parseAuthor(result.Author)
+		key := strings.ToLower(name)
+
+		feedback, ok := byAuthor[key]
+		if !ok {
+			feedback = &AuthorFeedback{Name: name, GeneratedAt: now}
+			byAuthor[key] = feedback
+			order = append(order, key)
+		}
+		if feedback.Email == "" {
+			feedback.Email = email
+		}
+
+		suggestions := result.Suggestions
+		if limit > 0 && len(suggestions) > limit {
+			suggestions = suggestions[:limit]
+		}
+		feedback.Items = append(feedback.Items, AuthorFeedbackItem{
+			Title:       result.Title,
+			Score:       result.Score.Total,
+			Level:       result.Score.Level,
+			Suggestions: suggestions,
+		})
+		feedback.Average += result.Score.Total
+	}
+
+	sort.Strings(order)
+	feedbacks := make([]AuthorFeedback, 0, len(order))
+	for _, key := range order {
+		feedback := byAuthor[key]
+		feedback.Average /= float64(len(feedback.Items))
+		sort.SliceStable(feedback.Items, func(i, j int) bool {
+			return feedback.Items[i].Score < feedback.Items[j].Score
+		})
+		feedbacks = append(feedbacks, *feedback)
+	}
+	return feedbacks
+}
+
+// parseAuthor 拆分 "姓名 <邮箱>" 形式的作者；只有姓名或只有邮箱时也能识别
+func parseAuthor(author string) (name, email string) {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return unknownAuthor, ""
+	}
+
+	if addr, err := mail.ParseAddress(author); err == nil {
+		if addr.Name == "" {
+			return addr.Address, addr.Address
+		}
+		return addr.Name, addr.Address
+	}
+	return author, ""
+}
+
+// generateAuthorFeedback 为每位作者生成一份可直接作为邮件正文的反馈，写入 author_feedback 目录
+func (r *Reporter) generateAuthorFeedback(results []models.AnalysisResult) error {
+	dir := filepath.Join(r.config.OutputDir, "author_feedback")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	markdown := template.Must(template.New("author_md").Parse(authorFeedbackMarkdown))
+	html := htmltemplate.Must(htmltemplate.New("author_html").Parse(authorFeedbackHTML))
+	format := r.config.Report.AuthorFeedback.Format
+
+	used := make(map[string]int)
+	for _, feedback := range r.groupByAuthor(results) {
+		base := unsafeFileChars.ReplaceAllString(feedback.Name, "_")
+		// 不同作者清洗后可能同名
+		used[base]++
+		if used[base] > 1 {
+			base = base + "_" + strconv.Itoa(used[base])
+		}
+
+		if format == "markdown" || format == "both" {
+			if err := writeTemplate(filepath.Join(dir, base+".md"), func(f *os.File) error {
+				return markdown.Execute(f, feedback)
+			}); err != nil {
+				return err
+			}
+		}
+		if format == "html" || format == "both" {
+			if err := writeTemplate(filepath.Join(dir, base+".html"), func(f *os.File) error {
+				return html.Execute(f, feedback)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTemplate(path string, execute func(*os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := execute(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+const authorFeedbackMarkdown = `{{if .Email}}收件人: {{.Name}} <{{.Email}}>
+{{end}}主题: 内容分析反馈（{{len .Items}}篇，平均 {{printf "%.1f" .Average}} 分）
+
+{{.Name}}，你好：
+
+以下是你近期内容的分析结果和主要改进建议，按得分从低到高排列。
+{{range .Items}}
+## {{.Title}}
+
+得分: {{printf "%.1f" .Score}}（{{.Level}}）
+{{if .Suggestions}}
+{{range .Suggestions}}- **{{.Current}}**：{{.Recommended}}
+{{end}}{{else}}
+暂无改进建议，请继续保持。
+{{end}}{{end}}
+生成时间: {{.GeneratedAt.Format "2006-01-02 15:04"}}
+`
+
+const authorFeedbackHTML = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+    <meta charset="UTF-8">
+    <title>内容分析反馈 - {{.Name}}</title>
+</head>
+<body style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', sans-serif; max-width: 640px; margin: 0 auto; padding: 20px; color: #333;">
+    {{if .Email}}<p style="color: #888;">收件人: {{.Name}} &lt;{{.Email}}&gt;</p>{{end}}
+    <p>{{.Name}}，你好：</p>
+    <p>以下是你近期 {{len .Items}} 篇内容的分析结果（平均 {{printf "%.1f" .Average}} 分）和主要改进建议，按得分从低到高排列。</p>
+    {{range .Items}}
+    <div style="border: 1px solid #eee; border-radius: 8px; padding: 12px 16px; margin: 12px 0;">
+        <h3 style="margin: 0 0 6px;">{{.Title}}</h3>
+        <p style="margin: 0 0 6px;">得分: <strong>{{printf "%.1f" .Score}}</strong>（{{.Level}}）</p>
+        {{if .Suggestions}}
+        <ul>
+            {{range .Suggestions}}<li><strong>{{.Current}}</strong>：{{.Recommended}}</li>{{end}}
+        </ul>
+        {{else}}<p>暂无改进建议，请继续保持。</p>{{end}}
+    </div>
+    {{end}}
+    <p style="color: #888; font-size: 12px;">生成时间: {{.GeneratedAt.Format "2006-01-02 15:04"}}</p>
+</body>
+</html>`
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func authorResult(author, title string, score float64, suggestions ...string) models.AnalysisResult {
+	result := models.AnalysisResult{
+		Author: author,
+		Title:  title,
+		Score:  models.OverallScore{Total: score},
+	}
+	for _, s := range suggestions {
+		result.Suggestions = append(result.Suggestions, models.Suggestion{Recommended: s})
+	}
+	return result
+}
+
+func TestGroupByAuthor(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.AuthorFeedback.TopSuggestions = 1
+	})
+	feedbacks := r.groupByAuthor([]models.AnalysisResult{
+		authorResult("张三 <zhangsan@example.com>", "露营清单", 80, "加一张封面图"),
+		authorResult("李四", "咖啡入门", 60),
+		authorResult("张三", "徒步路线", 50, "缩短标题", "增加小标题"),
+		authorResult("", "无名之作", 70),
+	})
+
+	var names []string
+	for _, f := range feedbacks {
+		names = append(names, f.Name)
+	}
+	want := []string{"张三", "未署名", "李四"}
+	sort.Strings(want)
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("作者 = %v, want %v", names, want)
+	}
+
+	byName := make(map[string]AuthorFeedback)
+	for _, f := range feedbacks {
+		byName[f.Name] = f
+	}
+	zhang := byName["张三"]
+	// 同一作者带邮箱和不带邮箱的署名合并，按得分从低到高排列
+	if zhang.Email != "zhangsan@example.com" || len(zhang.Items) != 2 {
+		t.Fatalf("张三 = %+v, want 邮箱和两篇内容", zhang)
+	}
+	if zhang.Items[0].Title != "徒步路线" || zhang.Items[1].Title != "露营清单" {
+		t.Errorf("张三的内容顺序 = %s, %s, want 徒步路线, 露营清单", zhang.Items[0].Title, zhang.Items[1].Title)
+	}
+	if zhang.Average != 65 {
+		t.Errorf("张三平均分 = %v, want 65", zhang.Average)
+	}
+	if got := zhang.Items[0].Suggestions; len(got) != 1 || got[0].Recommended != "缩短标题" {
+		t.Errorf("建议 = %+v, want 只保留第一条", got)
+	}
+	if li := byName["李四"]; li.Email != "" || len(li.Items) != 1 || li.Items[0].Title != "咖啡入门" {
+		t.Errorf("李四 = %+v, want 只有姓名和一篇内容", li)
+	}
+}
+
+func TestGenerateAuthorFeedbackFiles(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.AuthorFeedback = config.AuthorFeedbackConfig{Enabled: true, Format: "both"}
+	})
+	err := r.generateAuthorFeedback([]models.AnalysisResult{
+		authorResult("张三 <zhangsan@example.com>", "露营清单", 80, "加一张封面图"),
+		authorResult("李四", "咖啡入门", 60),
+	})
+	if err != nil {
+		t.Fatalf("generateAuthorFeedback() error = %v", err)
+	}
+
+	dir := filepath.Join(r.config.OutputDir, "author_feedback")
+	tests := []struct {
+		file    string
+		want    []string
+		notWant string
+	}{
+		{"张三.md", []string{"收件人: 张三 <zhangsan@example.com>", "露营清单", "加一张封面图"}, "咖啡入门"},
+		{"张三.html", []string{"露营清单"}, "咖啡入门"},
+		// 只有姓名的作者也生成文件，没有收件人行
+		{"李四.md", []string{"咖啡入门"}, "收件人"},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		for _, want := range tt.want {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s 缺少 %q", tt.file, want)
+			}
+		}
+		if strings.Contains(content, tt.notWant) {
+			t.Errorf("%s 不应包含 %q", tt.file, tt.notWant)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// internal/report/calendar.go
+package report
+
+import (
+	"sort"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// CalendarHealth 内容组合健康度：按类型和主题分布统计
+type CalendarHealth struct {
+	ByType       []BucketStat `json:"by_type"`
+	ByTopic      []BucketStat `json:"by_topic"`
+	UnderCovered []string     `json:"under_covered"` // 覆盖不足的主题
+}
+
+// BucketStat 单个分组的统计
+type BucketStat struct {
+	Name         string  `json:"name"`
+	Count        int     `json:"count"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// 主题篇数低于平均篇数的该比例时视为覆盖不足
+const underCoveredRatio = 0.5
+
+func (r *Reporter) generateCalendarHealth(results []models.AnalysisResult) CalendarHealth {
+	typeBuckets := make(map[string][]float64)
+	topicBuckets := make(map[string][]float64)
+
+	for _, result := range results {
+		contentType := result.ContentType
+		if contentType == "" {
+			contentType = "未分类"
+		}
+		typeBuckets[contentType] = append(typeBuckets[contentType], result.Score.Total)
+
+		for _, topic := range result.Topics {
+			topicBuckets[topic] = append(topicBuckets[topic], result.Score.Total)
+		}
+	}
+
+	health := CalendarHealth{
+		ByType:  bucketStats(typeBuckets),
+		ByTopic: bucketStats(topicBuckets),
+	}
+
+	if len(health.ByTopic) > 1 {
+		total := 0
+		for _, bucket := range health.ByTopic {
+			total += bucket.Count
+		}
+		average := float64(total) / float64(len(health.ByTopic))
+
+		for _, bucket := range health.ByTopic {
+			if float64(bucket.Count) < average*underCoveredRatio {
+				health.UnderCovered = append(health.UnderCovered, bucket.Name)
+			}
+		}
+	}
+
+	return health
+}
+
+// bucketStats 计算各分组篇数和平均分，按篇数降序、名称升序排列
+func bucketStats(buckets map[string][]float64) []BucketStat {
+	stats := make([]BucketStat, 0, len(buckets))
+
+	for name, scores := range buckets {
+		sum := 0.0
+		for _, score := range scores {
+			sum += score
+		}
+		stats = append(stats, BucketStat{
+			Name:         name,
+			Count:        len(scores),
+			AverageScore: sum / float64(len(scores)),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
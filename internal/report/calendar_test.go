@@ -0,0 +1,64 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestCalendarHealthBuckets(t *testing.T) {
+	r := newTestReporter(t, nil)
+	results := []models.AnalysisResult{
+		{ContentType: "教程", Topics: []string{"旅行", "美食"}, Score: models.OverallScore{Total: 80}},
+		{ContentType: "教程", Topics: []string{"旅行"}, Score: models.OverallScore{Total: 60}},
+		{ContentType: "测评", Topics: []string{"旅行", "数码"}, Score: models.OverallScore{Total: 90}},
+		{ContentType: "", Topics: []string{"旅行", "美食"}, Score: models.OverallScore{Total: 40}},
+		{ContentType: "测评", Topics: []string{"旅行"}, Score: models.OverallScore{Total: 70}},
+	}
+
+	health := r.generateCalendarHealth(results)
+
+	wantTypes := []BucketStat{
+		{Name: "教程", Count: 2, AverageScore: 70},
+		{Name: "测评", Count: 2, AverageScore: 80},
+		{Name: "未分类", Count: 1, AverageScore: 40},
+	}
+	if len(health.ByType) != len(wantTypes) {
+		t.Fatalf("ByType = %+v, want %+v", health.ByType, wantTypes)
+	}
+	for i, want := range wantTypes {
+		if health.ByType[i] != want {
+			t.Errorf("ByType[%d] = %+v, want %+v", i, health.ByType[i], want)
+		}
+	}
+
+	wantTopics := []BucketStat{
+		{Name: "旅行", Count: 5, AverageScore: 68},
+		{Name: "美食", Count: 2, AverageScore: 60},
+		{Name: "数码", Count: 1, AverageScore: 90},
+	}
+	if len(health.ByTopic) != len(wantTopics) {
+		t.Fatalf("ByTopic = %+v, want %+v", health.ByTopic, wantTopics)
+	}
+	for i, want := range wantTopics {
+		if health.ByTopic[i] != want {
+			t.Errorf("ByTopic[%d] = %+v, want %+v", i, health.ByTopic[i], want)
+		}
+	}
+
+	// 平均每个主题 8/3 篇，低于其一半（约1.33篇）的主题覆盖不足
+	if len(health.UnderCovered) != 1 || health.UnderCovered[0] != "数码" {
+		t.Errorf("UnderCovered = %v, want [数码]", health.UnderCovered)
+	}
+}
+
+func TestCalendarHealthSingleTopicNotUnderCovered(t *testing.T) {
+	r := newTestReporter(t, nil)
+	health := r.generateCalendarHealth([]models.AnalysisResult{
+		{Topics: []string{"旅行"}, Score: models.OverallScore{Total: 50}},
+	})
+
+	if len(health.UnderCovered) != 0 {
+		t.Errorf("只有一个主题时不应判断覆盖不足: %v", health.UnderCovered)
+	}
+}
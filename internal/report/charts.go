@@ -0,0 +1,170 @@
+// internal/report/charts.go
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// chartFont 图表文字字体，优先使用常见的中文字体，保证中文标签正常显示
+const chartFont = `-apple-system, 'PingFang SC', 'Microsoft YaHei', 'Noto Sans CJK SC', sans-serif`
+
+// ChartItem 图表中的一项
+type ChartItem struct {
+	Label string
+	Value float64
+}
+
+// 雷达图尺寸（viewBox 单位，实际大小随容器缩放）
+const (
+	radarWidth  = 480
+	radarHeight = 360
+	radarRadius = 120
+	radarLabel  = 20 // 标签离外圈的距离
+)
+
+// RadarChart 雷达图，每项一条轴，值从中心的0到外圈的 Max
+type RadarChart struct {
+	Items []ChartItem
+	Max   float64
+}
+
+// Vertex 第 i 条轴上值为 value 的点在中心为 (cx, cy)、外圈半径为 radius 的雷达图上的坐标。
+// 第一条轴在正上方，其余按顺时针排列；值超出 0-Max 时按边界处理
+func (c RadarChart) Vertex(i int, value, cx, cy, radius float64) (x, y float64) {
+	ratio := math.Max(0, math.Min(value/c.Max, 1))
+	angle := 2*math.Pi*float64(i)/float64(len(c.Items)) - math.Pi/2
+	return cx + radius*ratio*math.Cos(angle), cy + radius*ratio*math.Sin(angle)
+}
+
+// SVG 绘制雷达图：25%/50%/75%/100% 四圈网格、各轴和填充的数值区域，轴端标出名称和数值。
+// 少于3项时画不出多边形，返回空
+func (c RadarChart) SVG() template.HTML {
+	n := len(c.Items)
+	if n < 3 || c.Max <= 0 {
+		return ""
+	}
+	cx, cy := float64(radarWidth)/2, float64(radarHeight)/2
+
+	polygon := func(value func(i int) float64) string {
+		points := make([]string, n)
+		for i := range c.Items {
+			x, y := c.Vertex(i, value(i), cx, cy, radarRadius)
+			points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		return strings.Join(points, " ")
+	}
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="100%%" style="max-width:%dpx" font-size="12" font-family="%s">`,
+		radarWidth, radarHeight, radarWidth, chartFont)
+
+	for _, level := range []float64{0.25, 0.5, 0.75, 1} {
+		fmt.Fprintf(&s, `<polygon points="%s" fill="none" stroke="#dee2e6"/>`,
+			polygon(func(int) float64 { return c.Max * level }))
+	}
+	for i := range c.Items {
+		x, y := c.Vertex(i, c.Max, cx, cy, radarRadius)
+		fmt.Fprintf(&s, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#dee2e6"/>`, cx, cy, x, y)
+	}
+
+	fmt.Fprintf(&s, `<polygon points="%s" fill="#667eea" fill-opacity="0.3" stroke="#667eea" stroke-width="2"/>`,
+		polygon(func(i int) float64 { return c.Items[i].Value }))
+
+	for i, item := range c.Items {
+		x, y := c.Vertex(i, item.Value, cx, cy, radarRadius)
+		fmt.Fprintf(&s, `<circle cx="%.1f" cy="%.1f" r="3" fill="#667eea"><title>%s: %.1f</title></circle>`,
+			x, y, html.EscapeString(item.Label), item.Value)
+
+		// 标签放在外圈之外，按所在位置左对齐、居中或右对齐
+		lx, ly := c.Vertex(i, c.Max, cx, cy, radarRadius+radarLabel)
+		anchor := "middle"
+		switch {
+		case lx < cx-1:
+			anchor = "end"
+		case lx > cx+1:
+			anchor = "start"
+		}
+		fmt.Fprintf(&s, `<text x="%.1f" y="%.1f" text-anchor="%s" dominant-baseline="middle">%s %.1f</text>`,
+			lx, ly, anchor, html.EscapeString(item.Label), item.Value)
+	}
+	s.WriteString(`</svg>`)
+
+	return template.HTML(s.String())
+}
+
+// 条形图尺寸（viewBox 单位）
+const (
+	barWidth       = 420
+	barLabelWidth  = 120
+	barValueWidth  = 40
+	barRowHeight   = 24
+	barLabelLength = 8
+)
+
+// BarChart 横向条形图，每项一行，最大值的条占满绘图区
+type BarChart struct {
+	Items []ChartItem
+}
+
+// BarLength 值为 value 的条在最大长度为 maxLength 的绘图区中的长度
+func (c BarChart) BarLength(value, maxLength float64) float64 {
+	max := 0.0
+	for _, item := range c.Items {
+		max = math.Max(max, item.Value)
+	}
+	if max <= 0 {
+		return 0
+	}
+	return math.Max(0, value) / max * maxLength
+}
+
+// SVG 绘制条形图，左侧为标签（过长时截断，完整内容在悬停提示中），条后为数值。没有数据时返回空
+func (c BarChart) SVG() template.HTML {
+	if len(c.Items) == 0 {
+		return ""
+	}
+	plotWidth := float64(barWidth - barLabelWidth - barValueWidth)
+	height := len(c.Items) * barRowHeight
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="100%%" style="max-width:%dpx" font-size="12" font-family="%s">`,
+		barWidth, height, barWidth, chartFont)
+	for i, item := range c.Items {
+		y := i * barRowHeight
+		length := c.BarLength(item.Value, plotWidth)
+		label := html.EscapeString(item.Label)
+		fmt.Fprintf(&s, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle">%s</text>`,
+			barLabelWidth-8, y+barRowHeight/2, html.EscapeString(truncateRunes(item.Label, barLabelLength)))
+		fmt.Fprintf(&s, `<rect x="%d" y="%d" width="%.1f" height="%d" rx="3" fill="#667eea"><title>%s: %g</title></rect>`,
+			barLabelWidth, y+4, length, barRowHeight-8, label, item.Value)
+		fmt.Fprintf(&s, `<text x="%.1f" y="%d" dominant-baseline="middle" fill="#666">%g</text>`,
+			float64(barLabelWidth)+length+6, y+barRowHeight/2, item.Value)
+	}
+	s.WriteString(`</svg>`)
+
+	return template.HTML(s.String())
+}
+
+// dimensionRadar 各评分维度平均得分的雷达图，顺序与报告中的维度列表一致
+func dimensionRadar(dimensions []DimensionScore) template.HTML {
+	chart := RadarChart{Max: 100}
+	for _, d := range dimensions {
+		chart.Items = append(chart.Items, ChartItem{Label: d.Label, Value: d.Score})
+	}
+	return chart.SVG()
+}
+
+// keywordBars 热门关键词出现次数的条形图
+func keywordBars(keywords []models.Keyword) template.HTML {
+	var chart BarChart
+	for _, keyword := range keywords {
+		chart.Items = append(chart.Items, ChartItem{Label: keyword.Word, Value: float64(keyword.Frequency)})
+	}
+	return chart.SVG()
+}
@@ -0,0 +1,104 @@
+package report
+
+import (
+	"encoding/xml"
+	"html/template"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// parseSVG 检查 svg 是格式正确的XML，根元素为带 viewBox 的 <svg>（可随容器缩放），坐标中没有 NaN/Inf，返回所有文字
+func parseSVG(t *testing.T, svg template.HTML) string {
+	t.Helper()
+	if strings.Contains(string(svg), "NaN") || strings.Contains(string(svg), "Inf") {
+		t.Errorf("SVG 含无效坐标: %s", svg)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(string(svg)))
+	var text strings.Builder
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("SVG 不是格式正确的XML: %v\n%s", err, svg)
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				viewBox := ""
+				for _, attr := range tok.Attr {
+					if attr.Name.Local == "viewBox" {
+						viewBox = attr.Value
+					}
+				}
+				if tok.Name.Local != "svg" || viewBox == "" {
+					t.Errorf("根元素 = <%s viewBox=%q>, want 带 viewBox 的 <svg>", tok.Name.Local, viewBox)
+				}
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			text.Write(tok)
+		}
+	}
+	return text.String()
+}
+
+func TestDimensionRadar(t *testing.T) {
+	svg := dimensionRadar([]DimensionScore{
+		{Key: "content_quality", Label: "内容质量", Score: 82.5},
+		{Key: "engagement", Label: "互动<潜力>", Score: 0},
+		{Key: "visual", Label: "视觉效果", Score: 120}, // 超出上限时画在外圈
+	})
+	text := parseSVG(t, svg)
+	for _, want := range []string{"内容质量 82.5", "互动<潜力> 0.0", "视觉效果"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("雷达图文字缺少 %q: %s", want, text)
+		}
+	}
+
+	if svg := dimensionRadar([]DimensionScore{{Label: "内容质量"}, {Label: "标题"}}); svg != "" {
+		t.Errorf("少于3项时 = %s, want 空", svg)
+	}
+}
+
+func TestKeywordBars(t *testing.T) {
+	tests := []struct {
+		name     string
+		keywords []models.Keyword
+		want     []string
+	}{
+		{"chinese labels", []models.Keyword{{Word: "露营装备清单推荐大全", Frequency: 8}, {Word: "徒步", Frequency: 2}}, []string{"露营装备清单推", "徒步", "8", "2"}},
+		{"identical frequencies", []models.Keyword{{Word: "露营", Frequency: 3}, {Word: "徒步", Frequency: 3}}, []string{"露营", "徒步"}},
+		{"zero frequencies", []models.Keyword{{Word: "露营"}, {Word: "徒步"}}, []string{"露营", "徒步"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := parseSVG(t, keywordBars(tt.keywords))
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("条形图文字缺少 %q: %s", want, text)
+				}
+			}
+		})
+	}
+
+	if svg := keywordBars(nil); svg != "" {
+		t.Errorf("没有关键词时 = %s, want 空", svg)
+	}
+
+	// 次数相同的条一样长，次数为0时条长为0
+	if got := (BarChart{Items: []ChartItem{{Value: 3}, {Value: 3}}}).BarLength(3, 100); got != 100 {
+		t.Errorf("相同次数的条长 = %v, want 100", got)
+	}
+	if got := (BarChart{Items: []ChartItem{{Value: 0}, {Value: 0}}}).BarLength(0, 100); got != 0 {
+		t.Errorf("全为0时条长 = %v, want 0", got)
+	}
+}
@@ -0,0 +1,351 @@
+// internal/report/compare.go
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 对比报告中列出的进步最大和退步最大的内容数量
+const comparisonTopN = 5
+
+// ComparisonReport 两次分析结果的对比
+type ComparisonReport struct {
+	GeneratedAt   time.Time         `json:"generated_at"`
+	PreviousCount int               `json:"previous_count"`
+	CurrentCount  int               `json:"current_count"`
+	PreviousScore float64           `json:"previous_score"`
+	CurrentScore  float64           `json:"current_score"`
+	Dimensions    []DimensionChange `json:"dimensions"`
+	// 全部语料平均分上升的维度
+	ImprovedDimensions []string        `json:"improved_dimensions,omitempty"`
+	Matched            []ContentChange `json:"matched"`
+	Gainers            []ContentChange `json:"gainers,omitempty"`
+	Losers             []ContentChange `json:"losers,omitempty"`
+	Added              []ContentRef    `json:"added,omitempty"`
+	Removed            []ContentRef    `json:"removed,omitempty"`
+}
+
+// DimensionChange 一个评分维度在两次分析中的得分
+type DimensionChange struct {
+	Key      string  `json:"key"`
+	Label    string  `json:"label"`
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+	Delta    float64 `json:"delta"`
+}
+
+// ContentChange 两次分析中都存在的一篇内容的得分变化
+type ContentChange struct {
+	ContentID  string            `json:"content_id,omitempty"`
+	Title      string            `json:"title"`
+	Previous   float64           `json:"previous"`
+	Current    float64           `json:"current"`
+	Delta      float64           `json:"delta"`
+	Dimensions []DimensionChange `json:"dimensions"`
+}
+
+// ContentRef 只出现在其中一次分析中的内容
+type ContentRef struct {
+	ContentID string  `json:"content_id,omitempty"`
+	Title     string  `json:"title"`
+	Score     float64 `json:"score"`
+}
+
+// comparisonKey 按 ContentID 匹配两次分析的内容，没有ID时（如Markdown文件）使用标题
+func comparisonKey(result models.AnalysisResult) string {
+	if result.ContentID != "" {
+		return "id:" + result.ContentID
+	}
+	return "title:" + result.Title
+}
+
+// LoadReportResults 读取一次分析生成的 analysis_report.json 中的分析结果，path 为目录时读取其中的报告
+func LoadReportResults(path string) ([]models.AnalysisResult, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "analysis_report.json")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Results *[]models.AnalysisResult `json:"results"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("解析报告 %s 失败: %w", path, err)
+	}
+	if raw.Results == nil {
+		return nil, fmt.Errorf("报告 %s 中没有 results 字段", path)
+	}
+	return *raw.Results, nil
+}
+
+// Compare 对比两次分析：按内容匹配计算总分和各维度的变化，列出新增和移除的内容，
+// 并按全部语料的平均分找出整体进步的维度
+func Compare(previous, current []models.AnalysisResult) ComparisonReport {
+	report := ComparisonReport{
+		GeneratedAt:   time.Now(),
+		PreviousCount: len(previous),
+		CurrentCount:  len(current),
+		PreviousScore: averageTotal(previous),
+		CurrentScore:  averageTotal(current),
+		Dimensions:    diffDimensions(averageBreakdown(previous), averageBreakdown(current)),
+	}
+	for _, d := range report.Dimensions {
+		if d.Delta > 0 {
+			report.ImprovedDimensions = append(report.ImprovedDimensions, d.Label)
+		}
+	}
+
+	before := make(map[string]models.AnalysisResult, len(previous))
+	for _, result := range previous {
+		if _, ok := before[comparisonKey(result)]; !ok {
+			before[comparisonKey(result)] = result
+		}
+	}
+
+	seen := make(map[string]bool, len(current))
+	for _, result := range current {
+		key := comparisonKey(result)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		old, ok := before[key]
+		if !ok {
+			report.Added = append(report.Added, ContentRef{ContentID: result.ContentID, Title: result.Title, Score: result.Score.Total})
+			continue
+		}
+		report.Matched = append(report.Matched, ContentChange{
+			ContentID:  result.ContentID,
+			Title:      result.Title,
+			Previous:   old.Score.Total,
+			Current:    result.Score.Total,
+			Delta:      result.Score.Total - old.Score.Total,
+			Dimensions: diffDimensions(old.Score.Breakdown, result.Score.Breakdown),
+		})
+	}
+
+	for _, result := range previous {
+		key := comparisonKey(result)
+		if !seen[key] {
+			seen[key] = true
+			report.Removed = append(report.Removed, ContentRef{ContentID: result.ContentID, Title: result.Title, Score: result.Score.Total})
+		}
+	}
+
+	sort.SliceStable(report.Matched, func(i, j int) bool {
+		return report.Matched[i].Delta > report.Matched[j].Delta
+	})
+	for _, change := range report.Matched {
+		if change.Delta > 0 && len(report.Gainers) < comparisonTopN {
+			report.Gainers = append(report.Gainers, change)
+		}
+	}
+	for i := len(report.Matched) - 1; i >= 0 && len(report.Losers) < comparisonTopN; i-- {
+		if report.Matched[i].Delta < 0 {
+			report.Losers = append(report.Losers, report.Matched[i])
+		}
+	}
+
+	return report
+}
+
+// diffDimensions 计算内置维度和两次都有得分的自定义维度的变化
+func diffDimensions(before, after models.ScoreBreakdown) []DimensionChange {
+	var changes []DimensionChange
+	for _, d := range defaultDimensions {
+		changes = append(changes, newDimensionChange(d.key, d.label, d.score(before), d.score(after)))
+	}
+
+	custom := make([]string, 0, len(after.Custom))
+	for name := range after.Custom {
+		if _, ok := before.Custom[name]; ok {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	for _, name := range custom {
+		changes = append(changes, newDimensionChange(name, name, before.Custom[name], after.Custom[name]))
+	}
+	return changes
+}
+
+func newDimensionChange(key, label string, before, after float64) DimensionChange {
+	return DimensionChange{Key: key, Label: label, Previous: before, Current: after, Delta: after - before}
+}
+
+func averageTotal(results []models.AnalysisResult) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, result := range results {
+		sum += result.Score.Total
+	}
+	return sum / float64(len(results))
+}
+
+// averageBreakdown 各维度的平均分，自定义维度只在有得分的内容间平均
+func averageBreakdown(results []models.AnalysisResult) models.ScoreBreakdown {
+	var avg models.ScoreBreakdown
+	if len(results) == 0 {
+		return avg
+	}
+
+	customSum := make(map[string]float64)
+	customCount := make(map[string]int)
+	for _, result := range results {
+		b := result.Score.Breakdown
+		avg.ContentQuality += b.ContentQuality
+		avg.Engagement += b.Engagement
+		avg.Visual += b.Visual
+		avg.Title += b.Title
+		avg.Readability += b.Readability
+		avg.TrendRelevance += b.TrendRelevance
+		for name, score := range b.Custom {
+			customSum[name] += score
+			customCount[name]++
+		}
+	}
+
+	n := float64(len(results))
+	avg.ContentQuality /= n
+	avg.Engagement /= n
+	avg.Visual /= n
+	avg.Title /= n
+	avg.Readability /= n
+	avg.TrendRelevance /= n
+	if len(customSum) > 0 {
+		avg.Custom = make(map[string]float64, len(customSum))
+		for name, sum := range customSum {
+			avg.Custom[name] = sum / float64(customCount[name])
+		}
+	}
+	return avg
+}
+
+// GenerateComparisonReport 对比两次分析结果，输出对比报告（JSON 和 HTML）到输出目录
+func (r *Reporter) GenerateComparisonReport(previous, current []models.AnalysisResult) error {
+	if err := r.prepareOutputDir(); err != nil {
+		return err
+	}
+
+	comparison := Compare(previous, current)
+
+	jsonFile, err := os.Create(filepath.Join(r.config.OutputDir, "comparison_report.json"))
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+
+	encoder := json.NewEncoder(jsonFile)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(comparison); err != nil {
+		return fmt.Errorf("生成JSON对比报告失败: %w", err)
+	}
+
+	htmlFile, err := os.Create(filepath.Join(r.config.OutputDir, "comparison_report.html"))
+	if err != nil {
+		return err
+	}
+	defer htmlFile.Close()
+
+	tmpl, err := template.New("comparison").Parse(comparisonTemplate)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(htmlFile, comparison); err != nil {
+		return fmt.Errorf("生成HTML对比报告失败: %w", err)
+	}
+
+	return nil
+}
+
+const comparisonTemplate = `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+    <meta charset="UTF-8">
+    <title>分析结果对比报告</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', sans-serif; margin: 0; padding: 20px; background: #f5f7fa; }
+        .container { max-width: 1200px; margin: 0 auto; }
+        .card { background: white; padding: 20px; border-radius: 10px; margin-bottom: 20px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        table { width: 100%; border-collapse: collapse; }
+        th, td { padding: 8px; border-bottom: 1px solid #eee; text-align: left; }
+        .up { color: #28a745; }
+        .down { color: #dc3545; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="card">
+            <h1>分析结果对比报告</h1>
+            <p>生成时间: {{.GeneratedAt.Format "2006-01-02 15:04:05"}} | 上次: {{.PreviousCount}}篇，平均 {{printf "%.1f" .PreviousScore}} 分 | 本次: {{.CurrentCount}}篇，平均 {{printf "%.1f" .CurrentScore}} 分</p>
+            {{if .ImprovedDimensions}}<p class="up">整体进步的维度: {{range $i, $d := .ImprovedDimensions}}{{if $i}}、{{end}}{{$d}}{{end}}</p>{{end}}
+        </div>
+
+        <div class="card">
+            <h2>各维度平均分</h2>
+            <table>
+                <tr><th>维度</th><th>上次</th><th>本次</th><th>变化</th></tr>
+                {{range .Dimensions}}
+                <tr><td>{{.Label}}</td><td>{{printf "%.1f" .Previous}}</td><td>{{printf "%.1f" .Current}}</td>
+                    <td class="{{if gt .Delta 0.0}}up{{else if lt .Delta 0.0}}down{{end}}">{{printf "%+.1f" .Delta}}</td></tr>
+                {{end}}
+            </table>
+        </div>
+
+        <div class="card">
+            <h2>📈 进步最大</h2>
+            {{if .Gainers}}
+            <table>
+                <tr><th>标题</th><th>上次</th><th>本次</th><th>变化</th></tr>
+                {{range .Gainers}}<tr><td>{{.Title}}</td><td>{{printf "%.1f" .Previous}}</td><td>{{printf "%.1f" .Current}}</td><td class="up">{{printf "%+.1f" .Delta}}</td></tr>{{end}}
+            </table>
+            {{else}}<p>没有得分上升的内容</p>{{end}}
+        </div>
+
+        <div class="card">
+            <h2>📉 退步最大</h2>
+            {{if .Losers}}
+            <table>
+                <tr><th>标题</th><th>上次</th><th>本次</th><th>变化</th></tr>
+                {{range .Losers}}<tr><td>{{.Title}}</td><td>{{printf "%.1f" .Previous}}</td><td>{{printf "%.1f" .Current}}</td><td class="down">{{printf "%+.1f" .Delta}}</td></tr>{{end}}
+            </table>
+            {{else}}<p>没有得分下降的内容</p>{{end}}
+        </div>
+
+        {{if .Added}}
+        <div class="card">
+            <h2>🆕 新增内容</h2>
+            <table>
+                <tr><th>标题</th><th>得分</th></tr>
+                {{range .Added}}<tr><td>{{.Title}}</td><td>{{printf "%.1f" .Score}}</td></tr>{{end}}
+            </table>
+        </div>
+        {{end}}
+
+        {{if .Removed}}
+        <div class="card">
+            <h2>🗑️ 移除的内容</h2>
+            <table>
+                <tr><th>标题</th><th>上次得分</th></tr>
+                {{range .Removed}}<tr><td>{{.Title}}</td><td>{{printf "%.1f" .Score}}</td></tr>{{end}}
+            </table>
+        </div>
+        {{end}}
+    </div>
+</body>
+</html>`
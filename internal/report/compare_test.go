@@ -0,0 +1,113 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func compareResult(id, title string, total, readability float64) models.AnalysisResult {
+	return models.AnalysisResult{
+		ContentID: id,
+		Title:     title,
+		Score: models.OverallScore{
+			Total:     total,
+			Breakdown: models.ScoreBreakdown{ContentQuality: total, Readability: readability},
+		},
+	}
+}
+
+func TestCompareAddedRemovedChanged(t *testing.T) {
+	previous := []models.AnalysisResult{
+		compareResult("a", "露营清单", 60, 50),
+		compareResult("b", "咖啡入门", 80, 70),
+		compareResult("c", "旧文章", 70, 60),
+		compareResult("", "无ID的笔记", 50, 40),
+	}
+	current := []models.AnalysisResult{
+		compareResult("a", "露营清单（修订）", 75, 80),
+		compareResult("b", "咖啡入门", 72, 75),
+		compareResult("d", "新文章", 90, 85),
+		compareResult("", "无ID的笔记", 55, 45),
+	}
+
+	report := Compare(previous, current)
+
+	if len(report.Added) != 1 || report.Added[0].ContentID != "d" {
+		t.Errorf("Added = %+v, want [d]", report.Added)
+	}
+	if len(report.Removed) != 1 || report.Removed[0].ContentID != "c" {
+		t.Errorf("Removed = %+v, want [c]", report.Removed)
+	}
+
+	// 按ID匹配（标题可以变化），没有ID时按标题匹配；按变化从大到小排列
+	var matched []string
+	for _, m := range report.Matched {
+		matched = append(matched, m.Title)
+	}
+	if got := strings.Join(matched, ","); got != "露营清单（修订）,无ID的笔记,咖啡入门" {
+		t.Fatalf("Matched = %s", got)
+	}
+	if a := report.Matched[0]; a.Previous != 60 || a.Current != 75 || a.Delta != 15 {
+		t.Errorf("a 的变化 = %+v, want 60 -> 75", a)
+	}
+	if len(report.Gainers) != 2 || report.Gainers[0].ContentID != "a" {
+		t.Errorf("Gainers = %+v, want a 和无ID的笔记", report.Gainers)
+	}
+	if len(report.Losers) != 1 || report.Losers[0].ContentID != "b" || report.Losers[0].Delta != -8 {
+		t.Errorf("Losers = %+v, want [b -8]", report.Losers)
+	}
+
+	// 可读性平均分 55 -> 71.25，整体进步
+	var readability DimensionChange
+	for _, d := range report.Dimensions {
+		if d.Key == "readability" {
+			readability = d
+		}
+	}
+	if readability.Previous != 55 || readability.Current != 71.25 {
+		t.Errorf("readability = %+v, want 55 -> 71.25", readability)
+	}
+	found := false
+	for _, label := range report.ImprovedDimensions {
+		found = found || label == readability.Label
+	}
+	if !found {
+		t.Errorf("ImprovedDimensions = %v, want 包含 %s", report.ImprovedDimensions, readability.Label)
+	}
+}
+
+func TestGenerateComparisonReport(t *testing.T) {
+	r := newTestReporter(t, nil)
+	previous := []models.AnalysisResult{compareResult("a", "露营清单", 60, 50), compareResult("c", "旧文章", 70, 60)}
+	current := []models.AnalysisResult{compareResult("a", "露营清单", 75, 80), compareResult("d", "新文章", 90, 85)}
+	if err := r.GenerateComparisonReport(previous, current); err != nil {
+		t.Fatalf("GenerateComparisonReport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.config.OutputDir, "comparison_report.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ComparisonReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("解析 comparison_report.json 失败: %v", err)
+	}
+	if len(decoded.Added) != 1 || len(decoded.Removed) != 1 || len(decoded.Matched) != 1 {
+		t.Errorf("JSON 报告 = %+v, want 新增、移除、匹配各一篇", decoded)
+	}
+
+	html, err := os.ReadFile(filepath.Join(r.config.OutputDir, "comparison_report.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"露营清单", "旧文章", "新文章"} {
+		if !strings.Contains(string(html), title) {
+			t.Errorf("HTML 报告缺少 %s", title)
+		}
+	}
+}
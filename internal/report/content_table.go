@@ -0,0 +1,127 @@
+// internal/report/content_table.go
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// ContentTable HTML报告中的内容表格数据，以JSON嵌入页面，由页面脚本渲染、排序和筛选
+type ContentTable struct {
+	Columns []TableColumn `json:"columns"`
+	Bands   []ScoreBand   `json:"bands"`
+	Rows    []ContentRow  `json:"rows"`
+}
+
+// TableColumn 表格的一列，Numeric 的列按数值排序，其余按文字排序
+type TableColumn struct {
+	Key     string `json:"key"`
+	Label   string `json:"label"`
+	Numeric bool   `json:"numeric,omitempty"`
+}
+
+// ScoreBand 按 analysis.level_thresholds 划分的分数段，用于筛选
+type ScoreBand struct {
+	Level string `json:"level"`
+	Label string `json:"label"`
+}
+
+// ContentRow 一篇内容，Cells 的键与 TableColumn.Key 对应
+type ContentRow struct {
+	Cells   map[string]interface{} `json:"cells"`
+	Level   string                 `json:"level"`
+	Search  string                 `json:"search"`  // 关键词筛选匹配的文字：标题、关键词、标签和主题
+	Details []string               `json:"details"` // 展开后显示的评分理由、情感和警告
+}
+
+// buildContentTable 生成内容表格，评分维度列按 report.dimension_order 排列
+func (r *Reporter) buildContentTable(results []models.AnalysisResult) ContentTable {
+	thresholds := r.config.Analysis.LevelThresholds
+	table := ContentTable{
+		Columns: []TableColumn{
+			{Key: "title", Label: "标题"},
+			{Key: "total", Label: "总分", Numeric: true},
+		},
+		Bands: []ScoreBand{
+			{Level: "excellent", Label: fmt.Sprintf("优秀 (≥%g)", thresholds.Excellent)},
+			{Level: "good", Label: fmt.Sprintf("良好 (%g-%g)", thresholds.Good, thresholds.Excellent)},
+			{Level: "average", Label: fmt.Sprintf("一般 (%g-%g)", thresholds.Average, thresholds.Good)},
+			{Level: "poor", Label: fmt.Sprintf("较差 (<%g)", thresholds.Average)},
+		},
+		Rows: []ContentRow{},
+	}
+
+	// 各篇的自定义维度可能不同，以全部内容出现过的维度为列
+	var dimensions []DimensionScore
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, d := range r.orderedDimensions(result.Score.Breakdown) {
+			if !seen[d.Key] {
+				seen[d.Key] = true
+				dimensions = append(dimensions, d)
+			}
+		}
+	}
+	for _, d := range dimensions {
+		table.Columns = append(table.Columns, TableColumn{Key: "dim:" + d.Key, Label: d.Label, Numeric: true})
+	}
+	table.Columns = append(table.Columns,
+		TableColumn{Key: "words", Label: "字数", Numeric: true},
+		TableColumn{Key: "suggestions", Label: "建议数", Numeric: true},
+	)
+
+	for _, result := range results {
+		cells := map[string]interface{}{
+			"title":       result.Title,
+			"total":       roundScore(result.Score.Total),
+			"words":       result.TextAnalysis.WordCount,
+			"suggestions": len(result.Suggestions),
+		}
+		for _, d := range r.orderedDimensions(result.Score.Breakdown) {
+			cells["dim:"+d.Key] = roundScore(d.Score)
+		}
+
+		search := []string{result.Title}
+		for _, keyword := range result.Keywords {
+			search = append(search, keyword.Word)
+		}
+		search = append(search, result.Tags...)
+		search = append(search, result.Topics...)
+
+		details := []string{}
+		if result.Score.Reasoning != "" {
+			details = append(details, result.Score.Reasoning)
+		}
+		sentiment := "情感倾向: " + r.sentimentIndicator(result.Sentiment.Overall)
+		if c := result.Community; c != nil {
+			sentiment += fmt.Sprintf(" · 评论区: %s (%d条评论，正面%d / 中性%d / 负面%d)",
+				r.sentimentIndicator(c.Overall), c.CommentCount, c.Positive, c.Neutral, c.Negative)
+		}
+		details = append(details, sentiment)
+		for _, warning := range result.Warnings {
+			details = append(details, "⚠️ "+warning)
+		}
+		for _, component := range provenanceComponents {
+			if result.Provenance[component.key] == models.ProvenanceFallback {
+				details = append(details, "⚠️ "+component.label+"因AI服务失败使用了本地分析结果")
+			}
+		}
+
+		table.Rows = append(table.Rows, ContentRow{
+			Cells:   cells,
+			Level:   thresholds.Level(result.Score.Total),
+			Search:  strings.Join(search, " "),
+			Details: details,
+		})
+	}
+	return table
+}
+
+// roundScore 分数保留一位小数，减小嵌入页面的数据量。与报告其余部分的 %.1f 取舍一致
+func roundScore(score float64) float64 {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(score, 'f', 1, 64), 64)
+	return rounded
+}
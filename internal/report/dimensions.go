@@ -0,0 +1,69 @@
+// internal/report/dimensions.go
+package report
+
+import (
+	"sort"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// DimensionScore 报告中展示的一个评分维度
+type DimensionScore struct {
+	Key   string
+	Label string
+	Score float64
+}
+
+// 内置评分维度的默认展示顺序，键与 score_weights 字段名一致
+var defaultDimensions = []struct {
+	key   string
+	label string
+	score func(models.ScoreBreakdown) float64
+}{
+	{"content_quality", "内容质量", func(b models.ScoreBreakdown) float64 { return b.ContentQuality }},
+	{"engagement", "互动潜力", func(b models.ScoreBreakdown) float64 { return b.Engagement }},
+	{"visual", "视觉吸引力", func(b models.ScoreBreakdown) float64 { return b.Visual }},
+	{"title", "标题质量", func(b models.ScoreBreakdown) float64 { return b.Title }},
+	{"readability", "可读性", func(b models.ScoreBreakdown) float64 { return b.Readability }},
+	{"trend_relevance", "趋势相关性", func(b models.ScoreBreakdown) float64 { return b.TrendRelevance }},
+}
+
+// orderedDimensions 按 report.dimension_order 排列评分维度，只影响展示顺序。
+// 未列出的维度按默认顺序追加在后面：先内置维度，再按名称排序的自定义维度
+func (r *Reporter) orderedDimensions(breakdown models.ScoreBreakdown) []DimensionScore {
+	var all []DimensionScore
+	for _, d := range defaultDimensions {
+		all = append(all, DimensionScore{Key: d.key, Label: d.label, Score: d.score(breakdown)})
+	}
+
+	custom := make([]string, 0, len(breakdown.Custom))
+	for name := range breakdown.Custom {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+	for _, name := range custom {
+		all = append(all, DimensionScore{Key: name, Label: name, Score: breakdown.Custom[name]})
+	}
+
+	index := make(map[string]int, len(all))
+	for i, d := range all {
+		index[d.Key] = i
+	}
+
+	ordered := make([]DimensionScore, 0, len(all))
+	placed := make(map[string]bool)
+	for _, key := range r.config.Report.DimensionOrder {
+		// 配置的自定义维度可能在本次结果中没有得分
+		if i, ok := index[key]; ok && !placed[key] {
+			ordered = append(ordered, all[i])
+			placed[key] = true
+		}
+	}
+	for _, d := range all {
+		if !placed[d.Key] {
+			ordered = append(ordered, d)
+		}
+	}
+
+	return ordered
+}
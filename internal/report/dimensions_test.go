@@ -0,0 +1,59 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestRenderedDimensionOrder(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Formats = []string{"markdown"}
+		cfg.Report.DimensionOrder = []string{"readability", "title", "content_quality"}
+	})
+	results := []models.AnalysisResult{{
+		ContentID: "post-1",
+		Title:     "周末露营清单",
+		Score: models.OverallScore{Total: 70, Level: "good", Breakdown: models.ScoreBreakdown{
+			ContentQuality: 70, Engagement: 60, Visual: 50, Title: 80, Readability: 90, TrendRelevance: 40,
+		}},
+	}}
+	if err := r.GenerateReport(results); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(r.config.OutputDir, "analysis_report.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	section := string(data)
+	section = section[strings.Index(section, "## 📈 平均得分"):strings.Index(section, "## 📝 内容排名")]
+
+	// 配置的维度在前，其余按默认顺序追加
+	want := []string{"可读性", "标题质量", "内容质量", "互动潜力", "视觉吸引力", "趋势相关性"}
+	last := -1
+	for _, label := range want {
+		i := strings.Index(section, "| "+label+" |")
+		if i < 0 {
+			t.Fatalf("平均得分表缺少 %s:\n%s", label, section)
+		}
+		if i < last {
+			t.Errorf("%s 出现在前一维度之前，want 顺序 %v:\n%s", label, want, section)
+		}
+		last = i
+	}
+
+	table := r.buildContentTable(results)
+	var columns []string
+	for _, c := range table.Columns {
+		if strings.HasPrefix(c.Key, "dim:") {
+			columns = append(columns, c.Label)
+		}
+	}
+	if strings.Join(columns, ",") != strings.Join(want, ",") {
+		t.Errorf("内容表格维度列 = %v, want %v", columns, want)
+	}
+}
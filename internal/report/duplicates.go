@@ -0,0 +1,47 @@
+// internal/report/duplicates.go
+package report
+
+import (
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/services"
+)
+
+// DuplicateImageGroup 感知哈希相近、视为同一张的一组图片，及使用这些图片的内容
+type DuplicateImageGroup struct {
+	Images   []string `json:"images"`   // 图片路径
+	Contents []string `json:"contents"` // 使用这些图片的内容标题，不重复
+}
+
+// generateDuplicateImages 找出在多篇内容中重复使用的图片。
+// 同一篇内容里重复出现的图片不算，只列出至少被两篇内容使用的组
+func (r *Reporter) generateDuplicateImages(results []models.AnalysisResult) []DuplicateImageGroup {
+	var analyses []models.ImageAnalysis
+	var owners []int
+	for i, result := range results {
+		for _, analysis := range result.ImageAnalysis {
+			analyses = append(analyses, analysis)
+			owners = append(owners, i)
+		}
+	}
+
+	var groups []DuplicateImageGroup
+	for _, members := range services.FindDuplicateImages(analyses, r.config.Image.DuplicateDistance) {
+		var group DuplicateImageGroup
+		seenImages := make(map[string]bool)
+		seenContents := make(map[int]bool)
+		for _, i := range members {
+			if path := analyses[i].Path; !seenImages[path] {
+				seenImages[path] = true
+				group.Images = append(group.Images, path)
+			}
+			if owner := owners[i]; !seenContents[owner] {
+				seenContents[owner] = true
+				group.Contents = append(group.Contents, results[owner].Title)
+			}
+		}
+		if len(seenContents) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,216 @@
+// internal/report/excel.go
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Excel 报告的工作表
+const (
+	excelSummarySheet  = "概览"
+	excelContentSheet  = "内容"
+	excelKeywordsSheet = "关键词"
+)
+
+// generateExcelReport 生成 analysis_report.xlsx：概览（总体评分、各维度平均分、常见问题）、
+// 内容（与CSV相同的列，作为带筛选的表格）和关键词三个工作表，数值列写为数字，可直接排序
+func (r *Reporter) generateExcelReport(data ReportData) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	// 新建的工作簿自带 Sheet1，改名作为概览
+	if err := f.SetSheetName("Sheet1", excelSummarySheet); err != nil {
+		return err
+	}
+	for _, sheet := range []string{excelContentSheet, excelKeywordsSheet} {
+		if _, err := f.NewSheet(sheet); err != nil {
+			return err
+		}
+	}
+
+	// 分数保留一位小数
+	scoreFormat := "0.0"
+	scoreStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &scoreFormat})
+	if err != nil {
+		return err
+	}
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	if err := r.writeExcelSummary(f, data, scoreStyle, boldStyle); err != nil {
+		return fmt.Errorf("写入%s工作表失败: %w", excelSummarySheet, err)
+	}
+	if err := writeExcelContent(f, data, scoreStyle); err != nil {
+		return fmt.Errorf("写入%s工作表失败: %w", excelContentSheet, err)
+	}
+	if err := writeExcelKeywords(f, data); err != nil {
+		return fmt.Errorf("写入%s工作表失败: %w", excelKeywordsSheet, err)
+	}
+
+	return f.SaveAs(filepath.Join(r.config.OutputDir, "analysis_report.xlsx"))
+}
+
+// writeExcelSummary 概览：总体情况、按 report.dimension_order 排列的平均得分、常见问题和成功模式
+func (r *Reporter) writeExcelSummary(f *excelize.File, data ReportData, scoreStyle, boldStyle int) error {
+	sheet := excelSummarySheet
+	row := 1
+	set := func(values ...interface{}) error {
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		row++
+		return f.SetSheetRow(sheet, cell, &values)
+	}
+	heading := func(title string) error {
+		row++ // 与上一部分空一行
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		if err := set(title); err != nil {
+			return err
+		}
+		return f.SetCellStyle(sheet, cell, cell, boldStyle)
+	}
+
+	rows := [][]interface{}{
+		{"生成时间", data.GeneratedAt.Format("2006-01-02 15:04:05")},
+		{"内容数量", data.TotalContent},
+		{"总体评分", roundScore(data.OverallScore)},
+		{"最佳表现", data.Summary.BestPerforming},
+		{"需要改进", data.Summary.NeedImprovement},
+	}
+	for _, values := range rows {
+		if err := set(values...); err != nil {
+			return err
+		}
+	}
+	if err := f.SetCellStyle(sheet, "B3", "B3", scoreStyle); err != nil {
+		return err
+	}
+
+	if err := heading("平均得分"); err != nil {
+		return err
+	}
+	first := row
+	for _, d := range data.Dimensions {
+		if err := set(d.Label, roundScore(d.Score)); err != nil {
+			return err
+		}
+	}
+	if row > first {
+		if err := f.SetCellStyle(sheet, fmt.Sprintf("B%d", first), fmt.Sprintf("B%d", row-1), scoreStyle); err != nil {
+			return err
+		}
+	}
+
+	for _, list := range []struct {
+		title    string
+		items    []string
+		optional bool // 没有内容时不列出
+	}{
+		{"警告", data.Summary.Warnings, true},
+		{"常见问题", data.Summary.CommonIssues, false},
+		{"成功模式", data.Summary.SuccessPatterns, false},
+	} {
+		if list.optional && len(list.items) == 0 {
+			continue
+		}
+		if err := heading(list.title); err != nil {
+			return err
+		}
+		for _, item := range list.items {
+			if err := set(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.SetColWidth(sheet, "A", "A", 16)
+}
+
+// writeExcelContent 每篇内容一行，列与CSV报告相同，整个区域设为表格，表头带筛选
+func writeExcelContent(f *excelize.File, data ReportData, scoreStyle int) error {
+	sheet := excelContentSheet
+	headers := make([]interface{}, len(contentColumns))
+	for i, column := range contentColumns {
+		headers[i] = column.header
+	}
+	if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+		return err
+	}
+
+	for i, result := range data.Results {
+		values := make([]interface{}, len(contentColumns))
+		for j, column := range contentColumns {
+			value := column.value(result)
+			if score, ok := value.(float64); ok {
+				value = roundScore(score)
+			}
+			values[j] = value
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+
+	lastCol, _ := excelize.ColumnNumberToName(len(contentColumns))
+	lastRow := len(data.Results) + 1
+	if lastRow > 1 {
+		// 分数列显示一位小数
+		for j, column := range contentColumns {
+			if _, ok := column.value(data.Results[0]).(float64); ok {
+				col, _ := excelize.ColumnNumberToName(j + 1)
+				if err := f.SetCellStyle(sheet, col+"2", fmt.Sprintf("%s%d", col, lastRow), scoreStyle); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := addExcelTable(f, sheet, "Contents", lastCol, lastRow); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(sheet, "A", "A", 40); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "B", lastCol, 11)
+}
+
+// writeExcelKeywords 热门关键词及出现次数、相关度、趋势和分类
+func writeExcelKeywords(f *excelize.File, data ReportData) error {
+	sheet := excelKeywordsSheet
+	headers := []interface{}{"关键词", "出现次数", "相关度", "趋势", "分类"}
+	if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+		return err
+	}
+	for i, keyword := range data.TopKeywords {
+		values := []interface{}{keyword.Word, keyword.Frequency, keyword.Relevance, keyword.Trend, keyword.Category}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+
+	if err := addExcelTable(f, sheet, "Keywords", "E", len(data.TopKeywords)+1); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "A", "E", 14)
+}
+
+// addExcelTable 把 A1 到 lastCol+lastRow 设为表格并冻结表头。没有数据行时表格无效，只加筛选
+func addExcelTable(f *excelize.File, sheet, name, lastCol string, lastRow int) error {
+	if lastRow < 2 {
+		if err := f.AutoFilter(sheet, fmt.Sprintf("A1:%s1", lastCol), nil); err != nil {
+			return err
+		}
+	} else if err := f.AddTable(sheet, &excelize.Table{
+		Range:     fmt.Sprintf("A1:%s%d", lastCol, lastRow),
+		Name:      name,
+		StyleName: "TableStyleMedium2",
+	}); err != nil {
+		return err
+	}
+	return f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
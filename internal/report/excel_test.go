@@ -0,0 +1,93 @@
+package report
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExcelReport(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Formats = []string{"xlsx"}
+	})
+	results := []models.AnalysisResult{
+		{
+			ContentID: "post-1",
+			Title:     "周末露营清单",
+			Score:     models.OverallScore{Total: 72.46, Level: "good", Breakdown: models.ScoreBreakdown{Title: 80}},
+			Keywords:  []models.Keyword{{Word: "露营", Frequency: 5, Relevance: 0.9}},
+		},
+		{
+			ContentID: "post-2",
+			Title:     "徒步入门",
+			Score:     models.OverallScore{Total: 9.5, Level: "poor"},
+			Keywords:  []models.Keyword{{Word: "徒步", Frequency: 3, Relevance: 0.7}},
+		},
+	}
+	if err := r.GenerateReport(results); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	f, err := excelize.OpenFile(filepath.Join(r.config.OutputDir, "analysis_report.xlsx"))
+	if err != nil {
+		t.Fatalf("打开工作簿失败: %v", err)
+	}
+	defer f.Close()
+
+	sheets := make(map[string]bool)
+	for _, sheet := range f.GetSheetList() {
+		sheets[sheet] = true
+	}
+	for _, sheet := range []string{excelSummarySheet, excelContentSheet, excelKeywordsSheet} {
+		if !sheets[sheet] {
+			t.Errorf("缺少工作表 %s, 现有 %v", sheet, f.GetSheetList())
+		}
+	}
+
+	// 总分列（B）写为数字，按数值而不是文字排序（9.5 < 72.5）
+	for cell, want := range map[string]float64{"B2": 72.5, "B3": 9.5} {
+		assertNumericCell(t, f, excelContentSheet, cell, want)
+	}
+	if typ, _ := f.GetCellType(excelContentSheet, "A2"); typ == excelize.CellTypeUnset || typ == excelize.CellTypeNumber {
+		t.Errorf("标题单元格类型 = %v, want 文字", typ)
+	}
+	assertNumericCell(t, f, excelKeywordsSheet, "B2", 5)
+
+	// 内容和关键词工作表整体设为带筛选的表格
+	for sheet, name := range map[string]string{excelContentSheet: "Contents", excelKeywordsSheet: "Keywords"} {
+		tables, err := f.GetTables(sheet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tables) != 1 || tables[0].Name != name {
+			t.Errorf("%s 的表格 = %+v, want %s", sheet, tables, name)
+		}
+	}
+	lastCol, _ := excelize.ColumnNumberToName(len(contentColumns))
+	if tables, _ := f.GetTables(excelContentSheet); len(tables) == 1 && tables[0].Range != "A1:"+lastCol+"3" {
+		t.Errorf("内容表格范围 = %s, want A1:%s3", tables[0].Range, lastCol)
+	}
+}
+
+// assertNumericCell 数字单元格在 xlsx 中不带类型（默认 n）或类型为 n，值应为 want
+func assertNumericCell(t *testing.T, f *excelize.File, sheet, cell string, want float64) {
+	t.Helper()
+	typ, err := f.GetCellType(sheet, cell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ != excelize.CellTypeUnset && typ != excelize.CellTypeNumber {
+		t.Errorf("%s!%s 类型 = %v, want 数字", sheet, cell, typ)
+	}
+	raw, err := f.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := strconv.ParseFloat(raw, 64); err != nil || got != want {
+		t.Errorf("%s!%s = %q, want %v", sheet, cell, raw, want)
+	}
+}
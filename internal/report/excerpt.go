@@ -0,0 +1,86 @@
+// internal/report/excerpt.go
+package report
+
+import (
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// jsonResults 按 include_text / text_excerpt_len 处理结果中摘自正文的文本（CTA语句、小标题），
+// 返回副本，不影响HTML/CSV报告使用的原始结果
+func (r *Reporter) jsonResults(results []models.AnalysisResult) []models.AnalysisResult {
+	if !r.textTrimmed() {
+		return results
+	}
+	include := r.config.Report.IncludeText
+	limit := r.config.Report.TextExcerptLen
+
+	excerpt := func(s string) string {
+		if !include {
+			return ""
+		}
+		return truncateRunes(s, limit)
+	}
+
+	out := make([]models.AnalysisResult, len(results))
+	for i, result := range results {
+		text := &result.TextAnalysis
+
+		if include {
+			ctas := make([]string, len(text.CallToAction))
+			for j, cta := range text.CallToAction {
+				ctas[j] = excerpt(cta)
+			}
+			text.CallToAction = ctas
+		} else {
+			text.CallToAction = nil
+		}
+
+		placements := make([]models.CTAPlacement, len(text.CTAAnalysis.Placements))
+		for j, p := range text.CTAAnalysis.Placements {
+			p.Text = excerpt(p.Text)
+			placements[j] = p
+		}
+		text.CTAAnalysis.Placements = placements
+
+		headings := &text.ContentStructure.Headings
+		items := make([]models.Heading, len(headings.Headings))
+		for j, h := range headings.Headings {
+			h.Text = excerpt(h.Text)
+			items[j] = h
+		}
+		headings.Headings = items
+
+		// 标题层级问题的描述中引用了小标题原文
+		if include {
+			issues := make([]string, len(headings.Issues))
+			for j, issue := range headings.Issues {
+				issues[j] = excerpt(issue)
+			}
+			headings.Issues = issues
+		} else {
+			headings.Issues = nil
+		}
+
+		out[i] = result
+	}
+
+	return out
+}
+
+// textTrimmed JSON报告是否省略或截断了摘自正文的文本
+func (r *Reporter) textTrimmed() bool {
+	return !r.config.Report.IncludeText || r.config.Report.TextExcerptLen > 0
+}
+
+// truncateRunes 按字符截断，超出部分以省略号表示
+func truncateRunes(s string, limit int) string {
+	if limit <= 0 {
+		return s
+	}
+
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func excerptResult() models.AnalysisResult {
+	return models.AnalysisResult{
+		ContentID: "post-1",
+		TextAnalysis: models.TextAnalysis{
+			CallToAction: []string{"欢迎在评论区分享你的露营装备清单"},
+			CTAAnalysis: models.CTAAnalysis{
+				Placements: []models.CTAPlacement{{Text: "欢迎在评论区分享你的露营装备清单", Position: "end", Strength: "strong"}},
+			},
+			ContentStructure: models.ContentStructure{
+				Headings: models.HeadingAnalysis{
+					Headings: []models.Heading{{Level: 2, Text: "新手必备的三件套"}},
+					Issues:   []string{"缺少一级标题"},
+				},
+			},
+		},
+	}
+}
+
+func TestJSONResultsExcerpt(t *testing.T) {
+	tests := []struct {
+		name        string
+		include     bool
+		limit       int
+		wantCTA     []string
+		wantHeading string
+		wantIssues  []string
+	}{
+		{
+			name:        "完整保留",
+			include:     true,
+			wantCTA:     []string{"欢迎在评论区分享你的露营装备清单"},
+			wantHeading: "新手必备的三件套",
+			wantIssues:  []string{"缺少一级标题"},
+		},
+		{
+			name:        "按字数截断",
+			include:     true,
+			limit:       6,
+			wantCTA:     []string{"欢迎在评论区…"},
+			wantHeading: "新手必备的三…",
+			wantIssues:  []string{"缺少一级标题"},
+		},
+		{
+			name:    "不包含正文",
+			include: false,
+			limit:   6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReporter(t, func(cfg *config.Config) {
+				cfg.Report.IncludeText = tt.include
+				cfg.Report.TextExcerptLen = tt.limit
+			})
+			original := []models.AnalysisResult{excerptResult()}
+
+			got := r.jsonResults(original)[0].TextAnalysis
+
+			if !reflect.DeepEqual(got.CallToAction, tt.wantCTA) {
+				t.Errorf("CallToAction = %q, want %q", got.CallToAction, tt.wantCTA)
+			}
+			if p := got.CTAAnalysis.Placements[0]; p.Text != firstOrEmpty(tt.wantCTA) || p.Position != "end" {
+				t.Errorf("Placement = %+v, 文本应为 %q 且保留位置", p, firstOrEmpty(tt.wantCTA))
+			}
+			if h := got.ContentStructure.Headings.Headings[0]; h.Text != tt.wantHeading || h.Level != 2 {
+				t.Errorf("Heading = %+v, want %q", h, tt.wantHeading)
+			}
+			if !reflect.DeepEqual(got.ContentStructure.Headings.Issues, tt.wantIssues) {
+				t.Errorf("Issues = %q, want %q", got.ContentStructure.Headings.Issues, tt.wantIssues)
+			}
+
+			// HTML/CSV 仍使用原始结果
+			if !reflect.DeepEqual(original[0], excerptResult()) {
+				t.Error("jsonResults 不应修改原始结果")
+			}
+			// 截断或省略过的报告标记出来，增量分析不复用
+			if wantTrimmed := tt.name != "完整保留"; r.textTrimmed() != wantTrimmed {
+				t.Errorf("textTrimmed() = %v, want %v", r.textTrimmed(), wantTrimmed)
+			}
+		})
+	}
+}
+
+func firstOrEmpty(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return items[0]
+}
@@ -0,0 +1,141 @@
+// internal/report/markdown.go
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 改进建议按优先级分组输出的顺序和标题，未知优先级归入最后的“其他”
+var markdownPriorities = []struct {
+	key   string
+	label string
+}{
+	{"high", "🔴 高优先级"},
+	{"medium", "🟡 中优先级"},
+	{"low", "🟢 低优先级"},
+}
+
+// generateMarkdownReport 生成可嵌入 Wiki 和 PR 的 GitHub 风格 Markdown 报告，
+// 数据与HTML报告一致：平均得分、内容排名、热门关键词和按优先级分组的改进建议
+func (r *Reporter) generateMarkdownReport(data ReportData) error {
+	var b strings.Builder
+
+	b.WriteString("# 📊 内容分析报告\n\n")
+	fmt.Fprintf(&b, "生成时间: %s | 分析内容数量: %d 篇 | 总体评分: **%.1f**\n\n",
+		data.GeneratedAt.Format("2006-01-02 15:04:05"), data.TotalContent, data.OverallScore)
+	if usage := data.AIUsage; usage != nil {
+		fmt.Fprintf(&b, "AI 用量: %d 次请求，%d tokens，预计费用 $%.4f\n\n", usage.Requests, usage.TotalTokens, usage.EstimatedCost)
+	}
+	for _, warning := range data.Summary.Warnings {
+		fmt.Fprintf(&b, "> ⚠️ %s\n\n", warning)
+	}
+
+	b.WriteString("## 📈 平均得分\n\n")
+	rows := make([][]string, 0, len(data.Dimensions))
+	for _, dim := range data.Dimensions {
+		rows = append(rows, []string{dim.Label, fmt.Sprintf("%.1f", dim.Score)})
+	}
+	writeMarkdownTable(&b, []string{"维度", "平均得分"}, rows)
+
+	b.WriteString("\n## 📝 内容排名\n\n")
+	ranked := make([]models.AnalysisResult, len(data.Results))
+	copy(ranked, data.Results)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score.Total > ranked[j].Score.Total
+	})
+	rows = make([][]string, 0, len(ranked))
+	for i, result := range ranked {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			result.Title,
+			fmt.Sprintf("%.1f", result.Score.Total),
+			result.Score.Level,
+			r.sentimentIndicator(result.Sentiment.Overall),
+			result.Score.Reasoning,
+		})
+	}
+	writeMarkdownTable(&b, []string{"排名", "标题", "总分", "等级", "情感倾向", "评分说明"}, rows)
+
+	b.WriteString("\n## 🔥 热门关键词\n\n")
+	if len(data.TopKeywords) == 0 {
+		b.WriteString("暂无\n")
+	} else {
+		keywords := make([]string, len(data.TopKeywords))
+		for i, kw := range data.TopKeywords {
+			keywords[i] = fmt.Sprintf("`%s` (%d)", kw.Word, kw.Frequency)
+		}
+		b.WriteString(strings.Join(keywords, " · ") + "\n")
+	}
+
+	b.WriteString("\n## 💡 改进建议\n")
+	if len(data.Recommendations) == 0 {
+		b.WriteString("\n暂无全局改进建议\n")
+	}
+	grouped := make(map[string][]GlobalRecommendation)
+	for _, rec := range data.Recommendations {
+		grouped[rec.Priority] = append(grouped[rec.Priority], rec)
+	}
+	for _, p := range markdownPriorities {
+		writeMarkdownRecommendations(&b, p.label, grouped[p.key])
+		delete(grouped, p.key)
+	}
+	var others []GlobalRecommendation
+	for _, rec := range data.Recommendations {
+		if _, ok := grouped[rec.Priority]; ok {
+			others = append(others, rec)
+		}
+	}
+	writeMarkdownRecommendations(&b, "其他", others)
+
+	filename := filepath.Join(r.config.OutputDir, "analysis_report.md")
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
+
+// writeMarkdownRecommendations 输出一个优先级分组，组内为空时不输出标题
+func writeMarkdownRecommendations(b *strings.Builder, label string, recs []GlobalRecommendation) {
+	if len(recs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", label)
+	for _, rec := range recs {
+		fmt.Fprintf(b, "- **%s**：%s（影响内容: %d篇 | %s）\n",
+			rec.Category, markdownInline(rec.Description), len(rec.AffectedContent), rec.ExpectedImpact)
+	}
+}
+
+// writeMarkdownTable 输出 GFM 表格
+func writeMarkdownTable(b *strings.Builder, header []string, rows [][]string) {
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + markdownCell(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(header)
+	b.WriteString("|")
+	for range header {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
+
+// markdownCell 转义表格单元格中的竖线并合并为一行
+func markdownCell(s string) string {
+	return strings.ReplaceAll(markdownInline(s), "|", `\|`)
+}
+
+// markdownInline 将多行文本合并为一行
+func markdownInline(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,103 @@
+// internal/report/notion.go
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// generateNotionExport 按 report.notion.columns 生成可导入 Notion 数据库的 CSV 或 Markdown 表格
+func (r *Reporter) generateNotionExport(results []models.AnalysisResult) error {
+	notion := r.config.Report.Notion
+
+	header := make([]string, len(notion.Columns))
+	for i, column := range notion.Columns {
+		header[i] = column.Name
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		row := make([]string, len(notion.Columns))
+		for i, column := range notion.Columns {
+			row[i] = r.notionValue(result, column.Field)
+		}
+		rows = append(rows, row)
+	}
+
+	if notion.Format == "markdown" {
+		return writeNotionMarkdown(filepath.Join(r.config.OutputDir, "notion_export.md"), header, rows)
+	}
+	return writeNotionCSV(filepath.Join(r.config.OutputDir, "notion_export.csv"), header, rows)
+}
+
+// notionValue 将结果字段转为 Notion 可识别的单元格文本，多选属性以逗号分隔
+func (r *Reporter) notionValue(result models.AnalysisResult, field string) string {
+	switch field {
+	case "title":
+		return result.Title
+	case "content_id":
+		return result.ContentID
+	case "score":
+		return fmt.Sprintf("%.1f", result.Score.Total)
+	case "level":
+		return result.Score.Level
+	case "status":
+		if status, ok := r.config.Report.Notion.StatusMap[result.Score.Level]; ok {
+			return status
+		}
+		return result.Score.Level
+	case "tags":
+		return strings.Join(result.Tags, ", ")
+	case "topics":
+		return strings.Join(result.Topics, ", ")
+	case "keywords":
+		words := make([]string, len(result.Keywords))
+		for i, keyword := range result.Keywords {
+			words[i] = keyword.Word
+		}
+		return strings.Join(words, ", ")
+	case "content_type":
+		return result.ContentType
+	case "series":
+		return result.Series
+	case "sentiment":
+		return result.Sentiment.Overall
+	case "word_count":
+		return strconv.Itoa(result.TextAnalysis.WordCount)
+	case "reading_time":
+		return strconv.Itoa(result.Readability.ReadingTime)
+	case "suggestions":
+		return strconv.Itoa(len(result.Suggestions))
+	case "created_at":
+		return result.CreatedAt.Format("2006-01-02")
+	default:
+		return ""
+	}
+}
+
+func writeNotionCSV(filename string, header []string, rows [][]string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	// WriteAll 会在写完后 Flush 并返回写入错误
+	return writer.WriteAll(rows)
+}
+
+func writeNotionMarkdown(filename string, header []string, rows [][]string) error {
+	var b strings.Builder
+	writeMarkdownTable(&b, header, rows)
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
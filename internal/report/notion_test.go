@@ -0,0 +1,99 @@
+package report
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func notionResults() []models.AnalysisResult {
+	return []models.AnalysisResult{
+		{
+			ContentID: "post-1",
+			Title:     "周末露营清单",
+			Tags:      []string{"户外", "露营"},
+			Keywords:  []models.Keyword{{Word: "帐篷"}, {Word: "睡袋"}},
+			Score:     models.OverallScore{Total: 88.24, Level: "excellent"},
+		},
+		{
+			ContentID: "post-2",
+			Title:     "Tips | tricks",
+			Score:     models.OverallScore{Total: 42, Level: "poor"},
+		},
+	}
+}
+
+func TestNotionExportCSV(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Notion.Enabled = true
+		cfg.Report.Notion.Columns = []config.NotionColumn{
+			{Name: "Name", Field: "title"},
+			{Name: "Score", Field: "score"},
+			{Name: "Status", Field: "status"},
+			{Name: "Tags", Field: "tags"},
+			{Name: "Keywords", Field: "keywords"},
+			{Name: "ID", Field: "content_id"},
+		}
+	})
+
+	if err := r.generateNotionExport(notionResults()); err != nil {
+		t.Fatalf("generateNotionExport() error = %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(r.config.OutputDir, "notion_export.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("读取CSV失败: %v", err)
+	}
+
+	want := [][]string{
+		{"Name", "Score", "Status", "Tags", "Keywords", "ID"},
+		{"周末露营清单", "88.2", "可发布", "户外, 露营", "帐篷, 睡袋", "post-1"},
+		{"Tips | tricks", "42.0", "需重写", "", "", "post-2"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV =\n%q\nwant\n%q", records, want)
+	}
+}
+
+func TestNotionExportMarkdown(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Notion.Enabled = true
+		cfg.Report.Notion.Format = "markdown"
+		cfg.Report.Notion.StatusMap = map[string]string{"excellent": "Ready"}
+		cfg.Report.Notion.Columns = []config.NotionColumn{
+			{Name: "Name", Field: "title"},
+			{Name: "Status", Field: "status"},
+		}
+	})
+
+	if err := r.generateNotionExport(notionResults()); err != nil {
+		t.Fatalf("generateNotionExport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.config.OutputDir, "notion_export.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		"| Name | Status |",
+		"| --- | --- |",
+		"| 周末露营清单 | Ready |",
+		// 未映射的等级原样输出，单元格中的竖线需转义
+		`| Tips \| tricks | poor |`,
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("Markdown =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,93 @@
+// internal/report/opportunities.go
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// Opportunity 跨内容汇总的同一类改进，按合计预计提分排序
+type Opportunity struct {
+	Factor          string   `json:"factor"`
+	Description     string   `json:"description"`
+	Count           int      `json:"count"`
+	TotalGain       float64  `json:"total_gain"`   // 各篇预计提分之和
+	AverageGain     float64  `json:"average_gain"` // 每篇平均预计提分
+	AffectedContent []string `json:"affected_content"`
+}
+
+// opportunityActions 各类建议在汇总中的动作描述
+var opportunityActions = map[string]string{
+	"title":           "优化标题吸引力",
+	"title_length":    "缩短超出平台上限的标题",
+	"title_hashtags":  "调整标题话题标签数量",
+	"hashtags":        "补充话题标签",
+	"intro":           "补充吸引人的开头",
+	"headings":        "规范标题层级",
+	"cta":             "添加行动召唤",
+	"end_cta":         "在结尾补充行动召唤",
+	"strong_cta":      "改用祈使句式的行动召唤",
+	"readability":     "提升可读性",
+	"images":          "添加配图",
+	"image_text":      "放大图片文字或提高其对比度",
+	"image_ratio":     "按平台推荐比例裁剪图片",
+	"keyword_density": "调整主关键词密度",
+	"variety":         "调整句子和段落的长短节奏",
+	"link_anchors":    "改写笼统的链接锚文本",
+	"writing_issues":  "修正错别字和语法问题",
+	"emoji":           "减少过多的emoji",
+}
+
+// generateOpportunities 按建议类别汇总全部内容的预计提分，返回合计提分最高的前 report.top_opportunities 项
+func (r *Reporter) generateOpportunities(results []models.AnalysisResult) []Opportunity {
+	limit := r.config.Report.TopOpportunities
+	if limit <= 0 {
+		return nil
+	}
+
+	byFactor := make(map[string]*Opportunity)
+	for _, result := range results {
+		suggestions := append(append([]models.Suggestion{}, result.Suggestions...), result.Minor...)
+		for _, suggestion := range suggestions {
+			if suggestion.Factor == "" || suggestion.ProjectedGain <= 0 {
+				continue
+			}
+
+			opportunity, ok := byFactor[suggestion.Factor]
+			if !ok {
+				opportunity = &Opportunity{Factor: suggestion.Factor}
+				byFactor[suggestion.Factor] = opportunity
+			}
+			opportunity.Count++
+			opportunity.TotalGain += suggestion.ProjectedGain
+			opportunity.AffectedContent = append(opportunity.AffectedContent, result.Title)
+		}
+	}
+
+	opportunities := make([]Opportunity, 0, len(byFactor))
+	for _, opportunity := range byFactor {
+		opportunity.AverageGain = opportunity.TotalGain / float64(opportunity.Count)
+
+		action, ok := opportunityActions[opportunity.Factor]
+		if !ok {
+			action = opportunity.Factor
+		}
+		opportunity.Description = fmt.Sprintf("为%d篇内容%s，平均每篇+%.1f分", opportunity.Count, action, opportunity.AverageGain)
+
+		opportunities = append(opportunities, *opportunity)
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		if opportunities[i].TotalGain != opportunities[j].TotalGain {
+			return opportunities[i].TotalGain > opportunities[j].TotalGain
+		}
+		return opportunities[i].Factor < opportunities[j].Factor
+	})
+
+	if len(opportunities) > limit {
+		opportunities = opportunities[:limit]
+	}
+	return opportunities
+}
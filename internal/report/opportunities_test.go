@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestOpportunitiesRankedByTotalGain(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.TopOpportunities = 3
+	})
+
+	var results []models.AnalysisResult
+	for i := 0; i < 4; i++ {
+		result := models.AnalysisResult{
+			Title: fmt.Sprintf("post-%d", i),
+			// 每篇提分不多，但覆盖的内容最多
+			Suggestions: []models.Suggestion{{Factor: "cta", ProjectedGain: 3}},
+		}
+		if i == 0 {
+			// 单篇提分最高
+			result.Suggestions = append(result.Suggestions, models.Suggestion{Factor: "images", ProjectedGain: 10})
+			// 没有类别或没有提分的建议不参与汇总
+			result.Suggestions = append(result.Suggestions, models.Suggestion{ProjectedGain: 50}, models.Suggestion{Factor: "intro"})
+		}
+		if i < 2 {
+			result.Minor = []models.Suggestion{{Factor: "title", ProjectedGain: 5}}
+		}
+		if i == 3 {
+			result.Suggestions = append(result.Suggestions, models.Suggestion{Factor: "emoji", ProjectedGain: 1})
+		}
+		results = append(results, result)
+	}
+
+	opportunities := r.generateOpportunities(results)
+
+	var factors []string
+	for _, o := range opportunities {
+		factors = append(factors, o.Factor)
+	}
+	// cta 合计12分；images 与 title 合计都是10分，按类别名排序；emoji 超出数量上限
+	if want := []string{"cta", "images", "title"}; !reflect.DeepEqual(factors, want) {
+		t.Fatalf("factors = %v, want %v", factors, want)
+	}
+
+	cta := opportunities[0]
+	if cta.Count != 4 || cta.TotalGain != 12 || cta.AverageGain != 3 || len(cta.AffectedContent) != 4 {
+		t.Errorf("cta = %+v, want 4篇/合计12/平均3", cta)
+	}
+	if cta.Description != "为4篇内容添加行动召唤，平均每篇+3.0分" {
+		t.Errorf("Description = %q", cta.Description)
+	}
+	// 次要建议同样计入
+	if title := opportunities[2]; title.Count != 2 || title.TotalGain != 10 {
+		t.Errorf("title = %+v, want 2篇/合计10", title)
+	}
+}
+
+func TestOpportunitiesDisabled(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.TopOpportunities = 0
+	})
+
+	results := []models.AnalysisResult{{Suggestions: []models.Suggestion{{Factor: "cta", ProjectedGain: 3}}}}
+	if got := r.generateOpportunities(results); got != nil {
+		t.Errorf("top_opportunities 为0时 = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,85 @@
+// internal/report/output.go
+package report
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// reportFormat 一种输出格式及其生成函数
+type reportFormat struct {
+	name     string
+	generate func() error
+}
+
+// FormatError 部分格式生成失败，可通过 errors.Is / errors.As 检查各格式的底层错误
+type FormatError struct {
+	Succeeded []string
+	Failed    []FormatFailure
+}
+
+// FormatFailure 单个格式的失败原因
+type FormatFailure struct {
+	Format string
+	Err    error
+}
+
+func (e *FormatError) Error() string {
+	failed := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		failed[i] = fmt.Sprintf("%s（%v）", f.Format, f.Err)
+	}
+
+	succeeded := "无"
+	if len(e.Succeeded) > 0 {
+		succeeded = strings.Join(e.Succeeded, ", ")
+	}
+	return fmt.Sprintf("部分报告生成失败: 成功 %s；失败 %s", succeeded, strings.Join(failed, "; "))
+}
+
+func (e *FormatError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// prepareOutputDir 创建输出目录并预先写入一个临时文件，目录不可写时在生成任何报告之前返回错误
+func (r *Reporter) prepareOutputDir() error {
+	if err := os.MkdirAll(r.config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	probe, err := os.CreateTemp(r.config.OutputDir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("输出目录 %s 不可写: %w", r.config.OutputDir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return nil
+}
+
+// generateFormats 依次生成各格式，失败的格式记录后按 on_format_error 决定是否继续
+func (r *Reporter) generateFormats(formats []reportFormat) error {
+	result := &FormatError{}
+	for _, format := range formats {
+		if err := format.generate(); err != nil {
+			log.Printf("生成%s报告失败: %v", strings.ToUpper(format.name), err)
+			result.Failed = append(result.Failed, FormatFailure{Format: format.name, Err: err})
+			if r.config.Report.OnFormatError == "abort" {
+				break
+			}
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, format.name)
+	}
+
+	if len(result.Failed) > 0 {
+		return result
+	}
+	return nil
+}
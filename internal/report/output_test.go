@@ -0,0 +1,81 @@
+package report
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestGenerateReportUnwritableOutputDir(t *testing.T) {
+	// 输出目录的上级是普通文件，以 root 运行时同样无法创建
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.OutputDir = filepath.Join(blocker, "reports")
+	})
+
+	err := r.GenerateReport([]models.AnalysisResult{{ContentID: "post-1"}})
+	if err == nil {
+		t.Fatal("输出目录无法创建时应返回错误")
+	}
+	var formatErr *FormatError
+	if errors.As(err, &formatErr) {
+		t.Errorf("目录检查失败时不应开始生成任何格式: %v", err)
+	}
+}
+
+func TestGenerateReportPerFormatFailure(t *testing.T) {
+	tests := []struct {
+		policy        string
+		wantSucceeded []string
+		wantCSV       bool
+	}{
+		{policy: "continue", wantSucceeded: []string{"json", "csv"}, wantCSV: true},
+		{policy: "abort", wantSucceeded: []string{"json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			r := newTestReporter(t, func(cfg *config.Config) {
+				cfg.Report.Formats = []string{"json", "html", "csv"}
+				cfg.Report.OnFormatError = tt.policy
+			})
+			// 与HTML报告同名的目录使该格式无法写入
+			if err := os.Mkdir(filepath.Join(r.config.OutputDir, "analysis_report.html"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			err := r.GenerateReport([]models.AnalysisResult{{ContentID: "post-1", Title: "测试"}})
+
+			var formatErr *FormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("error = %v, want *FormatError", err)
+			}
+			if !reflect.DeepEqual(formatErr.Succeeded, tt.wantSucceeded) {
+				t.Errorf("Succeeded = %v, want %v", formatErr.Succeeded, tt.wantSucceeded)
+			}
+			if len(formatErr.Failed) != 1 || formatErr.Failed[0].Format != "html" {
+				t.Fatalf("Failed = %+v, want [html]", formatErr.Failed)
+			}
+			var pathErr *fs.PathError
+			if !errors.As(err, &pathErr) {
+				t.Errorf("应能通过 errors.As 取得HTML格式的底层错误: %v", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(r.config.OutputDir, "analysis_report.json")); err != nil {
+				t.Errorf("JSON报告应已生成: %v", err)
+			}
+			_, err = os.Stat(filepath.Join(r.config.OutputDir, "analysis_report.csv"))
+			if (err == nil) != tt.wantCSV {
+				t.Errorf("CSV报告存在 = %v, want %v", err == nil, tt.wantCSV)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// internal/report/pdf.go
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// PDF 使用的字体名，字体文件由 report.pdf.font_path 指定
+const pdfFont = "report"
+
+// PDF 版面：A4 纵向，单位毫米
+const (
+	pdfMargin     = 15.0
+	pdfLineHeight = 6.0
+	pdfBarWidth   = 80.0
+)
+
+// generatePDFReport 生成便于分享的PDF报告：第一页突出总体得分，随后为表现概况、
+// 各维度平均得分、热门关键词和改进建议，内容与HTML报告一致
+func (r *Reporter) generatePDFReport(data ReportData) error {
+	// fpdf 按 字体目录+文件名 查找字体，不接受绝对路径
+	fontPath := r.config.Report.PDF.FontPath
+	pdf := fpdf.New("P", "mm", "A4", filepath.Dir(fontPath))
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+
+	// 内置字体不含中文，必须加载支持中文的 TrueType 字体
+	pdf.AddUTF8Font(pdfFont, "", filepath.Base(fontPath))
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("加载PDF字体 %s 失败: %w", fontPath, err)
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 5)
+		pdf.SetFont(pdfFont, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, fmt.Sprintf("第 %d 页", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// 标题和总体得分
+	pdf.SetFont(pdfFont, "", 20)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 12, "内容分析报告", "", 1, "C", false, 0, "")
+	pdf.SetFont(pdfFont, "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, pdfLineHeight, fmt.Sprintf("生成时间: %s | 分析内容: %d篇",
+		data.GeneratedAt.Format("2006-01-02 15:04:05"), data.TotalContent), "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	red, green, blue := r.pdfScoreColor(data.OverallScore)
+	pdf.SetTextColor(red, green, blue)
+	pdf.SetFont(pdfFont, "", 48)
+	pdf.CellFormat(0, 22, fmt.Sprintf("%.1f", data.OverallScore), "", 1, "C", false, 0, "")
+	pdf.SetFont(pdfFont, "", 12)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, pdfLineHeight, "总体得分", "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	// 表现概况
+	pdfHeading(pdf, "表现概况")
+	pdfList(pdf, "警告", data.Summary.Warnings)
+	pdfParagraph(pdf, "最佳表现: "+data.Summary.BestPerforming)
+	pdfParagraph(pdf, "需要改进: "+data.Summary.NeedImprovement)
+	pdfList(pdf, "常见问题", data.Summary.CommonIssues)
+	pdfList(pdf, "成功模式", data.Summary.SuccessPatterns)
+
+	// 各维度平均得分，按 report.dimension_order 排列
+	pdfHeading(pdf, "评分维度")
+	for _, dimension := range data.Dimensions {
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.CellFormat(50, pdfLineHeight, dimension.Label, "", 0, "L", false, 0, "")
+
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.SetFillColor(230, 230, 230)
+		pdf.Rect(x, y+1, pdfBarWidth, pdfLineHeight-2, "F")
+		red, green, blue := r.pdfScoreColor(dimension.Score)
+		pdf.SetFillColor(red, green, blue)
+		pdf.Rect(x, y+1, pdfBarWidth*clampPercent(dimension.Score)/100, pdfLineHeight-2, "F")
+		pdf.SetX(x + pdfBarWidth + 4)
+		pdf.CellFormat(0, pdfLineHeight, fmt.Sprintf("%.1f", dimension.Score), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// 内容得分
+	pdfHeading(pdf, "内容详情")
+	for _, result := range data.Results {
+		pdf.SetFont(pdfFont, "", 11)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(0, pdfLineHeight, fmt.Sprintf("%s（%.1f分）", result.Title, result.Score.Total), "", "L", false)
+		if result.Score.Reasoning != "" {
+			pdf.SetFont(pdfFont, "", 9)
+			pdf.SetTextColor(90, 90, 90)
+			pdf.MultiCell(0, 5, result.Score.Reasoning, "", "L", false)
+		}
+		pdf.Ln(2)
+	}
+	pdf.Ln(2)
+
+	// 热门关键词
+	if len(data.TopKeywords) > 0 {
+		pdfHeading(pdf, "热门关键词")
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.SetTextColor(0, 0, 0)
+		keywords := ""
+		for i, keyword := range data.TopKeywords {
+			if i > 0 {
+				keywords += "    "
+			}
+			keywords += fmt.Sprintf("%s (%d)", keyword.Word, keyword.Frequency)
+		}
+		pdf.MultiCell(0, pdfLineHeight, keywords, "", "L", false)
+		pdf.Ln(4)
+	}
+
+	// 改进建议
+	if len(data.Recommendations) > 0 {
+		pdfHeading(pdf, "改进建议")
+		for _, rec := range data.Recommendations {
+			red, green, blue := pdfPriorityColor(rec.Priority)
+			pdf.SetFillColor(red, green, blue)
+			pdf.Rect(pdf.GetX(), pdf.GetY()+1, 1.5, pdfLineHeight-2, "F")
+			pdf.SetX(pdf.GetX() + 4)
+
+			pdf.SetFont(pdfFont, "", 11)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, pdfLineHeight, rec.Category, "", "L", false)
+			pdf.SetFont(pdfFont, "", 10)
+			pdf.MultiCell(0, pdfLineHeight, rec.Description, "", "L", false)
+			pdf.SetFont(pdfFont, "", 9)
+			pdf.SetTextColor(90, 90, 90)
+			pdf.MultiCell(0, 5, fmt.Sprintf("影响内容: %d篇 | %s", len(rec.AffectedContent), rec.ExpectedImpact), "", "L", false)
+			pdf.Ln(3)
+		}
+	}
+
+	return pdf.OutputFileAndClose(filepath.Join(r.config.OutputDir, "analysis_report.pdf"))
+}
+
+func pdfHeading(pdf *fpdf.Fpdf, text string) {
+	pdf.SetFont(pdfFont, "", 14)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 9, text, "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+func pdfParagraph(pdf *fpdf.Fpdf, text string) {
+	pdf.SetFont(pdfFont, "", 10)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(0, pdfLineHeight, text, "", "L", false)
+}
+
+func pdfList(pdf *fpdf.Fpdf, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	pdfParagraph(pdf, title+":")
+	for _, item := range items {
+		pdfParagraph(pdf, "  • "+item)
+	}
+	pdf.Ln(2)
+}
+
+// pdfScoreColor 与HTML报告的分数颜色一致，按 analysis.level_thresholds：excellent 绿色，good 蓝色，average 黄色，poor 红色
+func (r *Reporter) pdfScoreColor(score float64) (int, int, int) {
+	switch r.config.Analysis.LevelThresholds.Level(score) {
+	case "excellent":
+		return 40, 167, 69
+	case "good":
+		return 23, 162, 184
+	case "average":
+		return 255, 193, 7
+	default:
+		return 220, 53, 69
+	}
+}
+
+// pdfPriorityColor 与HTML报告建议左侧色条一致
+func pdfPriorityColor(priority string) (int, int, int) {
+	switch priority {
+	case "high":
+		return 220, 53, 69
+	case "medium":
+		return 255, 193, 7
+	default:
+		return 40, 167, 69
+	}
+}
+
+func clampPercent(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
@@ -0,0 +1,52 @@
+// internal/report/provenance.go
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 可能降级的分析组件在报告中的名称，按此顺序列出
+var provenanceComponents = []struct {
+	key   string
+	label string
+}{
+	{"sentiment", "情感分析"},
+	{"community_sentiment", "评论情感分析"},
+	{"topics", "主题提取"},
+	{"entities", "实体识别"},
+	{"hashtags", "话题标签推荐"},
+}
+
+// fallbackWarnings 因AI服务失败而有分析组件降级为本地分析的内容，占比达到 report.fallback_warning_ratio 时
+// 返回一条警告，列出各组件降级的篇数，提醒这部分结果不如AI分析可靠
+func (r *Reporter) fallbackWarnings(results []models.AnalysisResult) []string {
+	degraded := 0
+	counts := make(map[string]int)
+	for _, result := range results {
+		fellBack := false
+		for component, source := range result.Provenance {
+			if source == models.ProvenanceFallback {
+				counts[component]++
+				fellBack = true
+			}
+		}
+		if fellBack {
+			degraded++
+		}
+	}
+	if degraded == 0 || float64(degraded)/float64(len(results)) < r.config.Report.FallbackWarningRatio {
+		return nil
+	}
+
+	var parts []string
+	for _, component := range provenanceComponents {
+		if n := counts[component.key]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d 篇", component.label, n))
+		}
+	}
+	return []string{fmt.Sprintf("%d/%d 篇内容的部分分析因AI服务失败降级为本地分析（%s），这些结果仅供参考",
+		degraded, len(results), strings.Join(parts, "、"))}
+}
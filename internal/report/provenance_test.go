@@ -0,0 +1,54 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func TestFallbackWarnings(t *testing.T) {
+	fallback := models.AnalysisResult{Provenance: map[string]string{
+		"sentiment": models.ProvenanceFallback,
+		"topics":    models.ProvenanceFallback,
+	}}
+	ai := models.AnalysisResult{Provenance: map[string]string{
+		"sentiment": models.ProvenanceAI,
+		"topics":    models.ProvenanceAI,
+	}}
+	local := models.AnalysisResult{Provenance: map[string]string{
+		"sentiment": models.ProvenanceLocal,
+		"topics":    models.ProvenanceLocal,
+	}}
+
+	tests := []struct {
+		name    string
+		ratio   float64
+		results []models.AnalysisResult
+		want    string // 为空时不应有警告
+	}{
+		{"达到比例", 0.2, []models.AnalysisResult{fallback, ai, ai, ai}, "1/4 篇内容的部分分析因AI服务失败降级为本地分析（情感分析 1 篇、主题提取 1 篇）"},
+		{"低于比例", 0.2, []models.AnalysisResult{fallback, ai, ai, ai, ai, ai}, ""},
+		{"比例为0时总是警告", 0, []models.AnalysisResult{fallback, ai, ai, ai, ai, ai}, "1/6 篇内容"},
+		// 未配置AI时的本地分析不算降级
+		{"本地分析", 0, []models.AnalysisResult{local, local}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReporter(t, func(cfg *config.Config) { cfg.Report.FallbackWarningRatio = tt.ratio })
+
+			warnings := r.fallbackWarnings(tt.results)
+			if tt.want == "" {
+				if len(warnings) != 0 {
+					t.Errorf("fallbackWarnings() = %q, want 无警告", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.want) {
+				t.Errorf("fallbackWarnings() = %q, want 包含 %q", warnings, tt.want)
+			}
+		})
+	}
+}
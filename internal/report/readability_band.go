@@ -0,0 +1,123 @@
+// internal/report/readability_band.go
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 可读性分布图在目标区间内外的分类
+const (
+	BandBelow  = "below"
+	BandWithin = "within"
+	BandAbove  = "above"
+)
+
+// ReadabilityBand 各内容可读性相对目标区间的分布
+type ReadabilityBand struct {
+	Min    float64    `json:"min"`
+	Max    float64    `json:"max"`
+	Below  int        `json:"below"`
+	Within int        `json:"within"`
+	Above  int        `json:"above"`
+	Items  []BandItem `json:"items"`
+}
+
+// BandItem 单篇内容在可读性区间中的位置
+type BandItem struct {
+	Title    string  `json:"title"`
+	Score    float64 `json:"score"`
+	Position string  `json:"position"` // below, within, above
+}
+
+// 分布图尺寸（像素）
+const (
+	bandChartWidth  = 640
+	bandLabelWidth  = 180
+	bandRowHeight   = 22
+	bandAxisHeight  = 24
+	bandLabelLength = 14
+)
+
+// generateReadabilityBand 按 report.readability_band 将每篇内容的可读性分为低于、处于、高于目标区间。
+// 跳过了可读性分析阶段时返回 nil
+func (r *Reporter) generateReadabilityBand(results []models.AnalysisResult) *ReadabilityBand {
+	for _, stage := range r.config.Analysis.DisabledStages {
+		if stage == "readability" {
+			return nil
+		}
+	}
+
+	band := &ReadabilityBand{
+		Min: r.config.Report.ReadabilityBand.Min,
+		Max: r.config.Report.ReadabilityBand.Max,
+	}
+	for _, result := range results {
+		item := BandItem{
+			Title: result.Title,
+			Score: result.Readability.FleschScore,
+		}
+		item.Position = classifyBand(item.Score, band.Min, band.Max)
+		switch item.Position {
+		case BandBelow:
+			band.Below++
+		case BandAbove:
+			band.Above++
+		default:
+			band.Within++
+		}
+		band.Items = append(band.Items, item)
+	}
+
+	return band
+}
+
+// classifyBand 区间两端都算作区间内
+func classifyBand(score, min, max float64) string {
+	switch {
+	case score < min:
+		return BandBelow
+	case score > max:
+		return BandAbove
+	default:
+		return BandWithin
+	}
+}
+
+// SVG 将分布绘制为每篇一行的点图，阴影部分为目标区间，区间外的点以橙色标出
+func (b *ReadabilityBand) SVG() template.HTML {
+	plotWidth := float64(bandChartWidth - bandLabelWidth - 20)
+	x := func(score float64) float64 {
+		return float64(bandLabelWidth) + math.Max(0, math.Min(score, 100))/100*plotWidth
+	}
+	height := len(b.Items)*bandRowHeight + bandAxisHeight
+
+	var s strings.Builder
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="12">`, bandChartWidth, height)
+	fmt.Fprintf(&s, `<rect x="%.1f" y="0" width="%.1f" height="%d" fill="#d4edda"/>`,
+		x(b.Min), x(b.Max)-x(b.Min), len(b.Items)*bandRowHeight)
+
+	for i, item := range b.Items {
+		y := i*bandRowHeight + bandRowHeight/2
+		color := "#28a745"
+		if item.Position != BandWithin {
+			color = "#fd7e14"
+		}
+		fmt.Fprintf(&s, `<text x="0" y="%d" dominant-baseline="middle">%s</text>`, y, html.EscapeString(truncateRunes(item.Title, bandLabelLength)))
+		fmt.Fprintf(&s, `<circle cx="%.1f" cy="%d" r="5" fill="%s"><title>%s: %.1f</title></circle>`,
+			x(item.Score), y, color, html.EscapeString(item.Title), item.Score)
+	}
+
+	axisY := len(b.Items)*bandRowHeight + bandAxisHeight/2
+	for _, tick := range []float64{0, 25, 50, 75, 100} {
+		fmt.Fprintf(&s, `<text x="%.1f" y="%d" text-anchor="middle" fill="#666">%.0f</text>`, x(tick), axisY, tick)
+	}
+	s.WriteString(`</svg>`)
+
+	return template.HTML(s.String())
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func readabilityResult(title string, score float64) models.AnalysisResult {
+	return models.AnalysisResult{
+		Title:       title,
+		Readability: models.ReadabilityMetrics{FleschScore: score},
+	}
+}
+
+func TestReadabilityBand(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.ReadabilityBand = config.ReadabilityBandConfig{Min: 60, Max: 80}
+	})
+
+	band := r.generateReadabilityBand([]models.AnalysisResult{
+		readabilityResult("学术论文", 35),
+		readabilityResult("下限", 60),
+		readabilityResult("正好", 72),
+		readabilityResult("上限", 80),
+		readabilityResult("儿童读物", 95),
+	})
+	if band == nil {
+		t.Fatal("generateReadabilityBand() = nil")
+	}
+
+	wantPositions := []string{BandBelow, BandWithin, BandWithin, BandWithin, BandAbove}
+	for i, item := range band.Items {
+		if item.Position != wantPositions[i] {
+			t.Errorf("%s(%.0f) = %q, want %q", item.Title, item.Score, item.Position, wantPositions[i])
+		}
+	}
+	if band.Below != 1 || band.Within != 3 || band.Above != 1 {
+		t.Errorf("Below/Within/Above = %d/%d/%d, want 1/3/1", band.Below, band.Within, band.Above)
+	}
+
+	svg := string(band.SVG())
+	if strings.Count(svg, "<circle") != 5 {
+		t.Errorf("SVG 应为每篇内容绘制一个点:\n%s", svg)
+	}
+	// 区间外的点以橙色标出
+	if got := strings.Count(svg, `fill="#fd7e14"`); got != 2 {
+		t.Errorf("区间外的点数 = %d, want 2", got)
+	}
+}
+
+func TestReadabilityBandSkippedWithStage(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Analysis.DisabledStages = []string{"readability"}
+	})
+
+	if band := r.generateReadabilityBand([]models.AnalysisResult{readabilityResult("a", 50)}); band != nil {
+		t.Errorf("跳过可读性分析时应不生成分布图, got %+v", band)
+	}
+}
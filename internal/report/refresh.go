@@ -0,0 +1,145 @@
+// internal/report/refresh.go
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 过期时长对优先级的放大倍数上限，避免极旧的内容总排在最前
+const maxRefreshAgeFactor = 3.0
+
+// RefreshItem 更新队列中的一篇内容
+type RefreshItem struct {
+	Title          string
+	ContentID      string
+	PublishedAt    time.Time
+	AgeDays        int
+	Score          float64
+	EngagementRate float64 // 没有浏览量时为0
+	Priority       float64
+	Reasons        []string
+}
+
+// buildRefreshQueue 筛选发布超过 min_age_days、且评分或互动率表现好的内容，按优先级降序排列。
+// 优先级 = 表现 × 过期程度；表现综合评分和相对语料中位数的互动率
+func (r *Reporter) buildRefreshQueue(results []models.AnalysisResult, now time.Time) []RefreshItem {
+	cfg := r.config.Report.RefreshQueue
+
+	var rates []float64
+	for _, result := range results {
+		if rate, ok := result.Engagement.Rate(); ok {
+			rates = append(rates, rate)
+		}
+	}
+	medianRate := median(rates)
+
+	var queue []RefreshItem
+	for _, result := range results {
+		if result.PublishedAt == nil {
+			continue
+		}
+		ageDays := int(now.Sub(*result.PublishedAt).Hours() / 24)
+		if ageDays < cfg.MinAgeDays {
+			continue
+		}
+
+		item := RefreshItem{
+			Title:       result.Title,
+			ContentID:   result.ContentID,
+			PublishedAt: *result.PublishedAt,
+			AgeDays:     ageDays,
+			Score:       result.Score.Total,
+		}
+
+		performance := result.Score.Total / 100
+		rate, hasRate := result.Engagement.Rate()
+		goodEngagement := hasRate && medianRate > 0 && rate > medianRate
+		if hasRate && medianRate > 0 {
+			item.EngagementRate = rate
+			performance = 0.5*performance + 0.5*math.Min(rate/medianRate, 2)/2
+		}
+
+		if result.Score.Total >= cfg.MinScore {
+			item.Reasons = append(item.Reasons, fmt.Sprintf("评分%.1f", result.Score.Total))
+		}
+		if goodEngagement {
+			item.Reasons = append(item.Reasons, fmt.Sprintf("互动率%.2f%%，高于中位数%.2f%%", rate*100, medianRate*100))
+		}
+		if len(item.Reasons) == 0 {
+			continue
+		}
+		item.Reasons = append(item.Reasons, fmt.Sprintf("已发布%d天", ageDays))
+
+		ageFactor := math.Min(float64(ageDays)/float64(cfg.MinAgeDays), maxRefreshAgeFactor)
+		item.Priority = math.Round(performance*ageFactor*100) / 100
+
+		queue = append(queue, item)
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].Priority != queue[j].Priority {
+			return queue[i].Priority > queue[j].Priority
+		}
+		return queue[i].AgeDays > queue[j].AgeDays
+	})
+
+	if cfg.Limit > 0 && len(queue) > cfg.Limit {
+		queue = queue[:cfg.Limit]
+	}
+	return queue
+}
+
+// generateRefreshQueue 将更新队列导出为 refresh_queue.csv
+func (r *Reporter) generateRefreshQueue(results []models.AnalysisResult) error {
+	queue := r.buildRefreshQueue(results, time.Now())
+
+	file, err := os.Create(filepath.Join(r.config.OutputDir, "refresh_queue.csv"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows := [][]string{{"优先级排名", "标题", "内容ID", "发布日期", "已发布天数", "评分", "互动率", "优先级", "原因"}}
+	for i, item := range queue {
+		rate := ""
+		if item.EngagementRate > 0 {
+			rate = fmt.Sprintf("%.2f%%", item.EngagementRate*100)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			item.Title,
+			item.ContentID,
+			item.PublishedAt.Format("2006-01-02"),
+			fmt.Sprintf("%d", item.AgeDays),
+			fmt.Sprintf("%.1f", item.Score),
+			rate,
+			fmt.Sprintf("%.2f", item.Priority),
+			strings.Join(item.Reasons, "；"),
+		})
+	}
+
+	return csv.NewWriter(file).WriteAll(rows)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
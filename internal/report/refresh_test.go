@@ -0,0 +1,70 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func refreshResult(id string, published time.Time, score float64) models.AnalysisResult {
+	return models.AnalysisResult{
+		ContentID:   id,
+		Title:       id,
+		PublishedAt: &published,
+		Score:       models.OverallScore{Total: score},
+	}
+}
+
+func TestRefreshQueuePrefersOldGoodContent(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.RefreshQueue = config.RefreshQueueConfig{Enabled: true, MinAgeDays: 180, MinScore: 60}
+	})
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	days := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+
+	queue := r.buildRefreshQueue([]models.AnalysisResult{
+		refreshResult("fresh-good", days(30), 90),
+		refreshResult("old-good", days(400), 85),
+		refreshResult("stale-good", days(200), 85),
+		refreshResult("old-poor", days(500), 40),
+		{ContentID: "undated", Score: models.OverallScore{Total: 95}},
+	}, now)
+
+	var ids []string
+	for _, item := range queue {
+		ids = append(ids, item.ContentID)
+	}
+	// 刚发布的内容和表现差的内容不需要更新；同样评分下越旧越优先
+	if len(ids) != 2 || ids[0] != "old-good" || ids[1] != "stale-good" {
+		t.Fatalf("queue = %v, want [old-good stale-good]", ids)
+	}
+	if queue[0].AgeDays != 400 || queue[0].Priority <= queue[1].Priority {
+		t.Errorf("queue[0] = %+v, 应按过期程度获得更高优先级", queue[0])
+	}
+}
+
+func TestRefreshQueueEngagementAboveMedian(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.RefreshQueue = config.RefreshQueueConfig{Enabled: true, MinAgeDays: 180, MinScore: 60}
+	})
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	popular := refreshResult("popular", now.AddDate(-1, 0, 0), 50)
+	popular.Engagement = models.Engagement{Views: 1000, Likes: 100}
+	typical := refreshResult("typical", now.AddDate(-1, 0, 0), 50)
+	typical.Engagement = models.Engagement{Views: 1000, Likes: 20}
+	quiet := refreshResult("quiet", now.AddDate(-1, 0, 0), 50)
+	quiet.Engagement = models.Engagement{Views: 1000, Likes: 10}
+
+	queue := r.buildRefreshQueue([]models.AnalysisResult{popular, typical, quiet}, now)
+
+	// 评分都低于 min_score，只有互动率高于中位数的内容入选
+	if len(queue) != 1 || queue[0].ContentID != "popular" {
+		t.Fatalf("queue = %+v, want [popular]", queue)
+	}
+	if queue[0].EngagementRate != 0.1 {
+		t.Errorf("EngagementRate = %v, want 0.1", queue[0].EngagementRate)
+	}
+}
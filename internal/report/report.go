@@ -2,6 +2,7 @@
 package report
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -15,14 +16,33 @@ import (
 	"github.com/RobinCoderZhao/content-analyzer/internal/models"
 )
 
+// 单项得分低于该值时，在共性问题和全局建议中计为该方面的问题
+const weakDimensionScore = 60
+
 type Reporter struct {
-	config *config.Config
+	config  *config.Config
+	deleted []string
+	usage   *models.AIUsage
 }
 
 func NewReporter(cfg *config.Config) *Reporter {
 	return &Reporter{config: cfg}
 }
 
+// SetDeletedContent 记录相对基线已删除的内容文件，写入报告
+func (r *Reporter) SetDeletedContent(paths []string) {
+	r.deleted = paths
+}
+
+// SetAIUsage 记录本次运行的AI用量和估算费用，没有实际请求也没有命中缓存时不写入报告
+func (r *Reporter) SetAIUsage(usage models.AIUsage) {
+	if usage.Requests == 0 && usage.CachedResponses == 0 {
+		r.usage = nil
+		return
+	}
+	r.usage = &usage
+}
+
 type ReportData struct {
 	GeneratedAt     time.Time               `json:"generated_at"`
 	TotalContent    int                     `json:"total_content"`
@@ -31,6 +51,21 @@ type ReportData struct {
 	Summary         ReportSummary           `json:"summary"`
 	TopKeywords     []models.Keyword        `json:"top_keywords"`
 	Recommendations []GlobalRecommendation  `json:"recommendations"`
+	CalendarHealth  CalendarHealth          `json:"calendar_health"`
+	Series          []SeriesConsistency     `json:"series,omitempty"`
+	ReadabilityBand *ReadabilityBand        `json:"readability_band,omitempty"`
+	Opportunities   []Opportunity           `json:"opportunities,omitempty"`
+	DuplicateImages []DuplicateImageGroup   `json:"duplicate_images,omitempty"`
+	DeletedContent  []string                `json:"deleted_content,omitempty"`
+	AIUsage         *models.AIUsage         `json:"ai_usage,omitempty"`
+	// TextTrimmed 结果中摘自正文的文本按 include_text / text_excerpt_len 省略或截断过，
+	// 这样的报告不能作为 --changed-against 复用的来源
+	TextTrimmed bool `json:"text_trimmed,omitempty"`
+
+	// 按 report.dimension_order 排列的平均得分，仅用于HTML展示
+	Dimensions []DimensionScore `json:"-"`
+	// 内容表格，以JSON嵌入HTML报告由脚本排序和筛选
+	ContentTable ContentTable `json:"-"`
 }
 
 type ReportSummary struct {
@@ -39,6 +74,7 @@ type ReportSummary struct {
 	NeedImprovement string                `json:"need_improvement"`
 	CommonIssues    []string              `json:"common_issues"`
 	SuccessPatterns []string              `json:"success_patterns"`
+	Warnings        []string              `json:"warnings,omitempty"` // AI失败降级的内容较多时的提示
 }
 
 type GlobalRecommendation struct {
@@ -49,38 +85,64 @@ type GlobalRecommendation struct {
 	ExpectedImpact  string   `json:"expected_impact"`
 }
 
+// GenerateReport 生成各格式的报告。各格式互不影响，某一格式失败时按 report.on_format_error
+// 继续或停止生成其余格式，并返回列出成功和失败格式的 *FormatError
 func (r *Reporter) GenerateReport(results []models.AnalysisResult) error {
-	// 创建输出目录
-	if err := os.MkdirAll(r.config.OutputDir, 0755); err != nil {
-		return fmt.Errorf("创建输出目录失败: %w", err)
+	if err := r.prepareOutputDir(); err != nil {
+		return err
 	}
 
 	// 生成报告数据
 	reportData := r.generateReportData(results)
 
-	// 生成JSON报告
-	if err := r.generateJSONReport(reportData); err != nil {
-		return fmt.Errorf("生成JSON报告失败: %w", err)
+	generators := map[string]func() error{
+		"json":     func() error { return r.generateJSONReport(reportData) },
+		"html":     func() error { return r.generateHTMLReport(reportData) },
+		"csv":      func() error { return r.generateCSVReport(reportData) },
+		"markdown": func() error { return r.generateMarkdownReport(reportData) },
+		"pdf":      func() error { return r.generatePDFReport(reportData) },
+		"xlsx":     func() error { return r.generateExcelReport(reportData) },
 	}
 
-	// 生成HTML报告
-	if err := r.generateHTMLReport(reportData); err != nil {
-		return fmt.Errorf("生成HTML报告失败: %w", err)
+	// report.formats 已在加载配置时校验，只包含上面的格式
+	var formats []reportFormat
+	for _, name := range r.config.Report.Formats {
+		formats = append(formats, reportFormat{name: name, generate: generators[name]})
 	}
-
-	// 生成CSV报告
-	if err := r.generateCSVReport(reportData); err != nil {
-		return fmt.Errorf("生成CSV报告失败: %w", err)
+	if r.config.Report.Notion.Enabled {
+		formats = append(formats, reportFormat{
+			name:     "notion",
+			generate: func() error { return r.generateNotionExport(reportData.Results) },
+		})
+	}
+	if r.config.Report.AuthorFeedback.Enabled {
+		formats = append(formats, reportFormat{
+			name:     "author_feedback",
+			generate: func() error { return r.generateAuthorFeedback(reportData.Results) },
+		})
+	}
+	if r.config.Report.RefreshQueue.Enabled {
+		formats = append(formats, reportFormat{
+			name:     "refresh_queue",
+			generate: func() error { return r.generateRefreshQueue(reportData.Results) },
+		})
 	}
 
+	if err := r.generateFormats(formats); err != nil {
+		return err
+	}
+	r.notifyWebhook(reportData)
 	return nil
 }
 
 func (r *Reporter) generateReportData(results []models.AnalysisResult) ReportData {
 	data := ReportData{
-		GeneratedAt:  time.Now(),
-		TotalContent: len(results),
-		Results:      results,
+		GeneratedAt:    time.Now(),
+		TotalContent:   len(results),
+		Results:        results,
+		DeletedContent: r.deleted,
+		AIUsage:        r.usage,
+		ContentTable:   r.buildContentTable(results),
 	}
 
 	if len(results) == 0 {
@@ -96,6 +158,7 @@ func (r *Reporter) generateReportData(results []models.AnalysisResult) ReportDat
 
 	// 生成摘要
 	data.Summary = r.generateSummary(results)
+	data.Dimensions = r.orderedDimensions(data.Summary.AverageScores)
 
 	// 提取热门关键词
 	data.TopKeywords = r.extractTopKeywords(results)
@@ -103,6 +166,21 @@ func (r *Reporter) generateReportData(results []models.AnalysisResult) ReportDat
 	// 生成全局建议
 	data.Recommendations = r.generateGlobalRecommendations(results)
 
+	// 内容组合健康度
+	data.CalendarHealth = r.generateCalendarHealth(results)
+
+	// 系列一致性
+	data.Series = r.generateSeriesConsistency(results)
+
+	// 可读性目标区间分布
+	data.ReadabilityBand = r.generateReadabilityBand(results)
+
+	// 跨内容的最高收益改进
+	data.Opportunities = r.generateOpportunities(results)
+
+	// 多篇内容重复使用的图片
+	data.DuplicateImages = r.generateDuplicateImages(results)
+
 	return data
 }
 
@@ -117,6 +195,7 @@ func (r *Reporter) generateSummary(results []models.AnalysisResult) ReportSummar
 	worstScore := 100.0
 	bestContent := ""
 	worstContent := ""
+	customCounts := make(map[string]int)
 
 	for _, result := range results {
 		totalBreakdown.ContentQuality += result.Score.Breakdown.ContentQuality
@@ -125,6 +204,13 @@ func (r *Reporter) generateSummary(results []models.AnalysisResult) ReportSummar
 		totalBreakdown.Title += result.Score.Breakdown.Title
 		totalBreakdown.Readability += result.Score.Breakdown.Readability
 		totalBreakdown.TrendRelevance += result.Score.Breakdown.TrendRelevance
+		for name, score := range result.Score.Breakdown.Custom {
+			if totalBreakdown.Custom == nil {
+				totalBreakdown.Custom = make(map[string]float64)
+			}
+			totalBreakdown.Custom[name] += score
+			customCounts[name]++
+		}
 
 		if result.Score.Total > bestScore {
 			bestScore = result.Score.Total
@@ -146,6 +232,13 @@ func (r *Reporter) generateSummary(results []models.AnalysisResult) ReportSummar
 		Readability:    totalBreakdown.Readability / count,
 		TrendRelevance: totalBreakdown.TrendRelevance / count,
 	}
+	// 自定义维度只在计算成功的内容间平均
+	for name, score := range totalBreakdown.Custom {
+		if averageScores.Custom == nil {
+			averageScores.Custom = make(map[string]float64)
+		}
+		averageScores.Custom[name] = score / float64(customCounts[name])
+	}
 
 	// 分析常见问题
 	commonIssues := r.findCommonIssues(results)
@@ -157,6 +250,7 @@ func (r *Reporter) generateSummary(results []models.AnalysisResult) ReportSummar
 		NeedImprovement: worstContent,
 		CommonIssues:    commonIssues,
 		SuccessPatterns: successPatterns,
+		Warnings:        r.fallbackWarnings(results),
 	}
 }
 
@@ -164,13 +258,13 @@ func (r *Reporter) findCommonIssues(results []models.AnalysisResult) []string {
 	issues := make(map[string]int)
 
 	for _, result := range results {
-		if result.Score.Breakdown.Title < 60 {
+		if result.Score.Breakdown.Title < weakDimensionScore {
 			issues["标题吸引力不足"]++
 		}
-		if result.Score.Breakdown.Engagement < 60 {
+		if result.Score.Breakdown.Engagement < weakDimensionScore {
 			issues["缺乏互动元素"]++
 		}
-		if result.Score.Breakdown.Visual < 60 {
+		if result.Score.Breakdown.Visual < weakDimensionScore {
 			issues["视觉内容质量偏低"]++
 		}
 		if result.Readability.FleschScore < 50 {
@@ -182,12 +276,23 @@ func (r *Reporter) findCommonIssues(results []models.AnalysisResult) []string {
 	}
 
 	// 转换为切片并按频率排序
-	var commonIssues []string
+	var names []string
 	for issue, count := range issues {
 		if count > len(results)/3 { // 超过1/3的内容有此问题才算常见问题
-			commonIssues = append(commonIssues, fmt.Sprintf("%s (%d篇)", issue, count))
+			names = append(names, issue)
 		}
 	}
+	sort.Slice(names, func(i, j int) bool {
+		if issues[names[i]] != issues[names[j]] {
+			return issues[names[i]] > issues[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var commonIssues []string
+	for _, issue := range names {
+		commonIssues = append(commonIssues, fmt.Sprintf("%s (%d篇)", issue, issues[issue]))
+	}
 
 	return commonIssues
 }
@@ -248,8 +353,16 @@ func (r *Reporter) findSuccessPatterns(results []models.AnalysisResult) []string
 func (r *Reporter) extractTopKeywords(results []models.AnalysisResult) []models.Keyword {
 	keywordMap := make(map[string]*models.Keyword)
 
+	blacklist := make(map[string]bool)
+	for _, word := range r.config.Report.KeywordBlacklist {
+		blacklist[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+
 	for _, result := range results {
 		for _, keyword := range result.Keywords {
+			if blacklist[strings.ToLower(keyword.Word)] {
+				continue
+			}
 			if existing, exists := keywordMap[keyword.Word]; exists {
 				existing.Frequency += keyword.Frequency
 				existing.Relevance = (existing.Relevance + keyword.Relevance) / 2
@@ -266,8 +379,15 @@ func (r *Reporter) extractTopKeywords(results []models.AnalysisResult) []models.
 		keywords = append(keywords, *keyword)
 	}
 
+	// 频次相同时依次按相关度、词语排序，保证多次运行结果一致
 	sort.Slice(keywords, func(i, j int) bool {
-		return keywords[i].Frequency > keywords[j].Frequency
+		if keywords[i].Frequency != keywords[j].Frequency {
+			return keywords[i].Frequency > keywords[j].Frequency
+		}
+		if keywords[i].Relevance != keywords[j].Relevance {
+			return keywords[i].Relevance > keywords[j].Relevance
+		}
+		return keywords[i].Word < keywords[j].Word
 	})
 
 	// 返回前20个
@@ -287,10 +407,10 @@ func (r *Reporter) generateGlobalRecommendations(results []models.AnalysisResult
 	visualIssues := []string{}
 
 	for _, result := range results {
-		if result.Score.Breakdown.Title < 60 {
+		if result.Score.Breakdown.Title < weakDimensionScore {
 			titleIssues = append(titleIssues, result.Title)
 		}
-		if result.Score.Breakdown.Engagement < 60 {
+		if result.Score.Breakdown.Engagement < weakDimensionScore {
 			engagementIssues = append(engagementIssues, result.Title)
 		}
 		if len(result.ImageAnalysis) == 0 {
@@ -341,6 +461,10 @@ func (r *Reporter) generateJSONReport(data ReportData) error {
 	}
 	defer file.Close()
 
+	// data 为值拷贝，替换 Results 不影响其他格式的报告
+	data.Results = r.jsonResults(data.Results)
+	data.TextTrimmed = r.textTrimmed()
+
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
 
@@ -375,6 +499,19 @@ func (r *Reporter) generateHTMLReport(data ReportData) error {
         .priority-high { border-left-color: #dc3545; }
         .priority-medium { border-left-color: #ffc107; }
         .priority-low { border-left-color: #28a745; }
+        .warning { color: #856404; background: #fff3cd; padding: 8px; border-radius: 5px; margin: 5px 0; }
+        .table-controls { display: flex; gap: 10px; align-items: center; margin-bottom: 10px; }
+        .table-controls input { flex: 1; padding: 6px 10px; border: 1px solid #ddd; border-radius: 5px; }
+        .table-controls select { padding: 6px; border: 1px solid #ddd; border-radius: 5px; }
+        .content-table { width: 100%; border-collapse: collapse; font-size: 0.9em; }
+        .content-table th, .content-table td { padding: 8px; border-bottom: 1px solid #eee; text-align: right; white-space: nowrap; }
+        .content-table th:first-child, .content-table td:first-child { text-align: left; white-space: normal; }
+        .content-table th { position: sticky; top: 0; background: white; cursor: pointer; user-select: none; }
+        .content-table th.sorted-asc::after { content: " ▲"; }
+        .content-table th.sorted-desc::after { content: " ▼"; }
+        .content-table .title-cell { cursor: pointer; }
+        .content-table .details td { text-align: left; white-space: normal; background: #f8f9fa; color: #555; }
+        .content-table .details p { margin: 4px 0; }
     </style>
 </head>
 <body>
@@ -388,40 +525,24 @@ func (r *Reporter) generateHTMLReport(data ReportData) error {
         <div class="score-card">
             <div class="score">{{printf "%.1f" .OverallScore}}</div>
             <h2>总体评分</h2>
-            <p>{{if ge .OverallScore 80}}优秀表现！继续保持{{else if ge .OverallScore 60}}良好水平，还有提升空间{{else}}需要重点改进{{end}}</p>
+            <p>{{with level .OverallScore}}{{if eq . "excellent"}}优秀表现！继续保持{{else if eq . "good"}}良好水平，还有提升空间{{else}}需要重点改进{{end}}{{end}}</p>
         </div>
 
         <div class="grid">
             <div class="card">
                 <h3>📈 平均得分详情</h3>
+                {{radarChart .Dimensions}}
+                {{range .Dimensions}}
                 <div class="metric">
-                    <span>内容质量</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.ContentQuality}}</span>
-                </div>
-                <div class="metric">
-                    <span>互动潜力</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.Engagement}}</span>
-                </div>
-                <div class="metric">
-                    <span>视觉吸引力</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.Visual}}</span>
-                </div>
-                <div class="metric">
-                    <span>标题质量</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.Title}}</span>
-                </div>
-                <div class="metric">
-                    <span>可读性</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.Readability}}</span>
-                </div>
-                <div class="metric">
-                    <span>趋势相关性</span>
-                    <span>{{printf "%.1f" .Summary.AverageScores.TrendRelevance}}</span>
+                    <span>{{.Label}}</span>
+                    <span>{{printf "%.1f" .Score}}</span>
                 </div>
+                {{end}}
             </div>
 
             <div class="card">
                 <h3>🏆 表现概况</h3>
+                {{range .Summary.Warnings}}<div class="warning">⚠️ {{.}}</div>{{end}}
                 <p><strong>最佳表现:</strong> {{.Summary.BestPerforming}}</p>
                 <p><strong>需要改进:</strong> {{.Summary.NeedImprovement}}</p>
                 
@@ -438,30 +559,117 @@ func (r *Reporter) generateHTMLReport(data ReportData) error {
                     <li>{{.}}</li>
                 {{end}}
                 </ul>
+
+                {{if .DeletedContent}}
+                <h4>已删除内容:</h4>
+                <ul>
+                {{range .DeletedContent}}
+                    <li>{{.}}</li>
+                {{end}}
+                </ul>
+                {{end}}
+
+                {{with .AIUsage}}
+                <h4>AI 用量:</h4>
+                <p>{{.Requests}}次请求，{{.TotalTokens}} tokens（输入{{.PromptTokens}} / 输出{{.CompletionTokens}}），预计费用 ${{printf "%.4f" .EstimatedCost}}{{if .CachedResponses}}，另有{{.CachedResponses}}次命中缓存{{end}}</p>
+                {{if .UnpricedModels}}<p><small>未配置价格、未计入费用的模型: {{range $i, $m := .UnpricedModels}}{{if $i}}、{{end}}{{$m}}{{end}}</small></p>{{end}}
+                {{end}}
             </div>
         </div>
 
         <div class="card">
             <h3>📝 内容详情</h3>
+            <div class="table-controls">
+                <input type="search" id="content-search" placeholder="按标题、关键词、标签筛选">
+                <select id="content-band"><option value="">全部分数段</option></select>
+                <span id="content-count"></span>
+            </div>
+            <noscript><p>内容表格需要启用 JavaScript，也可以查看同目录下的 CSV 或 JSON 报告。</p></noscript>
             <div class="content-list">
-            {{range .Results}}
-                <div class="content-item">
-                    <h4>{{.Title}}</h4>
-                    <span class="content-score {{if ge .Score.Total 80}}score-excellent{{else if ge .Score.Total 60}}score-good{{else if ge .Score.Total 40}}score-average{{else}}score-poor{{end}}">
-                        {{printf "%.1f" .Score.Total}}分
-                    </span>
-                    <p>{{.Score.Reasoning}}</p>
+                <table class="content-table" id="content-table"><thead></thead><tbody></tbody></table>
+            </div>
+        </div>
+
+        <div class="grid">
+            <div class="card">
+                <h3>🗓️ 内容类型分布</h3>
+                {{range .CalendarHealth.ByType}}
+                <div class="metric">
+                    <span>{{.Name}} ({{.Count}}篇)</span>
+                    <span>{{printf "%.1f" .AverageScore}}</span>
                 </div>
+                {{end}}
+            </div>
+
+            <div class="card">
+                <h3>🧭 主题覆盖</h3>
+                {{range .CalendarHealth.ByTopic}}
+                <div class="metric">
+                    <span>{{.Name}} ({{.Count}}篇)</span>
+                    <span>{{printf "%.1f" .AverageScore}}</span>
+                </div>
+                {{end}}
+                {{if .CalendarHealth.UnderCovered}}
+                <p><strong>覆盖不足:</strong> {{range .CalendarHealth.UnderCovered}}<span class="keyword-tag">{{.}}</span>{{end}}</p>
+                {{end}}
+            </div>
+        </div>
+
+        {{if .Opportunities}}
+        <div class="card">
+            <h3>🚀 提升机会</h3>
+            {{range .Opportunities}}
+            <div class="metric">
+                <span>{{.Description}}</span>
+                <span>合计+{{printf "%.1f" .TotalGain}}分</span>
+            </div>
             {{end}}
+        </div>
+        {{end}}
+
+        {{if .DuplicateImages}}
+        <div class="card">
+            <h3>🖼️ 重复使用的图片</h3>
+            {{range .DuplicateImages}}
+            <div class="content-item">
+                <h4>{{len .Contents}}篇内容使用了同一张图片</h4>
+                <p>{{range $i, $c := .Contents}}{{if $i}}、{{end}}{{$c}}{{end}}</p>
+                <p><small>{{range $i, $p := .Images}}{{if $i}}<br>{{end}}{{$p}}{{end}}</small></p>
             </div>
+            {{end}}
         </div>
+        {{end}}
+
+        {{with .ReadabilityBand}}
+        <div class="card">
+            <h3>📖 可读性分布</h3>
+            <p>目标区间 {{printf "%.0f" .Min}}-{{printf "%.0f" .Max}}：区间内 {{.Within}}篇，偏难 {{.Below}}篇，偏易 {{.Above}}篇</p>
+            {{.SVG}}
+        </div>
+        {{end}}
+
+        {{if .Series}}
+        <div class="card">
+            <h3>🔗 系列一致性</h3>
+            {{range .Series}}
+            <div class="content-item">
+                <h4>{{.Name}} ({{.Count}}篇)</h4>
+                <span class="content-score score-{{level .ConsistencyScore}}">
+                    {{printf "%.1f" .ConsistencyScore}}分
+                </span>
+                <p>主流语调: {{.DominantTone}} | 平均篇幅: {{printf "%.0f" .AverageWords}}词</p>
+                {{range .Outliers}}
+                <p><small>⚠️ {{.Title}}: {{range $i, $r := .Reasons}}{{if $i}}；{{end}}{{$r}}{{end}}</small></p>
+                {{end}}
+            </div>
+            {{end}}
+        </div>
+        {{end}}
 
         <div class="grid">
             <div class="card">
                 <h3>🔥 热门关键词</h3>
-                {{range .TopKeywords}}
-                    <span class="keyword-tag">{{.Word}} ({{.Frequency}})</span>
-                {{end}}
+                {{keywordChart .TopKeywords}}
             </div>
 
             <div class="card">
@@ -476,10 +684,133 @@ func (r *Reporter) generateHTMLReport(data ReportData) error {
             </div>
         </div>
     </div>
+    <script id="content-data" type="application/json">{{.ContentTable}}</script>
+    <script>
+    (function () {
+        var data = JSON.parse(document.getElementById('content-data').textContent);
+        var table = document.getElementById('content-table');
+        var search = document.getElementById('content-search');
+        var band = document.getElementById('content-band');
+        var count = document.getElementById('content-count');
+        var sortKey = 'total', sortDesc = true;
+        var expanded = {};
+
+        data.bands.forEach(function (b) {
+            var option = document.createElement('option');
+            option.value = b.level;
+            option.textContent = b.label;
+            band.appendChild(option);
+        });
+
+        function cell(tag, text, className) {
+            var el = document.createElement(tag);
+            el.textContent = text;
+            if (className) el.className = className;
+            return el;
+        }
+
+        function renderHead() {
+            var tr = document.createElement('tr');
+            data.columns.forEach(function (col) {
+                var th = cell('th', col.label);
+                if (col.key === sortKey) th.className = sortDesc ? 'sorted-desc' : 'sorted-asc';
+                th.addEventListener('click', function () {
+                    // 数值列首次点击从高到低，文字列从A到Z
+                    if (sortKey === col.key) {
+                        sortDesc = !sortDesc;
+                    } else {
+                        sortKey = col.key;
+                        sortDesc = !!col.numeric;
+                    }
+                    render();
+                });
+                tr.appendChild(th);
+            });
+            var thead = table.tHead;
+            thead.textContent = '';
+            thead.appendChild(tr);
+        }
+
+        function compare(a, b) {
+            var x = a.row.cells[sortKey], y = b.row.cells[sortKey];
+            var result;
+            if (x === undefined || y === undefined) {
+                // 没有该维度得分的内容排在最后
+                result = (x === undefined) - (y === undefined);
+                return result !== 0 ? result : a.index - b.index;
+            }
+            result = typeof x === 'number' ? x - y : String(x).localeCompare(String(y), 'zh-CN');
+            if (sortDesc) result = -result;
+            return result !== 0 ? result : a.index - b.index;
+        }
+
+        function renderBody() {
+            var keyword = search.value.trim().toLowerCase();
+            var rows = data.rows.map(function (row, index) { return { row: row, index: index }; })
+                .filter(function (item) {
+                    return (!band.value || item.row.level === band.value) &&
+                        (!keyword || item.row.search.toLowerCase().indexOf(keyword) >= 0);
+                })
+                .sort(compare);
+
+            var tbody = table.tBodies[0];
+            tbody.textContent = '';
+            rows.forEach(function (item) {
+                var tr = document.createElement('tr');
+                data.columns.forEach(function (col) {
+                    var value = item.row.cells[col.key];
+                    var td;
+                    if (col.key === 'title') {
+                        td = cell('td', value, 'title-cell');
+                        td.title = '点击查看评分理由';
+                        td.addEventListener('click', function () {
+                            expanded[item.index] = !expanded[item.index];
+                            renderBody();
+                        });
+                    } else if (col.key === 'total') {
+                        td = document.createElement('td');
+                        td.appendChild(cell('span', value.toFixed(1), 'content-score score-' + item.row.level));
+                    } else if (value === undefined) {
+                        td = cell('td', '-');
+                    } else {
+                        td = cell('td', col.numeric && value % 1 !== 0 ? value.toFixed(1) : value);
+                    }
+                    tr.appendChild(td);
+                });
+                tbody.appendChild(tr);
+
+                if (expanded[item.index]) {
+                    var details = document.createElement('tr');
+                    details.className = 'details';
+                    var td = document.createElement('td');
+                    td.colSpan = data.columns.length;
+                    item.row.details.forEach(function (line) { td.appendChild(cell('p', line)); });
+                    details.appendChild(td);
+                    tbody.appendChild(details);
+                }
+            });
+            count.textContent = '显示 ' + rows.length + ' / ' + data.rows.length + ' 篇';
+        }
+
+        function render() {
+            renderHead();
+            renderBody();
+        }
+
+        search.addEventListener('input', renderBody);
+        band.addEventListener('change', renderBody);
+        render();
+    })();
+    </script>
 </body>
 </html>`
 
-	tmpl, err := template.New("report").Parse(tmplContent)
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"sentiment":    r.sentimentIndicator,
+		"level":        r.config.Analysis.LevelThresholds.Level,
+		"radarChart":   dimensionRadar,
+		"keywordChart": keywordBars,
+	}).Parse(tmplContent)
 	if err != nil {
 		return err
 	}
@@ -494,6 +825,42 @@ func (r *Reporter) generateHTMLReport(data ReportData) error {
 	return tmpl.Execute(file, data)
 }
 
+// sentimentIndicator 将情感倾向映射为配置的显示符号，仅用于人类可读报告
+func (r *Reporter) sentimentIndicator(overall string) string {
+	if indicator, ok := r.config.Report.SentimentIndicators[overall]; ok && indicator != "" {
+		return indicator + " " + overall
+	}
+	return overall
+}
+
+// contentColumn CSV 和 Excel 报告中每篇内容的一列，value 返回 float64（分数，显示一位小数）、int 或 string
+type contentColumn struct {
+	header string
+	value  func(result models.AnalysisResult) interface{}
+}
+
+// contentColumns CSV 和 Excel 报告的内容列
+var contentColumns = []contentColumn{
+	{"标题", func(r models.AnalysisResult) interface{} { return r.Title }},
+	{"总分", func(r models.AnalysisResult) interface{} { return r.Score.Total }},
+	{"内容质量", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.ContentQuality }},
+	{"互动性", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.Engagement }},
+	{"视觉效果", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.Visual }},
+	{"标题质量", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.Title }},
+	{"可读性", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.Readability }},
+	{"趋势相关性", func(r models.AnalysisResult) interface{} { return r.Score.Breakdown.TrendRelevance }},
+	{"字数", func(r models.AnalysisResult) interface{} { return r.TextAnalysis.WordCount }},
+	{"句子数", func(r models.AnalysisResult) interface{} { return r.TextAnalysis.SentenceCount }},
+	{"段落数", func(r models.AnalysisResult) interface{} { return r.TextAnalysis.ParagraphCount }},
+	{"关键词数", func(r models.AnalysisResult) interface{} { return len(r.Keywords) }},
+	{"情感倾向", func(r models.AnalysisResult) interface{} { return r.Sentiment.Overall }},
+	{"评论区情感", func(r models.AnalysisResult) interface{} { return communityOverall(r.Community) }},
+	{"评论数", func(r models.AnalysisResult) interface{} { return communityCount(r.Community) }},
+	{"阅读时间", func(r models.AnalysisResult) interface{} { return r.Readability.ReadingTime }},
+	{"建议数量", func(r models.AnalysisResult) interface{} { return len(r.Suggestions) }},
+	{"等级", func(r models.AnalysisResult) interface{} { return r.Score.Level }},
+}
+
 func (r *Reporter) generateCSVReport(data ReportData) error {
 	filename := filepath.Join(r.config.OutputDir, "analysis_report.csv")
 
@@ -504,38 +871,53 @@ func (r *Reporter) generateCSVReport(data ReportData) error {
 	defer file.Close()
 
 	// CSV头部
-	headers := []string{
-		"标题", "总分", "内容质量", "互动性", "视觉效果", "标题质量",
-		"可读性", "趋势相关性", "字数", "句子数", "段落数", "关键词数",
-		"情感倾向", "阅读时间", "建议数量", "等级",
+	headers := make([]string, len(contentColumns))
+	for i, column := range contentColumns {
+		headers[i] = column.header
 	}
 
-	// 写入CSV头部
-	file.WriteString(strings.Join(headers, ",") + "\n")
+	// UTF-8 BOM，否则 Excel 会按本地编码打开导致中文乱码
+	if _, err := file.WriteString("\uFEFF"); err != nil {
+		return err
+	}
+
+	// csv.Writer 负责字段中逗号、引号和换行的转义
+	writer := csv.NewWriter(file)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
 
 	// 写入数据
 	for _, result := range data.Results {
-		row := []string{
-			fmt.Sprintf(`"%s"`, strings.ReplaceAll(result.Title, `"`, `""`)),
-			fmt.Sprintf("%.1f", result.Score.Total),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.ContentQuality),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.Engagement),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.Visual),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.Title),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.Readability),
-			fmt.Sprintf("%.1f", result.Score.Breakdown.TrendRelevance),
-			fmt.Sprintf("%d", result.TextAnalysis.WordCount),
-			fmt.Sprintf("%d", result.TextAnalysis.SentenceCount),
-			fmt.Sprintf("%d", result.TextAnalysis.ParagraphCount),
-			fmt.Sprintf("%d", len(result.Keywords)),
-			result.Sentiment.Overall,
-			fmt.Sprintf("%d", result.Readability.ReadingTime),
-			fmt.Sprintf("%d", len(result.Suggestions)),
-			result.Score.Level,
+		row := make([]string, len(contentColumns))
+		for i, column := range contentColumns {
+			switch v := column.value(result).(type) {
+			case float64:
+				row[i] = fmt.Sprintf("%.1f", v)
+			default:
+				row[i] = fmt.Sprint(v)
+			}
 		}
 
-		file.WriteString(strings.Join(row, ",") + "\n")
+		if err := writer.Write(row); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
+}
+
+func communityOverall(community *models.CommunitySentiment) string {
+	if community == nil {
+		return ""
+	}
+	return community.Overall
+}
+
+func communityCount(community *models.CommunitySentiment) int {
+	if community == nil {
+		return 0
+	}
+	return community.CommentCount
 }
@@ -0,0 +1,204 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// newTestReporter 使用默认配置的报告生成器，报告写入临时目录，modify 可在创建前调整配置
+func newTestReporter(t *testing.T, modify func(cfg *config.Config)) *Reporter {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载默认配置失败: %v", err)
+	}
+	cfg.OutputDir = t.TempDir()
+	cfg.Report.WebhookURL = ""
+	if modify != nil {
+		modify(cfg)
+	}
+	return NewReporter(cfg)
+}
+
+func TestSentimentIndicatorOnlyInHumanReports(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Formats = []string{"json", "html", "csv", "markdown"}
+	})
+	results := []models.AnalysisResult{{
+		ContentID: "post-1",
+		Title:     "周末露营清单",
+		Sentiment: models.SentimentAnalysis{Overall: "positive"},
+		Score:     models.OverallScore{Total: 75, Level: "good"},
+	}}
+	if err := r.GenerateReport(results); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	tests := []struct {
+		file          string
+		wantIndicator bool
+	}{
+		{"analysis_report.html", true},
+		{"analysis_report.md", true},
+		{"analysis_report.json", false},
+		{"analysis_report.csv", false},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(r.config.OutputDir, tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		if !strings.Contains(content, "positive") {
+			t.Errorf("%s 缺少情感倾向", tt.file)
+		}
+		if got := strings.Contains(content, "😊"); got != tt.wantIndicator {
+			t.Errorf("%s 包含情感符号 = %v, want %v", tt.file, got, tt.wantIndicator)
+		}
+	}
+}
+
+func TestExtractTopKeywordsBlacklist(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.KeywordBlacklist = []string{" 小米官方 ", "2024"}
+	})
+	results := []models.AnalysisResult{
+		{Keywords: []models.Keyword{{Word: "小米官方", Frequency: 9}, {Word: "续航", Frequency: 3}, {Word: "2024", Frequency: 5}}},
+		{Keywords: []models.Keyword{{Word: "充电", Frequency: 2}, {Word: "小米官方", Frequency: 4}}},
+	}
+
+	var words []string
+	for _, kw := range r.extractTopKeywords(results) {
+		words = append(words, kw.Word)
+	}
+	if got := strings.Join(words, ","); got != "续航,充电" {
+		t.Errorf("热门关键词 = %q, want 续航,充电（黑名单中的词不应出现）", got)
+	}
+}
+
+func TestExtractTopKeywordsTieOrder(t *testing.T) {
+	r := newTestReporter(t, nil)
+	keywords := []models.Keyword{
+		{Word: "睡袋", Frequency: 3, Relevance: 0.1},
+		{Word: "帐篷", Frequency: 3, Relevance: 0.2},
+		{Word: "炊具", Frequency: 3, Relevance: 0.1},
+		{Word: "营地", Frequency: 5, Relevance: 0.05},
+	}
+
+	want := "营地,帐篷,炊具,睡袋"
+	for i := range keywords {
+		// 轮换输入顺序，结果应不变
+		rotated := append(append([]models.Keyword{}, keywords[i:]...), keywords[:i]...)
+		var words []string
+		for _, kw := range r.extractTopKeywords([]models.AnalysisResult{{Keywords: rotated}}) {
+			words = append(words, kw.Word)
+		}
+		if got := strings.Join(words, ","); got != want {
+			t.Errorf("输入轮换%d: 热门关键词 = %q, want %q（频次相同按相关度、再按词语排序）", i, got, want)
+		}
+	}
+}
+
+func TestCSVReportRoundTrip(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Formats = []string{"csv"}
+	})
+	titles := []string{
+		"露营, 徒步和\"轻量化\"装备\n第二行",
+		"普通标题",
+	}
+	var results []models.AnalysisResult
+	for i, title := range titles {
+		results = append(results, models.AnalysisResult{
+			ContentID: fmt.Sprintf("post-%d", i),
+			Title:     title,
+			Score:     models.OverallScore{Total: 72.46, Level: "good"},
+		})
+	}
+	if err := r.GenerateReport(results); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.config.OutputDir, "analysis_report.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("\uFEFF")) {
+		t.Error("CSV 缺少 UTF-8 BOM")
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.Reader 解析失败: %v", err)
+	}
+	if len(records) != len(titles)+1 {
+		t.Fatalf("记录数 = %d, want 表头加 %d 行", len(records), len(titles))
+	}
+	if len(records[0]) != len(contentColumns) || records[0][0] != "标题" {
+		t.Errorf("表头 = %v", records[0])
+	}
+	for i, title := range titles {
+		row := records[i+1]
+		if len(row) != len(contentColumns) {
+			t.Errorf("第%d行有 %d 列, want %d", i+1, len(row), len(contentColumns))
+			continue
+		}
+		if row[0] != title {
+			t.Errorf("第%d行标题 = %q, want %q", i+1, row[0], title)
+		}
+		if row[1] != "72.5" {
+			t.Errorf("第%d行总分 = %q, want 72.5", i+1, row[1])
+		}
+	}
+}
+
+// 标题中的 </script> 不能提前结束嵌入内容表格数据的脚本块
+func TestHTMLContentDataEscapesScript(t *testing.T) {
+	r := newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.Formats = []string{"html"}
+	})
+	title := "</script><script>alert(1)</script>"
+	results := []models.AnalysisResult{{
+		ContentID: "post-1",
+		Title:     title,
+		Score:     models.OverallScore{Total: 75, Level: "good"},
+	}}
+	if err := r.GenerateReport(results); err != nil {
+		t.Fatalf("生成报告失败: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.config.OutputDir, "analysis_report.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	if strings.Contains(page, title) {
+		t.Fatal("HTML 中出现未转义的标题")
+	}
+
+	const open = `<script id="content-data" type="application/json">`
+	_, block, found := strings.Cut(page, open)
+	if !found {
+		t.Fatal("HTML 中没有 content-data 脚本块")
+	}
+	block, _, _ = strings.Cut(block, "</script>")
+
+	// 脚本块在第一个 </script> 处结束，截取的内容应是完整的JSON，且标题原样保留
+	var table ContentTable
+	if err := json.Unmarshal([]byte(block), &table); err != nil {
+		t.Fatalf("content-data 不是完整的JSON: %v\n%s", err, block)
+	}
+	if len(table.Rows) != 1 || table.Rows[0].Cells["title"] != title {
+		t.Errorf("content-data 中的标题 = %+v, want %q", table.Rows, title)
+	}
+}
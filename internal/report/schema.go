@@ -0,0 +1,200 @@
+// internal/report/schema.go
+package report
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// jsonSchemaDraft 生成的 JSON Schema 版本
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schemaConstraints 反射无法得知的取值约束（枚举和数值范围），键为 "结构体名.字段名"。
+// 只列出取值固定的字段，注释中带"等"的开放取值不限制；跳过对应分析阶段时为零值的字段，枚举中包含空字符串
+var schemaConstraints = map[string]map[string]interface{}{
+	"OverallScore.Total": {"minimum": 0, "maximum": 100},
+	"OverallScore.Level": {"enum": []string{"excellent", "good", "average", "poor"}},
+
+	"ScoreBreakdown.ContentQuality": {"minimum": 0, "maximum": 100},
+	"ScoreBreakdown.Engagement":     {"minimum": 0, "maximum": 100},
+	"ScoreBreakdown.Visual":         {"minimum": 0, "maximum": 100},
+	"ScoreBreakdown.Title":          {"minimum": 0, "maximum": 100},
+	"ScoreBreakdown.Readability":    {"minimum": 0, "maximum": 100},
+	"ScoreBreakdown.TrendRelevance": {"minimum": 0, "maximum": 100},
+
+	"TextAnalysis.Language":    {"enum": []string{"zh", "en", "mixed", "und"}},
+	"LanguageMetrics.Language": {"enum": []string{"zh", "en", "other"}},
+	"LanguageMetrics.Share":    {"minimum": 0, "maximum": 1},
+	"ReadabilityMetrics.Grade": {"enum": []string{"容易", "中等", "困难", ""}},
+
+	"Keyword.Trend": {"enum": []string{"rising", "stable", "declining"}},
+
+	"SentimentAnalysis.Overall":    {"enum": []string{"positive", "negative", "neutral", ""}},
+	"SentimentAnalysis.Score":      {"minimum": -1, "maximum": 1},
+	"SentimentAnalysis.Confidence": {"minimum": 0, "maximum": 1},
+	"CommunitySentiment.Overall":   {"enum": []string{"positive", "negative", "neutral"}},
+	"CommunitySentiment.Score":     {"minimum": -1, "maximum": 1},
+
+	"Suggestion.Priority":   {"enum": []string{"high", "medium", "low"}},
+	"Suggestion.Confidence": {"minimum": 0, "maximum": 1},
+
+	"CTAAnalysis.Strength":  {"minimum": 0, "maximum": 1},
+	"CTAPlacement.Position": {"enum": []string{"early", "middle", "end"}},
+	"CTAPlacement.Strength": {"enum": []string{"strong", "weak"}},
+
+	"KeywordDensity.Source": {"enum": []string{"content", "extracted"}},
+	"KeywordDensity.Status": {"enum": []string{"below", "within", "above"}},
+
+	"TitleAnalysis.Unit":          {"enum": []string{"chars", "width"}},
+	"TitleAnalysis.HashtagStatus": {"enum": []string{"too_few", "ok", "too_many"}},
+
+	"WritingStyle.PersonPerspective": {"enum": []string{"first", "second", "third"}},
+	"WritingStyle.Formality":         {"minimum": 0, "maximum": 1},
+	"WritingStyle.Complexity":        {"minimum": 0, "maximum": 1},
+	"WritingStyle.Authenticity":      {"minimum": 0, "maximum": 1},
+
+	"WritingIssue.Source": {"enum": []string{"languagetool", "builtin"}},
+	"Entity.Type":         {"enum": []string{"person", "brand", "product", "location", "unknown"}},
+	"Entity.Source":       {"enum": []string{"dictionary", "capitalized", "ai"}},
+	"Link.Source":         {"enum": []string{"markdown", "html"}},
+	"Link.Issue":          {"enum": []string{"empty", "url", "generic"}},
+
+	"ImageText.Legibility":  {"minimum": 0, "maximum": 100},
+	"ImageText.Readability": {"minimum": 0, "maximum": 100},
+}
+
+// ResultSchema 单篇分析结果（models.AnalysisResult，即 analysis_report.json 中 results 的元素和 --file 的输出）的 JSON Schema
+func ResultSchema() map[string]interface{} {
+	return jsonSchema(reflect.TypeOf(models.AnalysisResult{}))
+}
+
+// ReportSchema 整个 analysis_report.json 的 JSON Schema
+func ReportSchema() map[string]interface{} {
+	return jsonSchema(reflect.TypeOf(ReportData{}))
+}
+
+// jsonSchema 按 encoding/json 的规则用反射生成 draft-07 的 JSON Schema：每个结构体放在 definitions 中，
+// 以 $ref 引用；没有 omitempty 的字段为必填，nil 时编码为 null 的指针、切片和 map 允许 null
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	g := schemaGenerator{definitions: make(map[string]interface{})}
+	schema := g.schema(t)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = t.Name()
+	schema["definitions"] = g.definitions
+	return schema
+}
+
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+// schema 类型 t 的非 null 值的 schema，结构体返回对 definitions 的引用
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.value(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.value(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := g.definitions[name]; !ok {
+			g.definitions[name] = nil // 先占位，避免自引用的类型无限递归
+			g.definitions[name] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}
+	default:
+		// interface{} 等无法确定的类型不限制
+		return map[string]interface{}{}
+	}
+}
+
+// value 类型 t 的值的 schema，nil 时编码为 null 的类型允许 null
+func (g *schemaGenerator) value(t reflect.Type) map[string]interface{} {
+	schema := g.schema(t)
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Map:
+		return nullable(schema)
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return nullable(schema)
+		}
+	}
+	return schema
+}
+
+// object 结构体的 schema，字段名和是否必填按 json 标签确定，匿名嵌入的结构体字段提升到外层
+func (g *schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := []string{}
+	g.addFields(t, properties, &required)
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+
+		// 带 omitempty 的 nil 值直接省略，出现时不会是 null
+		schema := g.value(field.Type)
+		if omitEmpty {
+			schema = g.schema(field.Type)
+		}
+		for key, value := range schemaConstraints[t.Name()+"."+field.Name] {
+			schema[key] = value
+		}
+		properties[name] = schema
+
+		// encoding/json 的 omitempty 不会省略结构体
+		if !omitEmpty || field.Type.Kind() == reflect.Struct {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullable 允许 schema 为 null：有 type 的加入 "null"，引用改为 anyOf
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		return schema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
@@ -0,0 +1,135 @@
+// internal/report/series.go
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// SeriesConsistency 系列内容在语调、篇幅、风格上的一致性
+type SeriesConsistency struct {
+	Name             string          `json:"name"`
+	Count            int             `json:"count"`
+	ConsistencyScore float64         `json:"consistency_score"` // 0-100
+	DominantTone     string          `json:"dominant_tone"`
+	AverageWords     float64         `json:"average_words"`
+	LengthVariation  float64         `json:"length_variation"` // 篇幅变异系数
+	ToneAgreement    float64         `json:"tone_agreement"`   // 与主流语调一致的比例
+	Outliers         []SeriesOutlier `json:"outliers,omitempty"`
+}
+
+// SeriesOutlier 破坏系列一致性的内容
+type SeriesOutlier struct {
+	Title   string   `json:"title"`
+	Reasons []string `json:"reasons"`
+}
+
+const (
+	// 篇幅偏离系列平均值超过该比例视为异常
+	seriesLengthTolerance = 0.5
+	// 正式程度偏离系列平均值超过该差值视为异常
+	seriesFormalityTolerance = 0.3
+)
+
+// generateSeriesConsistency 按 Series 分组计算一致性，只统计至少两篇的系列
+func (r *Reporter) generateSeriesConsistency(results []models.AnalysisResult) []SeriesConsistency {
+	groups := make(map[string][]models.AnalysisResult)
+	for _, result := range results {
+		if result.Series != "" {
+			groups[result.Series] = append(groups[result.Series], result)
+		}
+	}
+
+	var series []SeriesConsistency
+	for name, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		series = append(series, analyzeSeries(name, members))
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Name < series[j].Name
+	})
+
+	return series
+}
+
+func analyzeSeries(name string, members []models.AnalysisResult) SeriesConsistency {
+	count := float64(len(members))
+
+	// 篇幅和正式程度的平均值
+	var totalWords, totalFormality float64
+	toneCounts := make(map[string]int)
+	for _, m := range members {
+		totalWords += float64(m.TextAnalysis.WordCount)
+		totalFormality += m.TextAnalysis.WritingStyle.Formality
+		toneCounts[m.TextAnalysis.WritingStyle.Tone]++
+	}
+	meanWords := totalWords / count
+	meanFormality := totalFormality / count
+
+	dominantTone := ""
+	for tone, n := range toneCounts {
+		if n > toneCounts[dominantTone] || (n == toneCounts[dominantTone] && tone < dominantTone) {
+			dominantTone = tone
+		}
+	}
+
+	// 方差
+	var wordVariance, formalityVariance float64
+	for _, m := range members {
+		wordVariance += math.Pow(float64(m.TextAnalysis.WordCount)-meanWords, 2)
+		formalityVariance += math.Pow(m.TextAnalysis.WritingStyle.Formality-meanFormality, 2)
+	}
+	wordStd := math.Sqrt(wordVariance / count)
+	formalityStd := math.Sqrt(formalityVariance / count)
+
+	lengthVariation := 0.0
+	if meanWords > 0 {
+		lengthVariation = wordStd / meanWords
+	}
+	toneAgreement := float64(toneCounts[dominantTone]) / count
+
+	score := 100 * (0.4*(1-math.Min(lengthVariation, 1)) +
+		0.4*toneAgreement +
+		0.2*(1-math.Min(formalityStd*2, 1)))
+
+	consistency := SeriesConsistency{
+		Name:             name,
+		Count:            len(members),
+		ConsistencyScore: score,
+		DominantTone:     dominantTone,
+		AverageWords:     meanWords,
+		LengthVariation:  lengthVariation,
+		ToneAgreement:    toneAgreement,
+	}
+
+	// 找出异常内容
+	for _, m := range members {
+		var reasons []string
+
+		words := float64(m.TextAnalysis.WordCount)
+		if meanWords > 0 && math.Abs(words-meanWords)/meanWords > seriesLengthTolerance {
+			reasons = append(reasons, fmt.Sprintf("篇幅%d词，系列平均%.0f词", m.TextAnalysis.WordCount, meanWords))
+		}
+		if tone := m.TextAnalysis.WritingStyle.Tone; tone != dominantTone {
+			reasons = append(reasons, fmt.Sprintf("语调为%s，系列主流为%s", tone, dominantTone))
+		}
+		if math.Abs(m.TextAnalysis.WritingStyle.Formality-meanFormality) > seriesFormalityTolerance {
+			reasons = append(reasons, "正式程度与系列其他内容差异较大")
+		}
+
+		if len(reasons) > 0 {
+			consistency.Outliers = append(consistency.Outliers, SeriesOutlier{
+				Title:   m.Title,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	return consistency
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func seriesMember(title string, words int, tone string, formality float64) models.AnalysisResult {
+	return models.AnalysisResult{
+		Title:  title,
+		Series: "露营指南",
+		TextAnalysis: models.TextAnalysis{
+			WordCount:    words,
+			WritingStyle: models.WritingStyle{Tone: tone, Formality: formality},
+		},
+	}
+}
+
+func TestSeriesConsistencyConsistent(t *testing.T) {
+	r := newTestReporter(t, nil)
+	series := r.generateSeriesConsistency([]models.AnalysisResult{
+		seriesMember("第一篇", 500, "casual", 0.4),
+		seriesMember("第二篇", 520, "casual", 0.45),
+		seriesMember("第三篇", 480, "casual", 0.4),
+		{Title: "不属于系列", TextAnalysis: models.TextAnalysis{WordCount: 50}},
+	})
+
+	if len(series) != 1 {
+		t.Fatalf("系列数 = %d, want 1", len(series))
+	}
+	s := series[0]
+	if s.Count != 3 || s.DominantTone != "casual" || s.ToneAgreement != 1 {
+		t.Errorf("系列统计有误: %+v", s)
+	}
+	if s.ConsistencyScore < 90 {
+		t.Errorf("一致的系列得分 = %.1f, want >= 90", s.ConsistencyScore)
+	}
+	if len(s.Outliers) != 0 {
+		t.Errorf("一致的系列不应有异常内容: %+v", s.Outliers)
+	}
+}
+
+func TestSeriesConsistencyOutlier(t *testing.T) {
+	r := newTestReporter(t, nil)
+	series := r.generateSeriesConsistency([]models.AnalysisResult{
+		seriesMember("第一篇", 500, "casual", 0.4),
+		seriesMember("第二篇", 520, "casual", 0.4),
+		seriesMember("第三篇", 480, "casual", 0.4),
+		seriesMember("跑题的一篇", 1800, "formal", 0.95),
+	})
+
+	if len(series) != 1 {
+		t.Fatalf("系列数 = %d, want 1", len(series))
+	}
+	s := series[0]
+	if s.ConsistencyScore >= 80 {
+		t.Errorf("含异常内容的系列得分 = %.1f, want < 80", s.ConsistencyScore)
+	}
+	if len(s.Outliers) != 1 || s.Outliers[0].Title != "跑题的一篇" {
+		t.Fatalf("Outliers = %+v, want 只有 跑题的一篇", s.Outliers)
+	}
+	// 篇幅、语调和正式程度都偏离
+	if got := len(s.Outliers[0].Reasons); got != 3 {
+		t.Errorf("异常原因 = %v, want 3条", s.Outliers[0].Reasons)
+	}
+}
+
+func TestSeriesConsistencySkipsSingleItemSeries(t *testing.T) {
+	r := newTestReporter(t, nil)
+	if series := r.generateSeriesConsistency([]models.AnalysisResult{seriesMember("唯一一篇", 500, "casual", 0.4)}); len(series) != 0 {
+		t.Errorf("只有一篇的系列不应统计: %+v", series)
+	}
+}
@@ -0,0 +1,112 @@
+// internal/report/webhook.go
+package report
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+)
+
+const (
+	webhookTimeout   = 10 * time.Second
+	webhookTopIssues = 5
+)
+
+// 第一次重试前的等待，之后每次翻倍；测试中缩短
+var webhookRetryDelay = time.Second
+
+// WebhookPayload 报告生成成功后 POST 到 report.webhook_url 的摘要
+type WebhookPayload struct {
+	Event        string    `json:"event"` // 固定为 analysis.completed
+	Timestamp    time.Time `json:"timestamp"`
+	TotalContent int       `json:"total_content"`
+	OverallScore float64   `json:"overall_score"`
+	TopIssues    []string  `json:"top_issues"` // 最常见的问题，按涉及篇数从多到少
+	OutputDir    string    `json:"output_dir"`
+}
+
+// SignWebhook 计算请求体的 HMAC-SHA256 签名，作为 X-Signature 头的值，格式为 "sha256=十六进制摘要"
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyWebhook 把报告摘要发送到 report.webhook_url。失败只记录日志，不影响本次运行
+func (r *Reporter) notifyWebhook(data ReportData) {
+	url := r.config.Report.WebhookURL
+	if url == "" {
+		return
+	}
+
+	topIssues := data.Summary.CommonIssues
+	if len(topIssues) > webhookTopIssues {
+		topIssues = topIssues[:webhookTopIssues]
+	}
+	if topIssues == nil {
+		topIssues = []string{}
+	}
+	body, err := json.Marshal(WebhookPayload{
+		Event:        "analysis.completed",
+		Timestamp:    data.GeneratedAt,
+		TotalContent: data.TotalContent,
+		OverallScore: math.Round(data.OverallScore*10) / 10,
+		TopIssues:    topIssues,
+		OutputDir:    r.config.OutputDir,
+	})
+	if err != nil {
+		log.Printf("序列化 webhook 内容失败: %v", err)
+		return
+	}
+
+	if err := r.postWebhook(url, body); err != nil {
+		log.Printf("发送 webhook 通知失败: %v", err)
+	}
+}
+
+// postWebhook 发送请求，遇到 5xx 或网络错误时按指数退避重试 report.webhook_retries 次
+func (r *Reporter) postWebhook(url string, body []byte) error {
+	client := &http.Client{Timeout: webhookTimeout}
+	retries := r.config.Report.WebhookRetries
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(webhookRetryDelay << uint(attempt-1))
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret := r.config.Report.WebhookSecret; secret != "" {
+			req.Header.Set("X-Signature", SignWebhook(secret, body))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("服务器返回 %s", resp.Status)
+		default:
+			// 4xx 重试也不会成功
+			return fmt.Errorf("服务器返回 %s", resp.Status)
+		}
+	}
+	return fmt.Errorf("重试 %d 次后仍失败: %w", retries, lastErr)
+}
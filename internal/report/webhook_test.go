@@ -0,0 +1,135 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// webhookRecorder 记录收到的请求，前 failures 次返回 status
+type webhookRecorder struct {
+	mu         sync.Mutex
+	failures   int
+	status     int
+	bodies     [][]byte
+	signatures []string
+}
+
+func (rec *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.bodies = append(rec.bodies, body)
+	rec.signatures = append(rec.signatures, r.Header.Get("X-Signature"))
+	if len(rec.bodies) <= rec.failures {
+		w.WriteHeader(rec.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func shortenWebhookRetryDelay(t *testing.T) {
+	t.Helper()
+	delay := webhookRetryDelay
+	webhookRetryDelay = time.Millisecond
+	t.Cleanup(func() { webhookRetryDelay = delay })
+}
+
+func webhookReporter(t *testing.T, url string) *Reporter {
+	return newTestReporter(t, func(cfg *config.Config) {
+		cfg.Report.WebhookURL = url
+		cfg.Report.WebhookSecret = "s3cret"
+		cfg.Report.WebhookRetries = 2
+	})
+}
+
+func TestWebhookPayloadAndSignature(t *testing.T) {
+	rec := &webhookRecorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+	r := webhookReporter(t, server.URL)
+
+	generatedAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	r.notifyWebhook(ReportData{
+		GeneratedAt:  generatedAt,
+		TotalContent: 12,
+		OverallScore: 73.46,
+		Summary:      ReportSummary{CommonIssues: []string{"a", "b", "c", "d", "e", "f"}},
+	})
+
+	if len(rec.bodies) != 1 {
+		t.Fatalf("收到 %d 次请求, want 1", len(rec.bodies))
+	}
+	var payload WebhookPayload
+	if err := json.Unmarshal(rec.bodies[0], &payload); err != nil {
+		t.Fatalf("请求体不是 JSON: %v", err)
+	}
+	if payload.Event != "analysis.completed" || !payload.Timestamp.Equal(generatedAt) || payload.TotalContent != 12 {
+		t.Errorf("payload = %+v, want analysis.completed/%v/12", payload, generatedAt)
+	}
+	if payload.OverallScore != 73.5 {
+		t.Errorf("OverallScore = %v, want 保留一位小数 73.5", payload.OverallScore)
+	}
+	if len(payload.TopIssues) != webhookTopIssues {
+		t.Errorf("TopIssues = %v, want 前 %d 个", payload.TopIssues, webhookTopIssues)
+	}
+	if payload.OutputDir != r.config.OutputDir {
+		t.Errorf("OutputDir = %q, want %q", payload.OutputDir, r.config.OutputDir)
+	}
+	if want := SignWebhook("s3cret", rec.bodies[0]); rec.signatures[0] != want {
+		t.Errorf("X-Signature = %q, want %q", rec.signatures[0], want)
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	shortenWebhookRetryDelay(t)
+
+	tests := []struct {
+		name         string
+		failures     int
+		status       int
+		wantRequests int
+		wantErr      bool
+	}{
+		{"5xx 后成功", 2, http.StatusServiceUnavailable, 3, false},
+		{"5xx 超过重试次数", 5, http.StatusBadGateway, 3, true},
+		{"4xx 不重试", 1, http.StatusUnauthorized, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &webhookRecorder{failures: tt.failures, status: tt.status}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+			r := webhookReporter(t, server.URL)
+
+			err := r.postWebhook(server.URL, []byte(`{"event":"analysis.completed"}`))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("postWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rec.bodies) != tt.wantRequests {
+				t.Errorf("收到 %d 次请求, want %d", len(rec.bodies), tt.wantRequests)
+			}
+			// 每次重试都带同样的签名
+			for i, signature := range rec.signatures {
+				if signature != SignWebhook("s3cret", rec.bodies[i]) {
+					t.Errorf("第%d次请求 X-Signature = %q, want 正确签名", i+1, signature)
+				}
+			}
+		})
+	}
+}
+
+func TestSignWebhookKnownValue(t *testing.T) {
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+	want := "sha256=88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342"
+	if got := SignWebhook("key", []byte(`{"a":1}`)); got != want {
+		t.Errorf("SignWebhook() = %q, want %q", got, want)
+	}
+}
@@ -4,10 +4,13 @@ package services
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,15 +19,39 @@ import (
 )
 
 type AIService interface {
+	// AnalyzeSentiment 分析文本情感，未配置AI服务或AI失败时返回本地分析的结果，错误为 nil
 	AnalyzeSentiment(ctx context.Context, text string) (models.SentimentAnalysis, error)
+	// AnalyzeSentimentWithSource 与 AnalyzeSentiment 相同，另外返回结果来源：
+	// models.ProvenanceAI、未配置AI服务时的 models.ProvenanceLocal、AI失败降级时的 models.ProvenanceFallback
+	AnalyzeSentimentWithSource(ctx context.Context, text string) (models.SentimentAnalysis, string, error)
 	GenerateAdvice(ctx context.Context, analysis models.AnalysisResult) (string, error)
+	// ExtractTopics 提取话题，未配置AI服务或AI失败时返回本地提取的结果，错误为 nil
 	ExtractTopics(ctx context.Context, text string) ([]string, error)
+	// ExtractTopicsWithSource 与 ExtractTopics 相同，另外返回结果来源，取值同 AnalyzeSentimentWithSource
+	ExtractTopicsWithSource(ctx context.Context, text string) ([]string, string, error)
 	ImproveContent(ctx context.Context, content string, suggestions []models.Suggestion) (string, error)
+	// ImproveContentDiff 与 ImproveContent 相同，另外返回改写结果相对原文的插入和删除，未配置AI服务时返回 ErrAINotConfigured
+	ImproveContentDiff(ctx context.Context, content string, suggestions []models.Suggestion) (models.ContentDiff, error)
+	// ExtractEntities 识别正文中的人物、品牌、产品和地点，未配置AI服务时返回 ErrAINotConfigured
+	ExtractEntities(ctx context.Context, text string) ([]models.Entity, error)
+	// SuggestHashtags 参考候选词为文本推荐最多 count 个话题标签（不带 #），未配置AI服务时返回 ErrAINotConfigured
+	SuggestHashtags(ctx context.Context, text string, candidates []string, count int) ([]string, error)
+	// DescribeImage 用视觉模型为图片生成一句替代文本，未配置AI服务时返回 ErrAINotConfigured
+	DescribeImage(ctx context.Context, imagePath string) (string, error)
+	// Ping 向配置的提供方发送一次最小请求，用于健康检查
+	Ping(ctx context.Context) error
+	// UsageStats 服务创建以来AI请求累计的token用量和估算费用，未调用AI时为零
+	UsageStats() models.AIUsage
 }
 
 type aiService struct {
-	config     *config.Config
-	httpClient *http.Client
+	config      *config.Config
+	httpClient  *http.Client
+	rateLimit   *rateLimiter
+	concurrency concurrencyLimiter
+	// 未开启 ai.cache 时为 nil
+	cache *fileCache
+	usage usageTracker
 }
 
 type OpenAIRequest struct {
@@ -39,6 +66,29 @@ type Message struct {
 	Content string `json:"content"`
 }
 
+// OpenAIVisionRequest 带图片的 chat/completions 请求体，消息内容为文本和图片片段的数组
+type OpenAIVisionRequest struct {
+	Model       string                `json:"model"`
+	Messages    []OpenAIVisionMessage `json:"messages"`
+	Temperature float64               `json:"temperature,omitempty"`
+	MaxTokens   int                   `json:"max_tokens,omitempty"`
+}
+
+type OpenAIVisionMessage struct {
+	Role    string              `json:"role"`
+	Content []OpenAIContentPart `json:"content"`
+}
+
+type OpenAIContentPart struct {
+	Type     string          `json:"type"` // text, image_url
+	Text     string          `json:"text,omitempty"`
+	ImageURL *OpenAIImageURL `json:"image_url,omitempty"`
+}
+
+type OpenAIImageURL struct {
+	URL string `json:"url"` // 图片链接或 data URI
+}
+
 type OpenAIResponse struct {
 	Choices []Choice `json:"choices"`
 	Usage   Usage    `json:"usage"`
@@ -55,19 +105,96 @@ type Usage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
+// OllamaRequest 本地 Ollama /api/generate 接口的请求体
+type OllamaRequest struct {
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Options *OllamaOptions `json:"options,omitempty"`
+	Images  []string       `json:"images,omitempty"` // base64 编码的图片，需要多模态模型，如 llava
+}
+
+type OllamaOptions struct {
+	Temperature float64 `json:"temperature,omitempty"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type OllamaResponse struct {
+	Model           string `json:"model"`
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// GeminiRequest Google generateContent 接口的请求体
+type GeminiRequest struct {
+	Contents         []GeminiContent         `json:"contents"`
+	GenerationConfig *GeminiGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+type GeminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []GeminiPart `json:"parts"`
+}
+
+type GeminiPart struct {
+	Text       string            `json:"text,omitempty"`
+	InlineData *GeminiInlineData `json:"inlineData,omitempty"`
+}
+
+// GeminiInlineData 随请求发送的图片
+type GeminiInlineData struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"` // base64
+}
+
+type GeminiGenerationConfig struct {
+	Temperature     float64 `json:"temperature,omitempty"`
+	MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
+}
+
+type GeminiResponse struct {
+	Candidates    []GeminiCandidate `json:"candidates"`
+	UsageMetadata GeminiUsage       `json:"usageMetadata"`
+}
+
+type GeminiUsage struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+}
+
+type GeminiCandidate struct {
+	Content      GeminiContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
+}
+
+// aiConfigured 是否可以调用AI服务：本地 Ollama 不需要API密钥，其余提供方需要
+func aiConfigured(cfg config.AIConfig) bool {
+	return cfg.Provider == "ollama" || cfg.APIKey != ""
+}
+
 func NewAIService(cfg *config.Config) AIService {
 	return &aiService{
 		config: cfg,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		rateLimit:   newRateLimiter(cfg.AI.RequestsPerMinute),
+		concurrency: newConcurrencyLimiter(cfg.AI.MaxConcurrency),
+		cache:       newAICache(cfg.AI.Cache),
 	}
 }
 
 func (s *aiService) AnalyzeSentiment(ctx context.Context, text string) (models.SentimentAnalysis, error) {
+	sentiment, _, err := s.AnalyzeSentimentWithSource(ctx, text)
+	return sentiment, err
+}
+
+func (s *aiService) AnalyzeSentimentWithSource(ctx context.Context, text string) (models.SentimentAnalysis, string, error) {
 	// 如果没有配置API密钥，使用简化版本
-	if s.config.AI.APIKey == "" {
-		return s.simpleSentimentAnalysis(text), nil
+	if !aiConfigured(s.config.AI) {
+		return s.simpleSentimentAnalysis(text), models.ProvenanceLocal, nil
 	}
 
 	prompt := fmt.Sprintf(`请分析以下文本的情感倾向，返回JSON格式：
@@ -90,20 +217,22 @@ func (s *aiService) AnalyzeSentiment(ctx context.Context, text string) (models.S
 	response, err := s.callAI(ctx, prompt)
 	if err != nil {
 		// 如果AI调用失败，降级到简单版本
-		return s.simpleSentimentAnalysis(text), nil
+		log.Printf("AI情感分析失败，降级为关键词分析: %v", err)
+		return s.simpleSentimentAnalysis(text), models.ProvenanceFallback, nil
 	}
 
 	var sentiment models.SentimentAnalysis
 	if err := json.Unmarshal([]byte(response), &sentiment); err != nil {
 		// 解析失败，使用简单版本
-		return s.simpleSentimentAnalysis(text), nil
+		log.Printf("解析AI情感分析结果失败，降级为关键词分析: %v", err)
+		return s.simpleSentimentAnalysis(text), models.ProvenanceFallback, nil
 	}
 
-	return sentiment, nil
+	return sentiment, models.ProvenanceAI, nil
 }
 
 func (s *aiService) GenerateAdvice(ctx context.Context, analysis models.AnalysisResult) (string, error) {
-	if s.config.AI.APIKey == "" {
+	if !aiConfigured(s.config.AI) {
 		return s.simpleAdviceGeneration(analysis), nil
 	}
 
@@ -149,6 +278,7 @@ func (s *aiService) GenerateAdvice(ctx context.Context, analysis models.Analysis
 
 	response, err := s.callAI(ctx, prompt)
 	if err != nil {
+		log.Printf("AI生成建议失败，降级为规则建议: %v", err)
 		return s.simpleAdviceGeneration(analysis), nil
 	}
 
@@ -156,8 +286,13 @@ func (s *aiService) GenerateAdvice(ctx context.Context, analysis models.Analysis
 }
 
 func (s *aiService) ExtractTopics(ctx context.Context, text string) ([]string, error) {
-	if s.config.AI.APIKey == "" {
-		return s.simpleTopicExtraction(text), nil
+	topics, _, err := s.ExtractTopicsWithSource(ctx, text)
+	return topics, err
+}
+
+func (s *aiService) ExtractTopicsWithSource(ctx context.Context, text string) ([]string, string, error) {
+	if !aiConfigured(s.config.AI) {
+		return s.simpleTopicExtraction(text), models.ProvenanceLocal, nil
 	}
 
 	prompt := fmt.Sprintf(`从以下文本中提取主要话题标签，返回JSON数组格式：
@@ -173,20 +308,50 @@ func (s *aiService) ExtractTopics(ctx context.Context, text string) ([]string, e
 
 	response, err := s.callAI(ctx, prompt)
 	if err != nil {
-		return s.simpleTopicExtraction(text), nil
+		log.Printf("AI话题提取失败，降级为关键词提取: %v", err)
+		return s.simpleTopicExtraction(text), models.ProvenanceFallback, nil
 	}
 
 	var topics []string
 	if err := json.Unmarshal([]byte(response), &topics); err != nil {
-		return s.simpleTopicExtraction(text), nil
+		log.Printf("解析AI话题提取结果失败，降级为关键词提取: %v", err)
+		return s.simpleTopicExtraction(text), models.ProvenanceFallback, nil
 	}
 
-	return topics, nil
+	return topics, models.ProvenanceAI, nil
+}
+
+func (s *aiService) SuggestHashtags(ctx context.Context, text string, candidates []string, count int) ([]string, error) {
+	if !aiConfigured(s.config.AI) {
+		return nil, ErrAINotConfigured
+	}
+
+	prompt := fmt.Sprintf(`为以下内容推荐话题标签，返回JSON数组格式：
+["标签1", "标签2"]
+
+要求：
+1. 最多返回%d个标签，按相关度从高到低排列
+2. 标签不带 # 号，不含空格和标点，优先使用该领域常用的热门标签
+3. 可以参考候选词，也可以改写或补充：%s
+
+文本内容：
+%s`, count, strings.Join(candidates, "、"), text)
+
+	response, err := s.callAI(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashtags []string
+	if err := json.Unmarshal([]byte(response), &hashtags); err != nil {
+		return nil, fmt.Errorf("解析AI话题标签结果失败: %w", err)
+	}
+	return hashtags, nil
 }
 
 func (s *aiService) ImproveContent(ctx context.Context, content string, suggestions []models.Suggestion) (string, error) {
-	if s.config.AI.APIKey == "" {
-		return content, fmt.Errorf("AI service not configured")
+	if !aiConfigured(s.config.AI) {
+		return content, ErrAINotConfigured
 	}
 
 	suggestionText := ""
@@ -207,25 +372,210 @@ func (s *aiService) ImproveContent(ctx context.Context, content string, suggesti
 	return s.callAI(ctx, prompt)
 }
 
+func (s *aiService) ImproveContentDiff(ctx context.Context, content string, suggestions []models.Suggestion) (models.ContentDiff, error) {
+	improved, err := s.ImproveContent(ctx, content, suggestions)
+	if err != nil {
+		return models.ContentDiff{Improved: content}, err
+	}
+	return models.ContentDiff{Improved: improved, Hunks: DiffContent(content, improved)}, nil
+}
+
+func (s *aiService) ExtractEntities(ctx context.Context, text string) ([]models.Entity, error) {
+	if !aiConfigured(s.config.AI) {
+		return nil, ErrAINotConfigured
+	}
+
+	prompt := fmt.Sprintf(`识别以下文本中提到的人物、品牌、产品和地点，返回JSON数组格式：
+[{"name": "实体名称", "type": "person/brand/product/location", "count": 出现次数}]
+
+要求：
+1. 同一实体的不同叫法合并为一项，name 使用最常用的叫法
+2. 不确定类型的实体不要返回
+3. 没有实体时返回 []
+
+文本内容：
+%s`, text)
+
+	response, err := s.callAI(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []models.Entity
+	if err := json.Unmarshal([]byte(response), &entities); err != nil {
+		return nil, fmt.Errorf("解析AI实体识别结果失败: %w", err)
+	}
+
+	valid := entities[:0]
+	for _, entity := range entities {
+		entity.Name = strings.TrimSpace(entity.Name)
+		switch entity.Type {
+		case "person", "brand", "product", "location":
+		default:
+			continue
+		}
+		if entity.Name == "" {
+			continue
+		}
+		if entity.Count < 1 {
+			entity.Count = 1
+		}
+		entity.Source = "ai"
+		valid = append(valid, entity)
+	}
+	return valid, nil
+}
+
+func (s *aiService) DescribeImage(ctx context.Context, imagePath string) (string, error) {
+	if !aiConfigured(s.config.AI) {
+		return "", ErrAINotConfigured
+	}
+
+	img, err := loadPromptImage(s.config, imagePath)
+	if err != nil {
+		return "", err
+	}
+
+	prompt := `为这张图片写一句替代文本（alt text），供使用读屏软件的读者了解图片内容。
+
+要求：
+1. 不超过50个字，描述画面中的主体、场景和关键细节
+2. 图片中有醒目的文字时概括文字内容
+3. 不要以"图片"、"这张图"开头，只返回替代文本本身，不加引号和解释`
+
+	response, err := s.callAIWithImage(ctx, prompt, img)
+	if err != nil {
+		return "", err
+	}
+
+	// 模型偶尔会多写几行解释或加引号，只保留第一行
+	altText, _, _ := strings.Cut(strings.TrimSpace(response), "\n")
+	altText = strings.Trim(strings.TrimSpace(altText), "\"'“”「」")
+	if altText == "" {
+		return "", fmt.Errorf("AI未返回图片描述")
+	}
+	return altText, nil
+}
+
+func (s *aiService) Ping(ctx context.Context) error {
+	if !aiConfigured(s.config.AI) {
+		return ErrAINotConfigured
+	}
+
+	// 健康检查自带重试和计数，这里只发一次
+	_, err := s.callAIOnce(ctx, "ping", nil)
+	return err
+}
+
+func (s *aiService) UsageStats() models.AIUsage {
+	stats := s.usage.stats(s.config.AI.Pricing)
+	// 本地 Ollama 模型不产生费用
+	if s.config.AI.Provider == "ollama" {
+		stats.UnpricedModels = nil
+	}
+	return stats
+}
+
+// callAI 调用AI服务，开启 ai.cache 时相同的提示词直接返回缓存的响应
 func (s *aiService) callAI(ctx context.Context, prompt string) (string, error) {
+	return s.callAIWithImage(ctx, prompt, nil)
+}
+
+// callAIWithImage 同 callAI，img 不为 nil 时随提示词一起发送图片，缓存键同时包含图片内容
+func (s *aiService) callAIWithImage(ctx context.Context, prompt string, img *promptImage) (string, error) {
+	if s.cache == nil {
+		return s.callAIWithRetry(ctx, prompt, img)
+	}
+
+	keyText := prompt
+	if img != nil {
+		keyText += "\x00" + hashKey(string(img.data))
+	}
+	key := aiCacheKey(s.config.AI.Provider, s.model(img), keyText)
+	var cached string
+	if s.cache.get(key, &cached) {
+		s.usage.recordCacheHit()
+		return cached, nil
+	}
+
+	response, err := s.callAIWithRetry(ctx, prompt, img)
+	if err != nil {
+		return "", err
+	}
+	if err := s.cache.put(key, response); err != nil {
+		log.Printf("AI响应写入缓存失败: %v", err)
+	}
+	return response, nil
+}
+
+// callAIWithRetry 遇到限流、服务端错误或网络错误时按 ai.max_retries 退避重试
+func (s *aiService) callAIWithRetry(ctx context.Context, prompt string, img *promptImage) (string, error) {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		response, err := s.callAIOnce(ctx, prompt, img)
+		if err == nil {
+			return response, nil
+		}
+		lastErr = err
+		if attempt >= s.config.AI.MaxRetries || !isRetryable(err) {
+			break
+		}
+
+		delay := retryDelay(attempt, err)
+		// 剩余时间不够再等一轮时直接放弃，避免在截止时间后才返回
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			break
+		}
+		log.Printf("AI请求失败（第%d次），%v 后重试: %v", attempt+1, delay.Round(time.Millisecond), err)
+		if err := sleepContext(ctx, delay); err != nil {
+			break
+		}
+	}
+	return "", lastErr
+}
+
+// callAIOnce 发送一次请求，不做重试
+func (s *aiService) callAIOnce(ctx context.Context, prompt string, img *promptImage) (string, error) {
+	// 并发和速率限制相互独立：先占用并发名额，再等待速率时间片
+	if err := s.concurrency.Acquire(ctx); err != nil {
+		return "", err
+	}
+	defer s.concurrency.Release()
+
+	if err := s.rateLimit.Wait(ctx); err != nil {
+		return "", err
+	}
+
 	switch s.config.AI.Provider {
 	case "openai":
-		return s.callOpenAI(ctx, prompt)
+		return s.callOpenAI(ctx, prompt, img)
 	case "claude":
 		return s.callClaude(ctx, prompt)
+	case "gemini":
+		return s.callGemini(ctx, prompt, img)
+	case "ollama":
+		return s.callOllama(ctx, prompt, img)
 	default:
-		return "", fmt.Errorf("unsupported AI provider: %s", s.config.AI.Provider)
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedProvider, s.config.AI.Provider)
 	}
 }
 
-func (s *aiService) callOpenAI(ctx context.Context, prompt string) (string, error) {
+// model 本次请求使用的模型：带图片且设置了 ai.vision_model 时使用视觉模型
+func (s *aiService) model(img *promptImage) string {
+	if img != nil && s.config.AI.VisionModel != "" {
+		return s.config.AI.VisionModel
+	}
+	return s.config.AI.Model
+}
+
+func (s *aiService) callOpenAI(ctx context.Context, prompt string, img *promptImage) (string, error) {
 	url := "https://api.openai.com/v1/chat/completions"
 	if s.config.AI.BaseURL != "" {
 		url = s.config.AI.BaseURL + "/chat/completions"
 	}
 
-	reqBody := OpenAIRequest{
-		Model: s.config.AI.Model,
+	var reqBody interface{} = OpenAIRequest{
+		Model: s.model(img),
 		Messages: []Message{
 			{
 				Role:    "user",
@@ -235,6 +585,22 @@ func (s *aiService) callOpenAI(ctx context.Context, prompt string) (string, erro
 		Temperature: 0.7,
 		MaxTokens:   1000,
 	}
+	if img != nil {
+		reqBody = OpenAIVisionRequest{
+			Model: s.model(img),
+			Messages: []OpenAIVisionMessage{
+				{
+					Role: "user",
+					Content: []OpenAIContentPart{
+						{Type: "text", Text: prompt},
+						{Type: "image_url", ImageURL: &OpenAIImageURL{URL: img.dataURI()}},
+					},
+				},
+			},
+			Temperature: 0.7,
+			MaxTokens:   1000,
+		}
+	}
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -251,7 +617,7 @@ func (s *aiService) callOpenAI(ctx context.Context, prompt string) (string, erro
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("send request: %w", err)
+		return "", fmt.Errorf("%w: send request: %w", ErrAIUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -261,13 +627,19 @@ func (s *aiService) callOpenAI(ctx context.Context, prompt string) (string, erro
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return "", &APIError{
+			Provider:   "openai",
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
 	var response OpenAIResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return "", fmt.Errorf("parse response: %w", err)
 	}
+	s.usage.record(s.model(img), response.Usage.PromptTokens, response.Usage.CompletionTokens)
 
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response")
@@ -276,10 +648,139 @@ func (s *aiService) callOpenAI(ctx context.Context, prompt string) (string, erro
 	return response.Choices[0].Message.Content, nil
 }
 
+func (s *aiService) callGemini(ctx context.Context, prompt string, img *promptImage) (string, error) {
+	baseURL := "https://generativelanguage.googleapis.com/v1beta"
+	if s.config.AI.BaseURL != "" {
+		baseURL = strings.TrimSuffix(s.config.AI.BaseURL, "/")
+	}
+	url := baseURL + "/models/" + s.model(img) + ":generateContent"
+
+	parts := []GeminiPart{{Text: prompt}}
+	if img != nil {
+		parts = append(parts, GeminiPart{InlineData: &GeminiInlineData{
+			MimeType: img.mimeType,
+			Data:     base64.StdEncoding.EncodeToString(img.data),
+		}})
+	}
+	reqBody := GeminiRequest{
+		Contents: []GeminiContent{
+			{
+				Role:  "user",
+				Parts: parts,
+			},
+		},
+		GenerationConfig: &GeminiGenerationConfig{
+			Temperature:     0.7,
+			MaxOutputTokens: 1000,
+		},
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", s.config.AI.APIKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: send request: %w", ErrAIUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &APIError{
+			Provider:   "gemini",
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	var response GeminiResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("parse response: %w", err)
+	}
+	s.usage.record(s.model(img), response.UsageMetadata.PromptTokenCount, response.UsageMetadata.CandidatesTokenCount)
+
+	// 内容被安全策略拦截时 candidates 为空或没有 parts
+	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no candidates in response")
+	}
+
+	return response.Candidates[0].Content.Parts[0].Text, nil
+}
+
+func (s *aiService) callOllama(ctx context.Context, prompt string, img *promptImage) (string, error) {
+	baseURL := "http://localhost:11434"
+	if s.config.AI.BaseURL != "" {
+		baseURL = strings.TrimSuffix(s.config.AI.BaseURL, "/")
+	}
+
+	reqBody := OllamaRequest{
+		Model:  s.model(img),
+		Prompt: prompt,
+		Stream: false,
+		Options: &OllamaOptions{
+			Temperature: 0.7,
+			NumPredict:  1000,
+		},
+	}
+	if img != nil {
+		reqBody.Images = []string{base64.StdEncoding.EncodeToString(img.data)}
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/api/generate", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: send request: %w", ErrAIUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var response OllamaResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("parse response: %w", err)
+	}
+	s.usage.record(s.model(img), response.PromptEvalCount, response.EvalCount)
+
+	return response.Response, nil
+}
+
 func (s *aiService) callClaude(ctx context.Context, prompt string) (string, error) {
 	// Claude API调用实现
 	// 这里可以实现Claude API的调用逻辑
-	return "", fmt.Errorf("Claude API not implemented yet")
+	return "", fmt.Errorf("%w: Claude API not implemented yet", ErrUnsupportedProvider)
 }
 
 // 简化版本的分析方法，不依赖AI API
@@ -341,12 +842,16 @@ func (s *aiService) simpleAdviceGeneration(analysis models.AnalysisResult) strin
 	advice := strings.Builder{}
 	advice.WriteString("## 内容分析建议\n\n")
 
-	if analysis.Score.Total < 60 {
-		advice.WriteString("📊 **总体评分偏低**，建议重点关注以下方面：\n\n")
-	} else if analysis.Score.Total < 80 {
-		advice.WriteString("📊 **总体表现良好**，还有提升空间：\n\n")
-	} else {
+	// 与分析结果中的 level 使用同一组分数线
+	switch s.config.Analysis.LevelThresholds.Level(analysis.Score.Total) {
+	case "excellent":
 		advice.WriteString("📊 **内容质量优秀**，保持当前水准：\n\n")
+	case "good":
+		advice.WriteString("📊 **总体表现良好**，还有提升空间：\n\n")
+	case "average":
+		advice.WriteString("📊 **总体表现一般**，以下方面仍有明显提升空间：\n\n")
+	default:
+		advice.WriteString("📊 **总体评分偏低**，建议重点关注以下方面：\n\n")
 	}
 
 	// 根据各项得分给出具体建议
@@ -405,9 +910,16 @@ func (s *aiService) simpleTopicExtraction(text string) []string {
 		"娱乐": {"电影", "音乐", "游戏", "娱乐", "明星", "综艺", "动漫", "小说"},
 	}
 
+	// 按主题名排序遍历，同一文本每次得到相同顺序的结果
+	names := make([]string, 0, len(topicKeywords))
+	for topic := range topicKeywords {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+
 	text = strings.ToLower(text)
-	for topic, keywords := range topicKeywords {
-		for _, keyword := range keywords {
+	for _, topic := range names {
+		for _, keyword := range topicKeywords[topic] {
 			if strings.Contains(text, keyword) {
 				topics = append(topics, topic)
 				break
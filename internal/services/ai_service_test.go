@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// newTestAIService 指向 handler 的AI服务，默认不重试、不缓存，modify 可在创建前调整配置
+func newTestAIService(t *testing.T, provider string, handler http.HandlerFunc, modify func(cfg *config.Config)) *aiService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := testConfig(t)
+	cfg.AI.Provider = provider
+	cfg.AI.APIKey = "test-key"
+	cfg.AI.BaseURL = server.URL
+	cfg.AI.MaxRetries = 0
+	if modify != nil {
+		modify(cfg)
+	}
+	return NewAIService(cfg).(*aiService)
+}
+
+// writeOpenAIReply 以 chat/completions 的格式返回 content
+func writeOpenAIReply(w http.ResponseWriter, content string, promptTokens, completionTokens int) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OpenAIResponse{
+		Choices: []Choice{{Message: Message{Role: "assistant", Content: content}}},
+		Usage: Usage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+		},
+	})
+}
+
+// loadRecorder 记录请求的开始时间和同时进行中的最大请求数
+type loadRecorder struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	starts      []time.Time
+}
+
+func (l *loadRecorder) handler(latency time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.mu.Lock()
+		l.starts = append(l.starts, time.Now())
+		l.inFlight++
+		if l.inFlight > l.maxInFlight {
+			l.maxInFlight = l.inFlight
+		}
+		l.mu.Unlock()
+
+		time.Sleep(latency)
+
+		l.mu.Lock()
+		l.inFlight--
+		l.mu.Unlock()
+		writeOpenAIReply(w, "ok", 1, 1)
+	}
+}
+
+// minGap 相邻两次请求开始时间的最小间隔
+func (l *loadRecorder) minGap() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	starts := append([]time.Time(nil), l.starts...)
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	gap := time.Duration(-1)
+	for i := 1; i < len(starts); i++ {
+		if d := starts[i].Sub(starts[i-1]); gap < 0 || d < gap {
+			gap = d
+		}
+	}
+	return gap
+}
+
+// callConcurrently 同时发起 n 次不同提示词的请求
+func callConcurrently(t *testing.T, s *aiService, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := s.callAI(context.Background(), fmt.Sprintf("prompt %d", i)); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("请求失败: %v", err)
+	}
+}
+
+func TestAIRateAndConcurrencyLimits(t *testing.T) {
+	t.Run("tight rate, loose concurrency", func(t *testing.T) {
+		var rec loadRecorder
+		s := newTestAIService(t, "openai", rec.handler(10*time.Millisecond), func(cfg *config.Config) {
+			cfg.AI.RequestsPerMinute = 1200 // 每50ms一次
+			cfg.AI.MaxConcurrency = 10
+		})
+
+		callConcurrently(t, s, 5)
+
+		// 计时器可能略早触发，留出少量余量
+		if gap := rec.minGap(); gap < 45*time.Millisecond {
+			t.Errorf("请求开始间隔最小为 %v，应不小于50ms", gap)
+		}
+		if len(rec.starts) != 5 {
+			t.Errorf("请求数 = %d, want 5", len(rec.starts))
+		}
+	})
+
+	t.Run("loose rate, tight concurrency", func(t *testing.T) {
+		var rec loadRecorder
+		s := newTestAIService(t, "openai", rec.handler(40*time.Millisecond), func(cfg *config.Config) {
+			cfg.AI.RequestsPerMinute = 600000
+			cfg.AI.MaxConcurrency = 2
+		})
+
+		callConcurrently(t, s, 8)
+
+		if rec.maxInFlight != 2 {
+			t.Errorf("同时进行中的请求最多 %d 个，want 2", rec.maxInFlight)
+		}
+		if len(rec.starts) != 8 {
+			t.Errorf("请求数 = %d, want 8", len(rec.starts))
+		}
+	})
+}
+
+func TestAICacheSkipsRepeatedRequest(t *testing.T) {
+	var requests int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		writeOpenAIReply(w, "cached reply", 10, 5)
+	}
+	cacheDir := t.TempDir()
+	withCache := func(cfg *config.Config) {
+		cfg.AI.Cache = config.CacheConfig{Enabled: true, Dir: cacheDir}
+	}
+	s := newTestAIService(t, "openai", handler, withCache)
+
+	for i := 0; i < 2; i++ {
+		reply, err := s.callAI(context.Background(), "same prompt")
+		if err != nil {
+			t.Fatalf("第%d次 callAI() error = %v", i+1, err)
+		}
+		if reply != "cached reply" {
+			t.Errorf("第%d次 callAI() = %q, want %q", i+1, reply, "cached reply")
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("相同提示词发出 %d 次HTTP请求, want 1", got)
+	}
+	if usage := s.UsageStats(); usage.Requests != 1 || usage.CachedResponses != 1 {
+		t.Errorf("UsageStats() = %+v, want 1次请求、1次缓存命中", usage)
+	}
+
+	// 不同的提示词不命中缓存
+	if _, err := s.callAI(context.Background(), "other prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("不同提示词后HTTP请求数 = %d, want 2", got)
+	}
+
+	// 缓存写在磁盘上，新的服务实例（再次运行）同样命中
+	again := newTestAIService(t, "openai", handler, withCache)
+	if _, err := again.callAI(context.Background(), "same prompt"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("再次运行后HTTP请求数 = %d, want 2", got)
+	}
+}
+
+func TestGeminiProvider(t *testing.T) {
+	var got GeminiRequest
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-1.5-flash:generateContent" {
+			t.Errorf("path = %s, want /models/gemini-1.5-flash:generateContent", r.URL.Path)
+		}
+		if key := r.Header.Get("x-goog-api-key"); key != "test-key" {
+			t.Errorf("x-goog-api-key = %q, want test-key", key)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("解析请求体失败: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(GeminiResponse{
+			Candidates: []GeminiCandidate{{
+				Content: GeminiContent{Role: "model", Parts: []GeminiPart{
+					{Text: `{"overall":"negative","score":-0.6,"confidence":0.9}`},
+				}},
+				FinishReason: "STOP",
+			}},
+			UsageMetadata: GeminiUsage{PromptTokenCount: 12, CandidatesTokenCount: 8},
+		})
+	}
+	s := newTestAIService(t, "gemini", handler, func(cfg *config.Config) {
+		cfg.AI.Model = "gemini-1.5-flash"
+	})
+
+	sentiment, err := s.AnalyzeSentiment(context.Background(), "这次的露营体验很一般")
+	if err != nil {
+		t.Fatalf("AnalyzeSentiment() error = %v", err)
+	}
+	if sentiment.Overall != "negative" || sentiment.Score != -0.6 {
+		t.Errorf("sentiment = %+v, want Gemini 返回的 negative/-0.6", sentiment)
+	}
+	if len(got.Contents) != 1 || len(got.Contents[0].Parts) != 1 || !strings.Contains(got.Contents[0].Parts[0].Text, "这次的露营体验很一般") {
+		t.Errorf("请求 contents = %+v, want 单个 part 包含提示词", got.Contents)
+	}
+	if usage := s.UsageStats(); usage.PromptTokens != 12 || usage.CompletionTokens != 8 {
+		t.Errorf("UsageStats() = %+v, want 12/8 tokens", usage)
+	}
+}
+
+func TestGeminiFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":{"code":500}}`, http.StatusInternalServerError)
+		}},
+		{"blocked by safety", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"candidates":[{"content":{"parts":[]},"finishReason":"SAFETY"}]}`)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAIService(t, "gemini", tt.handler, nil)
+
+			text := "这个帐篷太差了，非常失望"
+			sentiment, source, err := s.AnalyzeSentimentWithSource(context.Background(), text)
+			if err != nil || source != models.ProvenanceFallback {
+				t.Fatalf("source, error = %q, %v, want %q, nil", source, err, models.ProvenanceFallback)
+			}
+			if want := s.simpleSentimentAnalysis(text); sentiment.Overall != want.Overall || sentiment.Score != want.Score {
+				t.Errorf("sentiment = %+v, want 本地分析结果 %+v", sentiment, want)
+			}
+		})
+	}
+}
+
+func TestOllamaProvider(t *testing.T) {
+	var got OllamaRequest
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			t.Errorf("path = %s, want /api/generate", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, 本地 Ollama 不需要密钥", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("解析请求体失败: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(OllamaResponse{
+			Model:           "llama3",
+			Response:        `{"overall":"positive","score":0.8,"confidence":0.7}`,
+			Done:            true,
+			PromptEvalCount: 20,
+			EvalCount:       10,
+		})
+	}
+	s := newTestAIService(t, "ollama", handler, func(cfg *config.Config) {
+		cfg.AI.APIKey = ""
+		cfg.AI.Model = "llama3"
+	})
+
+	sentiment, err := s.AnalyzeSentiment(context.Background(), "周末的露营非常开心")
+	if err != nil {
+		t.Fatalf("AnalyzeSentiment() error = %v", err)
+	}
+	if sentiment.Overall != "positive" || sentiment.Score != 0.8 {
+		t.Errorf("sentiment = %+v, want Ollama 返回的 positive/0.8", sentiment)
+	}
+	if got.Model != "llama3" || got.Stream || !strings.Contains(got.Prompt, "周末的露营非常开心") {
+		t.Errorf("请求 = %+v, want 模型 llama3、非流式且包含提示词", got)
+	}
+
+	// 本地模型不产生费用，也不提示缺少价格
+	usage := s.UsageStats()
+	if usage.TotalTokens != 30 || usage.EstimatedCost != 0 || len(usage.UnpricedModels) != 0 {
+		t.Errorf("UsageStats() = %+v, want 30 tokens、无费用", usage)
+	}
+}
+
+func TestOllamaFallback(t *testing.T) {
+	s := newTestAIService(t, "ollama", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"model \"llama3\" not found"}`, http.StatusNotFound)
+	}, func(cfg *config.Config) {
+		cfg.AI.APIKey = ""
+	})
+
+	text := "这个帐篷太差了，非常失望"
+	sentiment, source, err := s.AnalyzeSentimentWithSource(context.Background(), text)
+	if err != nil || source != models.ProvenanceFallback {
+		t.Fatalf("source, error = %q, %v, want %q, nil", source, err, models.ProvenanceFallback)
+	}
+	if sentiment.Overall != "negative" {
+		t.Errorf("sentiment.Overall = %q, want 本地分析的 negative", sentiment.Overall)
+	}
+}
+
+func TestExtractTopicsSource(t *testing.T) {
+	text := "周末露营装备清单：帐篷、睡袋和炉具"
+	tests := []struct {
+		name       string
+		apiKey     string
+		handler    http.HandlerFunc
+		wantSource string
+		wantTopics []string
+	}{
+		{"AI 返回话题", "test-key", func(w http.ResponseWriter, r *http.Request) {
+			writeOpenAIReply(w, `["露营","装备"]`, 10, 5)
+		}, models.ProvenanceAI, []string{"露营", "装备"}},
+		{"AI 失败降级", "test-key", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}, models.ProvenanceFallback, nil},
+		{"AI 返回无法解析", "test-key", func(w http.ResponseWriter, r *http.Request) {
+			writeOpenAIReply(w, "露营、装备", 10, 5)
+		}, models.ProvenanceFallback, nil},
+		{"未配置 AI", "", nil, models.ProvenanceLocal, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAIService(t, "openai", tt.handler, func(cfg *config.Config) { cfg.AI.APIKey = tt.apiKey })
+			want := tt.wantTopics
+			if want == nil {
+				want = s.simpleTopicExtraction(text)
+			}
+
+			topics, source, err := s.ExtractTopicsWithSource(context.Background(), text)
+			if err != nil || source != tt.wantSource {
+				t.Fatalf("source, error = %q, %v, want %q, nil", source, err, tt.wantSource)
+			}
+			if strings.Join(topics, ",") != strings.Join(want, ",") {
+				t.Errorf("topics = %v, want %v", topics, want)
+			}
+
+			// 不关心来源的调用方得到同样的结果，降级时错误也为 nil
+			topics, err = s.ExtractTopics(context.Background(), text)
+			if err != nil || strings.Join(topics, ",") != strings.Join(want, ",") {
+				t.Errorf("ExtractTopics() = %v, %v, want %v, nil", topics, err, want)
+			}
+		})
+	}
+}
+
+func TestSimpleAdviceUsesLevelThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds *config.LevelThresholds
+		total      float64
+		want       string
+	}{
+		{"默认分数线 90 为优秀", nil, 90, "内容质量优秀"},
+		{"默认分数线 82 为良好", nil, 82, "总体表现良好"},
+		{"默认分数线 60 为一般", nil, 60, "总体表现一般"},
+		{"默认分数线 40 为偏低", nil, 40, "总体评分偏低"},
+		{"自定义分数线 82 为优秀", &config.LevelThresholds{Excellent: 80, Good: 60, Average: 40}, 82, "内容质量优秀"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			if tt.thresholds != nil {
+				cfg.Analysis.LevelThresholds = *tt.thresholds
+			}
+			s := NewAIService(cfg).(*aiService)
+
+			analysis := models.AnalysisResult{}
+			analysis.Score.Total = tt.total
+			analysis.Score.Level = cfg.Analysis.LevelThresholds.Level(tt.total)
+			if advice := s.simpleAdviceGeneration(analysis); !strings.Contains(advice, tt.want) {
+				t.Errorf("level %s 的建议不包含 %q:\n%s", analysis.Score.Level, tt.want, advice)
+			}
+		})
+	}
+}
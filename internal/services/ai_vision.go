@@ -0,0 +1,49 @@
+// internal/services/ai_vision.go
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// 发送给视觉模型前把图片长边缩小到该尺寸，减小请求体积和token消耗
+const visionMaxDimension = 1024
+
+// promptImage 随提示词发送给视觉模型的图片
+type promptImage struct {
+	mimeType string
+	data     []byte
+}
+
+func (img *promptImage) dataURI() string {
+	return "data:" + img.mimeType + ";base64," + base64.StdEncoding.EncodeToString(img.data)
+}
+
+// loadPromptImage 读取图片（支持HEIC等各提供方不一定接受的格式），缩小后统一转为JPEG。
+// 透明区域按白色背景合成，避免转JPEG后变黑
+func loadPromptImage(cfg *config.Config, imagePath string) (*promptImage, error) {
+	loader := &imageService{config: cfg}
+	img, err := loader.loadImage(imagePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取图片 %s 失败: %w", imagePath, err)
+	}
+
+	img = downscale(img, visionMaxDimension)
+	bounds := img.Bounds()
+	flattened := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), img, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flattened, &jpeg.Options{Quality: normalizeJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("转换为JPEG失败: %w", err)
+	}
+	return &promptImage{mimeType: "image/jpeg", data: buf.Bytes()}, nil
+}
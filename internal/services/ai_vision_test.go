@@ -0,0 +1,97 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestDescribeImageWithMockedVisionResponse(t *testing.T) {
+	var request OpenAIVisionRequest
+	svc := newTestAIService(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("解析请求失败: %v", err)
+		}
+		// 模型多写了引号和解释，只保留第一行
+		writeOpenAIReply(w, "“湖边草地上搭着一顶橙色帐篷”\n说明：画面主体为帐篷。", 120, 20)
+	}, func(cfg *config.Config) {
+		cfg.AI.Model = "gpt-4o-mini"
+		cfg.AI.VisionModel = "gpt-4o"
+	})
+
+	path := writeScenePNG(t, t.TempDir(), "wide.png", gradientScene(2048, 512))
+	altText, err := svc.DescribeImage(context.Background(), path)
+	if err != nil {
+		t.Fatalf("DescribeImage() error = %v", err)
+	}
+	if altText != "湖边草地上搭着一顶橙色帐篷" {
+		t.Errorf("DescribeImage() = %q, want 去掉引号和解释的第一行", altText)
+	}
+
+	if request.Model != "gpt-4o" {
+		t.Errorf("请求模型 = %q, want vision_model gpt-4o", request.Model)
+	}
+	if len(request.Messages) != 1 || len(request.Messages[0].Content) != 2 {
+		t.Fatalf("请求消息 = %+v, want 一条带文字和图片的消息", request.Messages)
+	}
+	parts := request.Messages[0].Content
+	if parts[0].Type != "text" || !strings.Contains(parts[0].Text, "替代文本") {
+		t.Errorf("第一部分 = %+v, want 提示词", parts[0])
+	}
+	if parts[1].Type != "image_url" || parts[1].ImageURL == nil {
+		t.Fatalf("第二部分 = %+v, want image_url", parts[1])
+	}
+
+	// 图片转为 JPEG，长边缩小到 visionMaxDimension
+	const prefix = "data:image/jpeg;base64,"
+	if !strings.HasPrefix(parts[1].ImageURL.URL, prefix) {
+		t.Fatalf("图片 URL 前缀 = %.40q, want %s", parts[1].ImageURL.URL, prefix)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(parts[1].ImageURL.URL, prefix))
+	if err != nil {
+		t.Fatalf("解码 base64 失败: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("发送的图片不是 JPEG: %v", err)
+	}
+	if cfg.Width != visionMaxDimension || cfg.Height != visionMaxDimension/4 {
+		t.Errorf("发送的图片尺寸 = %dx%d, want %dx%d", cfg.Width, cfg.Height, visionMaxDimension, visionMaxDimension/4)
+	}
+
+	usage := svc.UsageStats()
+	if usage.TotalTokens != 140 {
+		t.Errorf("TotalTokens = %d, want 140", usage.TotalTokens)
+	}
+}
+
+func TestDescribeImageEmptyResponse(t *testing.T) {
+	svc := newTestAIService(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+		writeOpenAIReply(w, " \n ", 10, 0)
+	}, nil)
+
+	path := writeScenePNG(t, t.TempDir(), "scene.png", image.NewRGBA(image.Rect(0, 0, 32, 32)))
+	if _, err := svc.DescribeImage(context.Background(), path); err == nil {
+		t.Error("DescribeImage() error = nil, want 空响应报错")
+	}
+}
+
+func TestDescribeImageNotConfigured(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.AI.APIKey = ""
+	svc := NewAIService(cfg)
+
+	path := writeScenePNG(t, t.TempDir(), "scene.png", image.NewRGBA(image.Rect(0, 0, 32, 32)))
+	if _, err := svc.DescribeImage(context.Background(), path); !errors.Is(err, ErrAINotConfigured) {
+		t.Errorf("DescribeImage() error = %v, want ErrAINotConfigured", err)
+	}
+}
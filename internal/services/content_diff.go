@@ -0,0 +1,31 @@
+// internal/services/content_diff.go
+package services
+
+import (
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DiffContent 逐字比较原文和改写后的内容，返回按原文位置排列的插入和删除。
+// 相邻的细碎修改会合并为便于阅读的整段修改；内容相同时返回空列表
+func DiffContent(original, improved string) []models.DiffHunk {
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffCleanupSemantic(dmp.DiffMain(original, improved, false))
+
+	hunks := []models.DiffHunk{}
+	position := 0
+	for _, diff := range diffs {
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			position += utf8.RuneCountInString(diff.Text)
+		case diffmatchpatch.DiffDelete:
+			hunks = append(hunks, models.DiffHunk{Op: "delete", Position: position, Text: diff.Text})
+			position += utf8.RuneCountInString(diff.Text)
+		case diffmatchpatch.DiffInsert:
+			hunks = append(hunks, models.DiffHunk{Op: "insert", Position: position, Text: diff.Text})
+		}
+	}
+	return hunks
+}
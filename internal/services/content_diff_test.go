@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// applyHunks 把 hunks 应用到原文上，用于检查差异能还原出改写结果
+func applyHunks(original string, hunks []models.DiffHunk) string {
+	runes := []rune(original)
+	var b strings.Builder
+	position := 0
+	for _, hunk := range hunks {
+		b.WriteString(string(runes[position:hunk.Position]))
+		position = hunk.Position
+		switch hunk.Op {
+		case "insert":
+			b.WriteString(hunk.Text)
+		case "delete":
+			position += len([]rune(hunk.Text))
+		}
+	}
+	b.WriteString(string(runes[position:]))
+	return b.String()
+}
+
+func TestImproveContentDiff(t *testing.T) {
+	suggestions := []models.Suggestion{{Type: "content", Recommended: "补充装备清单"}}
+
+	tests := []struct {
+		name      string
+		original  string
+		improved  string
+		wantHunks []models.DiffHunk // 为 nil 时只检查能否还原
+	}{
+		{
+			name:      "插入",
+			original:  "周末去露营，带上帐篷。",
+			improved:  "周末去露营，带上帐篷和睡袋。",
+			wantHunks: []models.DiffHunk{{Op: "insert", Position: 10, Text: "和睡袋"}},
+		},
+		{
+			name:      "删除",
+			original:  "周末去露营，一定一定要带上帐篷。",
+			improved:  "周末去露营，一定要带上帐篷。",
+			wantHunks: []models.DiffHunk{{Op: "delete", Position: 8, Text: "一定"}},
+		},
+		{
+			name:      "未修改",
+			original:  "周末去露营。",
+			improved:  "周末去露营。",
+			wantHunks: []models.DiffHunk{},
+		},
+		{
+			name:     "整句改写",
+			original: "周末去露营，带上帐篷。We had fun.",
+			improved: "这个周末去湖边露营，记得带上帐篷和睡袋。We had a great time.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestAIService(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+				writeOpenAIReply(w, tt.improved, 10, 10)
+			}, nil)
+
+			diff, err := svc.ImproveContentDiff(context.Background(), tt.original, suggestions)
+			if err != nil {
+				t.Fatalf("ImproveContentDiff() error = %v", err)
+			}
+			if diff.Improved != tt.improved {
+				t.Errorf("Improved = %q, want %q", diff.Improved, tt.improved)
+			}
+			if tt.wantHunks != nil && !reflect.DeepEqual(diff.Hunks, tt.wantHunks) {
+				t.Errorf("Hunks = %+v, want %+v", diff.Hunks, tt.wantHunks)
+			}
+			// 差异必须基于原文和改写结果计算，应用后应得到改写结果
+			if got := applyHunks(tt.original, diff.Hunks); got != tt.improved {
+				t.Errorf("应用差异后 = %q, want %q", got, tt.improved)
+			}
+		})
+	}
+}
+
+func TestImproveContentDiffNotConfigured(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.AI.APIKey = ""
+	svc := NewAIService(cfg)
+
+	diff, err := svc.ImproveContentDiff(context.Background(), "周末去露营。", nil)
+	if !errors.Is(err, ErrAINotConfigured) {
+		t.Errorf("ImproveContentDiff() error = %v, want ErrAINotConfigured", err)
+	}
+	if diff.Improved != "周末去露营。" || len(diff.Hunks) != 0 {
+		t.Errorf("ImproveContentDiff() = %+v, want 原文且无差异", diff)
+	}
+}
@@ -0,0 +1,50 @@
+// internal/services/errors.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// 图片服务错误，可通过 errors.Is 判断
+var (
+	ErrImageNotFound     = errors.New("图片文件不存在")
+	ErrUnsupportedFormat = errors.New("不支持的图片格式")
+	ErrImageTooLarge     = errors.New("图片文件过大")
+	ErrImageDecode       = errors.New("图片解码失败")
+	ErrInvalidInline     = errors.New("内嵌图片数据无效")
+	ErrImageDownload     = errors.New("远程图片下载失败")
+	ErrHEICUnavailable   = errors.New("HEIC转换命令不可用")
+)
+
+// AI服务错误，可通过 errors.Is 判断
+var (
+	ErrAINotConfigured     = errors.New("AI service not configured")
+	ErrAIUnavailable       = errors.New("AI service unavailable")
+	ErrUnsupportedProvider = errors.New("unsupported AI provider")
+)
+
+// ErrInvalidConfig 服务配置不合法
+var ErrInvalidConfig = errors.New("服务配置错误")
+
+// APIError AI服务商返回的非200响应，可通过 errors.As 获取状态码
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // 响应头 Retry-After 给出的等待时间，未提供时为0
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API error %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Unwrap 限流和服务端错误视为服务暂时不可用
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500 {
+		return ErrAIUnavailable
+	}
+	return nil
+}
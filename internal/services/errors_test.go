@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	tests := []struct {
+		status      int
+		unavailable bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusBadRequest, false},
+		{http.StatusUnauthorized, false},
+	}
+	for _, tt := range tests {
+		// 模拟调用栈中逐层包装
+		err := fmt.Errorf("情感分析失败: %w", fmt.Errorf("调用AI失败: %w", &APIError{Provider: "openai", StatusCode: tt.status}))
+
+		if got := errors.Is(err, ErrAIUnavailable); got != tt.unavailable {
+			t.Errorf("状态码 %d: errors.Is(ErrAIUnavailable) = %v, want %v", tt.status, got, tt.unavailable)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("状态码 %d: errors.As 未取到 APIError", tt.status)
+		}
+	}
+}
+
+func TestValidateImageErrors(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.MaxSize = 10
+	svc := NewImageService(cfg)
+
+	dir := t.TempDir()
+	large := filepath.Join(dir, "large.png")
+	if err := os.WriteFile(large, make([]byte, 100), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	text := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(text, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{filepath.Join(dir, "missing.png"), ErrImageNotFound},
+		{text, ErrUnsupportedFormat},
+		{large, ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		err := svc.ValidateImage(tt.path)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ValidateImage(%s) = %v, want %v", filepath.Base(tt.path), err, tt.want)
+		}
+		if _, err := svc.AnalyzeImage(tt.path); !errors.Is(err, tt.want) {
+			t.Errorf("AnalyzeImage(%s) = %v, want %v", filepath.Base(tt.path), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// internal/services/file_cache.go
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// fileCache 磁盘缓存，每条记录存为缓存目录下以键命名的一个JSON文件
+type fileCache struct {
+	dir string
+	ttl time.Duration // 0表示不过期
+}
+
+type fileCacheEntry struct {
+	CachedAt time.Time       `json:"cached_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+func newFileCache(dir string, ttlHours int) fileCache {
+	return fileCache{dir: dir, ttl: time.Duration(ttlHours) * time.Hour}
+}
+
+// newAICache 未开启缓存时返回 nil
+func newAICache(cfg config.CacheConfig) *fileCache {
+	if !cfg.Enabled {
+		return nil
+	}
+	cache := newFileCache(cfg.Dir, cfg.TTLHours)
+	return &cache
+}
+
+// aiCacheKey 提供方、模型和提示词共同决定缓存键
+func aiCacheKey(provider, model, prompt string) string {
+	return hashKey(provider + "\x00" + model + "\x00" + prompt)
+}
+
+// hashKey 将任意字符串转为可用作文件名的缓存键
+func hashKey(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c fileCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get 读取未过期的记录到 v，记录不存在、已过期或损坏时返回 false
+func (c fileCache) get(key string, v interface{}) bool {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return false
+	}
+	if c.ttl > 0 && time.Since(entry.CachedAt) > c.ttl {
+		return false
+	}
+	return json.Unmarshal(entry.Value, v) == nil
+}
+
+// put 写入记录，先写临时文件再重命名，避免并发读到半截记录
+func (c fileCache) put(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(fileCacheEntry{CachedAt: time.Now(), Value: value})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("写入缓存失败: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
@@ -0,0 +1,155 @@
+// internal/services/health.go
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// 健康状态
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded" // 可用，但需要重试或响应较慢
+	HealthDown     = "down"
+	HealthSkipped  = "skipped" // 未配置，不参与整体状态
+)
+
+const (
+	healthCheckAttempts = 3
+	healthRetryBackoff  = 500 * time.Millisecond
+	// 响应超过该时长视为降级
+	healthSlowLatency = 5 * time.Second
+)
+
+// HealthReport 各服务的健康状态
+type HealthReport struct {
+	Status    string          `json:"status"`
+	CheckedAt time.Time       `json:"checked_at"`
+	Services  []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth 单个服务的检查结果
+type ServiceHealth struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Attempts  int     `json:"attempts,omitempty"`
+	Provider  string  `json:"provider,omitempty"`
+	Model     string  `json:"model,omitempty"`
+	Message   string  `json:"message,omitempty"`
+}
+
+// CheckHealth 检查所有服务，AI服务会实际请求一次提供方并记录往返延迟，临时错误会重试
+func (sm *ServiceManager) CheckHealth(ctx context.Context) HealthReport {
+	return newHealthReport(sm.checkAIHealth(ctx), sm.checkImageHealth())
+}
+
+// CheckLiveness 只检查本地服务，不请求AI提供方，适合频繁的存活探测；AI服务记为 skipped
+func (sm *ServiceManager) CheckLiveness() HealthReport {
+	ai := ServiceHealth{
+		Name:     "ai",
+		Status:   HealthSkipped,
+		Provider: sm.config.AI.Provider,
+		Model:    sm.config.AI.Model,
+		Message:  "存活检查不请求AI服务",
+	}
+	if !aiConfigured(sm.config.AI) {
+		ai.Message = "AI API密钥未配置，将使用简化版本"
+	}
+
+	return newHealthReport(ai, sm.checkImageHealth())
+}
+
+// newHealthReport 汇总各服务状态：任一服务 down 则整体 down，否则有 degraded 则整体 degraded
+func newHealthReport(services ...ServiceHealth) HealthReport {
+	report := HealthReport{
+		CheckedAt: time.Now(),
+		Services:  services,
+	}
+
+	report.Status = HealthOK
+	for _, service := range report.Services {
+		switch service.Status {
+		case HealthDown:
+			report.Status = HealthDown
+		case HealthDegraded:
+			if report.Status == HealthOK {
+				report.Status = HealthDegraded
+			}
+		}
+	}
+
+	return report
+}
+
+func (sm *ServiceManager) checkAIHealth(ctx context.Context) ServiceHealth {
+	health := ServiceHealth{
+		Name:     "ai",
+		Provider: sm.config.AI.Provider,
+		Model:    sm.config.AI.Model,
+	}
+
+	if !aiConfigured(sm.config.AI) {
+		health.Status = HealthSkipped
+		health.Message = "AI API密钥未配置，将使用简化版本"
+		return health
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		health.Attempts = attempt
+
+		start := time.Now()
+		err = sm.AIService.Ping(ctx)
+		health.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+		// 只有限流、5xx、网络错误等临时错误值得重试
+		if err == nil || !errors.Is(err, ErrAIUnavailable) || attempt == healthCheckAttempts {
+			break
+		}
+		if waitErr := sleepContext(ctx, healthRetryBackoff*time.Duration(attempt)); waitErr != nil {
+			err = waitErr
+			break
+		}
+	}
+
+	switch {
+	case err != nil:
+		health.Status = HealthDown
+		health.Message = "AI服务连接失败: " + err.Error()
+	case health.Attempts > 1:
+		health.Status = HealthDegraded
+		health.Message = "重试后连接成功"
+	case health.LatencyMs > float64(healthSlowLatency.Milliseconds()):
+		health.Status = HealthDegraded
+		health.Message = "响应较慢"
+	default:
+		health.Status = HealthOK
+	}
+
+	return health
+}
+
+func (sm *ServiceManager) checkImageHealth() ServiceHealth {
+	health := ServiceHealth{Name: "image", Status: HealthOK}
+
+	if err := sm.checkImageService(); err != nil {
+		health.Status = HealthDown
+		health.Message = err.Error()
+	}
+
+	return health
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
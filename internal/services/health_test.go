@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// newTestServiceManager AI服务指向 handler 的服务管理器
+func newTestServiceManager(t *testing.T, handler http.HandlerFunc, modify func(cfg *config.Config)) *ServiceManager {
+	t.Helper()
+	ai := newTestAIService(t, "openai", handler, modify)
+	return &ServiceManager{
+		AIService:    ai,
+		ImageService: NewImageService(ai.config),
+		config:       ai.config,
+	}
+}
+
+func TestCheckHealthAI(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32 // 前几次请求返回 statusCode
+		statusCode   int
+		latency      time.Duration
+		wantStatus   string
+		wantAttempts int
+		wantOverall  string
+	}{
+		{name: "ok", latency: 20 * time.Millisecond, wantStatus: HealthOK, wantAttempts: 1, wantOverall: HealthOK},
+		{name: "retried 5xx", failures: 1, statusCode: http.StatusServiceUnavailable, wantStatus: HealthDegraded, wantAttempts: 2, wantOverall: HealthDegraded},
+		{name: "auth error is not retried", failures: 100, statusCode: http.StatusUnauthorized, wantStatus: HealthDown, wantAttempts: 1, wantOverall: HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			sm := newTestServiceManager(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= tt.failures {
+					http.Error(w, "unavailable", tt.statusCode)
+					return
+				}
+				time.Sleep(tt.latency)
+				writeOpenAIReply(w, "pong", 1, 1)
+			}, nil)
+
+			report := sm.CheckHealth(context.Background())
+
+			if report.Status != tt.wantOverall {
+				t.Errorf("整体状态 = %q, want %q", report.Status, tt.wantOverall)
+			}
+			ai := report.Services[0]
+			if ai.Name != "ai" || ai.Provider != "openai" {
+				t.Fatalf("Services[0] = %+v, want ai/openai", ai)
+			}
+			if ai.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q (%s)", ai.Status, tt.wantStatus, ai.Message)
+			}
+			if ai.Attempts != tt.wantAttempts || int(atomic.LoadInt32(&requests)) != tt.wantAttempts {
+				t.Errorf("Attempts = %d, 请求次数 = %d, want %d", ai.Attempts, requests, tt.wantAttempts)
+			}
+			if ai.LatencyMs < float64(tt.latency.Milliseconds()) {
+				t.Errorf("LatencyMs = %.1f, 应不少于服务端延迟 %v", ai.LatencyMs, tt.latency)
+			}
+		})
+	}
+}
+
+func TestCheckHealthSkipsUnconfiguredAI(t *testing.T) {
+	sm := newTestServiceManager(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("未配置密钥时不应请求AI服务")
+	}, func(cfg *config.Config) {
+		cfg.AI.APIKey = ""
+	})
+
+	report := sm.CheckHealth(context.Background())
+
+	if ai := report.Services[0]; ai.Status != HealthSkipped || ai.Attempts != 0 {
+		t.Errorf("AI = %+v, want skipped", ai)
+	}
+	// 跳过的服务不影响整体状态
+	if report.Status != HealthOK {
+		t.Errorf("整体状态 = %q, want ok", report.Status)
+	}
+}
@@ -0,0 +1,80 @@
+// internal/services/image_cache.go
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// imageCacheVersion 缓存记录的格式版本，ImageAnalysis 增加或改变字段时加1，使旧记录失效
+const imageCacheVersion = 2
+
+// imageCache 按文件签名（路径+修改时间+大小）缓存单张图片的分析结果
+type imageCache struct {
+	store fileCache
+	// 图片相关配置的指纹，配置变化后旧记录自然失效
+	configHash string
+}
+
+// newImageCache 未开启缓存时返回 nil
+func newImageCache(cfg config.ImageConfig) *imageCache {
+	if !cfg.Cache.Enabled {
+		return nil
+	}
+
+	// 缓存设置本身不影响分析结果，不计入指纹
+	fingerprint := cfg
+	fingerprint.Cache = config.CacheConfig{}
+	data, _ := json.Marshal(fingerprint)
+	sum := sha256.Sum256(data)
+
+	return &imageCache{
+		store:      newFileCache(cfg.Cache.Dir, cfg.Cache.TTLHours),
+		configHash: hex.EncodeToString(sum[:8]),
+	}
+}
+
+// key 根据缓存格式版本和文件签名生成缓存键，文件不可读时返回错误
+func (c *imageCache) key(imagePath string) (string, error) {
+	absPath, err := filepath.Abs(imagePath)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", err
+	}
+
+	return hashKey(fmt.Sprintf("v%d|%s|%d|%d|%s", imageCacheVersion, absPath, info.ModTime().UnixNano(), info.Size(), c.configHash)), nil
+}
+
+// Get 返回未过期的缓存结果
+func (c *imageCache) Get(imagePath string) (models.ImageAnalysis, bool) {
+	key, err := c.key(imagePath)
+	if err != nil {
+		return models.ImageAnalysis{}, false
+	}
+
+	var analysis models.ImageAnalysis
+	if !c.store.get(key, &analysis) {
+		return models.ImageAnalysis{}, false
+	}
+	analysis.Path = imagePath
+	return analysis, true
+}
+
+// Put 写入缓存
+func (c *imageCache) Put(imagePath string, analysis models.ImageAnalysis) error {
+	key, err := c.key(imagePath)
+	if err != nil {
+		return err
+	}
+	return c.store.put(key, analysis)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeScenePNG 把 img 编码为 PNG 写入 dir
+func writeScenePNG(t *testing.T, dir, name string, img image.Image) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImageCacheHitAndModTimeMiss(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.Cache.Enabled = true
+	cfg.Image.Cache.Dir = t.TempDir()
+	svc := NewImageService(cfg).(*imageService)
+	path := writeScenePNG(t, t.TempDir(), "scene.png", brightSubjectScene(120, 80, image.Rect(40, 20, 80, 60)))
+
+	first, err := svc.AnalyzeImage(path)
+	if err != nil {
+		t.Fatalf("AnalyzeImage() error = %v", err)
+	}
+
+	// 改写缓存中的得分作为标记：命中缓存时返回标记值，重新分析时得到真实得分
+	marked := first
+	marked.Score = -1
+	if err := svc.cache.Put(path, marked); err != nil {
+		t.Fatal(err)
+	}
+	second, err := svc.AnalyzeImage(path)
+	if err != nil {
+		t.Fatalf("AnalyzeImage() error = %v", err)
+	}
+	if second.Score != -1 {
+		t.Fatalf("未变化的图片 Score = %v, want 命中缓存的 -1", second.Score)
+	}
+	if second.Path != path {
+		t.Errorf("缓存结果 Path = %q, want %q", second.Path, path)
+	}
+
+	// 修改时间变化后缓存失效
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	third, err := svc.AnalyzeImage(path)
+	if err != nil {
+		t.Fatalf("AnalyzeImage() error = %v", err)
+	}
+	if third.Score != first.Score {
+		t.Errorf("修改时间变化后 Score = %v, want 重新分析的 %v", third.Score, first.Score)
+	}
+}
@@ -0,0 +1,118 @@
+// internal/services/image_crop.go
+package services
+
+import (
+	"image"
+	"math"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// detectFocalPoint 以能量图的重心作为画面主体位置。
+// 每个采样点的能量为局部梯度加上与整体平均亮度的差，使纯色背景上的亮/暗主体整体（而不只是边缘）都有权重；
+// 只有高于平均能量的部分计入重心，避免大面积背景把重心拉回画面中央
+func (s *imageService) detectFocalPoint(img image.Image) models.FocalPoint {
+	center := models.FocalPoint{X: 0.5, Y: 0.5}
+
+	bounds := img.Bounds()
+	if bounds.Dx() < 2 || bounds.Dy() < 2 {
+		return center
+	}
+
+	type sample struct {
+		x, y, energy float64
+	}
+
+	mean := s.averageLuminance(img, bounds)
+	step := s.sampleStep(bounds)
+
+	var samples []sample
+	meanEnergy := 0.0
+	for y := bounds.Min.Y; y < bounds.Max.Y-1; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X-1; x += step {
+			current := luminanceAt(img, x, y)
+			energy := math.Abs(current-luminanceAt(img, x+1, y)) +
+				math.Abs(current-luminanceAt(img, x, y+1)) +
+				math.Abs(current-mean)
+			samples = append(samples, sample{
+				x:      float64(x-bounds.Min.X) + 0.5,
+				y:      float64(y-bounds.Min.Y) + 0.5,
+				energy: energy,
+			})
+			meanEnergy += energy
+		}
+	}
+	if len(samples) == 0 {
+		return center
+	}
+	meanEnergy /= float64(len(samples))
+
+	var sumX, sumY, total float64
+	for _, p := range samples {
+		weight := p.energy - meanEnergy
+		if weight <= 0 {
+			continue
+		}
+		sumX += weight * p.x
+		sumY += weight * p.y
+		total += weight
+	}
+
+	// 整张图没有变化时取中心
+	if total == 0 {
+		return center
+	}
+
+	return models.FocalPoint{
+		X: sumX / total / float64(bounds.Dx()),
+		Y: sumY / total / float64(bounds.Dy()),
+	}
+}
+
+// suggestCrops 为每个配置的宽高比给出能放下的最大裁剪框，尽量以主体为中心并限制在原图范围内。
+// 坐标为原图像素，width/height 为原图尺寸（分析时的图片可能经过缩放）
+func (s *imageService) suggestCrops(focal models.FocalPoint, width, height int) []models.CropSuggestion {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	var crops []models.CropSuggestion
+	for _, ratio := range s.config.Image.CropRatios {
+		rw, rh, err := config.ParseAspectRatio(ratio)
+		if err != nil {
+			continue
+		}
+
+		cropW, cropH := width, width*rh/rw
+		if cropH > height {
+			cropW, cropH = height*rw/rh, height
+		}
+		if cropW <= 0 || cropH <= 0 {
+			continue
+		}
+
+		x := clampInt(int(math.Round(focal.X*float64(width)))-cropW/2, 0, width-cropW)
+		y := clampInt(int(math.Round(focal.Y*float64(height)))-cropH/2, 0, height-cropH)
+
+		crops = append(crops, models.CropSuggestion{
+			Aspect: ratio,
+			X:      x,
+			Y:      y,
+			Width:  cropW,
+			Height: cropH,
+		})
+	}
+
+	return crops
+}
+
+func clampInt(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
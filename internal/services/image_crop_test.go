@@ -0,0 +1,80 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// brightSubjectScene 暗色背景上的一块亮色方块
+func brightSubjectScene(width, height int, subject image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{R: 20, G: 24, B: 30, A: 255}}, image.Point{}, draw.Src)
+	draw.Draw(img, subject, &image.Uniform{C: color.RGBA{R: 250, G: 240, B: 200, A: 255}}, image.Point{}, draw.Src)
+	return img
+}
+
+func TestDetectFocalPointFindsBrightSubject(t *testing.T) {
+	svc := NewImageService(testConfig(t)).(*imageService)
+	subject := image.Rect(300, 40, 340, 80)
+
+	focal := svc.detectFocalPoint(brightSubjectScene(400, 200, subject))
+
+	// 主体中心在 (320, 60)，即 (0.8, 0.3)
+	if math.Abs(focal.X-0.8) > 0.05 || math.Abs(focal.Y-0.3) > 0.05 {
+		t.Errorf("FocalPoint = (%.2f, %.2f), want 约 (0.80, 0.30)", focal.X, focal.Y)
+	}
+
+	plain := svc.detectFocalPoint(brightSubjectScene(100, 100, image.Rectangle{}))
+	if plain != (models.FocalPoint{X: 0.5, Y: 0.5}) {
+		t.Errorf("纯色图片 FocalPoint = %+v, want 中心", plain)
+	}
+}
+
+func TestSuggestedCropsKeepSubject(t *testing.T) {
+	subject := image.Rect(300, 40, 340, 80)
+	path := filepath.Join(t.TempDir(), "subject.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, brightSubjectScene(400, 200, subject)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cfg := testConfig(t)
+	cfg.Image.CropRatios = []string{"1:1", "9:16", "16:9"}
+	analysis, err := NewImageService(cfg).AnalyzeImage(path)
+	if err != nil {
+		t.Fatalf("AnalyzeImage() error = %v", err)
+	}
+
+	if len(analysis.Crops) != len(cfg.Image.CropRatios) {
+		t.Fatalf("Crops = %+v, want %d 个", analysis.Crops, len(cfg.Image.CropRatios))
+	}
+	bounds := image.Rect(0, 0, 400, 200)
+	for _, crop := range analysis.Crops {
+		rect := image.Rect(crop.X, crop.Y, crop.X+crop.Width, crop.Y+crop.Height)
+		if !subject.In(rect) {
+			t.Errorf("%s 裁剪框 %v 未包含主体 %v", crop.Aspect, rect, subject)
+		}
+		if !rect.In(bounds) {
+			t.Errorf("%s 裁剪框 %v 超出原图", crop.Aspect, rect)
+		}
+	}
+
+	want := map[string]image.Point{"1:1": {200, 200}, "9:16": {112, 200}, "16:9": {355, 200}}
+	for _, crop := range analysis.Crops {
+		if size := image.Pt(crop.Width, crop.Height); size != want[crop.Aspect] {
+			t.Errorf("%s 裁剪尺寸 = %v, want %v（能放下的最大框）", crop.Aspect, size, want[crop.Aspect])
+		}
+	}
+}
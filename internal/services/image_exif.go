@@ -0,0 +1,306 @@
+// internal/services/image_exif.go
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// EXIF 标签
+const (
+	exifTagMake             = 0x010F
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagGPSIFD           = 0x8825
+	exifTagDateTimeOriginal = 0x9003
+	exifTagOffsetOriginal   = 0x9011
+
+	gpsTagLatitudeRef  = 0x0001
+	gpsTagLatitude     = 0x0002
+	gpsTagLongitudeRef = 0x0003
+	gpsTagLongitude    = 0x0004
+)
+
+// EXIF 数据类型
+const (
+	exifTypeASCII    = 2
+	exifTypeShort    = 3
+	exifTypeLong     = 4
+	exifTypeRational = 5
+)
+
+var errNoEXIF = errors.New("no exif")
+
+// imageEXIF 从 EXIF 中读取的拍摄信息
+type imageEXIF struct {
+	make        string
+	model       string
+	orientation int
+	capturedAt  time.Time
+	gps         *models.GPSLocation
+}
+
+// applyEXIF 将 JPEG 的 EXIF 拍摄信息写入图片信息。没有 EXIF 或解析失败时不修改，也不报错；
+// 方向为旋转90/270度（5-8）时交换宽高，使其与实际显示方向一致
+func applyEXIF(info *models.Image, imagePath string) {
+	if info.Format != "jpeg" {
+		return
+	}
+	exif, err := readJPEGEXIF(imagePath)
+	if err != nil {
+		return
+	}
+
+	info.CameraMake = exif.make
+	info.CameraModel = exif.model
+	info.Orientation = exif.orientation
+	info.GPSLocation = exif.gps
+	if !exif.capturedAt.IsZero() {
+		capturedAt := exif.capturedAt
+		info.CapturedAt = &capturedAt
+	}
+	if exifRotated(exif.orientation) {
+		info.Width, info.Height = info.Height, info.Width
+	}
+}
+
+// exifRotated 方向值5-8表示图片需旋转90或270度显示
+func exifRotated(orientation int) bool {
+	return orientation >= 5 && orientation <= 8
+}
+
+// readJPEGEXIF 读取 JPEG 文件 APP1 段中的 EXIF，在图像数据（SOS）之前停止
+func readJPEGEXIF(imagePath string) (imageEXIF, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return imageEXIF{}, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	var soi [2]byte
+	if _, err := io.ReadFull(r, soi[:]); err != nil || soi != [2]byte{0xFF, 0xD8} {
+		return imageEXIF{}, errNoEXIF
+	}
+
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:2]); err != nil {
+			return imageEXIF{}, errNoEXIF
+		}
+		if header[0] != 0xFF {
+			return imageEXIF{}, errNoEXIF
+		}
+		marker := header[1]
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			continue // 无长度字段的标记
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return imageEXIF{}, errNoEXIF
+		}
+
+		if _, err := io.ReadFull(r, header[2:]); err != nil {
+			return imageEXIF{}, errNoEXIF
+		}
+		length := int(binary.BigEndian.Uint16(header[2:])) - 2
+		if length < 0 {
+			return imageEXIF{}, errNoEXIF
+		}
+		segment := make([]byte, length)
+		if _, err := io.ReadFull(r, segment); err != nil {
+			return imageEXIF{}, errNoEXIF
+		}
+
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseEXIF(segment[6:])
+		}
+	}
+}
+
+// tiffReader 按 TIFF 头声明的字节序读取 EXIF 数据，越界时返回错误而不是 panic
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte // 值的原始字节
+}
+
+var exifTypeSizes = map[uint16]int{1: 1, exifTypeASCII: 1, exifTypeShort: 2, exifTypeLong: 4, exifTypeRational: 8, 7: 1, 9: 4, 10: 8}
+
+// parseEXIF 解析 TIFF 结构的 EXIF 数据
+func parseEXIF(data []byte) (imageEXIF, error) {
+	if len(data) < 8 {
+		return imageEXIF{}, errNoEXIF
+	}
+	t := tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return imageEXIF{}, errNoEXIF
+	}
+	if t.order.Uint16(data[2:]) != 42 {
+		return imageEXIF{}, errNoEXIF
+	}
+
+	ifd0, err := t.readIFD(t.order.Uint32(data[4:]))
+	if err != nil {
+		return imageEXIF{}, err
+	}
+
+	exif := imageEXIF{
+		make:        t.ascii(ifd0[exifTagMake]),
+		model:       t.ascii(ifd0[exifTagModel]),
+		orientation: int(t.uint(ifd0[exifTagOrientation])),
+	}
+
+	dateTime, offset := t.ascii(ifd0[exifTagDateTime]), ""
+	if entry, ok := ifd0[exifTagExifIFD]; ok {
+		if sub, err := t.readIFD(t.uint(entry)); err == nil {
+			if original := t.ascii(sub[exifTagDateTimeOriginal]); original != "" {
+				dateTime = original
+			}
+			offset = t.ascii(sub[exifTagOffsetOriginal])
+		}
+	}
+	exif.capturedAt = parseEXIFTime(dateTime, offset)
+
+	if entry, ok := ifd0[exifTagGPSIFD]; ok {
+		if gps, err := t.readIFD(t.uint(entry)); err == nil {
+			exif.gps = t.gpsLocation(gps)
+		}
+	}
+
+	return exif, nil
+}
+
+// readIFD 读取一个 IFD 的全部条目
+func (t tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	// 用减法比较边界，偏移接近 uint32 上限时也不会溢出
+	if uint64(offset)+2 > uint64(len(t.data)) {
+		return nil, errNoEXIF
+	}
+	start := int(offset)
+	count := int(t.order.Uint16(t.data[start:]))
+	if count*12 > len(t.data)-start-2 {
+		return nil, errNoEXIF
+	}
+
+	entries := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		raw := t.data[start+2+i*12:]
+		tag := t.order.Uint16(raw)
+		entry := ifdEntry{typ: t.order.Uint16(raw[2:]), count: t.order.Uint32(raw[4:])}
+
+		size, ok := exifTypeSizes[entry.typ]
+		if !ok || entry.count > uint32(len(t.data)) {
+			continue
+		}
+		total := size * int(entry.count)
+		if total <= 4 {
+			entry.value = raw[8 : 8+total]
+		} else {
+			valueOffset := uint64(t.order.Uint32(raw[8:]))
+			if valueOffset+uint64(total) > uint64(len(t.data)) {
+				continue
+			}
+			entry.value = t.data[valueOffset : valueOffset+uint64(total)]
+		}
+		entries[tag] = entry
+	}
+	return entries, nil
+}
+
+func (t tiffReader) ascii(entry ifdEntry) string {
+	if entry.typ != exifTypeASCII {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+// uint 读取 SHORT 或 LONG 类型的第一个值
+func (t tiffReader) uint(entry ifdEntry) uint32 {
+	switch {
+	case entry.typ == exifTypeShort && len(entry.value) >= 2:
+		return uint32(t.order.Uint16(entry.value))
+	case entry.typ == exifTypeLong && len(entry.value) >= 4:
+		return t.order.Uint32(entry.value)
+	}
+	return 0
+}
+
+// rationals 读取 RATIONAL 类型的全部值
+func (t tiffReader) rationals(entry ifdEntry) []float64 {
+	if entry.typ != exifTypeRational {
+		return nil
+	}
+	values := make([]float64, 0, len(entry.value)/8)
+	for i := 0; i+8 <= len(entry.value); i += 8 {
+		num, den := t.order.Uint32(entry.value[i:]), t.order.Uint32(entry.value[i+4:])
+		if den == 0 {
+			return nil
+		}
+		values = append(values, float64(num)/float64(den))
+	}
+	return values
+}
+
+// gpsLocation 将度分秒换算为十进制经纬度，南纬、西经为负
+func (t tiffReader) gpsLocation(gps map[uint16]ifdEntry) *models.GPSLocation {
+	lat, lon := t.rationals(gps[gpsTagLatitude]), t.rationals(gps[gpsTagLongitude])
+	if len(lat) != 3 || len(lon) != 3 {
+		return nil
+	}
+
+	latitude := lat[0] + lat[1]/60 + lat[2]/3600
+	longitude := lon[0] + lon[1]/60 + lon[2]/3600
+	if t.ascii(gps[gpsTagLatitudeRef]) == "S" {
+		latitude = -latitude
+	}
+	if t.ascii(gps[gpsTagLongitudeRef]) == "W" {
+		longitude = -longitude
+	}
+	if math.Abs(latitude) > 90 || math.Abs(longitude) > 180 {
+		return nil
+	}
+
+	return &models.GPSLocation{
+		Latitude:  math.Round(latitude*1e6) / 1e6,
+		Longitude: math.Round(longitude*1e6) / 1e6,
+	}
+}
+
+// parseEXIFTime 解析 "2006:01:02 15:04:05" 格式的拍摄时间；
+// 有 OffsetTimeOriginal（如 "+08:00"）时按该时区，否则按UTC
+func parseEXIFTime(value, offset string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t
+		}
+	}
+	t, err := time.Parse("2006:01:02 15:04:05", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
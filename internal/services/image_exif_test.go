@@ -0,0 +1,228 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// tiffEntry 测试用 EXIF 条目，value 为按字节序编码好的值
+type tiffEntry struct {
+	tag, typ uint16
+	count    uint32
+	value    []byte
+}
+
+// buildIFD 编码位于 offset 处的 IFD，超过4字节的值紧跟在 IFD 之后
+func buildIFD(order binary.ByteOrder, offset int, entries []tiffEntry) []byte {
+	var head, data bytes.Buffer
+	dataStart := offset + 2 + len(entries)*12 + 4
+	binary.Write(&head, order, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(&head, order, e.tag)
+		binary.Write(&head, order, e.typ)
+		binary.Write(&head, order, e.count)
+		if len(e.value) <= 4 {
+			field := make([]byte, 4)
+			copy(field, e.value)
+			head.Write(field)
+			continue
+		}
+		binary.Write(&head, order, uint32(dataStart+data.Len()))
+		data.Write(e.value)
+	}
+	binary.Write(&head, order, uint32(0)) // 没有下一个 IFD
+	return append(head.Bytes(), data.Bytes()...)
+}
+
+func asciiEntry(tag uint16, s string) tiffEntry {
+	return tiffEntry{tag, exifTypeASCII, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+func shortEntry(order binary.ByteOrder, tag uint16, v uint16) tiffEntry {
+	b := make([]byte, 2)
+	order.PutUint16(b, v)
+	return tiffEntry{tag, exifTypeShort, 1, b}
+}
+
+func longEntry(order binary.ByteOrder, tag uint16, v uint32) tiffEntry {
+	b := make([]byte, 4)
+	order.PutUint32(b, v)
+	return tiffEntry{tag, exifTypeLong, 1, b}
+}
+
+// dmsEntry 度分秒形式的 GPS 坐标，秒保留两位小数
+func dmsEntry(order binary.ByteOrder, tag uint16, deg, min uint32, sec float64) tiffEntry {
+	b := make([]byte, 24)
+	for i, r := range [][2]uint32{{deg, 1}, {min, 1}, {uint32(sec * 100), 100}} {
+		order.PutUint32(b[i*8:], r[0])
+		order.PutUint32(b[i*8+4:], r[1])
+	}
+	return tiffEntry{tag, exifTypeRational, 3, b}
+}
+
+// sampleEXIF 佳能相机、指定方向、东八区拍摄于上海的 EXIF（TIFF 结构）
+func sampleEXIF(order binary.ByteOrder, orientation uint16) []byte {
+	header := make([]byte, 8)
+	if order == binary.BigEndian {
+		copy(header, "MM")
+	} else {
+		copy(header, "II")
+	}
+	order.PutUint16(header[2:], 42)
+	order.PutUint32(header[4:], 8)
+
+	ifd0 := func(exifOffset, gpsOffset uint32) []tiffEntry {
+		return []tiffEntry{
+			asciiEntry(exifTagMake, "Canon"),
+			asciiEntry(exifTagModel, "Canon EOS R6"),
+			shortEntry(order, exifTagOrientation, orientation),
+			asciiEntry(exifTagDateTime, "2024:05:02 09:00:00"),
+			longEntry(order, exifTagExifIFD, exifOffset),
+			longEntry(order, exifTagGPSIFD, gpsOffset),
+		}
+	}
+	sub := []tiffEntry{
+		asciiEntry(exifTagDateTimeOriginal, "2024:05:01 18:30:00"),
+		asciiEntry(exifTagOffsetOriginal, "+08:00"),
+	}
+	gps := []tiffEntry{
+		asciiEntry(gpsTagLatitudeRef, "N"),
+		dmsEntry(order, gpsTagLatitude, 31, 13, 48.6),
+		asciiEntry(gpsTagLongitudeRef, "E"),
+		dmsEntry(order, gpsTagLongitude, 121, 28, 22.44),
+	}
+
+	// 各 IFD 的长度与指针的值无关，先用占位值算出子 IFD 的位置
+	exifOffset := 8 + len(buildIFD(order, 8, ifd0(0, 0)))
+	subIFD := buildIFD(order, exifOffset, sub)
+	gpsOffset := exifOffset + len(subIFD)
+
+	tiff := append(header, buildIFD(order, 8, ifd0(uint32(exifOffset), uint32(gpsOffset)))...)
+	tiff = append(tiff, subIFD...)
+	return append(tiff, buildIFD(order, gpsOffset, gps)...)
+}
+
+// writeJPEG 写入 width x height 的 JPEG，exif 不为空时插入 APP1 段
+func writeJPEG(t *testing.T, dir, name string, width, height int, exif []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	if exif != nil {
+		payload := append([]byte("Exif\x00\x00"), exif...)
+		segment := []byte{0xFF, 0xE1, 0, 0}
+		binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+		segment = append(segment, payload...)
+		data = append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetImageInfoEXIF(t *testing.T) {
+	svc := NewImageService(testConfig(t))
+	dir := t.TempDir()
+	wantTime := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		exif          []byte
+		width, height int
+		orientation   int
+	}{
+		{"big endian upright", sampleEXIF(binary.BigEndian, 1), 64, 32, 1},
+		// 方向6表示顺时针旋转90度显示，宽高交换
+		{"little endian rotated", sampleEXIF(binary.LittleEndian, 6), 32, 64, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := svc.GetImageInfo(writeJPEG(t, dir, tt.name+".jpg", 64, 32, tt.exif))
+			if err != nil {
+				t.Fatalf("GetImageInfo() error = %v", err)
+			}
+			if info.Width != tt.width || info.Height != tt.height || info.Orientation != tt.orientation {
+				t.Errorf("宽x高/方向 = %dx%d/%d, want %dx%d/%d", info.Width, info.Height, info.Orientation, tt.width, tt.height, tt.orientation)
+			}
+			if info.CameraMake != "Canon" || info.CameraModel != "Canon EOS R6" {
+				t.Errorf("相机 = %q %q, want Canon / Canon EOS R6", info.CameraMake, info.CameraModel)
+			}
+			// 优先使用 DateTimeOriginal，并按 OffsetTimeOriginal 换算时区
+			if info.CapturedAt == nil || !info.CapturedAt.Equal(wantTime) {
+				t.Errorf("CapturedAt = %v, want %v", info.CapturedAt, wantTime)
+			}
+			if gps := info.GPSLocation; gps == nil || gps.Latitude != 31.230167 || gps.Longitude != 121.4729 {
+				t.Errorf("GPSLocation = %+v, want 31.230167, 121.4729", gps)
+			}
+		})
+	}
+}
+
+func TestGetImageInfoWithoutEXIF(t *testing.T) {
+	svc := NewImageService(testConfig(t))
+	dir := t.TempDir()
+
+	for name, exif := range map[string][]byte{
+		"plain.jpg":   nil,
+		"corrupt.jpg": []byte("MM\x00\x2a\xff\xff\xff\xff"), // IFD 偏移越界
+	} {
+		info, err := svc.GetImageInfo(writeJPEG(t, dir, name, 64, 32, exif))
+		if err != nil {
+			t.Fatalf("%s: GetImageInfo() error = %v", name, err)
+		}
+		if info.Width != 64 || info.Height != 32 || info.CameraModel != "" || info.CapturedAt != nil || info.GPSLocation != nil || info.Orientation != 0 {
+			t.Errorf("%s: info = %+v, want 只有基本信息", name, info)
+		}
+	}
+}
+
+func FuzzParseEXIF(f *testing.F) {
+	// sampleEXIF 中 IFD0 位于偏移8：2字节条目数，之后每个条目12字节（标签、类型、个数、值或值的偏移）
+	const ifd0 = 8
+	entry := func(i int) int { return ifd0 + 2 + i*12 }
+	patch := func(order binary.ByteOrder, at int, v uint32) []byte {
+		data := sampleEXIF(order, 6)
+		order.PutUint32(data[at:], v)
+		return data
+	}
+
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		valid := sampleEXIF(order, 6)
+		f.Add(valid)
+		f.Add(valid[:len(valid)/2])                  // 截断在值区域中
+		f.Add(patch(order, 4, 0xFFFFFFFF))           // IFD0 偏移越界
+		f.Add(patch(order, 4, uint32(len(valid)-1))) // IFD0 只剩1个字节
+		f.Add(patch(order, entry(0)+4, 0xFFFFFFFF))  // Make 的个数超大
+		f.Add(patch(order, entry(0)+8, 0xFFFFFFF0))  // Make 的值偏移越界
+		f.Add(patch(order, entry(4)+8, 0x7FFFFFFF))  // Exif 子 IFD 偏移越界
+		f.Add(patch(order, entry(5)+8, ifd0))        // GPS IFD 指回 IFD0
+		f.Add(patch(order, entry(5)+4, 0))           // GPS 指针个数为0
+
+		huge := sampleEXIF(order, 6)
+		order.PutUint16(huge[ifd0:], 0xFFFF) // IFD0 条目数超出数据长度
+		f.Add(huge)
+	}
+	f.Add([]byte("MM\x00\x2a\xff\xff\xff\xff"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		exif, err := parseEXIF(data)
+		if err != nil {
+			return
+		}
+		if gps := exif.gps; gps != nil && (gps.Latitude < -90 || gps.Latitude > 90 || gps.Longitude < -180 || gps.Longitude > 180) {
+			t.Errorf("GPSLocation = %+v, want 有效经纬度", gps)
+		}
+	})
+}
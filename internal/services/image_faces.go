@@ -0,0 +1,95 @@
+// internal/services/image_faces.go
+package services
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"sync"
+
+	pigo "github.com/esimov/pigo/core"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// 人脸检测前把图片长边缩小到该值，控制大图的检测耗时
+const faceMaxDimension = 640
+
+// 重叠检测框的合并阈值（交并比）
+const faceClusterIoU = 0.2
+
+// faceDetector 人脸检测器，第一次检测时才加载级联文件；加载失败时只提示一次，之后不再检测
+type faceDetector struct {
+	cfg        config.FaceConfig
+	once       sync.Once
+	classifier *pigo.Pigo
+}
+
+// newFaceDetector 未开启人脸检测时返回 nil
+func newFaceDetector(cfg config.FaceConfig) *faceDetector {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &faceDetector{cfg: cfg}
+}
+
+// load 读取并解析级联文件
+func (d *faceDetector) load() (*pigo.Pigo, error) {
+	data, err := os.ReadFile(d.cfg.Cascade)
+	if err != nil {
+		return nil, err
+	}
+	classifier, err := pigo.NewPigo().Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("解析级联文件失败: %w", err)
+	}
+	return classifier, nil
+}
+
+// Count 图片中的人脸数，检测器不可用时返回0。分类器只读，可在多个goroutine中同时使用
+func (d *faceDetector) Count(img image.Image) int {
+	d.once.Do(func() {
+		classifier, err := d.load()
+		if err != nil {
+			log.Printf("人脸级联文件 %s 不可用，跳过人脸检测: %v", d.cfg.Cascade, err)
+			return
+		}
+		d.classifier = classifier
+	})
+	if d.classifier == nil {
+		return 0
+	}
+
+	small := toNRGBA(downscale(img, faceMaxDimension))
+	width, height := small.Bounds().Dx(), small.Bounds().Dy()
+	maxSize := width
+	if height < maxSize {
+		maxSize = height
+	}
+	if maxSize < d.cfg.MinSize {
+		return 0
+	}
+
+	params := pigo.CascadeParams{
+		MinSize:     d.cfg.MinSize,
+		MaxSize:     maxSize,
+		ShiftFactor: 0.1,
+		ScaleFactor: 1.1,
+		ImageParams: pigo.ImageParams{
+			Pixels: pigo.RgbToGrayscale(small),
+			Rows:   height,
+			Cols:   width,
+			Dim:    width,
+		},
+	}
+	detections := d.classifier.ClusterDetections(d.classifier.RunCascade(params, 0), faceClusterIoU)
+
+	count := 0
+	for _, detection := range detections {
+		if float64(detection.Q) >= d.cfg.MinQuality {
+			count++
+		}
+	}
+	return count
+}
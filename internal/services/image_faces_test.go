@@ -0,0 +1,95 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+// testCascade 测试用的 pigo 人脸级联文件，来源见 testdata/README.md
+const testCascade = "testdata/facefinder"
+
+// landscapeScene 没有人物的风景：天空渐变、远山、草地和太阳
+func landscapeScene(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	horizon := height * 3 / 5
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var c color.RGBA
+			switch {
+			case (x-width*3/4)*(x-width*3/4)+(y-height/5)*(y-height/5) < (height/10)*(height/10):
+				c = color.RGBA{R: 255, G: 220, B: 120, A: 255}
+			case y > horizon:
+				c = color.RGBA{R: 60, G: uint8(120 + 60*(y-horizon)/(height-horizon)), B: 50, A: 255}
+			case y > horizon-(x%(width/3))*height/(3*width)-height/10:
+				c = color.RGBA{R: 90, G: 100, B: 120, A: 255}
+			default:
+				c = color.RGBA{R: uint8(120 + 80*y/horizon), G: uint8(170 + 50*y/horizon), B: 240, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFaceDetection(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      func(t *testing.T) string
+		wantFaces int
+	}{
+		{"人脸照片", func(t *testing.T) string { return "testdata/face.jpg" }, 1},
+		{"风景", func(t *testing.T) string {
+			return writeSceneJPEG(t, t.TempDir(), "landscape.jpg", landscapeScene(640, 400), 90)
+		}, 0},
+	}
+
+	cfg := testConfig(t)
+	cfg.Image.Faces.Enabled = true
+	cfg.Image.Faces.Cascade = testCascade
+	svc := NewImageService(cfg)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := svc.AnalyzeImage(tt.path(t))
+			if err != nil {
+				t.Fatalf("AnalyzeImage() error = %v", err)
+			}
+			visual := analysis.VisualElements
+			if visual.FaceCount != tt.wantFaces || visual.HasFaces != (tt.wantFaces > 0) {
+				t.Errorf("FaceCount/HasFaces = %d/%v, want %d/%v", visual.FaceCount, visual.HasFaces, tt.wantFaces, tt.wantFaces > 0)
+			}
+		})
+	}
+}
+
+func TestFaceDetectionDisabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		cascade string
+	}{
+		// 默认配置不开启人脸检测
+		{name: "默认关闭", cascade: testCascade},
+		{name: "级联文件缺失", enabled: true, cascade: filepath.Join(t.TempDir(), "missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			if tt.enabled {
+				cfg.Image.Faces.Enabled = true
+			}
+			cfg.Image.Faces.Cascade = tt.cascade
+
+			analysis, err := NewImageService(cfg).AnalyzeImage("testdata/face.jpg")
+			if err != nil {
+				t.Fatalf("AnalyzeImage() error = %v, want 跳过人脸检测", err)
+			}
+			if analysis.VisualElements.FaceCount != 0 || analysis.VisualElements.HasFaces {
+				t.Errorf("FaceCount/HasFaces = %d/%v, want 0/false", analysis.VisualElements.FaceCount, analysis.VisualElements.HasFaces)
+			}
+		})
+	}
+}
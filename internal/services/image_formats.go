@@ -0,0 +1,82 @@
+// internal/services/image_formats.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// WebP 和 BMP 解码器，注册到 image.Decode
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+// 单张HEIC图片转换的超时时间
+const heicConvertTimeout = 30 * time.Second
+
+// isHEIC HEIC/HEIF 没有纯Go解码器，按扩展名识别后交给外部命令转换
+func isHEIC(imagePath string) bool {
+	switch strings.ToLower(filepath.Ext(imagePath)) {
+	case ".heic", ".heif":
+		return true
+	}
+	return false
+}
+
+// decodeHEIC 调用 image.heic_command（如 heif-convert，也可用 ImageMagick 的 magick）
+// 将HEIC转为临时PNG后解码。未配置或找不到命令时返回 ErrHEICUnavailable，由调用方跳过该图片
+func (s *imageService) decodeHEIC(imagePath string) (image.Image, error) {
+	command := s.config.Image.HEICCommand
+	if command == "" {
+		return nil, fmt.Errorf("%w: 未配置 image.heic_command", ErrHEICUnavailable)
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		return nil, fmt.Errorf("%w: 需要安装 %s: %w", ErrHEICUnavailable, command, err)
+	}
+
+	tmp, err := os.CreateTemp("", "content-analyzer-heic-*.png")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), heicConvertTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, imagePath, tmp.Name())
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: HEIC转换失败: %w: %s", ErrImageDecode, err, strings.TrimSpace(stderr.String()))
+	}
+
+	file, err := os.Open(tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
+	}
+	return img, nil
+}
+
+// isAnimatedWebP 检查 VP8X 扩展头的动画标志。x/image/webp 能读取动态WebP的尺寸，但不能解码像素
+func isAnimatedWebP(r io.ReaderAt) bool {
+	header := make([]byte, 21)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return false
+	}
+	return string(header[0:4]) == "RIFF" && string(header[8:12]) == "WEBP" &&
+		string(header[12:16]) == "VP8X" && header[20]&0x02 != 0
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/bmp"
+)
+
+// 1x1 的无损和有损 WebP
+const (
+	losslessWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+	lossyWebP    = "UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA"
+)
+
+// animatedWebPHeader 只有 VP8X 扩展头、动画标志置位、画布 2x2 的 WebP
+func animatedWebPHeader() []byte {
+	header := []byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00")
+	header = append(header, 0x02, 0, 0, 0)    // 动画标志
+	header = append(header, 1, 0, 0, 1, 0, 0) // 画布宽高减一，各3字节
+	return header
+}
+
+func writeBytes(t *testing.T, path string, data []byte) string {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func decodeBase64(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecodeWebPAndBMP(t *testing.T) {
+	dir := t.TempDir()
+
+	var bmpData bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	if err := bmp.Encode(&bmpData, img); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		file          string
+		data          []byte
+		wantFormat    string
+		width, height int
+	}{
+		{"无损WebP", "lossless.webp", decodeBase64(t, losslessWebP), "webp", 1, 1},
+		{"有损WebP", "lossy.webp", decodeBase64(t, lossyWebP), "webp", 1, 1},
+		{"BMP", "photo.bmp", bmpData.Bytes(), "bmp", 40, 30},
+	}
+
+	svc := NewImageService(testConfig(t)).(*imageService)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeBytes(t, filepath.Join(dir, tt.file), tt.data)
+
+			info, err := svc.GetImageInfo(path)
+			if err != nil {
+				t.Fatalf("GetImageInfo() error = %v", err)
+			}
+			if info.Format != tt.wantFormat || info.Width != tt.width || info.Height != tt.height {
+				t.Errorf("GetImageInfo() = %s %dx%d, want %s %dx%d", info.Format, info.Width, info.Height, tt.wantFormat, tt.width, tt.height)
+			}
+
+			decoded, err := svc.loadImage(path)
+			if err != nil {
+				t.Fatalf("loadImage() error = %v", err)
+			}
+			if b := decoded.Bounds(); b.Dx() != tt.width || b.Dy() != tt.height {
+				t.Errorf("loadImage() bounds = %v, want %dx%d", b, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestAnimatedWebPRejected(t *testing.T) {
+	path := writeBytes(t, filepath.Join(t.TempDir(), "anim.webp"), animatedWebPHeader())
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if !isAnimatedWebP(file) {
+		t.Fatal("isAnimatedWebP() = false, want true")
+	}
+
+	svc := NewImageService(testConfig(t)).(*imageService)
+	_, err = svc.loadImage(path)
+	if !errors.Is(err, ErrImageDecode) || !strings.Contains(err.Error(), "动态WebP") {
+		t.Errorf("loadImage() error = %v, want ErrImageDecode 且说明不支持动态WebP", err)
+	}
+
+	// 静态WebP不是动画
+	static, err := os.Open(writeBytes(t, filepath.Join(t.TempDir(), "static.webp"), decodeBase64(t, losslessWebP)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer static.Close()
+	if isAnimatedWebP(static) {
+		t.Error("静态WebP isAnimatedWebP() = true, want false")
+	}
+}
+
+func TestDecodeHEIC(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("需要 sh 脚本模拟 heif-convert")
+	}
+	dir := t.TempDir()
+
+	// 转换命令以 "命令 输入 输出" 调用，这里直接复制一张PNG到输出路径
+	pngPath := filepath.Join(dir, "source.png")
+	f, err := os.Create(pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, color.RGBA{uint8(x * 4), uint8(y * 5), 100, 255})
+		}
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	convert := writeBytes(t, filepath.Join(dir, "heif-convert"), []byte("#!/bin/sh\ncp '"+pngPath+"' \"$2\"\n"))
+	if err := os.Chmod(convert, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	heicPath := writeBytes(t, filepath.Join(dir, "photo.heic"), []byte("not really heic"))
+
+	tests := []struct {
+		name    string
+		command string
+		wantErr bool
+	}{
+		{"转换成功", convert, false},
+		{"找不到转换命令", filepath.Join(dir, "missing-heif-convert"), true},
+		{"未配置转换命令", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			cfg.Image.HEICCommand = tt.command
+			cfg.Image.SupportedExt = append(cfg.Image.SupportedExt, ".heic")
+			svc := NewImageService(cfg).(*imageService)
+
+			info, err := svc.GetImageInfo(heicPath)
+			if tt.wantErr {
+				// 缺少命令不是图片本身的问题，不按解码失败处理
+				if !errors.Is(err, ErrHEICUnavailable) || errors.Is(err, ErrImageDecode) {
+					t.Errorf("GetImageInfo() error = %v, want 只是 ErrHEICUnavailable", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetImageInfo() error = %v", err)
+			}
+			if info.Format != "heic" || info.Width != 64 || info.Height != 48 {
+				t.Errorf("GetImageInfo() = %s %dx%d, want heic 64x48", info.Format, info.Width, info.Height)
+			}
+
+			analysis, err := svc.AnalyzeImage(heicPath)
+			if err != nil {
+				t.Fatalf("AnalyzeImage() error = %v", err)
+			}
+			if analysis.Score <= 0 {
+				t.Errorf("AnalyzeImage().Score = %v, want 按解码后的像素评分", analysis.Score)
+			}
+		})
+	}
+}
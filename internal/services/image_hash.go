@@ -0,0 +1,134 @@
+// internal/services/image_hash.go
+package services
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// dHash 把图片缩小为 (dHashSize+1)×dHashSize 的灰度图，比较每行相邻像素的亮度，得到 dHashSize² 位指纹
+const dHashSize = 8
+
+// perceptualHash 计算图片的差值哈希（dHash），以16位十六进制表示。
+// 缩放、重新压缩、轻微调色后的同一张图哈希相近；旋转、翻转和大幅裁剪后不再相近
+func perceptualHash(img image.Image) string {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return ""
+	}
+
+	// 每个格子取区域平均亮度，比取单个像素更不受压缩噪点影响
+	var grid [dHashSize][dHashSize + 1]float64
+	for gy := 0; gy < dHashSize; gy++ {
+		y0 := bounds.Min.Y + gy*bounds.Dy()/dHashSize
+		y1 := bounds.Min.Y + (gy+1)*bounds.Dy()/dHashSize
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for gx := 0; gx <= dHashSize; gx++ {
+			x0 := bounds.Min.X + gx*bounds.Dx()/(dHashSize+1)
+			x1 := bounds.Min.X + (gx+1)*bounds.Dx()/(dHashSize+1)
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			grid[gy][gx] = cellLuminance(img, image.Rect(x0, y0, x1, y1))
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < dHashSize; y++ {
+		for x := 0; x < dHashSize; x++ {
+			hash <<= 1
+			if grid[y][x] < grid[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", hash)
+}
+
+// cellLuminance 区域的平均亮度，大区域每个方向最多取16个采样点
+func cellLuminance(img image.Image, cell image.Rectangle) float64 {
+	stepX := cell.Dx()/16 + 1
+	stepY := cell.Dy()/16 + 1
+
+	sum, n := 0.0, 0
+	for y := cell.Min.Y; y < cell.Max.Y; y += stepY {
+		for x := cell.Min.X; x < cell.Max.X; x += stepX {
+			sum += luminanceAt(img, x, y)
+			n++
+		}
+	}
+	return sum / float64(n)
+}
+
+// HammingDistance 两个感知哈希不同的位数，0表示几乎相同
+func HammingDistance(a, b string) (int, error) {
+	x, err := strconv.ParseUint(a, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("感知哈希格式错误 %q: %w", a, err)
+	}
+	y, err := strconv.ParseUint(b, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("感知哈希格式错误 %q: %w", b, err)
+	}
+	return bits.OnesCount64(x ^ y), nil
+}
+
+// FindDuplicateImages 把感知哈希汉明距离不超过 threshold 的图片归为一组（距离可传递：A近B、B近C时三者同组），
+// 返回每组图片在 analyses 中的下标，只返回至少两张图片的组。没有哈希的图片不参与比较
+func FindDuplicateImages(analyses []models.ImageAnalysis, threshold int) [][]int {
+	if threshold < 0 {
+		return nil
+	}
+
+	parent := make([]int, len(analyses))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range analyses {
+		if analyses[i].PerceptualHash == "" {
+			continue
+		}
+		for j := i + 1; j < len(analyses); j++ {
+			if analyses[j].PerceptualHash == "" {
+				continue
+			}
+			distance, err := HammingDistance(analyses[i].PerceptualHash, analyses[j].PerceptualHash)
+			if err != nil || distance > threshold {
+				continue
+			}
+			if ri, rj := find(i), find(j); ri != rj {
+				parent[rj] = ri
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range analyses {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	var groups [][]int
+	for _, group := range members {
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// gradientScene 对角渐变背景上有一块亮色矩形，亮度变化足够生成稳定的哈希
+func gradientScene(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8(255 * (x + y) / (width + height))
+			c := color.RGBA{R: v, G: 255 - v, B: 120, A: 255}
+			if x > width/5 && x < width*2/5 && y > height/4 && y < height*3/4 {
+				c = color.RGBA{R: 250, G: 250, B: 250, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// resizeNearest 最近邻缩放
+func resizeNearest(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dst.Set(x, y, src.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+	return dst
+}
+
+func writeSceneJPEG(t *testing.T, dir, name string, img image.Image, quality int) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := jpeg.Encode(f, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResizedAndReencodedImagesAreDuplicates(t *testing.T) {
+	cfg := testConfig(t)
+	dir := t.TempDir()
+	original := gradientScene(400, 300)
+	paths := []string{
+		writeScenePNG(t, dir, "original.png", original),
+		writeSceneJPEG(t, dir, "resized.jpg", resizeNearest(original, 200, 150), 60),
+		writeSceneJPEG(t, dir, "recompressed.jpg", original, 30),
+		// 水平翻转后不再相近
+		writeScenePNG(t, dir, "flipped.png", flipHorizontal(original)),
+	}
+
+	svc := NewImageService(cfg)
+	var analyses []models.ImageAnalysis
+	for _, path := range paths {
+		analysis, err := svc.AnalyzeImage(path)
+		if err != nil {
+			t.Fatalf("AnalyzeImage(%s) error = %v", filepath.Base(path), err)
+		}
+		if analysis.PerceptualHash == "" {
+			t.Fatalf("%s 没有感知哈希", filepath.Base(path))
+		}
+		analyses = append(analyses, analysis)
+	}
+
+	for i := 1; i <= 2; i++ {
+		distance, err := HammingDistance(analyses[0].PerceptualHash, analyses[i].PerceptualHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if distance > cfg.Image.DuplicateDistance {
+			t.Errorf("%s 与原图的汉明距离 = %d, want 不超过 %d", filepath.Base(paths[i]), distance, cfg.Image.DuplicateDistance)
+		}
+	}
+
+	groups := FindDuplicateImages(analyses, cfg.Image.DuplicateDistance)
+	if len(groups) != 1 || len(groups[0]) != 3 {
+		t.Fatalf("FindDuplicateImages() = %v, want 原图、缩放和重新压缩的图片为一组", groups)
+	}
+	for _, i := range groups[0] {
+		if i == 3 {
+			t.Errorf("翻转后的图片不应与原图同组: %v", groups)
+		}
+	}
+}
+
+func TestFindDuplicateImagesSkipsMissingHash(t *testing.T) {
+	analyses := []models.ImageAnalysis{
+		{PerceptualHash: "ffff0000ffff0000"},
+		{PerceptualHash: ""},
+		{PerceptualHash: "ffff0000ffff0001"},
+	}
+	groups := FindDuplicateImages(analyses, 1)
+	if len(groups) != 1 || len(groups[0]) != 2 || groups[0][0] != 0 || groups[0][1] != 2 {
+		t.Errorf("FindDuplicateImages() = %v, want [[0 2]]", groups)
+	}
+	if groups := FindDuplicateImages(analyses, -1); groups != nil {
+		t.Errorf("threshold 为负数时 = %v, want nil", groups)
+	}
+}
+
+func flipHorizontal(src *image.RGBA) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			dst.Set(bounds.Max.X-1-x+bounds.Min.X, y, src.At(x, y))
+		}
+	}
+	return dst
+}
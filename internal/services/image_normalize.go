@@ -0,0 +1,108 @@
+// internal/services/image_normalize.go
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+)
+
+// 归一化为JPEG时的压缩质量
+const normalizeJPEGQuality = 90
+
+// normalizeImage 按配置在内存中统一图片的像素格式和尺寸。
+// 调色板（GIF）、YCbCr（JPEG）、灰度等格式都会转换为 NRGBA，保证颜色和质量指标在不同来源之间可比。
+func (s *imageService) normalizeImage(img image.Image) (image.Image, error) {
+	cfg := s.config.Image.Normalize
+	if !cfg.Enabled {
+		return img, nil
+	}
+
+	if cfg.MaxDimension > 0 {
+		img = downscale(img, cfg.MaxDimension)
+	}
+
+	normalized := toNRGBA(img)
+
+	if cfg.Format == "jpeg" {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, normalized, &jpeg.Options{Quality: normalizeJPEGQuality}); err != nil {
+			return nil, fmt.Errorf("转换为JPEG失败: %w", err)
+		}
+		decoded, err := jpeg.Decode(&buf)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
+		}
+		normalized = toNRGBA(decoded)
+	}
+
+	return normalized, nil
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		return nrgba
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+	return dst
+}
+
+// downscale 长边超过 maxDim 时按区域平均等比缩小，不会放大
+func downscale(img image.Image, maxDim int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxDim && h <= maxDim {
+		return img
+	}
+
+	scale := float64(maxDim) / float64(w)
+	if h > w {
+		scale = float64(maxDim) / float64(h)
+	}
+	dw := atLeast(1, int(float64(w)*scale))
+	dh := atLeast(1, int(float64(h)*scale))
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0 := bounds.Min.Y + y*h/dh
+		sy1 := atLeast(sy0+1, bounds.Min.Y+(y+1)*h/dh)
+		for x := 0; x < dw; x++ {
+			sx0 := bounds.Min.X + x*w/dw
+			sx1 := atLeast(sx0+1, bounds.Min.X+(x+1)*w/dw)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := color.NRGBAModel.Convert(img.At(sx, sy)).(color.NRGBA)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n),
+				G: uint8(g / n),
+				B: uint8(b / n),
+				A: uint8(a / n),
+			})
+		}
+	}
+
+	return dst
+}
+
+func atLeast(min, v int) int {
+	if v < min {
+		return min
+	}
+	return v
+}
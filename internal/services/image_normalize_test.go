@@ -0,0 +1,109 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// palettedScene 左半红、右半蓝、中间一块白色的调色板图片
+func palettedScene(width, height int) *image.Paletted {
+	palette := color.Palette{
+		color.RGBA{R: 200, G: 30, B: 30, A: 255},
+		color.RGBA{R: 20, G: 40, B: 180, A: 255},
+		color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	}
+	img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			index := uint8(0)
+			if x >= width/2 {
+				index = 1
+			}
+			if x > width/3 && x < width*2/3 && y > height/3 && y < height*2/3 {
+				index = 2
+			}
+			img.SetColorIndex(x, y, index)
+		}
+	}
+	return img
+}
+
+func TestNormalizeConvertsPalettedImage(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.Normalize.Enabled = true
+	cfg.Image.Normalize.MaxDimension = 50
+	svc := NewImageService(cfg).(*imageService)
+
+	normalized, err := svc.normalizeImage(palettedScene(200, 100))
+	if err != nil {
+		t.Fatalf("normalizeImage() error = %v", err)
+	}
+	nrgba, ok := normalized.(*image.NRGBA)
+	if !ok {
+		t.Fatalf("归一化结果类型 = %T, want *image.NRGBA", normalized)
+	}
+	if got := nrgba.Bounds().Size(); got != image.Pt(50, 25) {
+		t.Errorf("尺寸 = %v, want 50x25（长边缩放到 max_dimension 并保持比例）", got)
+	}
+
+	cfg.Image.Normalize.Enabled = false
+	if img, _ := svc.normalizeImage(palettedScene(20, 10)); reflect.TypeOf(img) != reflect.TypeOf(&image.Paletted{}) {
+		t.Errorf("未开启归一化时不应转换, got %T", img)
+	}
+}
+
+func TestNormalizedGIFMatchesPNG(t *testing.T) {
+	dir := t.TempDir()
+	scene := palettedScene(120, 80)
+
+	gifPath := filepath.Join(dir, "scene.gif")
+	f, err := os.Create(gifPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gif.Encode(f, scene, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	pngPath := filepath.Join(dir, "scene.png")
+	f, err = os.Create(pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, toNRGBA(scene)); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cfg := testConfig(t)
+	cfg.Image.Normalize.Enabled = true
+	svc := NewImageService(cfg)
+
+	fromGIF, err := svc.AnalyzeImage(gifPath)
+	if err != nil {
+		t.Fatalf("AnalyzeImage(gif) error = %v", err)
+	}
+	fromPNG, err := svc.AnalyzeImage(pngPath)
+	if err != nil {
+		t.Fatalf("AnalyzeImage(png) error = %v", err)
+	}
+
+	// 归一化后颜色和质量指标只取决于像素，与文件格式无关
+	if !reflect.DeepEqual(fromGIF.QualityMetrics, fromPNG.QualityMetrics) {
+		t.Errorf("QualityMetrics gif = %+v, png = %+v", fromGIF.QualityMetrics, fromPNG.QualityMetrics)
+	}
+	// 不足5种颜色时主色不排序，只比较集合
+	sort.Strings(fromGIF.VisualElements.DominantColors)
+	sort.Strings(fromPNG.VisualElements.DominantColors)
+	if !reflect.DeepEqual(fromGIF.VisualElements, fromPNG.VisualElements) {
+		t.Errorf("Colors gif = %+v, png = %+v", fromGIF.VisualElements, fromPNG.VisualElements)
+	}
+}
@@ -2,34 +2,52 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
+	"log"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/RobinCoderZhao/content-analyzer/internal/config"
 	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/pipeline"
 )
 
+// 默认每次像素遍历的目标采样点数
+const defaultSampleTarget = 10000
+
 type ImageService interface {
 	AnalyzeImage(imagePath string) (models.ImageAnalysis, error)
 	ValidateImage(imagePath string) error
 	GetImageInfo(imagePath string) (models.Image, error)
 	BatchAnalyze(imagePaths []string) ([]models.ImageAnalysis, error)
+	// SaveInlineImage 将 base64 data URI 解码到临时文件，调用方负责删除
+	SaveInlineImage(dataURI string) (string, error)
+	// DownloadImage 将远程图片下载到临时文件，调用方负责删除
+	DownloadImage(imageURL string) (string, error)
 }
 
 type imageService struct {
 	config *config.Config
+	// 找不到OCR命令时只提示一次
+	ocrMissing sync.Once
+	// 未开启 image.cache 时为 nil
+	cache *imageCache
+	// 未开启 image.faces 时为 nil
+	faces *faceDetector
 }
 
 func NewImageService(cfg *config.Config) ImageService {
-	return &imageService{config: cfg}
+	return &imageService{config: cfg, cache: newImageCache(cfg.Image), faces: newFaceDetector(cfg.Image.Faces)}
 }
 
 func (s *imageService) AnalyzeImage(imagePath string) (models.ImageAnalysis, error) {
@@ -38,6 +56,13 @@ func (s *imageService) AnalyzeImage(imagePath string) (models.ImageAnalysis, err
 		return models.ImageAnalysis{}, err
 	}
 
+	// 文件未变化时直接使用缓存结果
+	if s.cache != nil {
+		if cached, ok := s.cache.Get(imagePath); ok {
+			return cached, nil
+		}
+	}
+
 	// 获取图片基本信息
 	imgInfo, err := s.GetImageInfo(imagePath)
 	if err != nil {
@@ -50,25 +75,65 @@ func (s *imageService) AnalyzeImage(imagePath string) (models.ImageAnalysis, err
 		return models.ImageAnalysis{}, fmt.Errorf("加载图片失败: %w", err)
 	}
 
+	// 文字识别的坐标基于原图，需在归一化之前进行；识别失败不影响其余分析
+	var textOverlay *models.ImageText
+	if s.config.Image.EnableOCR {
+		textOverlay, err = s.analyzeImageText(imagePath, img)
+		if errors.Is(err, ErrOCRUnavailable) {
+			s.ocrMissing.Do(func() { log.Printf("%v，跳过图片文字检查", err) })
+		} else if err != nil {
+			log.Printf("图片 %s 文字识别失败: %v", imagePath, err)
+		}
+	}
+
+	// 感知哈希基于原图计算，不受归一化配置影响
+	hash := perceptualHash(img)
+
+	// 按配置统一格式和尺寸（仅在内存中，不修改原图）
+	img, err = s.normalizeImage(img)
+	if err != nil {
+		return models.ImageAnalysis{}, fmt.Errorf("图片归一化失败: %w", err)
+	}
+
 	// 分析图片
 	analysis := models.ImageAnalysis{
 		Path:                imagePath,
+		Info:                imgInfo,
 		VisualElements:      s.analyzeVisualElements(img, imgInfo),
 		CompositionAnalysis: s.analyzeComposition(img, imgInfo),
 		QualityMetrics:      s.analyzeQuality(img, imgInfo),
 		StyleAnalysis:       s.analyzeStyle(img, imgInfo),
+		FocalPoint:          s.detectFocalPoint(img),
+		TextOverlay:         textOverlay,
+		PerceptualHash:      hash,
+	}
+	if textOverlay != nil {
+		analysis.VisualElements.HasText = true
+		analysis.ExtractedText = textOverlay.Text
 	}
+	// 画面主体和裁剪框基于文件中存储的像素方向，EXIF旋转过的图片需换回原始宽高
+	cropWidth, cropHeight := imgInfo.Width, imgInfo.Height
+	if exifRotated(imgInfo.Orientation) {
+		cropWidth, cropHeight = cropHeight, cropWidth
+	}
+	analysis.Crops = s.suggestCrops(analysis.FocalPoint, cropWidth, cropHeight)
 
 	// 计算综合得分
 	analysis.Score = s.calculateImageScore(analysis)
 
+	if s.cache != nil {
+		if err := s.cache.Put(imagePath, analysis); err != nil {
+			log.Printf("图片 %s 写入缓存失败: %v", imagePath, err)
+		}
+	}
+
 	return analysis, nil
 }
 
 func (s *imageService) ValidateImage(imagePath string) error {
 	// 检查文件是否存在
 	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
-		return fmt.Errorf("图片文件不存在: %s", imagePath)
+		return fmt.Errorf("%w: %s", ErrImageNotFound, imagePath)
 	}
 
 	// 检查文件扩展名
@@ -82,7 +147,7 @@ func (s *imageService) ValidateImage(imagePath string) error {
 	}
 
 	if !supported {
-		return fmt.Errorf("不支持的图片格式: %s", ext)
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, ext)
 	}
 
 	// 检查文件大小
@@ -92,8 +157,8 @@ func (s *imageService) ValidateImage(imagePath string) error {
 	}
 
 	if fileInfo.Size() > s.config.Image.MaxSize {
-		return fmt.Errorf("图片文件过大: %d bytes (最大: %d bytes)",
-			fileInfo.Size(), s.config.Image.MaxSize)
+		return fmt.Errorf("%w: %d bytes (最大: %d bytes)",
+			ErrImageTooLarge, fileInfo.Size(), s.config.Image.MaxSize)
 	}
 
 	return nil
@@ -106,10 +171,21 @@ func (s *imageService) GetImageInfo(imagePath string) (models.Image, error) {
 	}
 	defer file.Close()
 
-	// 获取图片配置信息
-	config, format, err := image.DecodeConfig(file)
-	if err != nil {
-		return models.Image{}, err
+	// 获取图片配置信息，HEIC只能整张转换后得到尺寸
+	var config image.Config
+	var format string
+	if isHEIC(imagePath) {
+		img, err := s.decodeHEIC(imagePath)
+		if err != nil {
+			return models.Image{}, err
+		}
+		config = image.Config{Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+		format = "heic"
+	} else {
+		config, format, err = image.DecodeConfig(file)
+		if err != nil {
+			return models.Image{}, fmt.Errorf("%w: %w", ErrImageDecode, err)
+		}
 	}
 
 	// 获取文件信息
@@ -118,30 +194,74 @@ func (s *imageService) GetImageInfo(imagePath string) (models.Image, error) {
 		return models.Image{}, err
 	}
 
-	return models.Image{
+	info := models.Image{
 		Path:   imagePath,
 		Width:  config.Width,
 		Height: config.Height,
 		Size:   fileInfo.Size(),
 		Format: format,
-	}, nil
+	}
+	applyEXIF(&info, imagePath)
+
+	return info, nil
 }
 
+// BatchAnalyze 用 image.batch_workers 个 worker 并发分析，结果与输入顺序一致。
+// 单张图片失败时记录在该结果的 Error 中，不影响其余图片；全部失败时才返回错误
 func (s *imageService) BatchAnalyze(imagePaths []string) ([]models.ImageAnalysis, error) {
-	var analyses []models.ImageAnalysis
+	if len(imagePaths) == 0 {
+		return nil, nil
+	}
 
-	for _, path := range imagePaths {
-		analysis, err := s.AnalyzeImage(path)
-		if err != nil {
-			return nil, fmt.Errorf("分析图片 %s 失败: %w", path, err)
+	workers := s.config.Image.BatchWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(imagePaths) {
+		workers = len(imagePaths)
+	}
+
+	errs := make([]error, len(imagePaths))
+	analyses, err := pipeline.Collect(len(imagePaths), workers*2, func(add func(int, models.ImageAnalysis) error) {
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					analysis, err := s.AnalyzeImage(imagePaths[i])
+					if err != nil {
+						errs[i] = fmt.Errorf("分析图片 %s 失败: %w", imagePaths[i], err)
+						analysis = models.ImageAnalysis{Path: imagePaths[i], Error: err.Error()}
+					}
+					add(i, analysis)
+				}
+			}()
+		}
+		for i := range imagePaths {
+			jobs <- i
 		}
-		analyses = append(analyses, analysis)
+		close(jobs)
+		wg.Wait()
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return analyses, nil
+	for _, err := range errs {
+		if err == nil {
+			return analyses, nil
+		}
+	}
+	return analyses, errors.Join(errs...)
 }
 
 func (s *imageService) loadImage(imagePath string) (image.Image, error) {
+	if isHEIC(imagePath) {
+		return s.decodeHEIC(imagePath)
+	}
+
 	file, err := os.Open(imagePath)
 	if err != nil {
 		return nil, err
@@ -150,17 +270,16 @@ func (s *imageService) loadImage(imagePath string) (image.Image, error) {
 
 	img, _, err := image.Decode(file)
 	if err != nil {
-		return nil, err
+		if isAnimatedWebP(file) {
+			return nil, fmt.Errorf("%w: 暂不支持动态WebP", ErrImageDecode)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
 	}
 
 	return img, nil
 }
 
 func (s *imageService) analyzeVisualElements(img image.Image, imgInfo models.Image) models.VisualElements {
-	bounds := img.Bounds()
-	width := bounds.Max.X - bounds.Min.X
-	height := bounds.Max.Y - bounds.Min.Y
-
 	// 分析主要颜色
 	dominantColors := s.extractDominantColors(img)
 
@@ -169,7 +288,7 @@ func (s *imageService) analyzeVisualElements(img image.Image, imgInfo models.Ima
 
 	// 检测对象和特征
 	hasText := s.detectText(img)
-	hasFaces := s.detectFaces(img) 
+	faceCount := s.detectFaces(img)
 	objectCount := s.countObjects(img)
 
 	return models.VisualElements{
@@ -178,14 +297,13 @@ func (s *imageService) analyzeVisualElements(img image.Image, imgInfo models.Ima
 		Contrast:       contrast,
 		Saturation:     saturation,
 		HasText:        hasText,
-		HasFaces:       hasFaces,
+		HasFaces:       faceCount > 0,
+		FaceCount:      faceCount,
 		ObjectCount:    objectCount,
 	}
 }
 
 func (s *imageService) analyzeComposition(img image.Image, imgInfo models.Image) models.CompositionAnalysis {
-	bounds := img.Bounds()
-
 	return models.CompositionAnalysis{
 		RuleOfThirds:  s.checkRuleOfThirds(img),
 		Symmetry:      s.checkSymmetry(img),
@@ -252,10 +370,11 @@ func (s *imageService) analyzeStyle(img image.Image, imgInfo models.Image) model
 func (s *imageService) extractDominantColors(img image.Image) []string {
 	colorMap := make(map[string]int)
 	bounds := img.Bounds()
+	step := s.sampleStep(bounds)
 	
-	// 采样分析（每10个像素采样一次以提高性能）
-	for y := bounds.Min.Y; y < bounds.Max.Y; y += 10 {
-		for x := bounds.Min.X; x < bounds.Max.X; x += 10 {
+	// 按自适应步长采样，控制大图的计算量
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
 			r, g, b, _ := img.At(x, y).RGBA()
 			// 转换为8位颜色
 			r8 := uint8(r >> 8)
@@ -306,10 +425,11 @@ func (s *imageService) analyzeColorMetrics(img image.Image) (brightness, contras
 	var minLum, maxLum float64 = 1.0, 0.0
 	var totalSat float64
 	pixelCount := 0
+	step := s.sampleStep(bounds)
 	
 	// 采样分析
-	for y := bounds.Min.Y; y < bounds.Max.Y; y += 5 {
-		for x := bounds.Min.X; x < bounds.Max.X; x += 5 {
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
 			r, g, b, _ := img.At(x, y).RGBA()
 			
 			// 转换为0-1范围
@@ -323,4 +443,395 @@ func (s *imageService) analyzeColorMetrics(img image.Image) (brightness, contras
 			
 			// 计算亮度 (相对亮度公式)
 			luminance := 0.299*rf + 0.587*gf + 0.114*bf
-			if luminance > maxL
+			if luminance > maxLum {
+				maxLum = luminance
+			}
+			if luminance < minLum {
+				minLum = luminance
+			}
+
+			// 计算饱和度 (HSL模型)
+			maxC := math.Max(rf, math.Max(gf, bf))
+			minC := math.Min(rf, math.Min(gf, bf))
+			if maxC > 0 {
+				totalSat += (maxC - minC) / maxC
+			}
+
+			pixelCount++
+		}
+	}
+
+	if pixelCount == 0 {
+		return 0, 0, 0
+	}
+
+	count := float64(pixelCount)
+	brightness = 0.299*(totalR/count) + 0.587*(totalG/count) + 0.114*(totalB/count)
+	contrast = maxLum - minLum
+	saturation = totalSat / count
+
+	return brightness, contrast, saturation
+}
+
+// 对象和特征检测相关方法
+func (s *imageService) detectText(img image.Image) bool {
+	// 文字检测需要OCR支持，未启用时返回false
+	return false
+}
+
+// detectFaces 图片中的人脸数，未开启 image.faces 或级联文件不可用时为0
+func (s *imageService) detectFaces(img image.Image) int {
+	if s.faces == nil {
+		return 0
+	}
+	return s.faces.Count(img)
+}
+
+func (s *imageService) countObjects(img image.Image) int {
+	// 基于边缘密度粗略估计对象数量
+	edgeDensity := s.calculateEdgeDensity(img)
+
+	if edgeDensity > 0.3 {
+		return 5
+	} else if edgeDensity > 0.15 {
+		return 3
+	} else if edgeDensity > 0.05 {
+		return 2
+	}
+
+	return 1
+}
+
+// 构图分析相关方法
+func (s *imageService) checkRuleOfThirds(img image.Image) bool {
+	// 检查三分线交点附近是否比画面平均更"活跃"
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width < 3 || height < 3 {
+		return false
+	}
+
+	avgEnergy := s.regionEnergy(img, bounds)
+	if avgEnergy == 0 {
+		return false
+	}
+
+	regionW := width / 6
+	regionH := height / 6
+	for _, fx := range []int{width / 3, width * 2 / 3} {
+		for _, fy := range []int{height / 3, height * 2 / 3} {
+			x := bounds.Min.X + fx
+			y := bounds.Min.Y + fy
+			region := image.Rect(x-regionW/2, y-regionH/2, x+regionW/2, y+regionH/2).Intersect(bounds)
+			if s.regionEnergy(img, region) > avgEnergy*1.2 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *imageService) checkSymmetry(img image.Image) bool {
+	// 比较左右两侧镜像像素的亮度差异
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width < 2 || height < 1 {
+		return false
+	}
+
+	var totalDiff float64
+	samples := 0
+	step := s.sampleStep(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := 0; x < width/2; x += step {
+			left := luminanceAt(img, bounds.Min.X+x, y)
+			right := luminanceAt(img, bounds.Max.X-1-x, y)
+			totalDiff += math.Abs(left - right)
+			samples++
+		}
+	}
+
+	if samples == 0 {
+		return false
+	}
+
+	return totalDiff/float64(samples) < 0.1
+}
+
+func (s *imageService) detectLeadingLines(img image.Image) bool {
+	// 简化处理：边缘较多且方向性明显时认为存在引导线
+	return s.calculateEdgeDensity(img) > 0.2
+}
+
+func (s *imageService) calculateFramingScore(img image.Image) float64 {
+	// 主体越集中在画面中央区域，取景得分越高
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width < 4 || height < 4 {
+		return 0.5
+	}
+
+	center := image.Rect(
+		bounds.Min.X+width/4, bounds.Min.Y+height/4,
+		bounds.Min.X+width*3/4, bounds.Min.Y+height*3/4,
+	)
+
+	totalEnergy := s.regionEnergy(img, bounds)
+	if totalEnergy == 0 {
+		return 0.5
+	}
+
+	ratio := s.regionEnergy(img, center) / totalEnergy
+	return math.Min(0.4+ratio*0.4, 1.0)
+}
+
+func (s *imageService) calculateBalanceScore(img image.Image) float64 {
+	// 比较左右两半的平均亮度，越接近越平衡
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	if width < 2 {
+		return 0.5
+	}
+
+	mid := bounds.Min.X + width/2
+	left := s.averageLuminance(img, image.Rect(bounds.Min.X, bounds.Min.Y, mid, bounds.Max.Y))
+	right := s.averageLuminance(img, image.Rect(mid, bounds.Min.Y, bounds.Max.X, bounds.Max.Y))
+
+	return 1.0 - math.Abs(left-right)
+}
+
+func (s *imageService) calculateFocusClarity(img image.Image) float64 {
+	// 中心区域的清晰度作为焦点清晰度
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	if width < 4 || height < 4 {
+		return s.calculateSharpness(img)
+	}
+
+	center := image.Rect(
+		bounds.Min.X+width/4, bounds.Min.Y+height/4,
+		bounds.Min.X+width*3/4, bounds.Min.Y+height*3/4,
+	)
+
+	return math.Min(s.regionEnergy(img, center)*4, 1.0)
+}
+
+// 质量分析相关方法
+func (s *imageService) calculateSharpness(img image.Image) float64 {
+	// 使用相邻像素亮度差的平均值近似清晰度
+	return math.Min(s.regionEnergy(img, img.Bounds())*4, 1.0)
+}
+
+func (s *imageService) calculateNoiseLevel(img image.Image) float64 {
+	// 统计孤立的亮度跳变点作为噪点
+	bounds := img.Bounds()
+	noisy := 0
+	samples := 0
+	step := s.sampleStep(bounds)
+
+	for y := bounds.Min.Y + 1; y < bounds.Max.Y-1; y += step {
+		for x := bounds.Min.X + 1; x < bounds.Max.X-1; x += step {
+			center := luminanceAt(img, x, y)
+			neighbors := (luminanceAt(img, x-1, y) + luminanceAt(img, x+1, y) +
+				luminanceAt(img, x, y-1) + luminanceAt(img, x, y+1)) / 4
+			if math.Abs(center-neighbors) > 0.2 {
+				noisy++
+			}
+			samples++
+		}
+	}
+
+	if samples == 0 {
+		return 0
+	}
+
+	return float64(noisy) / float64(samples)
+}
+
+func (s *imageService) calculateExposureScore(img image.Image) float64 {
+	// 平均亮度越接近0.5，曝光越合适
+	brightness := s.averageLuminance(img, img.Bounds())
+	return 1.0 - math.Abs(brightness-0.5)*2
+}
+
+// 风格分析相关方法
+func (s *imageService) determineStyle(img image.Image, imgInfo models.Image) string {
+	_, _, saturation := s.analyzeColorMetrics(img)
+	colors := s.extractDominantColors(img)
+
+	if len(colors) <= 2 {
+		return "minimalist"
+	}
+	if saturation < 0.15 {
+		return "vintage"
+	}
+
+	if imgInfo.Height > 0 {
+		aspectRatio := float64(imgInfo.Width) / float64(imgInfo.Height)
+		if aspectRatio > 1.5 {
+			return "landscape"
+		} else if aspectRatio < 0.8 {
+			return "portrait"
+		}
+	}
+
+	return "modern"
+}
+
+func (s *imageService) determineMood(img image.Image) string {
+	brightness, _, saturation := s.analyzeColorMetrics(img)
+
+	if brightness > 0.6 && saturation > 0.4 {
+		return "happy"
+	}
+	if brightness > 0.5 && saturation > 0.6 {
+		return "energetic"
+	}
+	if brightness < 0.3 {
+		return "dark"
+	}
+	if saturation < 0.2 {
+		return "calm"
+	}
+
+	return "neutral"
+}
+
+func (s *imageService) detectFilter(img image.Image) string {
+	_, contrast, saturation := s.analyzeColorMetrics(img)
+
+	if saturation < 0.05 {
+		return "blackwhite"
+	}
+	if contrast < 0.3 {
+		return "faded"
+	}
+	if saturation > 0.7 {
+		return "vivid"
+	}
+
+	return "none"
+}
+
+func (s *imageService) calculateConsistency(img image.Image) float64 {
+	// 单张图片无法比较，给出默认一致性
+	return 0.8
+}
+
+func (s *imageService) calculateImageScore(analysis models.ImageAnalysis) float64 {
+	score := 50.0 // 基础分
+
+	// 质量评分影响
+	score += analysis.QualityMetrics.OverallQuality * 25
+
+	// 构图评分影响
+	if analysis.CompositionAnalysis.RuleOfThirds {
+		score += 5
+	}
+	if analysis.CompositionAnalysis.Symmetry {
+		score += 5
+	}
+
+	score += analysis.CompositionAnalysis.BalanceScore * 5
+	score += analysis.CompositionAnalysis.FocusClarity * 5
+
+	// 视觉元素影响
+	if analysis.VisualElements.HasFaces {
+		score += 5
+	}
+
+	// 限制在0-100范围内
+	return math.Max(0, math.Min(score, 100))
+}
+
+// 像素工具方法
+func (s *imageService) calculateEdgeDensity(img image.Image) float64 {
+	bounds := img.Bounds()
+	edges := 0
+	samples := 0
+	step := s.sampleStep(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y-1; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X-1; x += step {
+			current := luminanceAt(img, x, y)
+			dx := math.Abs(current - luminanceAt(img, x+1, y))
+			dy := math.Abs(current - luminanceAt(img, x, y+1))
+			if dx+dy > 0.1 {
+				edges++
+			}
+			samples++
+		}
+	}
+
+	if samples == 0 {
+		return 0
+	}
+
+	return float64(edges) / float64(samples)
+}
+
+func (s *imageService) regionEnergy(img image.Image, region image.Rectangle) float64 {
+	var total float64
+	samples := 0
+	step := s.sampleStep(region)
+
+	for y := region.Min.Y; y < region.Max.Y-1; y += step {
+		for x := region.Min.X; x < region.Max.X-1; x += step {
+			current := luminanceAt(img, x, y)
+			total += math.Abs(current-luminanceAt(img, x+1, y)) +
+				math.Abs(current-luminanceAt(img, x, y+1))
+			samples++
+		}
+	}
+
+	if samples == 0 {
+		return 0
+	}
+
+	return total / float64(samples)
+}
+
+func (s *imageService) averageLuminance(img image.Image, region image.Rectangle) float64 {
+	var total float64
+	samples := 0
+	step := s.sampleStep(region)
+
+	for y := region.Min.Y; y < region.Max.Y; y += step {
+		for x := region.Min.X; x < region.Max.X; x += step {
+			total += luminanceAt(img, x, y)
+			samples++
+		}
+	}
+
+	if samples == 0 {
+		return 0
+	}
+
+	return total / float64(samples)
+}
+
+// sampleStep 根据区域面积计算采样步长，使每次遍历的采样点数
+// 接近配置的目标值：大图不会过慢，小图也不会采样不足
+func (s *imageService) sampleStep(region image.Rectangle) int {
+	target := s.config.Image.SampleTarget
+	if target <= 0 {
+		target = defaultSampleTarget
+	}
+
+	pixels := region.Dx() * region.Dy()
+	if pixels <= target {
+		return 1
+	}
+
+	return int(math.Ceil(math.Sqrt(float64(pixels) / float64(target))))
+}
+
+func luminanceAt(img image.Image, x, y int) float64 {
+	gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+	return float64(gray.Y) / 255.0
+}
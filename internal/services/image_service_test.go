@@ -0,0 +1,117 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countingImage 不保存像素，只统计 At 的调用次数，用来衡量一次遍历的采样点数
+type countingImage struct {
+	bounds image.Rectangle
+	calls  int
+}
+
+func (c *countingImage) ColorModel() color.Model { return color.GrayModel }
+func (c *countingImage) Bounds() image.Rectangle { return c.bounds }
+func (c *countingImage) At(x, y int) color.Color {
+	c.calls++
+	return color.Gray{Y: 128}
+}
+
+func TestSampleStepBoundsWork(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.SampleTarget = 10000
+	svc := NewImageService(cfg).(*imageService)
+
+	tests := []struct {
+		width, height int
+	}{
+		{120, 80},
+		{1000, 1000},
+		{4032, 3024},
+		{8000, 6000},
+		{20000, 20000},
+	}
+	for _, tt := range tests {
+		img := &countingImage{bounds: image.Rect(0, 0, tt.width, tt.height)}
+		svc.averageLuminance(img, img.bounds)
+
+		pixels := tt.width * tt.height
+		if pixels <= cfg.Image.SampleTarget {
+			// 小图逐像素采样
+			if img.calls != pixels {
+				t.Errorf("%dx%d: 采样 %d 次，小图应逐像素采样 %d 次", tt.width, tt.height, img.calls, pixels)
+			}
+			continue
+		}
+		// 向上取整的步长最多在每行每列多出一个采样点
+		if limit := cfg.Image.SampleTarget * 11 / 10; img.calls > limit {
+			t.Errorf("%dx%d: 采样 %d 次，超过上限 %d", tt.width, tt.height, img.calls, limit)
+		}
+		if img.calls < cfg.Image.SampleTarget/2 {
+			t.Errorf("%dx%d: 采样 %d 次，远少于目标 %d", tt.width, tt.height, img.calls, cfg.Image.SampleTarget)
+		}
+	}
+}
+
+func TestSampleStepUsesConfiguredTarget(t *testing.T) {
+	cfg := testConfig(t)
+	bounds := image.Rect(0, 0, 4000, 4000)
+
+	cfg.Image.SampleTarget = 1000
+	coarse := NewImageService(cfg).(*imageService).sampleStep(bounds)
+	cfg.Image.SampleTarget = 100000
+	fine := NewImageService(cfg).(*imageService).sampleStep(bounds)
+
+	if coarse <= fine {
+		t.Errorf("目标采样点越少步长应越大: target=1000 步长 %d, target=100000 步长 %d", coarse, fine)
+	}
+}
+
+func TestBatchAnalyzeMixedPaths(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.BatchWorkers = 3
+	svc := NewImageService(cfg)
+
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.png")
+	if err := os.WriteFile(corrupt, []byte("not a png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{
+		writeScenePNG(t, dir, "a.png", brightSubjectScene(60, 40, image.Rect(10, 10, 20, 20))),
+		filepath.Join(dir, "missing.png"),
+		writeScenePNG(t, dir, "b.png", brightSubjectScene(80, 40, image.Rect(50, 10, 70, 30))),
+		corrupt,
+		writeScenePNG(t, dir, "c.png", brightSubjectScene(40, 40, image.Rect(0, 0, 10, 10))),
+	}
+
+	analyses, err := svc.BatchAnalyze(paths)
+	if err != nil {
+		t.Fatalf("BatchAnalyze() error = %v, want 部分成功时不报错", err)
+	}
+	if len(analyses) != len(paths) {
+		t.Fatalf("结果数 = %d, want %d", len(analyses), len(paths))
+	}
+	wantFailed := map[int]bool{1: true, 3: true}
+	for i, analysis := range analyses {
+		// 结果与输入顺序一致，失败的图片记录原因
+		if analysis.Path != paths[i] {
+			t.Errorf("analyses[%d].Path = %s, want %s", i, analysis.Path, paths[i])
+		}
+		if failed := analysis.Error != ""; failed != wantFailed[i] {
+			t.Errorf("analyses[%d] Error = %q, want 失败 = %v", i, analysis.Error, wantFailed[i])
+		}
+		if !wantFailed[i] && analysis.Info.Width == 0 {
+			t.Errorf("analyses[%d] 缺少图片信息", i)
+		}
+	}
+
+	// 全部失败时返回合并的错误
+	if _, err := svc.BatchAnalyze([]string{paths[1], corrupt}); err == nil {
+		t.Error("全部失败时 BatchAnalyze() error = nil, want 错误")
+	}
+}
@@ -0,0 +1,195 @@
+// internal/services/image_text.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// 单张图片文字识别的超时时间
+const ocrTimeout = 30 * time.Second
+
+// ErrOCRUnavailable 启用了OCR但找不到 tesseract 命令
+var ErrOCRUnavailable = errors.New("OCR命令不可用")
+
+// ocrLine 识别出的一行文字及其在原图中的位置
+type ocrLine struct {
+	words []string
+	box   image.Rectangle
+}
+
+// analyzeImageText 识别图片中的文字，按行检查字号（行高占图片高度的比例）和与背景的对比度。
+// 没有识别到文字时返回 nil
+func (s *imageService) analyzeImageText(imagePath string, img image.Image) (*models.ImageText, error) {
+	lines, err := s.recognizeLines(imagePath)
+	if err != nil || len(lines) == 0 {
+		return nil, err
+	}
+
+	cfg := s.config.Image.OCR
+	imageHeight := float64(img.Bounds().Dy())
+	result := &models.ImageText{
+		LineCount:      len(lines),
+		MinHeightRatio: 1,
+		MinContrast:    21,
+	}
+
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, joinOCRWords(line.words))
+
+		heightRatio := float64(line.box.Dy()) / imageHeight
+		if heightRatio < cfg.MinTextHeight {
+			result.SmallLines++
+		}
+		result.MinHeightRatio = math.Min(result.MinHeightRatio, heightRatio)
+
+		contrast := s.textContrast(img, line.box)
+		if contrast < cfg.MinContrast {
+			result.LowContrastLines++
+		}
+		result.MinContrast = math.Min(result.MinContrast, contrast)
+	}
+	result.Text = strings.Join(texts, "\n")
+	result.MinHeightRatio = math.Round(result.MinHeightRatio*1000) / 1000
+	result.MinContrast = math.Round(result.MinContrast*100) / 100
+
+	// 字太小和对比度不足各占一半，按问题行的比例扣分
+	count := float64(result.LineCount)
+	result.Legibility = 100 - 50*float64(result.SmallLines)/count - 50*float64(result.LowContrastLines)/count
+
+	return result, nil
+}
+
+// recognizeLines 调用 tesseract 输出 TSV，按块、段落、行合并单词及其边界框
+func (s *imageService) recognizeLines(imagePath string) ([]ocrLine, error) {
+	cfg := s.config.Image.OCR
+	if _, err := exec.LookPath(cfg.Command); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrOCRUnavailable, cfg.Command)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ocrTimeout)
+	defer cancel()
+
+	args := []string{imagePath, "stdout"}
+	if cfg.Languages != "" {
+		args = append(args, "-l", cfg.Languages)
+	}
+	args = append(args, "tsv")
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cfg.Command, args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("文字识别失败: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseOCRLines(string(output), cfg.MinConfidence), nil
+}
+
+// parseOCRLines 解析 tesseract 的 TSV 输出，只保留置信度足够的单词（level 5）
+func parseOCRLines(tsv string, minConfidence float64) []ocrLine {
+	var lines []ocrLine
+	index := make(map[string]int)
+
+	for i, row := range strings.Split(tsv, "\n") {
+		fields := strings.Split(strings.TrimRight(row, "\r"), "\t")
+		if i == 0 || len(fields) < 12 || fields[0] != "5" {
+			continue
+		}
+
+		text := strings.TrimSpace(fields[11])
+		confidence, err := strconv.ParseFloat(fields[10], 64)
+		if text == "" || err != nil || confidence < minConfidence {
+			continue
+		}
+
+		var rect [4]int
+		valid := true
+		for j := range rect {
+			if rect[j], err = strconv.Atoi(fields[6+j]); err != nil {
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+		box := image.Rect(rect[0], rect[1], rect[0]+rect[2], rect[1]+rect[3])
+
+		key := strings.Join(fields[1:5], "/")
+		if j, ok := index[key]; ok {
+			lines[j].words = append(lines[j].words, text)
+			lines[j].box = lines[j].box.Union(box)
+			continue
+		}
+		index[key] = len(lines)
+		lines = append(lines, ocrLine{words: []string{text}, box: box})
+	}
+
+	return lines
+}
+
+// joinOCRWords 拉丁文单词之间加空格，中日韩文字直接相连
+func joinOCRWords(words []string) string {
+	var sb strings.Builder
+	for i, word := range words {
+		if i > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(words[i-1])
+			next, _ := utf8.DecodeRuneInString(word)
+			if !unicode.Is(unicode.Han, prev) && !unicode.Is(unicode.Han, next) {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(word)
+	}
+	return sb.String()
+}
+
+// textContrast 文字区域的 WCAG 对比度，以区域内较暗和较亮的像素（10%和90%分位）近似文字和背景
+func (s *imageService) textContrast(img image.Image, box image.Rectangle) float64 {
+	box = box.Intersect(img.Bounds())
+	if box.Empty() {
+		return 1
+	}
+
+	step := s.sampleStep(box)
+	var values []float64
+	for y := box.Min.Y; y < box.Max.Y; y += step {
+		for x := box.Min.X; x < box.Max.X; x += step {
+			values = append(values, relativeLuminance(img.At(x, y)))
+		}
+	}
+	sort.Float64s(values)
+
+	dark := values[len(values)/10]
+	light := values[len(values)*9/10]
+	return (light + 0.05) / (dark + 0.05)
+}
+
+// relativeLuminance WCAG 定义的相对亮度，先将 sRGB 转为线性值
+func relativeLuminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	linear := func(v uint32) float64 {
+		f := float64(v) / 65535
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// ocrFixture 两行文字的 tesseract TSV：大号标题和一行小字，小字中的低置信度单词应被丢弃
+const ocrFixture = `level	page_num	block_num	par_num	line_num	word_num	left	top	width	height	conf	text
+5	1	1	1	1	1	20	20	160	60	95	BIG
+5	1	1	1	1	2	200	20	160	60	95	SALE
+5	1	2	1	1	1	20	300	100	6	90	tiny
+5	1	2	1	1	2	130	300	100	6	30	noise
+`
+
+// fakeTesseract 写一个输出 ocrFixture 的脚本代替 tesseract
+func fakeTesseract(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("需要 sh 脚本模拟 tesseract")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ocr.tsv"), []byte(ocrFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "tesseract")
+	script := "#!/bin/sh\ncat '" + filepath.Join(dir, "ocr.tsv") + "'\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// stripeText 用竖条纹模拟一行文字，ink 为文字颜色，背景为白色
+func stripeText(img *image.RGBA, box image.Rectangle, ink color.Color) {
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			if x%4 < 2 {
+				img.Set(x, y, ink)
+			}
+		}
+	}
+}
+
+func TestAnalyzeImageTextSmallLowContrast(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.EnableOCR = true
+	cfg.Image.OCR.Command = fakeTesseract(t)
+	svc := NewImageService(cfg).(*imageService)
+
+	img := image.NewRGBA(image.Rect(0, 0, 400, 400))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	// 标题：黑字、行高占15%；小字：浅灰字、行高不到2%
+	stripeText(img, image.Rect(20, 20, 360, 80), color.Black)
+	stripeText(img, image.Rect(20, 300, 230, 306), color.Gray{Y: 0xE0})
+
+	overlay, err := svc.analyzeImageText("poster.png", img)
+	if err != nil {
+		t.Fatalf("analyzeImageText() error = %v", err)
+	}
+	if overlay == nil {
+		t.Fatal("analyzeImageText() = nil, want 识别结果")
+	}
+
+	if want := "BIG SALE\ntiny"; overlay.Text != want {
+		t.Errorf("Text = %q, want %q", overlay.Text, want)
+	}
+	if overlay.LineCount != 2 || overlay.SmallLines != 1 || overlay.LowContrastLines != 1 {
+		t.Errorf("LineCount/SmallLines/LowContrastLines = %d/%d/%d, want 2/1/1",
+			overlay.LineCount, overlay.SmallLines, overlay.LowContrastLines)
+	}
+	if overlay.MinHeightRatio != 0.015 {
+		t.Errorf("MinHeightRatio = %v, want 0.015", overlay.MinHeightRatio)
+	}
+	if overlay.MinContrast >= cfg.Image.OCR.MinContrast {
+		t.Errorf("MinContrast = %v, want 低于 %v", overlay.MinContrast, cfg.Image.OCR.MinContrast)
+	}
+	// 一半的行字太小、一半的行对比度不足
+	if overlay.Legibility != 50 {
+		t.Errorf("Legibility = %v, want 50", overlay.Legibility)
+	}
+}
+
+func TestAnalyzeImageTextMissingCommand(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.EnableOCR = true
+	cfg.Image.OCR.Command = filepath.Join(t.TempDir(), "no-such-tesseract")
+	svc := NewImageService(cfg).(*imageService)
+
+	_, err := svc.analyzeImageText("poster.png", image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	if !errors.Is(err, ErrOCRUnavailable) {
+		t.Errorf("error = %v, want %v", err, ErrOCRUnavailable)
+	}
+}
@@ -0,0 +1,74 @@
+// internal/services/inline_image.go
+package services
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// data URI 的媒体类型对应的扩展名，用于临时文件命名和格式校验
+var inlineImageExts = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/jpg":  ".jpg",
+	"image/gif":  ".gif",
+	"image/bmp":  ".bmp",
+	"image/webp": ".webp",
+	"image/heic": ".heic",
+}
+
+// IsDataURI 判断图片路径是否为内嵌的 data URI
+func IsDataURI(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), "data:")
+}
+
+// SaveInlineImage 解码 data:image/...;base64,... 并写入临时文件。
+// 解码后的字节数同样受 image.max_size 限制，base64 无效时返回 ErrInvalidInline 和 ErrImageDecode
+func (s *imageService) SaveInlineImage(dataURI string) (string, error) {
+	header, payload, ok := strings.Cut(strings.TrimSpace(dataURI), ",")
+	if !ok {
+		return "", fmt.Errorf("%w: %w: 缺少数据部分", ErrImageDecode, ErrInvalidInline)
+	}
+
+	mediaType, params, _ := strings.Cut(strings.TrimPrefix(strings.ToLower(header), "data:"), ";")
+	if !strings.Contains(params, "base64") {
+		return "", fmt.Errorf("%w: 仅支持 base64 编码的内嵌图片", ErrUnsupportedFormat)
+	}
+	ext, ok := inlineImageExts[mediaType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, mediaType)
+	}
+
+	// 去掉换行等空白，先按编码长度估算，避免解码超大数据
+	payload = strings.Join(strings.Fields(payload), "")
+	if size := int64(base64.StdEncoding.DecodedLen(len(payload))); size > s.config.Image.MaxSize+2 {
+		return "", fmt.Errorf("%w: 约 %d bytes (最大: %d bytes)", ErrImageTooLarge, size, s.config.Image.MaxSize)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		// 部分导出工具省略了末尾的填充
+		var rawErr error
+		if data, rawErr = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "=")); rawErr != nil {
+			return "", fmt.Errorf("%w: %w: %w", ErrImageDecode, ErrInvalidInline, err)
+		}
+	}
+	if int64(len(data)) > s.config.Image.MaxSize {
+		return "", fmt.Errorf("%w: %d bytes (最大: %d bytes)", ErrImageTooLarge, len(data), s.config.Image.MaxSize)
+	}
+
+	file, err := os.CreateTemp("", "content-analyzer-inline-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("写入临时文件失败: %w", err)
+	}
+
+	return file.Name(), nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestSaveInlineImage(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Image.MaxSize = 16
+	svc := NewImageService(cfg)
+
+	path, err := svc.SaveInlineImage("data:image/PNG;base64,\n aGVsbG8g\n d29ybGQ")
+	if err != nil {
+		t.Fatalf("SaveInlineImage() error = %v", err)
+	}
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("解码内容 = %q, want %q（应忽略换行并接受省略的填充）", data, "hello world")
+	}
+
+	tests := []struct {
+		name string
+		uri  string
+		want error
+	}{
+		{"invalid base64", "data:image/png;base64,!!!!", ErrInvalidInline},
+		{"missing payload", "data:image/png;base64", ErrInvalidInline},
+		{"not base64", "data:image/png,raw", ErrUnsupportedFormat},
+		{"unknown media type", "data:image/tiff;base64,aGVsbG8=", ErrUnsupportedFormat},
+		{"too large", "data:image/png;base64,aGVsbG8gd29ybGQsIGhlbGxvIHdvcmxk", ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.SaveInlineImage(tt.uri); !errors.Is(err, tt.want) {
+				t.Errorf("SaveInlineImage() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
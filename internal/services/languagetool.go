@@ -0,0 +1,120 @@
+// internal/services/languagetool.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// LanguageTool 调用 LanguageTool 服务（/v2/check）检查拼写和语法
+type LanguageTool struct {
+	endpoint string
+	language string
+	client   *http.Client
+}
+
+// NewLanguageTool 按 analysis.writing_check 创建客户端，未配置 languagetool_url 时返回nil
+func NewLanguageTool(cfg config.WritingCheckConfig) *LanguageTool {
+	if cfg.LanguageToolURL == "" {
+		return nil
+	}
+	return &LanguageTool{
+		endpoint: strings.TrimRight(cfg.LanguageToolURL, "/") + "/v2/check",
+		language: cfg.Language,
+		client:   &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+	}
+}
+
+// languageToolResponse /v2/check 的响应，只取用到的字段
+type languageToolResponse struct {
+	Matches []struct {
+		Message      string `json:"message"`
+		Offset       int    `json:"offset"` // UTF-16 单位
+		Length       int    `json:"length"`
+		Replacements []struct {
+			Value string `json:"value"`
+		} `json:"replacements"`
+		Rule struct {
+			ID string `json:"id"`
+		} `json:"rule"`
+	} `json:"matches"`
+}
+
+// languageToolMaxSuggestions 每处问题最多保留的修改建议
+const languageToolMaxSuggestions = 3
+
+// Check 检查文本，返回的位置已从 LanguageTool 的 UTF-16 位置换算为字符位置
+func (lt *LanguageTool) Check(ctx context.Context, text string) ([]models.WritingIssue, error) {
+	form := url.Values{"text": {text}, "language": {lt.language}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, lt.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := lt.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求 LanguageTool 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &APIError{Provider: "LanguageTool", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result languageToolResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析 LanguageTool 响应失败: %w", err)
+	}
+
+	runes := []rune(text)
+	toRune := utf16RuneIndex(runes)
+	var issues []models.WritingIssue
+	for _, match := range result.Matches {
+		start, end := toRune(match.Offset), toRune(match.Offset+match.Length)
+		issue := models.WritingIssue{
+			Offset:  start,
+			Length:  end - start,
+			Text:    string(runes[start:end]),
+			Message: match.Message,
+			Rule:    match.Rule.ID,
+			Source:  "languagetool",
+		}
+		for _, replacement := range match.Replacements {
+			if len(issue.Suggestions) == languageToolMaxSuggestions {
+				break
+			}
+			issue.Suggestions = append(issue.Suggestions, replacement.Value)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// utf16RuneIndex 返回把 UTF-16 位置换算为字符位置的函数，超出文本的位置按文本末尾处理
+func utf16RuneIndex(runes []rune) func(int) int {
+	// offsets[i] 为第 i 个字符之前的 UTF-16 单位数
+	offsets := make([]int, len(runes)+1)
+	for i, r := range runes {
+		n := 1
+		if r > 0xFFFF { // 补充平面字符（如emoji）占两个单位
+			n = 2
+		}
+		offsets[i+1] = offsets[i] + n
+	}
+	return func(unit int) int {
+		return sort.SearchInts(offsets[:len(runes)], unit)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+// languageToolMatch 构造 /v2/check 响应中的一处问题
+func languageToolMatch(message string, offset, length int, rule string, replacements ...string) map[string]interface{} {
+	var values []map[string]string
+	for _, r := range replacements {
+		values = append(values, map[string]string{"value": r})
+	}
+	return map[string]interface{}{
+		"message":      message,
+		"offset":       offset,
+		"length":       length,
+		"replacements": values,
+		"rule":         map[string]string{"id": rule},
+	}
+}
+
+func TestLanguageToolKnownMisspelling(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/check" {
+			t.Errorf("请求路径 = %s, want /v2/check", r.URL.Path)
+		}
+		r.ParseForm()
+		form = map[string]string{"text": r.PostForm.Get("text"), "language": r.PostForm.Get("language")}
+		// "😀" 占两个 UTF-16 单位，"recieve" 从第10个单位开始
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"matches": []interface{}{
+				languageToolMatch("Possible spelling mistake found.", 10, 7, "MORFOLOGIK_RULE_EN_US", "receive", "relieve", "recite", "recipe"),
+			},
+		})
+	}))
+	defer server.Close()
+
+	lt := NewLanguageTool(config.WritingCheckConfig{LanguageToolURL: server.URL + "/", Language: "en-US", Timeout: 5})
+	text := "😀 I will recieve it."
+	issues, err := lt.Check(context.Background(), text)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if form["text"] != text || form["language"] != "en-US" {
+		t.Errorf("请求参数 = %v, want 原文和 en-US", form)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Check() 返回 %d 处问题, want 1", len(issues))
+	}
+	issue := issues[0]
+	if issue.Offset != 9 || issue.Length != 7 || issue.Text != "recieve" {
+		t.Errorf("位置 = %d/%d/%q, want 按字符计的 9/7/recieve", issue.Offset, issue.Length, issue.Text)
+	}
+	if len(issue.Suggestions) != languageToolMaxSuggestions || issue.Suggestions[0] != "receive" {
+		t.Errorf("Suggestions = %v, want 前 %d 个且第一个为 receive", issue.Suggestions, languageToolMaxSuggestions)
+	}
+	if issue.Rule != "MORFOLOGIK_RULE_EN_US" || issue.Source != "languagetool" {
+		t.Errorf("Rule/Source = %s/%s, want MORFOLOGIK_RULE_EN_US/languagetool", issue.Rule, issue.Source)
+	}
+}
+
+func TestLanguageToolServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	lt := NewLanguageTool(config.WritingCheckConfig{LanguageToolURL: server.URL, Language: "en-US", Timeout: 5})
+	if _, err := lt.Check(context.Background(), "text"); err == nil {
+		t.Error("Check() error = nil, want 503 错误")
+	}
+	if NewLanguageTool(config.WritingCheckConfig{}) != nil {
+		t.Error("未配置 languagetool_url 时 NewLanguageTool() != nil")
+	}
+}
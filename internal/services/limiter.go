@@ -0,0 +1,63 @@
+// internal/services/limiter.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiter 按固定间隔发放请求时间片，用于限制每分钟请求数。
+// 令牌桶容量为1，不允许空闲后突发多个请求
+type rateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// newRateLimiter 创建限速器，requestsPerMinute <= 0 时返回nil表示不限速
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(requestsPerMinute)), 1)}
+}
+
+// Wait 阻塞到下一个可用时间片，或在context取消（或截止时间早于下一个时间片）时返回错误
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// concurrencyLimiter 限制同时进行中的请求数
+type concurrencyLimiter chan struct{}
+
+// newConcurrencyLimiter 创建并发限制，max <= 0 时返回nil表示不限制
+func newConcurrencyLimiter(max int) concurrencyLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return make(concurrencyLimiter, max)
+}
+
+// Acquire 获取一个并发名额，或在context取消时返回错误
+func (c concurrencyLimiter) Acquire(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	select {
+	case c <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release 归还并发名额
+func (c concurrencyLimiter) Release() {
+	if c != nil {
+		<-c
+	}
+}
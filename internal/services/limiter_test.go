@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacing(t *testing.T) {
+	limiter := newRateLimiter(1200) // 每50ms一次
+
+	start := time.Now()
+	var starts []time.Duration
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("第%d次 Wait() error = %v", i+1, err)
+		}
+		starts = append(starts, time.Since(start))
+	}
+
+	// 第一次不等待，之后每次间隔约50ms，空闲后不突发；计时器可能略早触发，留出少量余量
+	if starts[0] > 10*time.Millisecond {
+		t.Errorf("第一次 Wait() 等待了 %v, want 立即返回", starts[0])
+	}
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i] - starts[i-1]; gap < 45*time.Millisecond {
+			t.Errorf("第%d次与上一次间隔 %v，应不小于50ms", i+1, gap)
+		}
+	}
+}
+
+func TestRateLimiterContextCanceled(t *testing.T) {
+	limiter := newRateLimiter(1) // 每分钟一次
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Error("Wait() error = nil, want 下一个时间片晚于截止时间时报错")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() 等待了 %v, want 截止时间前返回", elapsed)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := newRateLimiter(0)
+	if limiter != nil {
+		t.Fatalf("newRateLimiter(0) = %v, want nil", limiter)
+	}
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Errorf("不限速时 Wait() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// internal/services/remote_image.go
+package services
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// DownloadImage 下载远程图片到临时文件，调用方负责删除。
+// 响应的 Content-Length 或实际读取的字节数超过 image.max_size 时中止下载并返回 ErrImageTooLarge；
+// 格式按 Content-Type 判断，服务器未给出图片类型时按链接的扩展名判断。网络错误和非200响应返回 ErrImageDownload
+func (s *imageService) DownloadImage(imageURL string) (string, error) {
+	parsed, err := url.Parse(imageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("%w: 只支持 http(s) 链接: %s", ErrImageDownload, imageURL)
+	}
+
+	client := &http.Client{Timeout: time.Duration(s.config.Image.DownloadTimeout) * time.Second}
+	resp, err := client.Get(imageURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrImageDownload, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: 服务器返回 %s", ErrImageDownload, resp.Status)
+	}
+
+	maxSize := s.config.Image.MaxSize
+	if resp.ContentLength > maxSize {
+		return "", fmt.Errorf("%w: %d bytes (最大: %d bytes)", ErrImageTooLarge, resp.ContentLength, maxSize)
+	}
+
+	ext, err := remoteImageExt(resp.Header.Get("Content-Type"), parsed.Path)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "content-analyzer-remote-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer file.Close()
+
+	// 没有 Content-Length 或其值不实时，多读一个字节即可判断是否超限
+	written, err := io.Copy(file, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("%w: 读取响应失败: %w", ErrImageDownload, err)
+	}
+	if written > maxSize {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("%w: 超过 %d bytes", ErrImageTooLarge, maxSize)
+	}
+
+	return file.Name(), nil
+}
+
+// remoteImageExt 按 Content-Type 确定临时文件的扩展名，之后由 ValidateImage 按 supported_ext 校验。
+// CDN 常对图片返回 application/octet-stream，此时改用链接路径中的扩展名
+func remoteImageExt(contentType, urlPath string) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if ext, ok := inlineImageExts[mediaType]; ok {
+		return ext, nil
+	}
+	if mediaType != "" && mediaType != "application/octet-stream" && mediaType != "binary/octet-stream" {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, mediaType)
+	}
+
+	if ext := strings.ToLower(path.Ext(urlPath)); ext != "" {
+		return ext, nil
+	}
+	return "", fmt.Errorf("%w: 无法确定远程图片的格式", ErrUnsupportedFormat)
+}
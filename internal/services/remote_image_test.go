@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadImage(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, gradientScene(64, 48)); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tent.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngData.Bytes())
+		case "/cdn/tent.png":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(pngData.Bytes())
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(bytes.Repeat([]byte{0}, 2048))
+		case "/chunked.png":
+			// 先刷新响应头，不带 Content-Length，只能在读取时发现超限
+			w.Header().Set("Content-Type", "image/png")
+			w.(http.Flusher).Flush()
+			w.Write(bytes.Repeat([]byte{0}, 2048))
+		case "/page.png":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		maxSize int64
+		wantErr error
+		wantExt string
+	}{
+		{"正常图片", "/tent.png", 1 << 20, nil, ".png"},
+		{"octet-stream 按扩展名", "/cdn/tent.png", 1 << 20, nil, ".png"},
+		{"Content-Length 超限", "/large.png", 1024, ErrImageTooLarge, ""},
+		{"读取时超限", "/chunked.png", 1024, ErrImageTooLarge, ""},
+		{"Content-Type 不是图片", "/page.png", 1 << 20, ErrUnsupportedFormat, ""},
+		{"非200响应", "/missing.png", 1 << 20, ErrImageDownload, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			t.Setenv("TMPDIR", tmp)
+			cfg := testConfig(t)
+			cfg.Image.MaxSize = tt.maxSize
+			svc := NewImageService(cfg).(*imageService)
+
+			path, err := svc.DownloadImage(server.URL + tt.path)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DownloadImage() error = %v, want %v", err, tt.wantErr)
+				}
+				// 失败时不留下临时文件
+				if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+					t.Errorf("临时目录残留 %d 个文件", len(entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadImage() error = %v", err)
+			}
+			defer os.Remove(path)
+
+			if filepath.Ext(path) != tt.wantExt {
+				t.Errorf("临时文件扩展名 = %q, want %q", filepath.Ext(path), tt.wantExt)
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			decoded, _, err := image.DecodeConfig(f)
+			if err != nil || decoded.Width != 64 || decoded.Height != 48 {
+				t.Errorf("下载的图片 = %dx%d (err %v), want 64x48", decoded.Width, decoded.Height, err)
+			}
+		})
+	}
+}
+
+func TestDownloadImageRejectsNonHTTP(t *testing.T) {
+	svc := NewImageService(testConfig(t)).(*imageService)
+	for _, url := range []string{"file:///etc/passwd", "ftp://example.com/a.png", "not a url"} {
+		if _, err := svc.DownloadImage(url); !errors.Is(err, ErrImageDownload) {
+			t.Errorf("DownloadImage(%q) error = %v, want ErrImageDownload", url, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// internal/services/retry.go
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// isRetryable 判断错误是否值得重试：429、500、502、503、504 和网络错误；
+// 400/401 等客户端错误以及上下文取消不重试
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	// 网络错误在 callOpenAI 中包装为 ErrAIUnavailable
+	return errors.Is(err, ErrAIUnavailable)
+}
+
+// retryDelay 计算第 attempt 次失败（从0开始）后的等待时间。
+// 服务商给出 Retry-After 时以其为准（最长 retryMaxDelay，避免一个响应让整批分析停顿），
+// 否则按指数退避并加入随机抖动，避免并发请求同时重试
+func retryDelay(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if apiErr.RetryAfter > retryMaxDelay {
+			return retryMaxDelay
+		}
+		return apiErr.RetryAfter
+	}
+
+	delay := retryMaxDelay
+	if attempt < 16 {
+		if d := retryBaseDelay << uint(attempt); d < retryMaxDelay {
+			delay = d
+		}
+	}
+	// 在 [delay/2, delay) 内随机
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+// parseRetryAfter 解析 Retry-After 响应头，支持秒数和 HTTP 日期两种形式，无法解析时返回0
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		err      error
+		min, max time.Duration
+	}{
+		{"按 Retry-After 等待", 0, &APIError{StatusCode: 429, RetryAfter: 2 * time.Second}, 2 * time.Second, 2 * time.Second},
+		{"Retry-After 过大时截断", 0, &APIError{StatusCode: 429, RetryAfter: time.Hour}, retryMaxDelay, retryMaxDelay},
+		{"第一次退避", 0, ErrAIUnavailable, retryBaseDelay / 2, retryBaseDelay},
+		{"第三次退避", 2, &APIError{StatusCode: 503}, 2 * retryBaseDelay, 4 * retryBaseDelay},
+		{"退避不超过上限", 20, ErrAIUnavailable, retryMaxDelay / 2, retryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got := retryDelay(tt.attempt, tt.err)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("retryDelay() = %v, want [%v, %v]", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"3600", time.Hour},
+		{"-1", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/content-analyzer/internal/config"
-	"github.com/content-analyzer/internal/models"
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
 )
 
 // ServiceManager 服务管理器
@@ -26,53 +28,40 @@ func NewServiceManager(cfg *config.Config) *ServiceManager {
 	}
 }
 
-// HealthCheck 健康检查
+// HealthCheck 健康检查，记录各服务状态，图片服务不可用时返回错误
 func (sm *ServiceManager) HealthCheck(ctx context.Context) error {
 	log.Println("开始服务健康检查...")
 
-	// 检查AI服务
-	if err := sm.checkAIService(ctx); err != nil {
-		log.Printf("AI服务检查失败: %v", err)
-	} else {
-		log.Println("✅ AI服务正常")
-	}
+	report := sm.CheckHealth(ctx)
+	for _, service := range report.Services {
+		switch service.Status {
+		case HealthOK:
+			log.Printf("✅ %s服务正常 (%.0fms)", service.Name, service.LatencyMs)
+		case HealthDegraded:
+			log.Printf("⚠️  %s服务降级 (%.0fms, 尝试%d次): %s", service.Name, service.LatencyMs, service.Attempts, service.Message)
+		case HealthSkipped:
+			log.Printf("⚠️  %s", service.Message)
+		default:
+			log.Printf("%s服务检查失败: %s", service.Name, service.Message)
+		}
 
-	// 检查图片服务
-	if err := sm.checkImageService(); err != nil {
-		log.Printf("图片服务检查失败: %v", err)
-		return fmt.Errorf("图片服务不可用: %w", err)
+		if service.Name == "image" && service.Status == HealthDown {
+			return fmt.Errorf("图片服务不可用: %s", service.Message)
+		}
 	}
-	log.Println("✅ 图片服务正常")
 
 	log.Println("服务健康检查完成")
 	return nil
 }
 
-func (sm *ServiceManager) checkAIService(ctx context.Context) error {
-	// 如果没有配置API密钥，跳过检查
-	if sm.config.AI.APIKey == "" {
-		log.Println("⚠️  AI API密钥未配置，将使用简化版本")
-		return nil
-	}
-
-	// 简单的连通性测试
-	testText := "这是一个测试文本"
-	_, err := sm.AIService.AnalyzeSentiment(ctx, testText)
-	if err != nil {
-		return fmt.Errorf("AI服务连接失败: %w", err)
-	}
-
-	return nil
-}
-
 func (sm *ServiceManager) checkImageService() error {
 	// 检查图片服务配置
 	if len(sm.config.Image.SupportedExt) == 0 {
-		return fmt.Errorf("未配置支持的图片格式")
+		return fmt.Errorf("%w: 未配置支持的图片格式", ErrInvalidConfig)
 	}
 
 	if sm.config.Image.MaxSize <= 0 {
-		return fmt.Errorf("图片大小限制配置错误")
+		return fmt.Errorf("%w: 图片大小限制配置错误", ErrInvalidConfig)
 	}
 
 	return nil
@@ -112,36 +101,48 @@ func (sm *ServiceManager) GenerateContentAdvice(ctx context.Context, analysis mo
 	return sm.AIService.GenerateAdvice(ctx, analysis)
 }
 
-// ExtractContentTopics 提取内容主题
+// ExtractContentTopics 提取内容主题，未配置AI服务或AI失败时返回本地提取的结果
 func (sm *ServiceManager) ExtractContentTopics(ctx context.Context, text string) ([]string, error) {
 	return sm.AIService.ExtractTopics(ctx, text)
 }
 
-// ValidateContent 验证内容质量
+// 标题长度上限（字符数）
+const maxTitleLength = 50
+
+// ValidateContent 验证内容质量，词数按 CountPlainWords 统计
 func (sm *ServiceManager) ValidateContent(content models.Content) []string {
+	return sm.ValidateContentWithCounter(content, CountPlainWords)
+}
+
+// ValidateContentWithCounter 与 ValidateContent 相同，词数由 countWords 统计。countWords 应与分析时统计
+// text_analysis.word_count 的分词方式相同（analyzer.ContentAnalyzer.CountWords），使检查结果与分析报告中的词数一致
+func (sm *ServiceManager) ValidateContentWithCounter(content models.Content, countWords func(text string) int) []string {
 	var issues []string
 
 	// 检查内容长度
-	wordCount := len(content.Text)
+	wordCount := countWords(content.Text)
 	if wordCount < sm.config.Analysis.MinWordCount {
-		issues = append(issues, fmt.Sprintf("内容过短，当前%d字，建议至少%d字",
+		issues = append(issues, fmt.Sprintf("内容过短，当前%d词，建议至少%d词",
 			wordCount, sm.config.Analysis.MinWordCount))
 	}
 
 	if wordCount > sm.config.Analysis.MaxWordCount {
-		issues = append(issues, fmt.Sprintf("内容过长，当前%d字，建议不超过%d字",
+		issues = append(issues, fmt.Sprintf("内容过长，当前%d词，建议不超过%d词",
 			wordCount, sm.config.Analysis.MaxWordCount))
 	}
 
 	// 检查标题
 	if len(content.Title) == 0 {
 		issues = append(issues, "缺少标题")
-	} else if len(content.Title) > 100 {
-		issues = append(issues, "标题过长，建议控制在50字以内")
+	} else if utf8.RuneCountInString(content.Title) > maxTitleLength {
+		issues = append(issues, fmt.Sprintf("标题过长，建议控制在%d字以内", maxTitleLength))
 	}
 
-	// 检查图片
+	// 检查图片，远程图片和内嵌的 data URI 图片没有本地文件，不检查
 	for i, img := range content.Images {
+		if img.Path == "" || IsDataURI(img.Path) {
+			continue
+		}
 		if err := sm.ImageService.ValidateImage(img.Path); err != nil {
 			issues = append(issues, fmt.Sprintf("图片%d验证失败: %v", i+1, err))
 		}
@@ -149,3 +150,24 @@ func (sm *ServiceManager) ValidateContent(content models.Content) []string {
 
 	return issues
 }
+
+// CountPlainWords 不依赖分词词典统计词数：每个汉字计为一个词，其余按空白切分，标点不计
+func CountPlainWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			count++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				count++
+			}
+			inWord = true
+		case unicode.IsSpace(r):
+			inWord = false
+		}
+	}
+	return count
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// testConfig 默认配置，不读取环境变量中的AI密钥，保证测试不访问网络
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	t.Setenv("AI_API_KEY", "")
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载默认配置失败: %v", err)
+	}
+	return cfg
+}
+
+func TestValidateContentTitleLength(t *testing.T) {
+	sm := NewServiceManager(testConfig(t))
+	text := strings.Repeat("周末去露营。", 20)
+
+	tests := []struct {
+		name      string
+		title     string
+		wantIssue bool
+	}{
+		{"34个汉字", strings.Repeat("露", 34), false},
+		{"50个汉字", strings.Repeat("露", 50), false},
+		{"51个汉字", strings.Repeat("露", 51), true},
+		{"51个英文字母", strings.Repeat("a", 51), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := strings.Join(sm.ValidateContent(models.Content{Title: tt.title, Text: text}), "; ")
+			if got := strings.Contains(issues, "标题过长"); got != tt.wantIssue {
+				t.Errorf("ValidateContent() = %q, want 标题过长: %v", issues, tt.wantIssue)
+			}
+		})
+	}
+}
+
+func TestCountPlainWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"周末露营", 4},
+		{"周末，露营。", 4},
+		{"camping in the woods", 4},
+		{"  camping,  tent! ", 2},
+		{"周末去camping 2次", 6},
+	}
+
+	for _, tt := range tests {
+		if got := CountPlainWords(tt.text); got != tt.want {
+			t.Errorf("CountPlainWords(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// internal/services/usage.go
+package services
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// usageTracker 累计各模型的token用量，并发请求共用
+type usageTracker struct {
+	mu     sync.Mutex
+	models map[string]*modelUsage
+	cached int // 命中 ai.cache、未实际请求的次数
+}
+
+type modelUsage struct {
+	requests, prompt, completion int
+}
+
+// record 记录一次成功响应的用量，服务商未返回用量时token数为0，仍计为一次请求
+func (t *usageTracker) record(model string, promptTokens, completionTokens int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.models == nil {
+		t.models = make(map[string]*modelUsage)
+	}
+	usage, ok := t.models[model]
+	if !ok {
+		usage = &modelUsage{}
+		t.models[model] = usage
+	}
+	usage.requests++
+	usage.prompt += promptTokens
+	usage.completion += completionTokens
+}
+
+func (t *usageTracker) recordCacheHit() {
+	t.mu.Lock()
+	t.cached++
+	t.mu.Unlock()
+}
+
+// stats 汇总用量，按 pricing 中各模型每1000个token的价格估算费用
+func (t *usageTracker) stats(pricing map[string]config.TokenPrice) models.AIUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := models.AIUsage{CachedResponses: t.cached}
+	for model, usage := range t.models {
+		stats.Requests += usage.requests
+		stats.PromptTokens += usage.prompt
+		stats.CompletionTokens += usage.completion
+
+		price, ok := pricing[model]
+		if !ok {
+			if usage.prompt+usage.completion > 0 {
+				stats.UnpricedModels = append(stats.UnpricedModels, model)
+			}
+			continue
+		}
+		stats.EstimatedCost += float64(usage.prompt)/1000*price.Prompt + float64(usage.completion)/1000*price.Completion
+	}
+	stats.TotalTokens = stats.PromptTokens + stats.CompletionTokens
+	stats.EstimatedCost = math.Round(stats.EstimatedCost*1e6) / 1e6
+	sort.Strings(stats.UnpricedModels)
+	return stats
+}
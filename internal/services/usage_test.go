@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"image"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/config"
+)
+
+func TestUsageSummedAcrossCalls(t *testing.T) {
+	var calls int32
+	s := newTestAIService(t, "openai", func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n == 5 {
+			http.Error(w, "overloaded", http.StatusInternalServerError)
+			return
+		}
+		// 第n次请求用量为 100n + 10n
+		writeOpenAIReply(w, "reply", 100*n, 10*n)
+	}, func(cfg *config.Config) {
+		cfg.AI.Model = "gpt-4o-mini"
+		cfg.AI.VisionModel = "llava-custom"
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := s.callAI(ctx, "prompt"); err != nil {
+			t.Fatalf("第%d次 callAI() error = %v", i+1, err)
+		}
+	}
+	path := writeScenePNG(t, t.TempDir(), "scene.png", image.NewRGBA(image.Rect(0, 0, 16, 16)))
+	if _, err := s.DescribeImage(ctx, path); err != nil {
+		t.Fatalf("DescribeImage() error = %v", err)
+	}
+	// 失败的请求不计入用量
+	if _, err := s.callAI(ctx, "prompt"); err == nil {
+		t.Fatal("第5次 callAI() error = nil, want 500 错误")
+	}
+
+	usage := s.UsageStats()
+	if usage.Requests != 4 {
+		t.Errorf("Requests = %d, want 4", usage.Requests)
+	}
+	if usage.PromptTokens != 1000 || usage.CompletionTokens != 100 || usage.TotalTokens != 1100 {
+		t.Errorf("tokens = %d/%d/%d, want 1000/100/1100", usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens)
+	}
+	// gpt-4o-mini: 600/1000*0.00015 + 60/1000*0.0006；llava-custom 没有价格，只统计用量
+	if want := 0.000126; math.Abs(usage.EstimatedCost-want) > 1e-9 {
+		t.Errorf("EstimatedCost = %v, want %v", usage.EstimatedCost, want)
+	}
+	if len(usage.UnpricedModels) != 1 || usage.UnpricedModels[0] != "llava-custom" {
+		t.Errorf("UnpricedModels = %v, want [llava-custom]", usage.UnpricedModels)
+	}
+}
+
+func TestUsageTrackerConcurrentRecords(t *testing.T) {
+	var tracker usageTracker
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				tracker.record("gpt-3.5-turbo", 3, 2)
+			}
+			tracker.recordCacheHit()
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	stats := tracker.stats(map[string]config.TokenPrice{"gpt-3.5-turbo": {Prompt: 1, Completion: 2}})
+	if stats.Requests != 1000 || stats.CachedResponses != 10 {
+		t.Errorf("Requests/CachedResponses = %d/%d, want 1000/10", stats.Requests, stats.CachedResponses)
+	}
+	if stats.TotalTokens != 5000 || stats.EstimatedCost != 7 {
+		t.Errorf("TotalTokens/EstimatedCost = %d/%v, want 5000/7", stats.TotalTokens, stats.EstimatedCost)
+	}
+}
@@ -0,0 +1,132 @@
+// internal/store/sqlite.go
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+	_ "modernc.org/sqlite" // 纯Go实现，不需要cgo
+)
+
+// migrations 按顺序执行的建表和升级语句，已执行到第几条记录在 PRAGMA user_version 中。
+// 只能在末尾追加，不能修改已发布的语句
+var migrations = []string{
+	`CREATE TABLE results (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		content_id  TEXT    NOT NULL,
+		title       TEXT    NOT NULL,
+		analyzed_at INTEGER NOT NULL, -- Unix 纳秒
+		total_score REAL    NOT NULL,
+		result      TEXT    NOT NULL  -- AnalysisResult 的JSON
+	);
+	CREATE INDEX idx_results_content ON results (content_id, analyzed_at);`,
+}
+
+// SQLiteStore 基于 SQLite 的结果存储
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite 打开（不存在时创建）数据库文件并执行未执行过的迁移，path 为 ":memory:" 时使用内存数据库
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("打开数据库 %s 失败: %w", path, err)
+	}
+	// 内存数据库每个连接各自独立，只用一个连接；文件数据库也只有本进程写入
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("迁移数据库 %s 失败: %w", path, err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("数据库版本 %d 高于程序支持的版本 %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("第 %d 条迁移: %w", i+1, err)
+		}
+		// PRAGMA 不支持参数占位符
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Save 在一个事务中写入全部结果
+func (s *SQLiteStore) Save(results []models.AnalysisResult, analyzedAt time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO results (content_id, title, analyzed_at, total_score, result) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("序列化 %s 的结果失败: %w", result.Title, err)
+		}
+		if _, err := stmt.Exec(contentKey(result), result.Title, analyzedAt.UnixNano(), result.Score.Total, string(data)); err != nil {
+			return fmt.Errorf("保存 %s 的结果失败: %w", result.Title, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// History 查询该内容的历次结果，内容没有ID时以标题查询
+func (s *SQLiteStore) History(contentID string) ([]HistoryEntry, error) {
+	rows, err := s.db.Query(`SELECT analyzed_at, result FROM results WHERE content_id = ? ORDER BY analyzed_at, id`, contentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var analyzedAt int64
+		var data string
+		if err := rows.Scan(&analyzedAt, &data); err != nil {
+			return nil, err
+		}
+		entry := HistoryEntry{AnalyzedAt: time.Unix(0, analyzedAt)}
+		if err := json.Unmarshal([]byte(data), &entry.Result); err != nil {
+			return nil, fmt.Errorf("解析 %s 的历史结果失败: %w", contentID, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Close 关闭数据库
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,114 @@
+package store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+func openMemory(t *testing.T) *SQLiteStore {
+	t.Helper()
+	s, err := OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func storedResult(id, title string, total float64) models.AnalysisResult {
+	return models.AnalysisResult{
+		ContentID: id,
+		Title:     title,
+		Score:     models.OverallScore{Total: total},
+		Tags:      []string{"露营"},
+	}
+}
+
+func TestSQLiteSaveAndHistory(t *testing.T) {
+	s := openMemory(t)
+	first := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	// 后一次运行先写入，History 仍按分析时间排列
+	if err := s.Save([]models.AnalysisResult{storedResult("a", "露营清单", 80), storedResult("b", "徒步路线", 60)}, second); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := s.Save([]models.AnalysisResult{storedResult("a", "露营清单", 70)}, first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	history, err := s.History("a")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("History(a) 有 %d 条, want 2", len(history))
+	}
+	if !history[0].AnalyzedAt.Equal(first) || history[0].Result.Score.Total != 70 {
+		t.Errorf("history[0] = %v/%v, want %v/70", history[0].AnalyzedAt, history[0].Result.Score.Total, first)
+	}
+	if !history[1].AnalyzedAt.Equal(second) || history[1].Result.Score.Total != 80 {
+		t.Errorf("history[1] = %v/%v, want %v/80", history[1].AnalyzedAt, history[1].Result.Score.Total, second)
+	}
+	if got := history[1].Result; got.Title != "露营清单" || len(got.Tags) != 1 || got.Tags[0] != "露营" {
+		t.Errorf("保存的结果 = %+v, want 完整还原", got)
+	}
+
+	if history, err := s.History("missing"); err != nil || len(history) != 0 {
+		t.Errorf("History(missing) = %v, %v, want 空", history, err)
+	}
+}
+
+func TestSQLiteHistoryByTitleWithoutID(t *testing.T) {
+	s := openMemory(t)
+	if err := s.Save([]models.AnalysisResult{storedResult("", "没有ID的文章", 50)}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	history, err := s.History("没有ID的文章")
+	if err != nil || len(history) != 1 {
+		t.Errorf("History(标题) = %v, %v, want 1 条", history, err)
+	}
+}
+
+func TestSQLiteMigrationsAreIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	s, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save([]models.AnalysisResult{storedResult("a", "标题", 90)}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// 再次打开不重复执行迁移，已有数据保留
+	s, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("再次 OpenSQLite() error = %v", err)
+	}
+	defer s.Close()
+	if history, err := s.History("a"); err != nil || len(history) != 1 {
+		t.Errorf("History(a) = %v, %v, want 1 条", history, err)
+	}
+}
+
+func TestSQLiteRejectsNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`PRAGMA user_version = 99`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := OpenSQLite(path); err == nil || !strings.Contains(err.Error(), "99") {
+		t.Errorf("OpenSQLite() error = %v, want 数据库版本高于程序支持的版本", err)
+	}
+}
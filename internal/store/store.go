@@ -0,0 +1,31 @@
+// internal/store/store.go
+package store
+
+import (
+	"time"
+
+	"github.com/RobinCoderZhao/content-analyzer/internal/models"
+)
+
+// ResultStore 保存每次运行的分析结果，用于查看同一内容的历史变化
+type ResultStore interface {
+	// Save 保存一次运行的全部结果，analyzedAt 为本次运行的时间
+	Save(results []models.AnalysisResult, analyzedAt time.Time) error
+	// History 返回该内容的历次结果，按分析时间从早到晚排列
+	History(contentID string) ([]HistoryEntry, error)
+	Close() error
+}
+
+// HistoryEntry 一次运行中某篇内容的分析结果
+type HistoryEntry struct {
+	AnalyzedAt time.Time             `json:"analyzed_at"`
+	Result     models.AnalysisResult `json:"result"`
+}
+
+// contentKey 结果入库时使用的内容ID，没有ID时（如Markdown文件）使用标题
+func contentKey(result models.AnalysisResult) string {
+	if result.ContentID != "" {
+		return result.ContentID
+	}
+	return result.Title
+}